@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/GoPolymarket/polymarket-trader/internal/app"
+	"github.com/GoPolymarket/polymarket-trader/internal/config"
+	"github.com/GoPolymarket/polymarket-trader/internal/feed"
+)
+
+func main() {
+	cfgPath := flag.String("config", "config.yaml", "path to config file")
+	eventsPath := flag.String("events", "", "recorded order book events (JSONL)")
+	speed := flag.Float64("speed", 0, "replay speed multiplier (0 replays as fast as possible)")
+	flag.Parse()
+
+	if *eventsPath == "" {
+		log.Fatal("-events is required")
+	}
+
+	cfg, err := config.LoadFile(*cfgPath)
+	if err != nil {
+		log.Printf("warning: config file: %v, using defaults", err)
+		cfg = config.Default()
+	}
+	// Backtests always trade against the paper simulator and never notify or
+	// touch a persisted paper account.
+	cfg.TradingMode = "paper"
+	cfg.DryRun = false
+	cfg.Paper.StateFile = ""
+	cfg.Telegram.Enabled = false
+	cfg.Discord.Enabled = false
+	cfg.Slack.Enabled = false
+	cfg.Record.Enabled = false
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("invalid config %s:\n  - %s", *cfgPath, strings.ReplaceAll(err.Error(), "\n", "\n  - "))
+	}
+
+	f, err := os.Open(*eventsPath)
+	if err != nil {
+		log.Fatalf("events: %v", err)
+	}
+	events, err := feed.ReadEvents(f)
+	f.Close()
+	if err != nil {
+		log.Fatalf("events %s: %v", *eventsPath, err)
+	}
+	log.Printf("replaying %d events from %s (speed=%g)", len(events), *eventsPath, *speed)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	a := app.New(cfg, nil, nil, nil, nil, nil, nil)
+	replayer := feed.NewReplayer(events, *speed)
+	if err := a.Replay(ctx, replayer.Events(ctx)); err != nil && err != context.Canceled {
+		log.Fatalf("replay: %v", err)
+	}
+
+	orders, fills, pnl := a.Stats()
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(map[string]interface{}{
+		"orders":         orders,
+		"fills":          fills,
+		"realized_pnl":   pnl,
+		"unrealized_pnl": a.UnrealizedPnL(),
+		"paper":          a.PaperSnapshot(),
+		"risk":           a.RiskSnapshot(),
+		"kpi":            a.KPIStats(),
+	}); err != nil {
+		log.Fatalf("write report: %v", err)
+	}
+}
@@ -21,6 +21,8 @@ func main() {
 	cfgPath := flag.String("config", "config.yaml", "path to config file")
 	phase := flag.String("phase", "", "rollout phase preset: paper|shadow|live-small|live")
 	modeOverride := flag.String("mode", "", "override trading mode: paper|live")
+	preserveOrders := flag.Bool("preserve-orders", false, "leave live orders resting on shutdown and re-adopt them on the next start")
+	preflight := flag.Bool("preflight", false, "check API credentials and that orders build and sign for the selected markets, then exit without trading")
 	flag.Parse()
 
 	cfg, err := config.LoadFile(*cfgPath)
@@ -28,15 +30,11 @@ func main() {
 		log.Printf("warning: config file: %v, using defaults", err)
 		cfg = config.Default()
 	}
-	cfg.ApplyEnv()
-	if v := strings.ToLower(strings.TrimSpace(*modeOverride)); v != "" {
-		cfg.TradingMode = v
-	}
-	if err := config.ApplyRolloutPhase(&cfg, *phase); err != nil {
-		log.Fatalf("invalid -phase: %v", err)
+	if err := applyOverrides(&cfg, *modeOverride, *phase, *preserveOrders); err != nil {
+		log.Fatalf("config %s: %v", *cfgPath, err)
 	}
 	if err := cfg.Validate(); err != nil {
-		log.Fatalf("invalid config: %v", err)
+		log.Fatalf("invalid config %s:\n  - %s", *cfgPath, strings.ReplaceAll(err.Error(), "\n", "\n  - "))
 	}
 
 	mode := strings.ToLower(strings.TrimSpace(cfg.TradingMode))
@@ -81,14 +79,16 @@ func main() {
 		log.Println("paper mode without API credentials: using public market/orderbook data")
 	}
 
+	var builderCfg *auth.BuilderConfig
 	if mode == "live" && cfg.BuilderKey != "" && cfg.BuilderSecret != "" {
-		clobClient = clobClient.WithBuilderConfig(&auth.BuilderConfig{
+		builderCfg = &auth.BuilderConfig{
 			Local: &auth.BuilderCredentials{
 				Key:        strings.TrimSpace(cfg.BuilderKey),
 				Secret:     strings.TrimSpace(cfg.BuilderSecret),
 				Passphrase: strings.TrimSpace(cfg.BuilderPassphrase),
 			},
-		})
+		}
+		clobClient = clobClient.WithBuilderConfig(builderCfg)
 		log.Println("builder attribution enabled")
 	}
 
@@ -100,11 +100,50 @@ func main() {
 
 	a := app.New(cfg, clobClient, wsClient, signer, sdkClient.Gamma, sdkClient.Data, sdkClient.RTDS)
 
+	// Optional second wallet for the taker path.
+	if mode == "live" && cfg.TakerAccount.Configured() {
+		takerSigner, err := auth.NewPrivateKeySigner(strings.TrimSpace(cfg.TakerAccount.PrivateKey), 137)
+		if err != nil {
+			log.Fatalf("taker account signer: %v", err)
+		}
+		takerKey := &auth.APIKey{
+			Key:        strings.TrimSpace(cfg.TakerAccount.APIKey),
+			Secret:     strings.TrimSpace(cfg.TakerAccount.APISecret),
+			Passphrase: strings.TrimSpace(cfg.TakerAccount.APIPassphrase),
+		}
+		takerSDK := polymarket.NewClient()
+		takerCLOB := takerSDK.CLOB.WithAuth(takerSigner, takerKey)
+		if builderCfg != nil {
+			takerCLOB = takerCLOB.WithBuilderConfig(builderCfg)
+		}
+		a.SetTakerAccount(takerCLOB, takerSDK.CLOBWS.Authenticate(takerSigner, takerKey), takerSigner)
+		log.Printf("taker account enabled: %s", takerSigner.Address())
+	}
+
+	if *preflight {
+		if err := a.Preflight(ctx); err != nil {
+			log.Fatalf("preflight failed:\n  - %s", strings.ReplaceAll(err.Error(), "\n", "\n  - "))
+		}
+		log.Println("preflight passed")
+		return
+	}
+
 	// Phase 2.3: Start HTTP API server if enabled.
 	var apiServer *api.Server
 	if cfg.API.Enabled {
-		apiServer = api.NewServer(cfg.API.Addr, a, a.Portfolio, a.BuilderTracker)
+		// Pass absent trackers as nil interfaces so the API reports them
+		// not_configured rather than calling through a nil pointer.
+		var portfolioProvider api.PortfolioProvider
+		if a.Portfolio != nil {
+			portfolioProvider = a.Portfolio
+		}
+		var builderProvider api.BuilderProvider
+		if a.BuilderTracker != nil {
+			builderProvider = a.BuilderTracker
+		}
+		apiServer = api.NewServer(cfg.API.Addr, a, portfolioProvider, builderProvider)
 		apiServer.SetAuthToken(cfg.API.Token)
+		apiServer.SetExternalSignals(cfg.API.ExternalSignals)
 		if err := apiServer.Start(ctx); err != nil {
 			log.Printf("warning: api server failed to start: %v", err)
 		}
@@ -116,6 +155,26 @@ func main() {
 		cancel()
 	}()
 
+	// SIGHUP re-reads the config file and applies the hot-reloadable subset.
+	hupCh := make(chan os.Signal, 1)
+	signal.Notify(hupCh, syscall.SIGHUP)
+	go func() {
+		for range hupCh {
+			next, err := config.LoadFile(*cfgPath)
+			if err != nil {
+				log.Printf("config reload: %v", err)
+				continue
+			}
+			if err := applyOverrides(&next, *modeOverride, *phase, *preserveOrders); err != nil {
+				log.Printf("config reload: %v", err)
+				continue
+			}
+			if err := a.ReloadConfig(next); err != nil {
+				log.Printf("config reload: %v", err)
+			}
+		}
+	}()
+
 	if err := a.Run(ctx); err != nil && err != context.Canceled {
 		log.Printf("run error: %v", err)
 	}
@@ -125,3 +184,24 @@ func main() {
 	}
 	a.Shutdown(context.Background())
 }
+
+// applyOverrides layers environment variables, secret files and command-line
+// flags on top of a loaded config, in the same order at startup and on reload.
+// Market overrides are rebuilt over the result before the rollout phase
+// clamps them along with the globals.
+func applyOverrides(cfg *config.Config, modeOverride, phase string, preserveOrders bool) error {
+	cfg.ApplyEnv()
+	if err := cfg.ResolveSecrets(); err != nil {
+		return err
+	}
+	if v := strings.ToLower(strings.TrimSpace(modeOverride)); v != "" {
+		cfg.TradingMode = v
+	}
+	if preserveOrders {
+		cfg.PreserveOrdersOnShutdown = true
+	}
+	if err := cfg.ResolveMarketOverrides(); err != nil {
+		return err
+	}
+	return config.ApplyRolloutPhase(cfg, phase)
+}
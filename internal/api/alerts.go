@@ -0,0 +1,71 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// nearLossLimitPct is the daily loss usage from which near_loss_limit fires,
+// the same line the coach uses to turn defensive.
+const nearLossLimitPct = 80
+
+// alertSeverityRank orders alerts most urgent first.
+var alertSeverityRank = map[string]int{"critical": 0, "warn": 1, "info": 2}
+
+// GET /api/alerts — the warnings currently in effect across risk, feed and
+// builder sync, one per condition, most severe first.
+func (s *Server) handleAlerts(w http.ResponseWriter, _ *http.Request) {
+	snap := s.appState.RiskSnapshot()
+	rs := buildRiskStatus(snap)
+	alerts := []Alert{}
+
+	if snap.EmergencyStop {
+		alerts = append(alerts, Alert{Code: "emergency_stop", Severity: "critical",
+			Message: "Emergency stop is active; no new orders are placed."})
+	}
+	// Emergency stop and the loss cooldown have alerts of their own.
+	var blocked []string
+	for _, reason := range rs.blockedReasons {
+		if reason != "emergency_stop" && reason != "loss_cooldown_active" {
+			blocked = append(blocked, reason)
+		}
+	}
+	if len(blocked) > 0 {
+		alerts = append(alerts, Alert{Code: "risk_blocked", Severity: "critical",
+			Message: "Trading is blocked: " + strings.Join(blocked, ", ") + "."})
+	}
+	if s.appState.IsRunning() && !s.appState.FeedConnected() {
+		alerts = append(alerts, Alert{Code: "feed_disconnected", Severity: "critical",
+			Message: "Order book feed is disconnected; quotes are not being refreshed."})
+	}
+	if snap.InCooldown {
+		alerts = append(alerts, Alert{Code: "in_cooldown", Severity: "warn",
+			Message: fmt.Sprintf("Loss cooldown active for another %s.", snap.CooldownRemaining.Round(time.Second))})
+	}
+	if rs.usagePct >= nearLossLimitPct && rs.remainingUSDC > 0 {
+		alerts = append(alerts, Alert{Code: "near_loss_limit", Severity: "warn",
+			Message: fmt.Sprintf("%.0f%% of the daily loss limit used; %.2f USDC left.", rs.usagePct, rs.remainingUSDC)})
+	}
+	if s.builderStale() {
+		alerts = append(alerts, Alert{Code: "builder_stale", Severity: "info",
+			Message: fmt.Sprintf("Builder volume has not synced in the last %s.", builderStaleAfter)})
+	}
+
+	slices.SortStableFunc(alerts, func(x, y Alert) int {
+		return alertSeverityRank[x.Severity] - alertSeverityRank[y.Severity]
+	})
+	s.writeJSON(w, AlertsResponse{Alerts: alerts, Count: len(alerts)})
+}
+
+// builderStale reports whether a configured builder tracker has never synced
+// or last synced more than builderStaleAfter ago.
+func (s *Server) builderStale() bool {
+	if s.builder == nil {
+		return false
+	}
+	lastSync := s.builder.LastSync()
+	return lastSync.IsZero() || time.Since(lastSync) > builderStaleAfter
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// exportSection is one report embedded in the export bundle.
+type exportSection struct {
+	name    string
+	path    string
+	handler http.HandlerFunc
+}
+
+// GET /api/export?window=7d — every reviewer-facing report in one JSON
+// object, with a checksum over the embedded reports.
+func (s *Server) handleExport(w http.ResponseWriter, r *http.Request) {
+	generatedAt := time.Now().UTC()
+	window := parseStageWindow(r.URL.Query().Get("window"))
+	sections := []exportSection{
+		{"status", "/api/status", s.handleStatus},
+		{"paper", "/api/paper", s.handlePaper},
+		{"stage_report", "/api/stage-report?window=" + window.label, s.handleStageReport},
+		{"grant_report", "/api/grant-report", s.handleGrantReport},
+		{"execution_quality", "/api/execution-quality", s.handleExecutionQuality},
+		{"daily_report", "/api/daily-report", s.handleDailyReport},
+		{"coach", "/api/coach", s.handleCoach},
+	}
+
+	reports := make(map[string]json.RawMessage, len(sections))
+	for _, sec := range sections {
+		body, err := renderJSON(sec.handler, sec.path)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("export %s: %v", sec.name, err), http.StatusInternalServerError)
+			return
+		}
+		reports[sec.name] = body
+	}
+	// Map keys marshal sorted, so the checksum is stable for equal reports.
+	payload, err := json.Marshal(reports)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sum := sha256.Sum256(payload)
+
+	out := map[string]interface{}{
+		"generated_at":    generatedAt,
+		"window":          map[string]interface{}{"label": window.label, "days": window.days},
+		"checksum_sha256": hex.EncodeToString(sum[:]),
+		"version":         "export-v1",
+	}
+	for name, body := range reports {
+		out[name] = body
+	}
+	s.writeJSON(w, out)
+}
+
+// renderJSON runs a report handler against a synthetic GET request and
+// returns its body, so the export matches the standalone endpoints exactly.
+func renderJSON(h http.HandlerFunc, target string) (json.RawMessage, error) {
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	rec := &bufferedResponse{header: make(http.Header), code: http.StatusOK}
+	h(rec, req)
+	if rec.code != http.StatusOK {
+		return nil, fmt.Errorf("status %d: %s", rec.code, bytes.TrimSpace(rec.body.Bytes()))
+	}
+	body := bytes.TrimSpace(rec.body.Bytes())
+	if !json.Valid(body) {
+		return nil, fmt.Errorf("invalid json from %s", target)
+	}
+	return json.RawMessage(body), nil
+}
+
+// bufferedResponse is a minimal in-memory http.ResponseWriter.
+type bufferedResponse struct {
+	header http.Header
+	code   int
+	body   bytes.Buffer
+}
+
+func (b *bufferedResponse) Header() http.Header         { return b.header }
+func (b *bufferedResponse) Write(p []byte) (int, error) { return b.body.Write(p) }
+func (b *bufferedResponse) WriteHeader(code int)        { b.code = code }
@@ -0,0 +1,26 @@
+package api
+
+// dependencyNames are the integrations /api/ready reports on: the CLOB REST
+// client, the Data API, the market WebSocket and the RTDS price stream.
+var dependencyNames = []string{"clob", "data", "ws", "rtds"}
+
+// Health states shared by /api/ready dependencies and the portfolio and
+// builder trackers.
+const (
+	healthOK            = "ok"
+	healthDegraded      = "degraded"
+	healthNotConfigured = "not_configured"
+)
+
+// dependencyStatus classifies an integration: not_configured without a
+// client, degraded while its latest call failed, ok otherwise.
+func dependencyStatus(configured bool, err error) DependencyStatus {
+	switch {
+	case !configured:
+		return DependencyStatus{Status: healthNotConfigured}
+	case err != nil:
+		return DependencyStatus{Status: healthDegraded, Error: err.Error()}
+	default:
+		return DependencyStatus{Status: healthOK}
+	}
+}
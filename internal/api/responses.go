@@ -0,0 +1,313 @@
+package api
+
+import (
+	"time"
+
+	"github.com/GoPolymarket/polymarket-trader/internal/feed"
+)
+
+// StatusResponse is the body of GET /api/status. The portfolio fields are
+// only present when a portfolio tracker is configured. LastHeartbeat is null
+// until the first keepalive has been sent.
+type StatusResponse struct {
+	Running        bool       `json:"running"`
+	DryRun         bool       `json:"dry_run"`
+	TradingMode    string     `json:"trading_mode"`
+	FeedConnected  bool       `json:"feed_connected"`
+	LastHeartbeat  *time.Time `json:"last_heartbeat"`
+	HeartbeatOK    bool       `json:"heartbeat_ok"`
+	UptimeS        float64    `json:"uptime_s"`
+	Orders         int        `json:"orders"`
+	Fills          int        `json:"fills"`
+	PnL            float64    `json:"pnl"`
+	Assets         []string   `json:"assets"`
+	PortfolioValue *float64   `json:"portfolio_value,omitempty"`
+	PortfolioSync  *time.Time `json:"portfolio_sync,omitempty"`
+	// PortfolioStatus is ok, degraded while the last portfolio sync failed
+	// (see PortfolioError), or not_configured without a portfolio tracker.
+	PortfolioStatus string `json:"portfolio_status"`
+	PortfolioError  string `json:"portfolio_error,omitempty"`
+}
+
+// DependencyStatus is the health of one integration under "dependencies" in
+// GET /api/ready. Error is the latest failure while Status is degraded.
+type DependencyStatus struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// PnLResponse is the body of GET /api/pnl. UnpricedAssets lists open
+// positions with neither a book mid nor a last price, which are left out of
+// UnrealizedPnL.
+type PnLResponse struct {
+	RealizedPnL    float64  `json:"realized_pnl"`
+	UnrealizedPnL  float64  `json:"unrealized_pnl"`
+	TotalPnL       float64  `json:"total_pnl"`
+	UnpricedAssets []string `json:"unpriced_assets"`
+	PortfolioValue *float64 `json:"portfolio_value,omitempty"`
+}
+
+// PerfResponse is the body of GET /api/perf. EstimatedEquityUSDC is null
+// outside paper mode, where the starting balance is unknown.
+type PerfResponse struct {
+	TradingMode         string   `json:"trading_mode"`
+	Orders              int      `json:"orders"`
+	Fills               int      `json:"fills"`
+	RealizedPnLUSDC     float64  `json:"realized_pnl_usdc"`
+	UnrealizedPnLUSDC   float64  `json:"unrealized_pnl_usdc"`
+	TotalPnLUSDC        float64  `json:"total_pnl_usdc"`
+	PnLPerFillUSDC      float64  `json:"pnl_per_fill_usdc"`
+	FeesPaidUSDC        float64  `json:"fees_paid_usdc"`
+	NetPnLAfterFeesUSDC float64  `json:"net_pnl_after_fees_usdc"`
+	EstimatedEquityUSDC *float64 `json:"estimated_equity_usdc"`
+
+	// Average realized edge of today's maker fills against the mid at
+	// placement, and how many fills it covers.
+	MakerSpreadCaptureBps     float64 `json:"maker_spread_capture_bps"`
+	MakerSpreadCaptureSamples int     `json:"maker_spread_capture_samples"`
+
+	// Share of today's submitted orders that filled at least in part.
+	FillRatioDaily float64 `json:"fill_ratio_daily"`
+
+	// Deepest fall of net PnL after fees from its running peak, today and
+	// since startup. Percentages are of equity at that peak.
+	MaxDrawdownUSDC        float64 `json:"max_drawdown_usdc"`
+	MaxDrawdownPct         float64 `json:"max_drawdown_pct"`
+	SessionMaxDrawdownUSDC float64 `json:"session_max_drawdown_usdc"`
+	SessionMaxDrawdownPct  float64 `json:"session_max_drawdown_pct"`
+}
+
+// MakerParams are the maker quoting parameters in effect.
+type MakerParams struct {
+	MinSpreadBps         float64 `json:"min_spread_bps"`
+	SpreadMultiplier     float64 `json:"spread_multiplier"`
+	OrderSizeUSDC        float64 `json:"order_size_usdc"`
+	MinOrderSizeUSDC     float64 `json:"min_order_size_usdc"`
+	MaxOrdersPerMarket   int     `json:"max_orders_per_market"`
+	InventorySkewBps     float64 `json:"inventory_skew_bps"`
+	InventoryWidenFactor float64 `json:"inventory_widen_factor"`
+	VolSpreadMultiplier  float64 `json:"vol_spread_multiplier"`
+	VolSpreadMaxBps      float64 `json:"vol_spread_max_bps"`
+	PostFillPauseMs      int64   `json:"post_fill_pause_ms"`
+	UsePairFairValue     bool    `json:"use_pair_fair_value"`
+	QuoteMode            string  `json:"quote_mode"`
+	TickSize             float64 `json:"tick_size"`
+}
+
+// TakerParams are the taker signal parameters in effect.
+type TakerParams struct {
+	MinImbalance       float64 `json:"min_imbalance"`
+	DepthLevels        int     `json:"depth_levels"`
+	AmountUSDC         float64 `json:"amount_usdc"`
+	MaxSlippageBps     float64 `json:"max_slippage_bps"`
+	CooldownS          float64 `json:"cooldown_s"`
+	MinConfidenceBps   float64 `json:"min_confidence_bps"`
+	FlowWeight         float64 `json:"flow_weight"`
+	ImbalanceWeight    float64 `json:"imbalance_weight"`
+	ConvergenceWeight  float64 `json:"convergence_weight"`
+	MomentumWeight     float64 `json:"momentum_weight"`
+	MinConvergenceBps  float64 `json:"min_convergence_bps"`
+	MinCompositeScore  float64 `json:"min_composite_score"`
+	RequireFeeCoverage bool    `json:"require_fee_coverage"`
+}
+
+// StrategyResponse is the body of GET /api/strategy. AssetID and Override
+// are set when the parameters were requested for one asset.
+type StrategyResponse struct {
+	AssetID  string      `json:"asset_id,omitempty"`
+	Override bool        `json:"override"`
+	Maker    MakerParams `json:"maker"`
+	Taker    TakerParams `json:"taker"`
+}
+
+// StrategyToggleRequest is the body of POST /api/strategy/toggle. A nil
+// field leaves that strategy unchanged.
+type StrategyToggleRequest struct {
+	Maker *bool `json:"maker,omitempty"`
+	Taker *bool `json:"taker,omitempty"`
+}
+
+// StrategyToggleResponse reports which strategies are enabled after a toggle.
+type StrategyToggleResponse struct {
+	Maker bool `json:"maker"`
+	Taker bool `json:"taker"`
+}
+
+// EmergencyStopClearRequest is the body of POST /api/emergency-stop/clear.
+// Confirm must be EmergencyStopClearConfirm, so a stray request cannot
+// resume trading.
+type EmergencyStopClearRequest struct {
+	Confirm string `json:"confirm"`
+}
+
+// EmergencyStopClearConfirm is the confirmation the clear request requires.
+const EmergencyStopClearConfirm = "RESUME_TRADING"
+
+// EmergencyStopClearResponse reports whether the emergency stop was cleared
+// and, when it was refused, the guardrails still blocking trading.
+type EmergencyStopClearResponse struct {
+	Cleared bool     `json:"cleared"`
+	Reasons []string `json:"reasons"`
+}
+
+// SimulateQuoteRequest is the body of POST /api/simulate/quote. Inventory is
+// optional; without it the quote is computed flat.
+type SimulateQuoteRequest struct {
+	AssetID   string             `json:"asset_id,omitempty"`
+	Bids      []feed.Level       `json:"bids"`
+	Asks      []feed.Level       `json:"asks"`
+	Inventory *SimulateInventory `json:"inventory,omitempty"`
+}
+
+// SimulateInventory is the position a simulated quote is skewed for.
+type SimulateInventory struct {
+	NetPosition   float64 `json:"net_position"`
+	AvgEntryPrice float64 `json:"avg_entry_price"`
+}
+
+// SimulateQuoteResponse is the maker quote for a simulated book. RawBuyPrice
+// and RawSellPrice are the prices before the fee floor widened them.
+type SimulateQuoteResponse struct {
+	AssetID      string  `json:"asset_id,omitempty"`
+	BuyPrice     float64 `json:"buy_price"`
+	SellPrice    float64 `json:"sell_price"`
+	Size         float64 `json:"size"`
+	FeeRateBps   float64 `json:"fee_rate_bps"`
+	FeeAdjusted  bool    `json:"fee_adjusted"`
+	RawBuyPrice  float64 `json:"raw_buy_price"`
+	RawSellPrice float64 `json:"raw_sell_price"`
+}
+
+// NettedPositionsResponse is the body of GET /api/positions/netted.
+type NettedPositionsResponse struct {
+	Markets          []NettedMarket `json:"markets"`
+	LockedProfitUSDC float64        `json:"locked_profit_usdc"`
+}
+
+// NettedMarket is one binary market's position across both outcome tokens,
+// keyed by the lower asset ID. Positions in tokens without a known pair are
+// listed alone with an empty pair_asset_id. NetExposure is in shares of
+// AssetID: positive is long it, negative long its pair.
+type NettedMarket struct {
+	AssetID           string  `json:"asset_id"`
+	PairAssetID       string  `json:"pair_asset_id"`
+	NetSize           float64 `json:"net_size"`
+	AvgEntryPrice     float64 `json:"avg_entry_price"`
+	PairNetSize       float64 `json:"pair_net_size"`
+	PairAvgEntryPrice float64 `json:"pair_avg_entry_price"`
+	HedgedSize        float64 `json:"hedged_size"`
+	NetExposure       float64 `json:"net_exposure"`
+	LockedProfitUSDC  float64 `json:"locked_profit_usdc"`
+}
+
+// RejectionsResponse is the body of GET /api/rejections.
+type RejectionsResponse struct {
+	Rejections []RejectionEntry `json:"rejections"`
+	Count      int              `json:"count"`
+}
+
+// RejectionEntry is an order that was not placed. Source is "risk" for a
+// risk-check block and "clob" for an exchange or build error; Reason is the
+// error text.
+type RejectionEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	AssetID   string    `json:"asset_id"`
+	Side      string    `json:"side"`
+	SizeUSDC  float64   `json:"size_usdc"`
+	Source    string    `json:"source"`
+	Reason    string    `json:"reason"`
+}
+
+// SelectorCandidatesResponse is the body of GET /api/selector/candidates.
+// LastRun is null until the market selector has run.
+type SelectorCandidatesResponse struct {
+	LastRun    *time.Time          `json:"last_run"`
+	Candidates []SelectorCandidate `json:"candidates"`
+	Count      int                 `json:"count"`
+	Selected   int                 `json:"selected"`
+}
+
+// SelectorCandidate is one market from the last selection run. Score is 0
+// for markets that failed a filter.
+type SelectorCandidate struct {
+	MarketID      string                `json:"market_id"`
+	Question      string                `json:"question"`
+	TokenIDs      []string              `json:"token_ids"`
+	Liquidity     float64               `json:"liquidity"`
+	Volume24hr    float64               `json:"volume_24hr"`
+	Spread        float64               `json:"spread"`
+	DaysToEnd     float64               `json:"days_to_end"`
+	VolatilityBps float64               `json:"volatility_bps"`
+	Score         float64               `json:"score"`
+	Filters       SelectorFilterResults `json:"filters"`
+	Passed        bool                  `json:"passed"`
+	Selected      bool                  `json:"selected"`
+}
+
+// SelectorFilterResults reports whether a market passed each selector
+// filter. Volatility is only measured for markets that pass the others and
+// reads true when it was not measured.
+type SelectorFilterResults struct {
+	Liquidity  bool `json:"liquidity"`
+	Volume24hr bool `json:"volume_24hr"`
+	Spread     bool `json:"spread"`
+	DaysToEnd  bool `json:"days_to_end"`
+	Volatility bool `json:"volatility"`
+}
+
+// FillsSummaryResponse is the body of GET /api/fills/summary: per-trade
+// outcomes over closed round-trips, in USDC before fees. Losses are negative.
+type FillsSummaryResponse struct {
+	RoundTrips      int     `json:"round_trips"`
+	Wins            int     `json:"wins"`
+	Losses          int     `json:"losses"`
+	WinRatePct      float64 `json:"win_rate_pct"`
+	AvgWinUSDC      float64 `json:"avg_win_usdc"`
+	AvgLossUSDC     float64 `json:"avg_loss_usdc"`
+	LargestWinUSDC  float64 `json:"largest_win_usdc"`
+	LargestLossUSDC float64 `json:"largest_loss_usdc"`
+	ExpectancyUSDC  float64 `json:"expectancy_usdc"`
+}
+
+// AlertsResponse is the body of GET /api/alerts.
+type AlertsResponse struct {
+	Alerts []Alert `json:"alerts"`
+	Count  int     `json:"count"`
+}
+
+// Alert is one active warning. Code is one of emergency_stop, risk_blocked,
+// feed_disconnected, in_cooldown, near_loss_limit or builder_stale; Severity
+// is critical, warn or info.
+type Alert struct {
+	Code     string `json:"code"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// RiskResponse is the body of GET /api/risk.
+type RiskResponse struct {
+	EmergencyStop                 bool               `json:"emergency_stop"`
+	DailyPnL                      float64            `json:"daily_pnl"`
+	DailyLossLimitUSDC            float64            `json:"daily_loss_limit_usdc"`
+	DailyLossUsedPct              float64            `json:"daily_loss_used_pct"`
+	DailyLossRemainingUSDC        float64            `json:"daily_loss_remaining_usdc"`
+	DailyLossRemainingPct         float64            `json:"daily_loss_remaining_pct"`
+	CanTrade                      bool               `json:"can_trade"`
+	BlockedReasons                []string           `json:"blocked_reasons"`
+	ConsecutiveLosses             int                `json:"consecutive_losses"`
+	MaxConsecutiveLosses          int                `json:"max_consecutive_losses"`
+	InCooldown                    bool               `json:"in_cooldown"`
+	CooldownRemainingS            float64            `json:"cooldown_remaining_s"`
+	CooldownMultiplier            float64            `json:"cooldown_multiplier"`
+	DrawdownVelocityUSDCPerMin    float64            `json:"drawdown_velocity_usdc_per_min"`
+	MaxDrawdownVelocityUSDCPerMin float64            `json:"max_drawdown_velocity_usdc_per_min"`
+	PositionsUSDC                 map[string]float64 `json:"positions_usdc"`
+	ConcentrationHHI              float64            `json:"concentration_hhi"`
+	ConcentrationWarnHHI          float64            `json:"concentration_warn_hhi"`
+	ConcentrationWarning          bool               `json:"concentration_warning"`
+	GrossExposureUSDC             float64            `json:"gross_exposure_usdc"`
+	GrossExposureLimitUSDC        float64            `json:"gross_exposure_limit_usdc"`
+	GroupExposureUSDC             map[string]float64 `json:"group_exposure_usdc"`
+	GroupExposureLimitUSDC        float64            `json:"group_exposure_limit_usdc"`
+	MaxOrderNotionalUSDC          float64            `json:"max_order_notional_usdc"`
+}
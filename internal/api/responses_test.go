@@ -0,0 +1,142 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+func jsonKeys(t *testing.T, v interface{}) []string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func TestResponseStructsKeepWireFieldNames(t *testing.T) {
+	value, synced := 12.5, time.Now()
+	cases := []struct {
+		name string
+		resp interface{}
+		want []string
+	}{
+		{"status", StatusResponse{PortfolioValue: &value, PortfolioSync: &synced}, []string{
+			"assets", "dry_run", "feed_connected", "fills", "heartbeat_ok", "last_heartbeat", "orders", "pnl",
+			"portfolio_status", "portfolio_sync", "portfolio_value", "running", "trading_mode", "uptime_s",
+		}},
+		{"status without portfolio", StatusResponse{}, []string{
+			"assets", "dry_run", "feed_connected", "fills", "heartbeat_ok", "last_heartbeat", "orders", "pnl",
+			"portfolio_status", "running", "trading_mode", "uptime_s",
+		}},
+		{"dependency", DependencyStatus{}, []string{"status"}},
+		{"degraded dependency", DependencyStatus{Status: "degraded", Error: "timeout"}, []string{"error", "status"}},
+		{"pnl", PnLResponse{}, []string{"realized_pnl", "total_pnl", "unpriced_assets", "unrealized_pnl"}},
+		{"alerts", AlertsResponse{}, []string{"alerts", "count"}},
+		{"alert", Alert{}, []string{"code", "message", "severity"}},
+		{"selector candidates", SelectorCandidatesResponse{}, []string{"candidates", "count", "last_run", "selected"}},
+		{"selector candidate", SelectorCandidate{}, []string{
+			"days_to_end", "filters", "liquidity", "market_id", "passed", "question", "score",
+			"selected", "spread", "token_ids", "volatility_bps", "volume_24hr",
+		}},
+		{"selector filters", SelectorFilterResults{}, []string{"days_to_end", "liquidity", "spread", "volatility", "volume_24hr"}},
+		{"rejections", RejectionsResponse{}, []string{"count", "rejections"}},
+		{"rejection", RejectionEntry{}, []string{"asset_id", "reason", "side", "size_usdc", "source", "timestamp"}},
+		{"netted positions", NettedPositionsResponse{}, []string{"locked_profit_usdc", "markets"}},
+		{"netted market", NettedMarket{}, []string{
+			"asset_id", "avg_entry_price", "hedged_size", "locked_profit_usdc", "net_exposure",
+			"net_size", "pair_asset_id", "pair_avg_entry_price", "pair_net_size",
+		}},
+		{"perf", PerfResponse{}, []string{
+			"estimated_equity_usdc", "fees_paid_usdc", "fill_ratio_daily", "fills", "maker_spread_capture_bps",
+			"maker_spread_capture_samples", "max_drawdown_pct", "max_drawdown_usdc", "net_pnl_after_fees_usdc", "orders",
+			"pnl_per_fill_usdc", "realized_pnl_usdc", "session_max_drawdown_pct", "session_max_drawdown_usdc",
+			"total_pnl_usdc", "trading_mode", "unrealized_pnl_usdc",
+		}},
+		{"risk", RiskResponse{}, []string{
+			"blocked_reasons", "can_trade", "concentration_hhi", "concentration_warn_hhi",
+			"concentration_warning", "consecutive_losses", "cooldown_multiplier", "cooldown_remaining_s",
+			"daily_loss_limit_usdc", "daily_loss_remaining_pct", "daily_loss_remaining_usdc",
+			"daily_loss_used_pct", "daily_pnl", "drawdown_velocity_usdc_per_min", "emergency_stop",
+			"gross_exposure_limit_usdc", "gross_exposure_usdc", "group_exposure_limit_usdc",
+			"group_exposure_usdc", "in_cooldown", "max_consecutive_losses",
+			"max_drawdown_velocity_usdc_per_min", "max_order_notional_usdc", "positions_usdc",
+		}},
+	}
+	for _, tc := range cases {
+		if got := jsonKeys(t, tc.resp); !slices.Equal(got, tc.want) {
+			t.Errorf("%s: keys changed\n got: %v\nwant: %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestPerfResponseDecodesFromHandler(t *testing.T) {
+	s := NewServer(":0", &mockAppState{tradingMode: "live", fills: 4, pnl: 2}, nil, nil)
+	w := httptest.NewRecorder()
+	s.handlePerf(w, httptest.NewRequest(http.MethodGet, "/api/perf", nil))
+
+	var resp PerfResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.TradingMode != "live" || resp.Fills != 4 || resp.PnLPerFillUSDC != 0.5 {
+		t.Fatalf("unexpected perf response: %+v", resp)
+	}
+	if resp.EstimatedEquityUSDC != nil {
+		t.Fatalf("expected null equity outside paper mode, got %v", *resp.EstimatedEquityUSDC)
+	}
+}
+
+func TestPerfResponseCarriesMakerSpreadCapture(t *testing.T) {
+	state := &mockAppState{kpiStats: map[string]interface{}{
+		"maker_spread_capture_bps":           12.345,
+		"maker_spread_capture_samples_daily": 3,
+	}}
+	s := NewServer(":0", state, nil, nil)
+	w := httptest.NewRecorder()
+	s.handlePerf(w, httptest.NewRequest(http.MethodGet, "/api/perf", nil))
+
+	var resp PerfResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.MakerSpreadCaptureBps != 12.35 || resp.MakerSpreadCaptureSamples != 3 {
+		t.Fatalf("expected maker spread capture 12.35 bps over 3 fills, got %+v", resp)
+	}
+}
+
+func TestPerfResponseCarriesMaxDrawdown(t *testing.T) {
+	state := &mockAppState{kpiStats: map[string]interface{}{
+		"max_drawdown_usdc":         12.5,
+		"max_drawdown_pct":          1.234,
+		"session_max_drawdown_usdc": 80.0,
+		"session_max_drawdown_pct":  7.619,
+	}}
+	s := NewServer(":0", state, nil, nil)
+	w := httptest.NewRecorder()
+	s.handlePerf(w, httptest.NewRequest(http.MethodGet, "/api/perf", nil))
+
+	var resp PerfResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.MaxDrawdownUSDC != 12.5 || resp.MaxDrawdownPct != 1.23 {
+		t.Fatalf("expected today's drawdown 12.5 USDC / 1.23%%, got %+v", resp)
+	}
+	if resp.SessionMaxDrawdownUSDC != 80 || resp.SessionMaxDrawdownPct != 7.62 {
+		t.Fatalf("expected session drawdown 80 USDC / 7.62%%, got %+v", resp)
+	}
+}
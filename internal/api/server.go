@@ -13,14 +13,18 @@ import (
 	"net"
 	"net/http"
 	"reflect"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/GoPolymarket/polymarket-trader/internal/config"
 	"github.com/GoPolymarket/polymarket-trader/internal/execution"
+	"github.com/GoPolymarket/polymarket-trader/internal/feed"
 	"github.com/GoPolymarket/polymarket-trader/internal/paper"
 	"github.com/GoPolymarket/polymarket-trader/internal/risk"
+	"github.com/GoPolymarket/polymarket-trader/internal/strategy"
 	"github.com/GoPolymarket/polymarket-trader/internal/telegramtmpl"
 )
 
@@ -32,21 +36,52 @@ type AppState interface {
 	IsRunning() bool
 	IsDryRun() bool
 	MonitoredAssets() []string
+	StaleAssets() []string
+	CancelCooldownAssets() []string
+	BookMetrics(assetID string, levels int, depthBps float64) (feed.BookMetrics, bool)
 	SetEmergencyStop(stop bool)
 	RecentFills(limit int) []execution.Fill
+	RoundTrips() []execution.RoundTrip
+	Rejections(limit int) []execution.Rejection
+	FillHistory(offset, limit int) []execution.Fill
 	ActiveOrders() []execution.OrderState
 	TrackedPositions() map[string]execution.Position
+	TokenPairs() map[string]string
 	UnrealizedPnL() float64
+	UnrealizedPnLByMarket() map[string]float64
+	UnpricedPositions() []string
 	RiskSnapshot() risk.Snapshot
+	// RiskSnapshots returns the risk state of each trading account, primary
+	// first.
+	RiskSnapshots() []risk.Snapshot
 	TradingMode() string
+	// ExecMode is where orders execute: paper (the simulator) under dry-run,
+	// else the trading mode. Paper fees and equity apply only when it is paper.
+	ExecMode() string
 	PaperSnapshot() paper.Snapshot
 	KPIStats() map[string]interface{}
+	PnLHistory(window, bucket time.Duration) []map[string]interface{}
+	SubmitExternalSignal(ctx context.Context, sig strategy.ExternalSignal) (string, error)
+	EffectiveConfig() config.Config
+	Flows() (window time.Duration, flows map[string]strategy.FlowStat)
+	FeedConnected() bool
+	HeartbeatStatus() (last time.Time, err error)
+	// DependencyHealth reports whether the named integration (see
+	// dependencyNames) has a client and its latest error, nil while healthy.
+	DependencyHealth(name string) (configured bool, err error)
+	Rescan(ctx context.Context) (added, removed []string, err error)
+	SelectorCandidates() (lastRun time.Time, candidates []strategy.CandidateEvaluation)
+	Book(assetID string) (feed.BookView, bool)
+	StrategyParams(assetID string) (maker strategy.MakerConfig, taker strategy.TakerConfig, override bool)
+	SetStrategiesEnabled(maker, taker *bool) (makerOn, takerOn bool, err error)
+	SimulateQuote(assetID string, bids, asks []feed.Level, inv *strategy.InventoryState) (strategy.QuotePreview, error)
 }
 
 // PortfolioProvider exposes portfolio data (nil if unavailable).
 type PortfolioProvider interface {
 	TotalValue() float64
 	LastSync() time.Time
+	LastError() error
 }
 
 // BuilderProvider exposes builder volume data (nil if unavailable).
@@ -54,6 +89,7 @@ type BuilderProvider interface {
 	DailyVolumeJSON() interface{}
 	LeaderboardJSON() interface{}
 	LastSync() time.Time
+	LastError() error
 }
 
 // Server is a lightweight HTTP API for the trading dashboard.
@@ -64,6 +100,8 @@ type Server struct {
 	builder    BuilderProvider
 	startedAt  time.Time
 	authToken  string
+
+	externalSignals bool
 }
 
 // NewServer creates a new API server bound to addr.
@@ -79,8 +117,14 @@ func NewServer(addr string, appState AppState, portfolio PortfolioProvider, buil
 	mux.HandleFunc("/api/health", s.handleHealth)
 	mux.HandleFunc("/api/ready", s.handleReady)
 	mux.HandleFunc("/api/status", s.handleStatus)
+	mux.HandleFunc("/api/config", s.handleConfig)
 	mux.HandleFunc("/api/positions", s.handlePositions)
+	mux.HandleFunc("/api/positions/netted", s.handleNettedPositions)
 	mux.HandleFunc("/api/pnl", s.handlePnL)
+	mux.HandleFunc("/api/pnl-history", s.handlePnLHistory)
+	mux.HandleFunc("/api/pnl-by-market", s.handlePnLByMarket)
+	mux.HandleFunc("/api/flows", s.handleFlows)
+	mux.HandleFunc("/api/strategy", s.handleStrategy)
 	mux.HandleFunc("/api/perf", s.handlePerf)
 	mux.HandleFunc("/api/coach", s.handleCoach)
 	mux.HandleFunc("/api/sizing", s.handleSizing)
@@ -96,13 +140,26 @@ func NewServer(addr string, appState AppState, portfolio PortfolioProvider, buil
 	mux.HandleFunc("/api/stage-report", s.handleStageReport)
 	mux.HandleFunc("/api/grant-package", s.handleGrantPackage)
 	mux.HandleFunc("/api/grant-report", s.handleGrantReport)
+	mux.HandleFunc("/api/export", s.handleExport)
 	mux.HandleFunc("/api/trades", s.handleTrades)
+	mux.HandleFunc("/api/trades.csv", s.handleTradesCSV)
+	mux.HandleFunc("/api/fills/summary", s.handleFillsSummary)
+	mux.HandleFunc("/api/rejections", s.handleRejections)
 	mux.HandleFunc("/api/orders", s.handleOrders)
 	mux.HandleFunc("/api/markets", s.handleMarkets)
+	mux.HandleFunc("/api/markets/", s.handleMarketDetail)
+	mux.HandleFunc("/api/book/", s.handleBook)
 	mux.HandleFunc("/api/builder", s.handleBuilder)
 	mux.HandleFunc("/api/risk", s.handleRisk)
+	mux.HandleFunc("/api/alerts", s.handleAlerts)
 	mux.HandleFunc("/api/paper", s.handlePaper)
 	mux.HandleFunc("/api/emergency-stop", s.handleEmergencyStop)
+	mux.HandleFunc("/api/emergency-stop/clear", s.handleEmergencyStopClear)
+	mux.HandleFunc("/api/rescan", s.handleRescan)
+	mux.HandleFunc("/api/selector/candidates", s.handleSelectorCandidates)
+	mux.HandleFunc("/api/strategy/toggle", s.handleStrategyToggle)
+	mux.HandleFunc("/api/simulate/quote", s.handleSimulateQuote)
+	mux.HandleFunc("/api/signals/external", s.handleExternalSignal)
 
 	s.httpServer = &http.Server{
 		Addr:              addr,
@@ -120,6 +177,12 @@ func (s *Server) SetAuthToken(token string) {
 	s.authToken = strings.TrimSpace(token)
 }
 
+// SetExternalSignals enables the external signal ingestion endpoint. It only
+// accepts requests while an auth token is also configured.
+func (s *Server) SetExternalSignals(enabled bool) {
+	s.externalSignals = enabled
+}
+
 // Start begins serving HTTP requests.
 func (s *Server) Start(_ context.Context) error {
 	ln, err := net.Listen("tcp", s.httpServer.Addr)
@@ -197,6 +260,11 @@ func (s *Server) handleReady(w http.ResponseWriter, _ *http.Request) {
 		"trading_mode": s.appState.TradingMode(),
 		"uptime_s":     time.Since(s.startedAt).Seconds(),
 	}
+	deps := make(map[string]DependencyStatus, len(dependencyNames))
+	for _, name := range dependencyNames {
+		deps[name] = dependencyStatus(s.appState.DependencyHealth(name))
+	}
+	resp["dependencies"] = deps
 	if !ready {
 		resp["reason"] = "app_not_running"
 		w.WriteHeader(http.StatusServiceUnavailable)
@@ -207,23 +275,41 @@ func (s *Server) handleReady(w http.ResponseWriter, _ *http.Request) {
 // GET /api/status — overall system status.
 func (s *Server) handleStatus(w http.ResponseWriter, _ *http.Request) {
 	orders, fills, pnl := s.appState.Stats()
-	resp := map[string]interface{}{
-		"running":      s.appState.IsRunning(),
-		"dry_run":      s.appState.IsDryRun(),
-		"trading_mode": s.appState.TradingMode(),
-		"uptime_s":     time.Since(s.startedAt).Seconds(),
-		"orders":       orders,
-		"fills":        fills,
-		"pnl":          pnl,
-		"assets":       s.appState.MonitoredAssets(),
-	}
+	resp := StatusResponse{
+		Running:       s.appState.IsRunning(),
+		DryRun:        s.appState.IsDryRun(),
+		TradingMode:   s.appState.TradingMode(),
+		FeedConnected: s.appState.FeedConnected(),
+		UptimeS:       time.Since(s.startedAt).Seconds(),
+		Orders:        orders,
+		Fills:         fills,
+		PnL:           pnl,
+		Assets:        s.appState.MonitoredAssets(),
+	}
+	if last, err := s.appState.HeartbeatStatus(); !last.IsZero() {
+		resp.LastHeartbeat, resp.HeartbeatOK = &last, err == nil
+	}
+	resp.PortfolioStatus = healthNotConfigured
 	if s.portfolio != nil {
-		resp["portfolio_value"] = s.portfolio.TotalValue()
-		resp["portfolio_sync"] = s.portfolio.LastSync()
+		value, lastSync := s.portfolio.TotalValue(), s.portfolio.LastSync()
+		resp.PortfolioValue, resp.PortfolioSync = &value, &lastSync
+		dep := dependencyStatus(true, s.portfolio.LastError())
+		resp.PortfolioStatus, resp.PortfolioError = dep.Status, dep.Error
 	}
 	s.writeJSON(w, resp)
 }
 
+// GET /api/config — effective configuration after env overrides and reloads,
+// keyed like config.yaml, with secrets shown as "***".
+func (s *Server) handleConfig(w http.ResponseWriter, _ *http.Request) {
+	cfg, err := s.appState.EffectiveConfig().Map()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.writeJSON(w, cfg)
+}
+
 // GET /api/positions — current tracked positions.
 func (s *Server) handlePositions(w http.ResponseWriter, _ *http.Request) {
 	positions := s.appState.TrackedPositions()
@@ -250,21 +336,222 @@ func (s *Server) handlePositions(w http.ResponseWriter, _ *http.Request) {
 	s.writeJSON(w, map[string]interface{}{"positions": entries})
 }
 
-// GET /api/pnl — realized + unrealized PnL.
+// GET /api/positions/netted — positions with each binary market's YES and NO
+// tokens combined, so a hedged pair shows as the directional exposure it
+// really carries plus the profit locked in by the hedged part.
+func (s *Server) handleNettedPositions(w http.ResponseWriter, _ *http.Request) {
+	positions := s.appState.TrackedPositions()
+	pairs := s.appState.TokenPairs()
+	markets := []NettedMarket{}
+	var locked float64
+	for id, p := range positions {
+		pairID, paired := pairs[id]
+		if paired && pairID < id {
+			if _, held := positions[pairID]; held {
+				continue // reported under the pair's lower asset ID
+			}
+		}
+		other := positions[pairID]
+		if p.NetSize == 0 && other.NetSize == 0 {
+			continue
+		}
+		if paired && pairID < id {
+			id, pairID, p, other = pairID, id, other, p
+		}
+		m := NettedMarket{
+			AssetID:       id,
+			NetSize:       p.NetSize,
+			AvgEntryPrice: p.AvgEntryPrice,
+			NetExposure:   p.NetSize,
+		}
+		if paired {
+			m.PairAssetID = pairID
+			m.PairNetSize = other.NetSize
+			m.PairAvgEntryPrice = other.AvgEntryPrice
+			// Short one outcome is long the other, so the pair nets to one
+			// signed size in this asset's terms. Shares held long on both sides
+			// pay out 1 USDC per pair whatever the result.
+			m.NetExposure = p.NetSize - other.NetSize
+			m.HedgedSize = math.Min(math.Max(p.NetSize, 0), math.Max(other.NetSize, 0))
+			if m.HedgedSize > 0 {
+				m.LockedProfitUSDC = round2(m.HedgedSize * (1 - p.AvgEntryPrice - other.AvgEntryPrice))
+			}
+		}
+		locked += m.LockedProfitUSDC
+		markets = append(markets, m)
+	}
+	sort.Slice(markets, func(i, j int) bool { return markets[i].AssetID < markets[j].AssetID })
+	s.writeJSON(w, NettedPositionsResponse{Markets: markets, LockedProfitUSDC: round2(locked)})
+}
+
+// GET /api/pnl-by-market — realized/unrealized PnL split per tracked asset,
+// unrealized marked to the book mid.
+func (s *Server) handlePnLByMarket(w http.ResponseWriter, _ *http.Request) {
+	positions := s.appState.TrackedPositions()
+	unrealized := s.appState.UnrealizedPnLByMarket()
+	unpriced := append([]string{}, s.appState.UnpricedPositions()...)
+	unpricedSet := make(map[string]bool, len(unpriced))
+	for _, id := range unpriced {
+		unpricedSet[id] = true
+	}
+	type marketPnL struct {
+		AssetID       string  `json:"asset_id"`
+		RealizedPnL   float64 `json:"realized_pnl"`
+		UnrealizedPnL float64 `json:"unrealized_pnl"`
+		TotalPnL      float64 `json:"total_pnl"`
+		Fills         int     `json:"fills"`
+		NetSize       float64 `json:"net_size"`
+		Priced        bool    `json:"priced"`
+	}
+	entries := []marketPnL{}
+	var realizedSum, unrealizedSum float64
+	for id, p := range positions {
+		u := unrealized[id]
+		if p.NetSize == 0 && p.RealizedPnL == 0 && u == 0 {
+			continue
+		}
+		entries = append(entries, marketPnL{
+			AssetID:       id,
+			RealizedPnL:   p.RealizedPnL,
+			UnrealizedPnL: u,
+			TotalPnL:      p.RealizedPnL + u,
+			Fills:         p.TotalFills,
+			NetSize:       p.NetSize,
+			Priced:        !unpricedSet[id],
+		})
+		realizedSum += p.RealizedPnL
+		unrealizedSum += u
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].AssetID < entries[j].AssetID })
+	s.writeJSON(w, map[string]interface{}{
+		"markets":              entries,
+		"count":                len(entries),
+		"total_realized_pnl":   realizedSum,
+		"total_unrealized_pnl": unrealizedSum,
+		"total_pnl":            realizedSum + unrealizedSum,
+		"unpriced_assets":      unpriced,
+	})
+}
+
+// GET /api/flows — net flow and VWAP per monitored asset over the taker's
+// flow window, the inputs behind taker signals.
+func (s *Server) handleFlows(w http.ResponseWriter, _ *http.Request) {
+	window, flows := s.appState.Flows()
+	type assetFlow struct {
+		AssetID string  `json:"asset_id"`
+		NetFlow float64 `json:"net_flow"`
+		VWAP    float64 `json:"vwap"`
+		Trades  int     `json:"trades"`
+	}
+	entries := make([]assetFlow, 0, len(flows))
+	for id, f := range flows {
+		entries = append(entries, assetFlow{AssetID: id, NetFlow: f.NetFlow, VWAP: f.VWAP, Trades: f.Trades})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].AssetID < entries[j].AssetID })
+	s.writeJSON(w, map[string]interface{}{
+		"window":     window.String(),
+		"window_sec": window.Seconds(),
+		"assets":     entries,
+		"count":      len(entries),
+	})
+}
+
+// GET /api/strategy?asset_id= — maker and taker parameters in effect,
+// globally or for one asset after its market override.
+func (s *Server) handleStrategy(w http.ResponseWriter, r *http.Request) {
+	assetID := strings.TrimSpace(r.URL.Query().Get("asset_id"))
+	maker, taker, override := s.appState.StrategyParams(assetID)
+	s.writeJSON(w, StrategyResponse{
+		AssetID:  assetID,
+		Override: override,
+		Maker: MakerParams{
+			MinSpreadBps:         maker.MinSpreadBps,
+			SpreadMultiplier:     maker.SpreadMultiplier,
+			OrderSizeUSDC:        maker.OrderSizeUSDC,
+			MinOrderSizeUSDC:     maker.MinOrderSizeUSDC,
+			MaxOrdersPerMarket:   maker.MaxOrdersPerMarket,
+			InventorySkewBps:     maker.InventorySkewBps,
+			InventoryWidenFactor: maker.InventoryWidenFactor,
+			VolSpreadMultiplier:  maker.VolSpreadMultiplier,
+			VolSpreadMaxBps:      maker.VolSpreadMaxBps,
+			PostFillPauseMs:      maker.PostFillPause.Milliseconds(),
+			UsePairFairValue:     maker.UsePairFairValue,
+			QuoteMode:            maker.QuoteMode,
+			TickSize:             maker.TickSize,
+		},
+		Taker: TakerParams{
+			MinImbalance:       taker.MinImbalance,
+			DepthLevels:        taker.DepthLevels,
+			AmountUSDC:         taker.AmountUSDC,
+			MaxSlippageBps:     taker.MaxSlippageBps,
+			CooldownS:          taker.Cooldown.Seconds(),
+			MinConfidenceBps:   taker.MinConfidenceBps,
+			FlowWeight:         taker.FlowWeight,
+			ImbalanceWeight:    taker.ImbalanceWeight,
+			ConvergenceWeight:  taker.ConvergenceWeight,
+			MomentumWeight:     taker.MomentumWeight,
+			MinConvergenceBps:  taker.MinConvergenceBps,
+			MinCompositeScore:  taker.MinCompositeScore,
+			RequireFeeCoverage: taker.RequireFeeCoverage,
+		},
+	})
+}
+
+// GET /api/pnl — realized + unrealized PnL. Open positions with no price to
+// mark them at are listed in unpriced_assets and left out of unrealized PnL.
 func (s *Server) handlePnL(w http.ResponseWriter, _ *http.Request) {
 	_, _, realized := s.appState.Stats()
 	unrealized := s.appState.UnrealizedPnL()
-	resp := map[string]interface{}{
-		"realized_pnl":   realized,
-		"unrealized_pnl": unrealized,
-		"total_pnl":      realized + unrealized,
+	resp := PnLResponse{
+		RealizedPnL:    realized,
+		UnrealizedPnL:  unrealized,
+		TotalPnL:       realized + unrealized,
+		UnpricedAssets: append([]string{}, s.appState.UnpricedPositions()...),
 	}
 	if s.portfolio != nil {
-		resp["portfolio_value"] = s.portfolio.TotalValue()
+		value := s.portfolio.TotalValue()
+		resp.PortfolioValue = &value
 	}
 	s.writeJSON(w, resp)
 }
 
+// GET /api/pnl-history?window=24h&bucket=5m — PnL time series for charting equity.
+func (s *Server) handlePnLHistory(w http.ResponseWriter, r *http.Request) {
+	window, err := parseHistoryDuration(r.URL.Query().Get("window"), 24*time.Hour)
+	if err != nil || window <= 0 {
+		http.Error(w, "invalid window", http.StatusBadRequest)
+		return
+	}
+	bucket, err := parseHistoryDuration(r.URL.Query().Get("bucket"), 0)
+	if err != nil || bucket < 0 {
+		http.Error(w, "invalid bucket", http.StatusBadRequest)
+		return
+	}
+	points := s.appState.PnLHistory(window, bucket)
+	s.writeJSON(w, map[string]interface{}{
+		"window_s": window.Seconds(),
+		"bucket_s": bucket.Seconds(),
+		"points":   points,
+		"count":    len(points),
+	})
+}
+
+// parseHistoryDuration accepts Go durations plus a day suffix such as "7d".
+func parseHistoryDuration(raw string, def time.Duration) (time.Duration, error) {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	if raw == "" {
+		return def, nil
+	}
+	if days, ok := strings.CutSuffix(raw, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(raw)
+}
+
 // GET /api/perf — high-level performance metrics.
 func (s *Server) handlePerf(w http.ResponseWriter, _ *http.Request) {
 	orders, fills, realized := s.appState.Stats()
@@ -278,23 +565,35 @@ func (s *Server) handlePerf(w http.ResponseWriter, _ *http.Request) {
 	mode := s.appState.TradingMode()
 	paperSnap := s.appState.PaperSnapshot()
 	fees := 0.0
-	var estimatedEquity interface{}
-	if mode == "paper" {
+	var estimatedEquity *float64
+	if s.appState.ExecMode() == "paper" {
 		fees = paperSnap.FeesPaidUSDC
-		estimatedEquity = paperSnap.InitialBalanceUSDC + total - fees
+		equity := paperSnap.InitialBalanceUSDC + total - fees
+		estimatedEquity = &equity
 	}
+	kpiStats := s.appState.KPIStats()
 
-	s.writeJSON(w, map[string]interface{}{
-		"trading_mode":            mode,
-		"orders":                  orders,
-		"fills":                   fills,
-		"realized_pnl_usdc":       realized,
-		"unrealized_pnl_usdc":     unrealized,
-		"total_pnl_usdc":          total,
-		"pnl_per_fill_usdc":       pnlPerFill,
-		"fees_paid_usdc":          fees,
-		"net_pnl_after_fees_usdc": total - fees,
-		"estimated_equity_usdc":   estimatedEquity,
+	s.writeJSON(w, PerfResponse{
+		TradingMode:         mode,
+		Orders:              orders,
+		Fills:               fills,
+		RealizedPnLUSDC:     realized,
+		UnrealizedPnLUSDC:   unrealized,
+		TotalPnLUSDC:        total,
+		PnLPerFillUSDC:      pnlPerFill,
+		FeesPaidUSDC:        fees,
+		NetPnLAfterFeesUSDC: total - fees,
+		EstimatedEquityUSDC: estimatedEquity,
+
+		MakerSpreadCaptureBps:     round2(mapFloat(kpiStats, "maker_spread_capture_bps", 0)),
+		MakerSpreadCaptureSamples: int(mapFloat(kpiStats, "maker_spread_capture_samples_daily", 0)),
+
+		FillRatioDaily: round2(mapFloat(kpiStats, "fill_ratio_daily", 0)),
+
+		MaxDrawdownUSDC:        round2(mapFloat(kpiStats, "max_drawdown_usdc", 0)),
+		MaxDrawdownPct:         round2(mapFloat(kpiStats, "max_drawdown_pct", 0)),
+		SessionMaxDrawdownUSDC: round2(mapFloat(kpiStats, "session_max_drawdown_usdc", 0)),
+		SessionMaxDrawdownPct:  round2(mapFloat(kpiStats, "session_max_drawdown_pct", 0)),
 	})
 }
 
@@ -574,7 +873,7 @@ func (s *Server) handleKPI(w http.ResponseWriter, _ *http.Request) {
 	totalPnL := realized + unrealized
 	paperSnap := s.appState.PaperSnapshot()
 	fees := 0.0
-	if mode == "paper" {
+	if s.appState.ExecMode() == "paper" {
 		fees = paperSnap.FeesPaidUSDC
 	}
 	netPnLAfterFees := totalPnL - fees
@@ -594,7 +893,7 @@ func (s *Server) handleKPI(w http.ResponseWriter, _ *http.Request) {
 	feeToGrossPnLRatio := safeDiv(fees, feeDenominator)
 
 	recentFills := s.appState.RecentFills(200)
-	execMetrics := calculateExecutionQualityMetrics(mode, fills, totalPnL, paperSnap, recentFills)
+	execMetrics := calculateExecutionQualityMetrics(s.appState.ExecMode(), fills, totalPnL, paperSnap, recentFills)
 	execBreakdown := calculateExecutionLossBreakdown(execMetrics)
 
 	executionLossBps := mapFloat(kpiStats, "execution_loss_bps", execBreakdown.TotalLossBps)
@@ -614,6 +913,12 @@ func (s *Server) handleKPI(w http.ResponseWriter, _ *http.Request) {
 	netPnL30dTotal := mapFloat(kpiStats, "net_pnl_30d_total_usdc", totalPnL)
 	netPnL30dSelected := mapFloat(kpiStats, "net_pnl_30d_selected_for_rav_usdc", netPnL30dTotal)
 	netPnL30dWindowDays := mapInt(kpiStats, "net_pnl_30d_window_effective_days", 0)
+	netPnL7d := map[string]interface{}{
+		"realized_only_usdc":    round2(mapFloat(kpiStats, "net_pnl_7d_realized_usdc", 0)),
+		"total_usdc":            round2(mapFloat(kpiStats, "net_pnl_7d_total_usdc", 0)),
+		"after_fees_usdc":       round2(mapFloat(kpiStats, "net_pnl_7d_after_fees_usdc", 0)),
+		"effective_window_days": mapInt(kpiStats, "net_pnl_7d_window_effective_days", 0),
+	}
 
 	execQualityFactor30d := mapFloat(kpiStats, "exec_quality_factor_30d", 0)
 	if execQualityFactor30d <= 0 {
@@ -690,6 +995,7 @@ func (s *Server) handleKPI(w http.ResponseWriter, _ *http.Request) {
 			"exec_quality_factor_30d": round2(execQualityFactor30d),
 			"builder_factor_30d":      round2(builderFactor30d),
 		},
+		"net_pnl_7d": netPnL7d,
 		"process_metrics": map[string]interface{}{
 			"signal_count_daily":                     signalCount,
 			"maker_signal_count_daily":               makerSignals,
@@ -742,7 +1048,7 @@ func (s *Server) handleGrantReport(w http.ResponseWriter, r *http.Request) {
 	paperSnap := s.appState.PaperSnapshot()
 	fees := 0.0
 	var estimatedEquity interface{}
-	if mode == "paper" {
+	if s.appState.ExecMode() == "paper" {
 		fees = paperSnap.FeesPaidUSDC
 		estimatedEquity = paperSnap.InitialBalanceUSDC + total - fees
 	}
@@ -755,6 +1061,7 @@ func (s *Server) handleGrantReport(w http.ResponseWriter, r *http.Request) {
 	builderNeverSynced := true
 	builderStale := false
 	builderData := map[string]interface{}{
+		"status":             healthNotConfigured,
 		"configured":         builderConfigured,
 		"daily_volume_count": builderDailyVolumeCount,
 		"leaderboard_count":  builderLeaderboardCount,
@@ -784,6 +1091,7 @@ func (s *Server) handleGrantReport(w http.ResponseWriter, r *http.Request) {
 			builderStale = age > builderStaleAfter
 		}
 		builderData = map[string]interface{}{
+			"status":             dependencyStatus(true, s.builder.LastError()).Status,
 			"configured":         builderConfigured,
 			"daily_volume_count": builderDailyVolumeCount,
 			"leaderboard_count":  builderLeaderboardCount,
@@ -965,8 +1273,12 @@ func formatCSVNumber(v interface{}) string {
 	}
 }
 
-// GET /api/trades?limit=50 — recent trade fills.
+// GET /api/trades?limit=50 — recent trade fills. Add ?format=csv for the full history.
 func (s *Server) handleTrades(w http.ResponseWriter, r *http.Request) {
+	if strings.EqualFold(strings.TrimSpace(r.URL.Query().Get("format")), "csv") {
+		s.writeTradesCSV(w)
+		return
+	}
 	limit := 50
 	if v := r.URL.Query().Get("limit"); v != "" {
 		if n, err := strconv.Atoi(v); err == nil && n > 0 {
@@ -996,6 +1308,98 @@ func (s *Server) handleTrades(w http.ResponseWriter, r *http.Request) {
 	s.writeJSON(w, map[string]interface{}{"trades": entries, "count": len(entries)})
 }
 
+// GET /api/fills/summary — win rate, average and largest win/loss, and
+// expectancy over the tracker's closed round-trips.
+func (s *Server) handleFillsSummary(w http.ResponseWriter, _ *http.Request) {
+	sum := execution.SummarizeRoundTrips(s.appState.RoundTrips())
+	s.writeJSON(w, FillsSummaryResponse{
+		RoundTrips:      sum.Trips,
+		Wins:            sum.Wins,
+		Losses:          sum.Losses,
+		WinRatePct:      round2(sum.WinRate * 100),
+		AvgWinUSDC:      round2(sum.AvgWin),
+		AvgLossUSDC:     round2(sum.AvgLoss),
+		LargestWinUSDC:  round2(sum.LargestWin),
+		LargestLossUSDC: round2(sum.LargestLoss),
+		ExpectancyUSDC:  round2(sum.Expectancy),
+	})
+}
+
+// GET /api/rejections?limit=50 — recent orders blocked by risk checks or
+// refused by the CLOB, most recent first.
+func (s *Server) handleRejections(w http.ResponseWriter, r *http.Request) {
+	limit := 50
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			limit = n
+		}
+	}
+	rejections := s.appState.Rejections(limit)
+	entries := make([]RejectionEntry, len(rejections))
+	for i, rj := range rejections {
+		entries[i] = RejectionEntry{
+			Timestamp: rj.Time,
+			AssetID:   rj.AssetID,
+			Side:      rj.Side,
+			SizeUSDC:  rj.SizeUSDC,
+			Source:    rj.Source,
+			Reason:    rj.Reason,
+		}
+	}
+	s.writeJSON(w, RejectionsResponse{Rejections: entries, Count: len(entries)})
+}
+
+// GET /api/trades.csv — full fill history as CSV.
+func (s *Server) handleTradesCSV(w http.ResponseWriter, _ *http.Request) {
+	s.writeTradesCSV(w)
+}
+
+// tradesCSVPageSize bounds how many fills are copied out of the tracker per page.
+const tradesCSVPageSize = 500
+
+// writeTradesCSV streams the fill history oldest-first, one page at a time.
+func (s *Server) writeTradesCSV(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="trades.csv"`)
+	cw := csv.NewWriter(w)
+	header := []string{"trade_id", "asset_id", "side", "price", "size", "fee", "notional", "timestamp"}
+	if err := cw.Write(header); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	flusher, _ := w.(http.Flusher)
+	for offset := 0; ; offset += tradesCSVPageSize {
+		page := s.appState.FillHistory(offset, tradesCSVPageSize)
+		for _, f := range page {
+			record := []string{
+				f.TradeID,
+				f.AssetID,
+				f.Side,
+				fmt.Sprintf("%.6f", f.Price),
+				fmt.Sprintf("%.6f", f.Size),
+				fmt.Sprintf("%.6f", f.Fee),
+				fmt.Sprintf("%.6f", f.Price*f.Size),
+				f.Timestamp.UTC().Format(time.RFC3339Nano),
+			}
+			if err := cw.Write(record); err != nil {
+				log.Printf("trades csv: %v", err)
+				return
+			}
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			log.Printf("trades csv: %v", err)
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		if len(page) < tradesCSVPageSize {
+			return
+		}
+	}
+}
+
 // GET /api/orders — active (LIVE) orders.
 func (s *Server) handleOrders(w http.ResponseWriter, _ *http.Request) {
 	orders := s.appState.ActiveOrders()
@@ -1007,6 +1411,7 @@ func (s *Server) handleOrders(w http.ResponseWriter, _ *http.Request) {
 		Price      float64   `json:"price"`
 		OrigSize   float64   `json:"orig_size"`
 		FilledSize float64   `json:"filled_size"`
+		Strategy   string    `json:"strategy,omitempty"`
 		CreatedAt  time.Time `json:"created_at"`
 	}
 	entries := make([]orderEntry, len(orders))
@@ -1019,23 +1424,90 @@ func (s *Server) handleOrders(w http.ResponseWriter, _ *http.Request) {
 			Price:      o.Price,
 			OrigSize:   o.OrigSize,
 			FilledSize: o.FilledSize,
+			Strategy:   o.Strategy,
 			CreatedAt:  o.CreatedAt,
 		}
 	}
 	s.writeJSON(w, map[string]interface{}{"orders": entries, "count": len(entries)})
 }
 
-// GET /api/markets — monitored markets.
+// GET /api/markets — monitored markets, those with stale books and those
+// the maker is sitting out after churning quotes without fills.
 func (s *Server) handleMarkets(w http.ResponseWriter, _ *http.Request) {
 	assets := s.appState.MonitoredAssets()
-	s.writeJSON(w, map[string]interface{}{"assets": assets, "count": len(assets)})
+	stale := s.appState.StaleAssets()
+	if stale == nil {
+		stale = []string{}
+	}
+	cooling := s.appState.CancelCooldownAssets()
+	if cooling == nil {
+		cooling = []string{}
+	}
+	s.writeJSON(w, map[string]interface{}{
+		"assets":          assets,
+		"count":           len(assets),
+		"stale_assets":    stale,
+		"stale_count":     len(stale),
+		"cooldown_assets": cooling,
+		"cooldown_count":  len(cooling),
+	})
+}
+
+// GET /api/markets/{assetID}?levels=5&bps=100 — spread, depth and imbalance
+// for one monitored asset's book.
+func (s *Server) handleMarketDetail(w http.ResponseWriter, r *http.Request) {
+	assetID := strings.TrimSpace(strings.TrimPrefix(r.URL.Path, "/api/markets/"))
+	if assetID == "" {
+		s.handleMarkets(w, r)
+		return
+	}
+	levels := 5
+	if v := r.URL.Query().Get("levels"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "invalid levels", http.StatusBadRequest)
+			return
+		}
+		levels = n
+	}
+	depthBps := 100.0
+	if v := r.URL.Query().Get("bps"); v != "" {
+		bps, err := strconv.ParseFloat(v, 64)
+		if err != nil || bps < 0 {
+			http.Error(w, "invalid bps", http.StatusBadRequest)
+			return
+		}
+		depthBps = bps
+	}
+	metrics, ok := s.appState.BookMetrics(assetID, levels, depthBps)
+	if !ok {
+		http.Error(w, "unknown asset", http.StatusNotFound)
+		return
+	}
+	s.writeJSON(w, metrics)
+}
+
+// GET /api/book/{asset_id} — every stored level of the book the bot is
+// acting on, with mid, spread and last update.
+func (s *Server) handleBook(w http.ResponseWriter, r *http.Request) {
+	assetID := strings.TrimSpace(strings.TrimPrefix(r.URL.Path, "/api/book/"))
+	if assetID == "" {
+		http.Error(w, "missing asset_id", http.StatusBadRequest)
+		return
+	}
+	book, ok := s.appState.Book(assetID)
+	if !ok {
+		http.Error(w, "unknown asset", http.StatusNotFound)
+		return
+	}
+	s.writeJSON(w, book)
 }
 
 // GET /api/builder — builder volume and leaderboard data.
 func (s *Server) handleBuilder(w http.ResponseWriter, _ *http.Request) {
 	if s.builder == nil {
 		s.writeJSON(w, map[string]interface{}{
-			"status":             "not_configured",
+			"status":             healthNotConfigured,
 			"configured":         false,
 			"daily_volume_count": 0,
 			"leaderboard_count":  0,
@@ -1050,6 +1522,7 @@ func (s *Server) handleBuilder(w http.ResponseWriter, _ *http.Request) {
 	leaderboard := s.builder.LeaderboardJSON()
 	lastSync := s.builder.LastSync()
 	neverSynced := lastSync.IsZero()
+	dep := dependencyStatus(true, s.builder.LastError())
 	var lastSyncAgeS interface{}
 	stale := neverSynced
 	if !neverSynced {
@@ -1060,7 +1533,8 @@ func (s *Server) handleBuilder(w http.ResponseWriter, _ *http.Request) {
 		lastSyncAgeS = age.Seconds()
 		stale = age > builderStaleAfter
 	}
-	s.writeJSON(w, map[string]interface{}{
+	resp := map[string]interface{}{
+		"status":             dep.Status,
 		"configured":         true,
 		"daily_volume":       dailyVolume,
 		"daily_volume_count": countEntries(dailyVolume),
@@ -1071,7 +1545,11 @@ func (s *Server) handleBuilder(w http.ResponseWriter, _ *http.Request) {
 		"never_synced":       neverSynced,
 		"stale":              stale,
 		"stale_after_s":      builderStaleAfter.Seconds(),
-	})
+	}
+	if dep.Error != "" {
+		resp["error"] = dep.Error
+	}
+	s.writeJSON(w, resp)
 }
 
 func countEntries(v interface{}) int {
@@ -1096,6 +1574,7 @@ type riskStatus struct {
 }
 
 type builderStatus struct {
+	status             string // ok, degraded or not_configured
 	configured         bool
 	dailyVolumeCount   int
 	leaderboardCount   int
@@ -1108,6 +1587,7 @@ type builderStatus struct {
 func (s *Server) currentBuilderStatus() builderStatus {
 	if s.builder == nil {
 		return builderStatus{
+			status:             healthNotConfigured,
 			configured:         false,
 			dailyVolumeCount:   0,
 			leaderboardCount:   0,
@@ -1132,6 +1612,7 @@ func (s *Server) currentBuilderStatus() builderStatus {
 	}
 	fresh := !neverSynced && !stale
 	return builderStatus{
+		status:             dependencyStatus(true, s.builder.LastError()).Status,
 		configured:         true,
 		dailyVolumeCount:   countEntries(s.builder.DailyVolumeJSON()),
 		leaderboardCount:   countEntries(s.builder.LeaderboardJSON()),
@@ -1172,6 +1653,9 @@ func buildRiskStatus(snap risk.Snapshot) riskStatus {
 	if snap.InCooldown {
 		st.blockedReasons = append(st.blockedReasons, "loss_cooldown_active")
 	}
+	if snap.GrossExposureLimit > 0 && snap.GrossExposureUSDC >= snap.GrossExposureLimit {
+		st.blockedReasons = append(st.blockedReasons, "gross_exposure_limit_reached")
+	}
 	st.canTrade = len(st.blockedReasons) == 0
 	return st
 }
@@ -1297,23 +1781,6 @@ func clamp(v, min, max float64) float64 {
 	return v
 }
 
-func marketScoreValue(realizedPnL float64, fills int, fillSharePct float64) float64 {
-	score := 50.0
-	if fills > 0 {
-		score += clamp((realizedPnL/float64(fills))*40, -30, 30)
-	}
-	score += clamp(realizedPnL*6, -20, 20)
-	if fills >= 10 {
-		score += 10
-	} else if fills < 3 {
-		score -= 5
-	}
-	if fillSharePct >= 50 && realizedPnL < 0 {
-		score -= 15
-	}
-	return clamp(score, 0, 100)
-}
-
 func marketBucket(score float64) string {
 	if score >= 70 {
 		return "focus"
@@ -1342,7 +1809,7 @@ func buildMarketScores(positions map[string]execution.Position) []marketScore {
 			fillSharePct = float64(pos.TotalFills) / float64(totalFills) * 100
 		}
 		pnlPerFill := pos.RealizedPnL / float64(pos.TotalFills)
-		score := marketScoreValue(pos.RealizedPnL, pos.TotalFills, fillSharePct)
+		score := strategy.MarketProfitScore(pos.RealizedPnL, pos.TotalFills, fillSharePct)
 		scores = append(scores, marketScore{
 			AssetID:         assetID,
 			RealizedPnLUSDC: pos.RealizedPnL,
@@ -1363,14 +1830,74 @@ func buildMarketScores(positions map[string]execution.Position) []marketScore {
 	return scores
 }
 
+// defaultInsightConcentrationHHI flags concentration in /api/insights when
+// risk.concentration_warn_hhi is not configured.
+const defaultInsightConcentrationHHI = 0.5
+
+// marketConcentration is the Herfindahl index of the book, the sum of squared
+// shares: 1 with everything in one market, 1/n when spread evenly over n.
+type marketConcentration struct {
+	ExposureHHI         float64 `json:"exposure_hhi"`
+	FillHHI             float64 `json:"fill_hhi"`
+	Markets             int     `json:"markets"`
+	TopAssetID          string  `json:"top_asset_id,omitempty"`
+	TopExposureSharePct float64 `json:"top_exposure_share_pct"`
+	WarnHHI             float64 `json:"warn_hhi"`
+	High                bool    `json:"high"`
+}
+
+// buildMarketConcentration measures concentration of open exposure (entry
+// notional, as the risk manager counts it) and of fill share across markets.
+func buildMarketConcentration(positions map[string]execution.Position, warnHHI float64) marketConcentration {
+	if warnHHI <= 0 {
+		warnHHI = defaultInsightConcentrationHHI
+	}
+	out := marketConcentration{WarnHHI: warnHHI}
+
+	exposures := make(map[string]float64, len(positions))
+	var totalExposure float64
+	totalFills := 0
+	for assetID, pos := range positions {
+		if exposure := math.Abs(pos.NetSize * pos.AvgEntryPrice); exposure > 0 {
+			exposures[assetID] = exposure
+			totalExposure += exposure
+		}
+		if pos.TotalFills > 0 {
+			totalFills += pos.TotalFills
+		}
+	}
+
+	var topExposure float64
+	for assetID, exposure := range exposures {
+		share := exposure / totalExposure
+		out.ExposureHHI += share * share
+		if exposure > topExposure || (exposure == topExposure && assetID < out.TopAssetID) {
+			topExposure, out.TopAssetID = exposure, assetID
+		}
+	}
+	out.Markets = len(exposures)
+	if totalExposure > 0 {
+		out.TopExposureSharePct = round2(topExposure / totalExposure * 100)
+	}
+	for _, pos := range positions {
+		if pos.TotalFills > 0 && totalFills > 0 {
+			share := float64(pos.TotalFills) / float64(totalFills)
+			out.FillHHI += share * share
+		}
+	}
+	out.High = out.Markets > 0 && out.ExposureHHI > warnHHI
+	return out
+}
+
 func buildInsightRecommendations(
 	canTrade bool,
 	blockedReasons []string,
 	fills int,
 	pnlPerFill float64,
 	scores []marketScore,
+	concentration marketConcentration,
 ) []coachAction {
-	recs := make([]coachAction, 0, 6)
+	recs := make([]coachAction, 0, 7)
 	if !canTrade {
 		recs = append(recs, coachAction{
 			Code:     "pause_trading",
@@ -1378,6 +1905,14 @@ func buildInsightRecommendations(
 			Message:  fmt.Sprintf("Trading blocked by risk rules: %s", strings.Join(blockedReasons, ",")),
 		})
 	}
+	if concentration.High {
+		recs = append(recs, coachAction{
+			Code:     "diversify_exposure",
+			Severity: "warn",
+			Message: fmt.Sprintf("Exposure is concentrated (HHI %.2f, %.0f%% in %s); spread size across more markets or trim the largest position.",
+				concentration.ExposureHHI, concentration.TopExposureSharePct, concentration.TopAssetID),
+		})
+	}
 	if len(scores) == 0 {
 		recs = append(recs, coachAction{
 			Code:     "collect_more_data",
@@ -1479,8 +2014,16 @@ func sumFillNotional(fills []execution.Fill) float64 {
 	return total
 }
 
+func sumFillFees(fills []execution.Fill) float64 {
+	total := 0.0
+	for _, f := range fills {
+		total += f.Fee
+	}
+	return total
+}
+
 func calculateExecutionQualityMetrics(
-	mode string,
+	execMode string,
 	fills int,
 	totalPnL float64,
 	paperSnap paper.Snapshot,
@@ -1489,12 +2032,14 @@ func calculateExecutionQualityMetrics(
 	fees := 0.0
 	volume := 0.0
 	trades := fills
-	if mode == "paper" {
+	if execMode == "paper" {
 		fees = paperSnap.FeesPaidUSDC
 		volume = paperSnap.TotalVolumeUSDC
 		if paperSnap.TotalTrades > 0 {
 			trades = paperSnap.TotalTrades
 		}
+	} else {
+		fees = sumFillFees(recentFills)
 	}
 
 	fallbackVolume := sumFillNotional(recentFills)
@@ -2813,19 +3358,39 @@ func (s *Server) writeStageReportCSV(
 func (s *Server) handleRisk(w http.ResponseWriter, _ *http.Request) {
 	snap := s.appState.RiskSnapshot()
 	rs := buildRiskStatus(snap)
-	s.writeJSON(w, map[string]interface{}{
-		"emergency_stop":            snap.EmergencyStop,
-		"daily_pnl":                 snap.DailyPnL,
-		"daily_loss_limit_usdc":     snap.DailyLossLimitUSDC,
-		"daily_loss_used_pct":       rs.usagePct,
-		"daily_loss_remaining_usdc": rs.remainingUSDC,
-		"daily_loss_remaining_pct":  rs.remainingPct,
-		"can_trade":                 rs.canTrade,
-		"blocked_reasons":           rs.blockedReasons,
-		"consecutive_losses":        snap.ConsecutiveLosses,
-		"max_consecutive_losses":    snap.MaxConsecutiveLosses,
-		"in_cooldown":               snap.InCooldown,
-		"cooldown_remaining_s":      snap.CooldownRemaining.Seconds(),
+	positionsUSDC := snap.PositionsUSDC
+	if positionsUSDC == nil {
+		positionsUSDC = map[string]float64{}
+	}
+	groupExposure := snap.GroupExposureUSDC
+	if groupExposure == nil {
+		groupExposure = map[string]float64{}
+	}
+	s.writeJSON(w, RiskResponse{
+		EmergencyStop:                 snap.EmergencyStop,
+		DailyPnL:                      snap.DailyPnL,
+		DailyLossLimitUSDC:            snap.DailyLossLimitUSDC,
+		DailyLossUsedPct:              rs.usagePct,
+		DailyLossRemainingUSDC:        rs.remainingUSDC,
+		DailyLossRemainingPct:         rs.remainingPct,
+		CanTrade:                      rs.canTrade,
+		BlockedReasons:                rs.blockedReasons,
+		ConsecutiveLosses:             snap.ConsecutiveLosses,
+		MaxConsecutiveLosses:          snap.MaxConsecutiveLosses,
+		InCooldown:                    snap.InCooldown,
+		CooldownRemainingS:            snap.CooldownRemaining.Seconds(),
+		CooldownMultiplier:            snap.CooldownMultiplier,
+		DrawdownVelocityUSDCPerMin:    snap.DrawdownVelocity,
+		MaxDrawdownVelocityUSDCPerMin: snap.MaxDrawdownVelocity,
+		PositionsUSDC:                 positionsUSDC,
+		ConcentrationHHI:              snap.ConcentrationHHI,
+		ConcentrationWarnHHI:          snap.ConcentrationWarnHHI,
+		ConcentrationWarning:          snap.ConcentrationWarning,
+		GrossExposureUSDC:             snap.GrossExposureUSDC,
+		GrossExposureLimitUSDC:        snap.GrossExposureLimit,
+		GroupExposureUSDC:             groupExposure,
+		GroupExposureLimitUSDC:        snap.GroupExposureLimit,
+		MaxOrderNotionalUSDC:          snap.MaxOrderNotional,
 	})
 }
 
@@ -2985,7 +3550,7 @@ func (s *Server) handleSizing(w http.ResponseWriter, _ *http.Request) {
 
 	recentFills := s.appState.RecentFills(200)
 	metrics := calculateExecutionQualityMetrics(
-		mode,
+		s.appState.ExecMode(),
 		fills,
 		totalPnL,
 		s.appState.PaperSnapshot(),
@@ -3051,13 +3616,16 @@ func (s *Server) handleInsights(w http.ResponseWriter, _ *http.Request) {
 		snap.MaxConsecutiveLosses,
 	)
 
-	marketScores := buildMarketScores(s.appState.TrackedPositions())
+	positions := s.appState.TrackedPositions()
+	marketScores := buildMarketScores(positions)
+	concentration := buildMarketConcentration(positions, snap.ConcentrationWarnHHI)
 	recommendations := buildInsightRecommendations(
 		rs.canTrade,
 		rs.blockedReasons,
 		fills,
 		pnlPerFill,
 		marketScores,
+		concentration,
 	)
 
 	s.writeJSON(w, map[string]interface{}{
@@ -3066,6 +3634,7 @@ func (s *Server) handleInsights(w http.ResponseWriter, _ *http.Request) {
 		"can_trade":       rs.canTrade,
 		"blocked_reasons": rs.blockedReasons,
 		"market_scores":   marketScores,
+		"concentration":   concentration,
 		"recommendations": recommendations,
 		"summary": map[string]interface{}{
 			"fills":               fills,
@@ -3091,7 +3660,7 @@ func (s *Server) handleAlphaManager(w http.ResponseWriter, _ *http.Request) {
 	snap := s.appState.RiskSnapshot()
 	rs := buildRiskStatus(snap)
 	recentFills := s.appState.RecentFills(200)
-	metrics := calculateExecutionQualityMetrics(mode, fills, totalPnL, s.appState.PaperSnapshot(), recentFills)
+	metrics := calculateExecutionQualityMetrics(s.appState.ExecMode(), fills, totalPnL, s.appState.PaperSnapshot(), recentFills)
 	breakdown := calculateExecutionLossBreakdown(metrics)
 	marketPolicies := buildAlphaMarketPolicies(buildMarketScores(s.appState.TrackedPositions()))
 	strategyPolicies := buildAlphaStrategyPolicies(metrics, breakdown, fills)
@@ -3129,7 +3698,7 @@ func (s *Server) handleGrowthFunnel(w http.ResponseWriter, _ *http.Request) {
 	fees := 0.0
 	var estimatedEquity interface{}
 	paperSnap := s.appState.PaperSnapshot()
-	if mode == "paper" {
+	if s.appState.ExecMode() == "paper" {
 		fees = paperSnap.FeesPaidUSDC
 		estimatedEquity = paperSnap.InitialBalanceUSDC + totalPnL - fees
 	}
@@ -3158,13 +3727,12 @@ func (s *Server) handleGrowthFunnel(w http.ResponseWriter, _ *http.Request) {
 
 // GET /api/profiles — productized parameter presets for user segmentation.
 func (s *Server) handleProfiles(w http.ResponseWriter, _ *http.Request) {
-	mode := s.appState.TradingMode()
 	_, fills, realized := s.appState.Stats()
 	unrealized := s.appState.UnrealizedPnL()
 	totalPnL := realized + unrealized
 	snap := s.appState.RiskSnapshot()
 	rs := buildRiskStatus(snap)
-	metrics := calculateExecutionQualityMetrics(mode, fills, totalPnL, s.appState.PaperSnapshot(), s.appState.RecentFills(120))
+	metrics := calculateExecutionQualityMetrics(s.appState.ExecMode(), fills, totalPnL, s.appState.PaperSnapshot(), s.appState.RecentFills(120))
 	recommended := recommendProfile(rs, metrics)
 
 	s.writeJSON(w, map[string]interface{}{
@@ -3183,7 +3751,6 @@ func (s *Server) handleProfiles(w http.ResponseWriter, _ *http.Request) {
 // GET /api/ecosystem-playbook — builder/grant ecosystem automation playbook.
 func (s *Server) handleEcosystemPlaybook(w http.ResponseWriter, _ *http.Request) {
 	generatedAt := time.Now().UTC()
-	mode := s.appState.TradingMode()
 	_, fills, realized := s.appState.Stats()
 	unrealized := s.appState.UnrealizedPnL()
 	totalPnL := realized + unrealized
@@ -3191,7 +3758,7 @@ func (s *Server) handleEcosystemPlaybook(w http.ResponseWriter, _ *http.Request)
 	snap := s.appState.RiskSnapshot()
 	rs := buildRiskStatus(snap)
 	builder := s.currentBuilderStatus()
-	metrics := calculateExecutionQualityMetrics(mode, fills, totalPnL, s.appState.PaperSnapshot(), s.appState.RecentFills(200))
+	metrics := calculateExecutionQualityMetrics(s.appState.ExecMode(), fills, totalPnL, s.appState.PaperSnapshot(), s.appState.RecentFills(200))
 
 	hasTradingActivity := fills > 0
 	readinessScore := calcReadinessScore(builder.fresh, rs.canTrade, hasTradingActivity)
@@ -3230,7 +3797,7 @@ func (s *Server) handleExecutionQuality(w http.ResponseWriter, _ *http.Request)
 	rs := buildRiskStatus(snap)
 	recentFills := s.appState.RecentFills(200)
 	metrics := calculateExecutionQualityMetrics(
-		mode,
+		s.appState.ExecMode(),
 		fills,
 		totalPnL,
 		s.appState.PaperSnapshot(),
@@ -3279,7 +3846,7 @@ func (s *Server) handleDailyReport(w http.ResponseWriter, _ *http.Request) {
 
 	recentFills := s.appState.RecentFills(200)
 	metrics := calculateExecutionQualityMetrics(
-		mode,
+		s.appState.ExecMode(),
 		fills,
 		totalPnL,
 		s.appState.PaperSnapshot(),
@@ -3353,7 +3920,7 @@ func (s *Server) handleTelegramTemplates(w http.ResponseWriter, r *http.Request)
 	)
 	recentFills := s.appState.RecentFills(200)
 	metrics := calculateExecutionQualityMetrics(
-		mode,
+		s.appState.ExecMode(),
 		fills,
 		totalPnL,
 		s.appState.PaperSnapshot(),
@@ -3464,7 +4031,7 @@ func (s *Server) handleStageReport(w http.ResponseWriter, r *http.Request) {
 	readinessScore := calcReadinessScore(builder.fresh, rs.canTrade, hasTradingActivity)
 
 	recentFills := s.appState.RecentFills(200)
-	metrics := calculateExecutionQualityMetrics(mode, fills, totalPnL, paperSnap, recentFills)
+	metrics := calculateExecutionQualityMetrics(s.appState.ExecMode(), fills, totalPnL, paperSnap, recentFills)
 	breakdown := calculateExecutionLossBreakdown(metrics)
 	profitUplift := buildExecutionProfitUplift(metrics, breakdown)
 	grantReadinessScore := int(math.Round(float64(readinessScore)*0.6 + metrics.QualityScore*0.4))
@@ -3600,6 +4167,7 @@ func (s *Server) handleStageReport(w http.ResponseWriter, r *http.Request) {
 			"source_breakdown_total_loss_bps":   breakdown.TotalLossBps,
 		},
 		"builder": map[string]interface{}{
+			"status":             builder.status,
 			"configured":         builder.configured,
 			"daily_volume_count": builder.dailyVolumeCount,
 			"leaderboard_count":  builder.leaderboardCount,
@@ -3647,7 +4215,7 @@ func (s *Server) handleGrantPackage(w http.ResponseWriter, r *http.Request) {
 	readinessScore := calcReadinessScore(builder.fresh, rs.canTrade, hasTradingActivity)
 
 	recentFills := s.appState.RecentFills(200)
-	metrics := calculateExecutionQualityMetrics(mode, fills, totalPnL, s.appState.PaperSnapshot(), recentFills)
+	metrics := calculateExecutionQualityMetrics(s.appState.ExecMode(), fills, totalPnL, s.appState.PaperSnapshot(), recentFills)
 	breakdown := calculateExecutionLossBreakdown(metrics)
 	profitUplift := buildExecutionProfitUplift(metrics, breakdown)
 	grantReadinessScore := int(math.Round(float64(readinessScore)*0.6 + metrics.QualityScore*0.4))
@@ -3767,3 +4335,203 @@ func (s *Server) handleEmergencyStop(w http.ResponseWriter, r *http.Request) {
 	s.appState.SetEmergencyStop(true)
 	s.writeJSON(w, map[string]string{"status": "emergency_stop_activated"})
 }
+
+// POST /api/emergency-stop/clear — resume trading after an emergency stop.
+// It is refused with 409 and the blocking reasons while any other guardrail,
+// such as the daily loss cap or a loss cooldown, would still halt trading.
+func (s *Server) handleEmergencyStopClear(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var req EmergencyStopClearRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&req); err != nil {
+		http.Error(w, "invalid json: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Confirm != EmergencyStopClearConfirm {
+		http.Error(w, fmt.Sprintf("confirm must be %q", EmergencyStopClearConfirm), http.StatusBadRequest)
+		return
+	}
+	// Clearing resumes every account, so any account's guardrail blocks it.
+	reasons := make([]string, 0, 3)
+	for _, snap := range s.appState.RiskSnapshots() {
+		for _, reason := range buildRiskStatus(snap).blockedReasons {
+			if reason != "emergency_stop" && !slices.Contains(reasons, reason) {
+				reasons = append(reasons, reason)
+			}
+		}
+	}
+	if len(reasons) > 0 {
+		w.WriteHeader(http.StatusConflict)
+		s.writeJSON(w, EmergencyStopClearResponse{Reasons: reasons})
+		return
+	}
+	s.appState.SetEmergencyStop(false)
+	log.Println("emergency stop cleared via API")
+	s.writeJSON(w, EmergencyStopClearResponse{Cleared: true, Reasons: reasons})
+}
+
+// POST /api/rescan — reselect markets now and report the feed changes.
+func (s *Server) handleRescan(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	added, removed, err := s.appState.Rescan(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	added = append([]string{}, added...)
+	removed = append([]string{}, removed...)
+	sort.Strings(added)
+	sort.Strings(removed)
+	s.writeJSON(w, map[string]interface{}{
+		"added":   added,
+		"removed": removed,
+	})
+}
+
+// GET /api/selector/candidates — every market the last selection run
+// evaluated, with its inputs, the filters it passed and whether it was
+// selected. LastRun is null until the selector has run.
+func (s *Server) handleSelectorCandidates(w http.ResponseWriter, _ *http.Request) {
+	lastRun, evals := s.appState.SelectorCandidates()
+	resp := SelectorCandidatesResponse{Candidates: make([]SelectorCandidate, len(evals))}
+	if !lastRun.IsZero() {
+		resp.LastRun = &lastRun
+	}
+	for i, e := range evals {
+		tokenIDs := append([]string{}, e.TokenIDs...)
+		resp.Candidates[i] = SelectorCandidate{
+			MarketID:      e.MarketID,
+			Question:      e.Question,
+			TokenIDs:      tokenIDs,
+			Liquidity:     e.Liquidity,
+			Volume24hr:    e.Volume24hr,
+			Spread:        e.Spread,
+			DaysToEnd:     round2(e.DaysToEnd),
+			VolatilityBps: round2(e.VolatilityBps),
+			Score:         e.Score,
+			Filters: SelectorFilterResults{
+				Liquidity:  e.Filters.Liquidity,
+				Volume24hr: e.Filters.Volume24hr,
+				Spread:     e.Filters.Spread,
+				DaysToEnd:  e.Filters.DaysToEnd,
+				Volatility: e.Filters.Volatility,
+			},
+			Passed:   e.Filters.Passed(),
+			Selected: e.Selected,
+		}
+		if e.Selected {
+			resp.Selected++
+		}
+	}
+	resp.Count = len(resp.Candidates)
+	s.writeJSON(w, resp)
+}
+
+// POST /api/strategy/toggle — switch the maker and/or taker on or off.
+// Omitted fields are left as they are.
+func (s *Server) handleStrategyToggle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var req StrategyToggleRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&req); err != nil {
+		http.Error(w, "invalid json: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Maker == nil && req.Taker == nil {
+		http.Error(w, "maker or taker is required", http.StatusBadRequest)
+		return
+	}
+	makerOn, takerOn, err := s.appState.SetStrategiesEnabled(req.Maker, req.Taker)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	s.writeJSON(w, StrategyToggleResponse{Maker: makerOn, Taker: takerOn})
+}
+
+// POST /api/simulate/quote — the maker quote the current config would post
+// for a hypothetical book. Nothing is placed.
+func (s *Server) handleSimulateQuote(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var req SimulateQuoteRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&req); err != nil {
+		http.Error(w, "invalid json: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(req.Bids) == 0 || len(req.Asks) == 0 {
+		http.Error(w, "bids and asks are required", http.StatusBadRequest)
+		return
+	}
+	var inv *strategy.InventoryState
+	if req.Inventory != nil {
+		inv = &strategy.InventoryState{NetPosition: req.Inventory.NetPosition, AvgEntryPrice: req.Inventory.AvgEntryPrice}
+	}
+	preview, err := s.appState.SimulateQuote(strings.TrimSpace(req.AssetID), req.Bids, req.Asks, inv)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.writeJSON(w, SimulateQuoteResponse{
+		AssetID:      req.AssetID,
+		BuyPrice:     preview.Quote.BuyPrice,
+		SellPrice:    preview.Quote.SellPrice,
+		Size:         preview.Quote.Size,
+		FeeRateBps:   preview.FeeRateBps,
+		FeeAdjusted:  preview.FeeAdjusted,
+		RawBuyPrice:  preview.RawQuote.BuyPrice,
+		RawSellPrice: preview.RawQuote.SellPrice,
+	})
+}
+
+// POST /api/signals/external — place an order for a signal from an external feed.
+func (s *Server) handleExternalSignal(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.externalSignals {
+		http.Error(w, "external signals disabled", http.StatusNotFound)
+		return
+	}
+	if strings.TrimSpace(s.authToken) == "" {
+		http.Error(w, "external signals require api.token", http.StatusForbidden)
+		return
+	}
+
+	var sig strategy.ExternalSignal
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&sig); err != nil {
+		http.Error(w, "invalid json: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	sig.Normalize()
+	if err := sig.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	orderID, err := s.appState.SubmitExternalSignal(r.Context(), sig)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		s.writeJSON(w, map[string]interface{}{
+			"status": "rejected",
+			"reason": err.Error(),
+		})
+		return
+	}
+	s.writeJSON(w, map[string]interface{}{
+		"status":   "placed",
+		"order_id": orderID,
+		"strategy": strategy.ExternalStrategyTag,
+	})
+}
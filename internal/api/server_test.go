@@ -1,18 +1,27 @@
 package api
 
 import (
+	"context"
 	"encoding/csv"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"math"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/ws"
+
+	"github.com/GoPolymarket/polymarket-trader/internal/config"
 	"github.com/GoPolymarket/polymarket-trader/internal/execution"
+	"github.com/GoPolymarket/polymarket-trader/internal/feed"
 	"github.com/GoPolymarket/polymarket-trader/internal/paper"
 	"github.com/GoPolymarket/polymarket-trader/internal/risk"
+	"github.com/GoPolymarket/polymarket-trader/internal/strategy"
 )
 
 type mockAppState struct {
@@ -22,47 +31,226 @@ type mockAppState struct {
 	fills         int
 	pnl           float64
 	assets        []string
+	staleAssets   []string
+	coolingAssets []string
+	bookMetrics   map[string]feed.BookMetrics
 	positions     map[string]execution.Position
 	unrealPnL     float64
 	recentFills   []execution.Fill
 	activeOrders  []execution.OrderState
 	riskSnapshot  risk.Snapshot
+	takerRisk     *risk.Snapshot // the taker account's, nil without one
 	tradingMode   string
+	execMode      string // defaults to tradingMode
 	paperSnapshot paper.Snapshot
 	kpiStats      map[string]interface{}
+	cfg           config.Config
+	mids          map[string]float64
+	flows         map[string]strategy.FlowStat
+	flowWindow    time.Duration
+	feedConnected bool
+	roundTrips    []execution.RoundTrip
+	tokenPairs    map[string]string
+	unpriced      []string
+	rejections    []execution.Rejection
+	selectorRun   time.Time
+	selectorEvals []strategy.CandidateEvaluation
+	lastHeartbeat time.Time
+	heartbeatErr  error
+	dependencies  map[string]error // configured dependencies and their errors
+
+	rescanAdded   []string
+	rescanRemoved []string
+	rescanErr     error
+	rescanCalls   int
+
+	books *feed.BookSnapshot
+
+	makerParams     strategy.MakerConfig
+	takerParams     strategy.TakerConfig
+	strategyAssetID string // asset with an override
+	overrideMaker   strategy.MakerConfig
+
+	makerEnabled bool
+	takerEnabled bool
+	toggleErr    error
+	simFeeRate   float64
+	stopCalls    []bool
+	toggleCalls  int
+
+	externalSignals []strategy.ExternalSignal
+	externalErr     error
+
+	pnlHistory       []map[string]interface{}
+	pnlHistoryWindow time.Duration
+	pnlHistoryBucket time.Duration
 }
 
-func (m *mockAppState) Stats() (int, int, float64)                      { return m.orders, m.fills, m.pnl }
-func (m *mockAppState) IsRunning() bool                                 { return m.running }
-func (m *mockAppState) IsDryRun() bool                                  { return m.dryRun }
-func (m *mockAppState) MonitoredAssets() []string                       { return m.assets }
-func (m *mockAppState) SetEmergencyStop(_ bool)                         {}
-func (m *mockAppState) RecentFills(limit int) []execution.Fill          { return m.recentFills }
+func (m *mockAppState) Stats() (int, int, float64)             { return m.orders, m.fills, m.pnl }
+func (m *mockAppState) IsRunning() bool                        { return m.running }
+func (m *mockAppState) IsDryRun() bool                         { return m.dryRun }
+func (m *mockAppState) MonitoredAssets() []string              { return m.assets }
+func (m *mockAppState) StaleAssets() []string                  { return m.staleAssets }
+func (m *mockAppState) CancelCooldownAssets() []string         { return m.coolingAssets }
+func (m *mockAppState) SetEmergencyStop(stop bool)             { m.stopCalls = append(m.stopCalls, stop) }
+func (m *mockAppState) RecentFills(limit int) []execution.Fill { return m.recentFills }
+func (m *mockAppState) RoundTrips() []execution.RoundTrip      { return m.roundTrips }
+func (m *mockAppState) Rejections(limit int) []execution.Rejection {
+	if limit < len(m.rejections) {
+		return m.rejections[:limit]
+	}
+	return m.rejections
+}
+func (m *mockAppState) FillHistory(offset, limit int) []execution.Fill {
+	if offset >= len(m.recentFills) {
+		return nil
+	}
+	end := offset + limit
+	if end > len(m.recentFills) {
+		end = len(m.recentFills)
+	}
+	return m.recentFills[offset:end]
+}
 func (m *mockAppState) ActiveOrders() []execution.OrderState            { return m.activeOrders }
 func (m *mockAppState) TrackedPositions() map[string]execution.Position { return m.positions }
+func (m *mockAppState) TokenPairs() map[string]string                   { return m.tokenPairs }
 func (m *mockAppState) UnrealizedPnL() float64                          { return m.unrealPnL }
+func (m *mockAppState) UnpricedPositions() []string                     { return m.unpriced }
 func (m *mockAppState) RiskSnapshot() risk.Snapshot                     { return m.riskSnapshot }
 func (m *mockAppState) TradingMode() string                             { return m.tradingMode }
 func (m *mockAppState) PaperSnapshot() paper.Snapshot                   { return m.paperSnapshot }
 func (m *mockAppState) KPIStats() map[string]interface{}                { return m.kpiStats }
+func (m *mockAppState) FeedConnected() bool                             { return m.feedConnected }
+func (m *mockAppState) HeartbeatStatus() (time.Time, error)             { return m.lastHeartbeat, m.heartbeatErr }
+func (m *mockAppState) DependencyHealth(name string) (bool, error) {
+	err, ok := m.dependencies[name]
+	return ok, err
+}
+func (m *mockAppState) SelectorCandidates() (time.Time, []strategy.CandidateEvaluation) {
+	return m.selectorRun, m.selectorEvals
+}
+func (m *mockAppState) RiskSnapshots() []risk.Snapshot {
+	if m.takerRisk == nil {
+		return []risk.Snapshot{m.riskSnapshot}
+	}
+	return []risk.Snapshot{m.riskSnapshot, *m.takerRisk}
+}
+func (m *mockAppState) ExecMode() string {
+	if m.execMode == "" {
+		return m.tradingMode
+	}
+	return m.execMode
+}
+
+func (m *mockAppState) StrategyParams(assetID string) (strategy.MakerConfig, strategy.TakerConfig, bool) {
+	if assetID != "" && assetID == m.strategyAssetID {
+		return m.overrideMaker, m.takerParams, true
+	}
+	return m.makerParams, m.takerParams, false
+}
+
+func (m *mockAppState) SimulateQuote(assetID string, bids, asks []feed.Level, inv *strategy.InventoryState) (strategy.QuotePreview, error) {
+	event := ws.OrderbookEvent{AssetID: assetID}
+	for _, l := range bids {
+		event.Bids = append(event.Bids, ws.OrderbookLevel{Price: fmt.Sprint(l.Price), Size: fmt.Sprint(l.Size)})
+	}
+	for _, l := range asks {
+		event.Asks = append(event.Asks, ws.OrderbookLevel{Price: fmt.Sprint(l.Price), Size: fmt.Sprint(l.Size)})
+	}
+	var state []strategy.InventoryState
+	if inv != nil {
+		state = append(state, *inv)
+	}
+	raw, err := strategy.NewMaker(m.makerParams).ComputeQuote(event, state...)
+	if err != nil {
+		return strategy.QuotePreview{}, err
+	}
+	quote, adjusted := strategy.ApplyFeeFloor(raw, m.simFeeRate)
+	return strategy.QuotePreview{Quote: quote, RawQuote: raw, FeeRateBps: m.simFeeRate, FeeAdjusted: adjusted}, nil
+}
+
+func (m *mockAppState) SetStrategiesEnabled(maker, taker *bool) (bool, bool, error) {
+	m.toggleCalls++
+	if m.toggleErr != nil {
+		return false, false, m.toggleErr
+	}
+	if maker != nil {
+		m.makerEnabled = *maker
+	}
+	if taker != nil {
+		m.takerEnabled = *taker
+	}
+	return m.makerEnabled, m.takerEnabled, nil
+}
+
+func (m *mockAppState) Book(assetID string) (feed.BookView, bool) {
+	if m.books == nil {
+		return feed.BookView{}, false
+	}
+	return m.books.Book(assetID)
+}
+
+func (m *mockAppState) Rescan(ctx context.Context) ([]string, []string, error) {
+	m.rescanCalls++
+	return m.rescanAdded, m.rescanRemoved, m.rescanErr
+}
+
+func (m *mockAppState) BookMetrics(assetID string, levels int, depthBps float64) (feed.BookMetrics, bool) {
+	bm, ok := m.bookMetrics[assetID]
+	bm.Levels, bm.DepthBps = levels, depthBps
+	return bm, ok
+}
+
+func (m *mockAppState) EffectiveConfig() config.Config { return m.cfg.Redacted() }
+
+func (m *mockAppState) Flows() (time.Duration, map[string]strategy.FlowStat) {
+	return m.flowWindow, m.flows
+}
+
+func (m *mockAppState) UnrealizedPnLByMarket() map[string]float64 {
+	out := make(map[string]float64)
+	for id, p := range m.positions {
+		if mid, ok := m.mids[id]; ok && p.NetSize != 0 {
+			out[id] = (mid - p.AvgEntryPrice) * p.NetSize
+		}
+	}
+	return out
+}
+
+func (m *mockAppState) PnLHistory(window, bucket time.Duration) []map[string]interface{} {
+	m.pnlHistoryWindow, m.pnlHistoryBucket = window, bucket
+	return m.pnlHistory
+}
+
+func (m *mockAppState) SubmitExternalSignal(_ context.Context, sig strategy.ExternalSignal) (string, error) {
+	if m.externalErr != nil {
+		return "", m.externalErr
+	}
+	m.externalSignals = append(m.externalSignals, sig)
+	return fmt.Sprintf("ext-%d", len(m.externalSignals)), nil
+}
 
 type mockPortfolio struct {
 	value    float64
 	lastSync time.Time
+	err      error
 }
 
 func (m *mockPortfolio) TotalValue() float64 { return m.value }
 func (m *mockPortfolio) LastSync() time.Time { return m.lastSync }
+func (m *mockPortfolio) LastError() error    { return m.err }
 
 type mockBuilder struct {
 	lastSync    time.Time
 	dailyVolume interface{}
 	leaderboard interface{}
+	err         error
 }
 
 func (m *mockBuilder) DailyVolumeJSON() interface{} { return m.dailyVolume }
 func (m *mockBuilder) LeaderboardJSON() interface{} { return m.leaderboard }
 func (m *mockBuilder) LastSync() time.Time          { return m.lastSync }
+func (m *mockBuilder) LastError() error             { return m.err }
 
 func TestHandleStatus(t *testing.T) {
 	state := &mockAppState{
@@ -108,6 +296,193 @@ func TestHandleStatus(t *testing.T) {
 	if resp["trading_mode"] != "paper" {
 		t.Errorf("expected trading_mode=paper, got %v", resp["trading_mode"])
 	}
+	if resp["last_heartbeat"] != nil || resp["heartbeat_ok"] != false {
+		t.Errorf("expected no heartbeat yet, got last=%v ok=%v", resp["last_heartbeat"], resp["heartbeat_ok"])
+	}
+}
+
+func TestHandleStatusReportsHeartbeat(t *testing.T) {
+	sent := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	state := &mockAppState{lastHeartbeat: sent, heartbeatErr: errors.New("connection reset")}
+	s := NewServer(":0", state, nil, nil)
+
+	w := httptest.NewRecorder()
+	s.handleStatus(w, httptest.NewRequest(http.MethodGet, "/api/status", nil))
+
+	var resp StatusResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.LastHeartbeat == nil || !resp.LastHeartbeat.Equal(sent) {
+		t.Fatalf("expected last_heartbeat=%v, got %v", sent, resp.LastHeartbeat)
+	}
+	if resp.HeartbeatOK {
+		t.Fatal("expected heartbeat_ok=false after a failed heartbeat")
+	}
+
+	state.heartbeatErr = nil
+	w = httptest.NewRecorder()
+	s.handleStatus(w, httptest.NewRequest(http.MethodGet, "/api/status", nil))
+	resp = StatusResponse{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !resp.HeartbeatOK {
+		t.Fatal("expected heartbeat_ok=true after a successful heartbeat")
+	}
+}
+
+func TestHandleFillsSummary(t *testing.T) {
+	state := &mockAppState{roundTrips: []execution.RoundTrip{
+		{AssetID: "asset-1", Side: "BUY", RealizedPnL: 3},
+		{AssetID: "asset-1", Side: "SELL", RealizedPnL: -1},
+		{AssetID: "asset-2", Side: "BUY", RealizedPnL: 1},
+	}}
+	s := NewServer(":0", state, nil, nil)
+
+	w := httptest.NewRecorder()
+	s.handleFillsSummary(w, httptest.NewRequest(http.MethodGet, "/api/fills/summary", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var resp FillsSummaryResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := FillsSummaryResponse{
+		RoundTrips:      3,
+		Wins:            2,
+		Losses:          1,
+		WinRatePct:      66.67,
+		AvgWinUSDC:      2,
+		AvgLossUSDC:     -1,
+		LargestWinUSDC:  3,
+		LargestLossUSDC: -1,
+		ExpectancyUSDC:  1,
+	}
+	if resp != want {
+		t.Fatalf("expected %+v, got %+v", want, resp)
+	}
+}
+
+func TestHandleNettedPositionsCombinesYesNoPair(t *testing.T) {
+	state := &mockAppState{
+		positions: map[string]execution.Position{
+			"yes-1":  {AssetID: "yes-1", NetSize: 10, AvgEntryPrice: 0.45},
+			"no-1":   {AssetID: "no-1", NetSize: 6, AvgEntryPrice: 0.50},
+			"solo-1": {AssetID: "solo-1", NetSize: -3, AvgEntryPrice: 0.60},
+			"flat-1": {AssetID: "flat-1", RealizedPnL: 2},
+		},
+		tokenPairs: map[string]string{"yes-1": "no-1", "no-1": "yes-1"},
+	}
+	s := NewServer(":0", state, nil, nil)
+
+	w := httptest.NewRecorder()
+	s.handleNettedPositions(w, httptest.NewRequest(http.MethodGet, "/api/positions/netted", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var resp NettedPositionsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := []NettedMarket{
+		{
+			AssetID: "no-1", PairAssetID: "yes-1",
+			NetSize: 6, AvgEntryPrice: 0.50, PairNetSize: 10, PairAvgEntryPrice: 0.45,
+			HedgedSize: 6, NetExposure: -4, LockedProfitUSDC: 0.3,
+		},
+		{AssetID: "solo-1", NetSize: -3, AvgEntryPrice: 0.60, NetExposure: -3},
+	}
+	if !reflect.DeepEqual(resp.Markets, want) {
+		t.Fatalf("expected %+v, got %+v", want, resp.Markets)
+	}
+	if resp.LockedProfitUSDC != 0.3 {
+		t.Fatalf("expected 0.3 locked profit, got %v", resp.LockedProfitUSDC)
+	}
+}
+
+func TestHandleRejectionsReturnsSeededRejection(t *testing.T) {
+	at := time.Date(2026, 3, 2, 15, 4, 5, 0, time.UTC)
+	state := &mockAppState{rejections: []execution.Rejection{{
+		Time:     at,
+		AssetID:  "asset-1",
+		Side:     "BUY",
+		SizeUSDC: 25,
+		Source:   execution.RejectedByRisk,
+		Reason:   "position limit exceeded for asset-1",
+	}}}
+	s := NewServer(":0", state, nil, nil)
+
+	w := httptest.NewRecorder()
+	s.handleRejections(w, httptest.NewRequest(http.MethodGet, "/api/rejections", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var resp RejectionsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := RejectionEntry{
+		Timestamp: at,
+		AssetID:   "asset-1",
+		Side:      "BUY",
+		SizeUSDC:  25,
+		Source:    "risk",
+		Reason:    "position limit exceeded for asset-1",
+	}
+	if resp.Count != 1 || len(resp.Rejections) != 1 || resp.Rejections[0] != want {
+		t.Fatalf("expected [%+v], got %+v", want, resp)
+	}
+}
+
+func TestHandleSelectorCandidatesReportsSpreadFailure(t *testing.T) {
+	ran := time.Date(2026, 3, 2, 15, 4, 5, 0, time.UTC)
+	state := &mockAppState{
+		selectorRun: ran,
+		selectorEvals: []strategy.CandidateEvaluation{
+			{
+				MarketID: "wide", TokenIDs: []string{"t-wide"}, Liquidity: 5000, Volume24hr: 1000, Spread: 0.2, DaysToEnd: 60,
+				Filters: strategy.SelectorFilters{Liquidity: true, Volume24hr: true, Spread: false, DaysToEnd: true, Volatility: true},
+			},
+			{
+				MarketID: "good", TokenIDs: []string{"t-good"}, Liquidity: 5000, Volume24hr: 1000, Spread: 0.05, DaysToEnd: 60, Score: 42,
+				Filters:  strategy.SelectorFilters{Liquidity: true, Volume24hr: true, Spread: true, DaysToEnd: true, Volatility: true},
+				Selected: true,
+			},
+		},
+	}
+	s := NewServer(":0", state, nil, nil)
+
+	w := httptest.NewRecorder()
+	s.handleSelectorCandidates(w, httptest.NewRequest(http.MethodGet, "/api/selector/candidates", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var resp SelectorCandidatesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.LastRun == nil || !resp.LastRun.Equal(ran) || resp.Count != 2 || resp.Selected != 1 {
+		t.Fatalf("unexpected summary %+v", resp)
+	}
+	wide := resp.Candidates[0]
+	if wide.MarketID != "wide" || wide.Passed || wide.Selected || wide.Filters.Spread || wide.Spread != 0.2 {
+		t.Fatalf("expected wide to fail the spread filter, got %+v", wide)
+	}
+	if !wide.Filters.Liquidity || !wide.Filters.Volume24hr || !wide.Filters.DaysToEnd {
+		t.Fatalf("expected wide to pass the other filters, got %+v", wide.Filters)
+	}
+	if good := resp.Candidates[1]; !good.Passed || !good.Selected || good.Score != 42 {
+		t.Fatalf("unexpected good candidate %+v", good)
+	}
+
+	w = httptest.NewRecorder()
+	s = NewServer(":0", &mockAppState{}, nil, nil)
+	s.handleSelectorCandidates(w, httptest.NewRequest(http.MethodGet, "/api/selector/candidates", nil))
+	if body := w.Body.String(); !strings.Contains(body, `"last_run":null`) || !strings.Contains(body, `"candidates":[]`) {
+		t.Fatalf("expected empty candidates before the first run, got %s", body)
+	}
 }
 
 func TestHandlePositions(t *testing.T) {
@@ -137,6 +512,100 @@ func TestHandlePositions(t *testing.T) {
 	}
 }
 
+func TestHandleFlows(t *testing.T) {
+	state := &mockAppState{
+		flowWindow: 2 * time.Minute,
+		flows: map[string]strategy.FlowStat{
+			"asset-2": {},
+			"asset-1": {NetFlow: 0.5, VWAP: 0.42, Trades: 3},
+		},
+	}
+	s := NewServer(":0", state, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/flows", nil)
+	w := httptest.NewRecorder()
+	s.handleFlows(w, req)
+
+	var resp struct {
+		Window    string  `json:"window"`
+		WindowSec float64 `json:"window_sec"`
+		Assets    []struct {
+			AssetID string  `json:"asset_id"`
+			NetFlow float64 `json:"net_flow"`
+			VWAP    float64 `json:"vwap"`
+			Trades  int     `json:"trades"`
+		} `json:"assets"`
+		Count int `json:"count"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Window != "2m0s" || resp.WindowSec != 120 || resp.Count != 2 {
+		t.Fatalf("unexpected window/count: %+v", resp)
+	}
+	a1, a2 := resp.Assets[0], resp.Assets[1]
+	if a1.AssetID != "asset-1" || a1.NetFlow != 0.5 || a1.VWAP != 0.42 || a1.Trades != 3 {
+		t.Errorf("unexpected asset-1 flow: %+v", a1)
+	}
+	if a2.AssetID != "asset-2" || a2.Trades != 0 {
+		t.Errorf("expected quiet asset-2 listed with no trades, got %+v", a2)
+	}
+}
+
+func TestHandlePnLByMarket(t *testing.T) {
+	state := &mockAppState{
+		positions: map[string]execution.Position{
+			"asset-1": {AssetID: "asset-1", NetSize: 10, AvgEntryPrice: 0.40, RealizedPnL: 1.5, TotalFills: 4},
+			"asset-2": {AssetID: "asset-2", NetSize: -5, AvgEntryPrice: 0.70, RealizedPnL: -0.5, TotalFills: 2},
+			"asset-3": {AssetID: "asset-3", NetSize: 3, AvgEntryPrice: 0.20, TotalFills: 1},
+			"flat":    {AssetID: "flat", TotalFills: 2},
+		},
+		mids:     map[string]float64{"asset-1": 0.45, "asset-2": 0.60},
+		unpriced: []string{"asset-3"},
+	}
+	s := NewServer(":0", state, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/pnl-by-market", nil)
+	w := httptest.NewRecorder()
+	s.handlePnLByMarket(w, req)
+
+	var resp struct {
+		Markets []struct {
+			AssetID       string  `json:"asset_id"`
+			RealizedPnL   float64 `json:"realized_pnl"`
+			UnrealizedPnL float64 `json:"unrealized_pnl"`
+			TotalPnL      float64 `json:"total_pnl"`
+			Fills         int     `json:"fills"`
+			NetSize       float64 `json:"net_size"`
+			Priced        bool    `json:"priced"`
+		} `json:"markets"`
+		TotalPnL       float64  `json:"total_pnl"`
+		UnpricedAssets []string `json:"unpriced_assets"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Markets) != 3 {
+		t.Fatalf("expected 3 markets (flat one skipped), got %+v", resp.Markets)
+	}
+	m1, m2, m3 := resp.Markets[0], resp.Markets[1], resp.Markets[2]
+	if m1.AssetID != "asset-1" || math.Abs(m1.UnrealizedPnL-0.5) > 1e-9 || math.Abs(m1.TotalPnL-2.0) > 1e-9 || m1.Fills != 4 || m1.NetSize != 10 {
+		t.Errorf("unexpected asset-1 row: %+v", m1)
+	}
+	if m2.AssetID != "asset-2" || math.Abs(m2.UnrealizedPnL-0.5) > 1e-9 || math.Abs(m2.TotalPnL) > 1e-9 {
+		t.Errorf("expected short asset-2 to gain as the mid falls, got %+v", m2)
+	}
+	if m3.AssetID != "asset-3" || m3.UnrealizedPnL != 0 || m3.Priced {
+		t.Errorf("expected asset-3 without a mid to be unpriced with no unrealized PnL, got %+v", m3)
+	}
+	if !m1.Priced || !m2.Priced || len(resp.UnpricedAssets) != 1 || resp.UnpricedAssets[0] != "asset-3" {
+		t.Errorf("expected only asset-3 unpriced, got %+v", resp)
+	}
+	if math.Abs(resp.TotalPnL-2.0) > 1e-9 {
+		t.Errorf("expected total_pnl 2.0, got %f", resp.TotalPnL)
+	}
+}
+
 func TestHandlePnL(t *testing.T) {
 	state := &mockAppState{pnl: 5.0, unrealPnL: 2.5}
 	s := NewServer(":0", state, nil, nil)
@@ -159,6 +628,9 @@ func TestHandlePnL(t *testing.T) {
 	if resp["total_pnl"].(float64) != 7.5 {
 		t.Errorf("expected total_pnl=7.5, got %v", resp["total_pnl"])
 	}
+	if unpriced, ok := resp["unpriced_assets"].([]interface{}); !ok || len(unpriced) != 0 {
+		t.Errorf("expected empty unpriced_assets, got %v", resp["unpriced_assets"])
+	}
 }
 
 func TestHandlePerfPaper(t *testing.T) {
@@ -172,6 +644,7 @@ func TestHandlePerfPaper(t *testing.T) {
 			InitialBalanceUSDC: 1000,
 			FeesPaidUSDC:       0.5,
 		},
+		kpiStats: map[string]interface{}{"fill_ratio_daily": 0.3},
 	}
 	s := NewServer(":0", state, nil, nil)
 
@@ -202,6 +675,9 @@ func TestHandlePerfPaper(t *testing.T) {
 	if resp["fees_paid_usdc"].(float64) != 0.5 {
 		t.Fatalf("expected fees_paid_usdc=0.5, got %v", resp["fees_paid_usdc"])
 	}
+	if resp["fill_ratio_daily"].(float64) != 0.3 {
+		t.Fatalf("expected fill_ratio_daily=0.3, got %v", resp["fill_ratio_daily"])
+	}
 	if resp["net_pnl_after_fees_usdc"].(float64) != 3.0 {
 		t.Fatalf("expected net_pnl_after_fees_usdc=3.0, got %v", resp["net_pnl_after_fees_usdc"])
 	}
@@ -239,6 +715,37 @@ func TestHandlePerfLive(t *testing.T) {
 	}
 }
 
+func TestHandlePerfLiveDryRunReportsSimulatedFees(t *testing.T) {
+	state := &mockAppState{
+		tradingMode: "live",
+		execMode:    "paper",
+		fills:       2,
+		pnl:         5.0,
+		paperSnapshot: paper.Snapshot{
+			InitialBalanceUSDC: 1000,
+			FeesPaidUSDC:       0.5,
+		},
+	}
+	s := NewServer(":0", state, nil, nil)
+
+	w := httptest.NewRecorder()
+	s.handlePerf(w, httptest.NewRequest(http.MethodGet, "/api/perf", nil))
+
+	var resp map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp["trading_mode"] != "live" {
+		t.Fatalf("expected trading_mode=live, got %v", resp["trading_mode"])
+	}
+	if resp["fees_paid_usdc"].(float64) != 0.5 {
+		t.Fatalf("expected the simulator's fees under dry-run, got %v", resp["fees_paid_usdc"])
+	}
+	if resp["estimated_equity_usdc"].(float64) != 1004.5 {
+		t.Fatalf("expected estimated_equity_usdc=1004.5, got %v", resp["estimated_equity_usdc"])
+	}
+}
+
 func TestHandleGrantReport(t *testing.T) {
 	state := &mockAppState{
 		tradingMode: "paper",
@@ -1012,6 +1519,10 @@ func TestHandleKPI(t *testing.T) {
 			"net_pnl_30d_realized_usdc":                 9.0,
 			"net_pnl_30d_total_usdc":                    11.0,
 			"net_pnl_30d_selected_for_rav_usdc":         11.0,
+			"net_pnl_7d_realized_usdc":                  4.0,
+			"net_pnl_7d_total_usdc":                     5.5,
+			"net_pnl_7d_after_fees_usdc":                5.0,
+			"net_pnl_7d_window_effective_days":          3,
 			"exec_quality_factor_30d":                   1.10,
 			"builder_factor_30d":                        1.05,
 			"execution_loss_bps":                        24.5,
@@ -1051,6 +1562,13 @@ func TestHandleKPI(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected north_star object, got %T", resp["north_star"])
 	}
+	weekly, ok := resp["net_pnl_7d"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected net_pnl_7d object, got %T", resp["net_pnl_7d"])
+	}
+	if weekly["total_usdc"] != 5.5 || weekly["after_fees_usdc"] != 5.0 || weekly["effective_window_days"] != 3.0 {
+		t.Fatalf("unexpected net_pnl_7d: %v", weekly)
+	}
 	netPnL30d, ok := northStar["net_pnl_30d"].(map[string]interface{})
 	if !ok {
 		t.Fatalf("expected net_pnl_30d object, got %T", northStar["net_pnl_30d"])
@@ -1636,6 +2154,53 @@ func TestHandleInsightsScores(t *testing.T) {
 	}
 }
 
+func TestBuildMarketConcentration(t *testing.T) {
+	dominant := map[string]execution.Position{
+		"asset-big":   {AssetID: "asset-big", NetSize: 180, AvgEntryPrice: 0.5, TotalFills: 18},
+		"asset-small": {AssetID: "asset-small", NetSize: 20, AvgEntryPrice: 0.5, TotalFills: 2},
+	}
+	c := buildMarketConcentration(dominant, 0)
+	if math.Abs(c.ExposureHHI-0.82) > 1e-9 || math.Abs(c.FillHHI-0.82) > 1e-9 {
+		t.Fatalf("expected HHI 0.82 for a 90/10 split, got exposure=%f fills=%f", c.ExposureHHI, c.FillHHI)
+	}
+	if !c.High || c.TopAssetID != "asset-big" || c.TopExposureSharePct != 90 {
+		t.Fatalf("expected a high concentration flag on asset-big, got %+v", c)
+	}
+	recs := buildInsightRecommendations(true, nil, 20, 0.1, buildMarketScores(dominant), c)
+	if !hasCoachAction(recs, "diversify_exposure") {
+		t.Fatalf("expected a diversify recommendation, got %+v", recs)
+	}
+
+	even := map[string]execution.Position{
+		"asset-a": {AssetID: "asset-a", NetSize: 20, AvgEntryPrice: 0.5, TotalFills: 5},
+		"asset-b": {AssetID: "asset-b", NetSize: -20, AvgEntryPrice: 0.5, TotalFills: 5},
+		"asset-c": {AssetID: "asset-c", NetSize: 40, AvgEntryPrice: 0.25, TotalFills: 5},
+		"asset-d": {AssetID: "asset-d", NetSize: 10, AvgEntryPrice: 1, TotalFills: 5},
+	}
+	c = buildMarketConcentration(even, 0)
+	if math.Abs(c.ExposureHHI-0.25) > 1e-9 || math.Abs(c.FillHHI-0.25) > 1e-9 || c.High {
+		t.Fatalf("expected HHI 0.25 and no flag for an even spread, got %+v", c)
+	}
+	recs = buildInsightRecommendations(true, nil, 20, 0.1, buildMarketScores(even), c)
+	if hasCoachAction(recs, "diversify_exposure") {
+		t.Fatalf("expected no diversify recommendation for an even spread, got %+v", recs)
+	}
+
+	// A configured threshold replaces the default.
+	if c = buildMarketConcentration(even, 0.2); !c.High {
+		t.Fatalf("expected HHI 0.25 to exceed a 0.2 threshold, got %+v", c)
+	}
+}
+
+func hasCoachAction(recs []coachAction, code string) bool {
+	for _, r := range recs {
+		if r.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
 func TestHandleInsightsNoData(t *testing.T) {
 	state := &mockAppState{
 		fills: 0,
@@ -1894,6 +2459,41 @@ func TestHandleExecutionQualityLowEdge(t *testing.T) {
 	}
 }
 
+func TestHandleExecutionQualityLiveFillFees(t *testing.T) {
+	state := &mockAppState{
+		tradingMode: "live",
+		fills:       2,
+		pnl:         2.0,
+		recentFills: []execution.Fill{
+			{AssetID: "a", Side: "BUY", Price: 0.5, Size: 100, Fee: 0.5},
+			{AssetID: "a", Side: "SELL", Price: 0.5, Size: 100, Fee: 0.5},
+		},
+	}
+	s := NewServer(":0", state, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/execution-quality", nil)
+	w := httptest.NewRecorder()
+	s.handleExecutionQuality(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var resp map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	metrics := resp["metrics"].(map[string]interface{})
+	if !approxEqual(metrics["fees_paid_usdc"].(float64), 1.0) {
+		t.Fatalf("expected fees_paid_usdc=1, got %v", metrics["fees_paid_usdc"])
+	}
+	if !approxEqual(metrics["gross_edge_bps"].(float64), 200) {
+		t.Fatalf("expected gross_edge_bps=200, got %v", metrics["gross_edge_bps"])
+	}
+	if !approxEqual(metrics["net_edge_bps"].(float64), 100) {
+		t.Fatalf("expected net_edge_bps=100, got %v", metrics["net_edge_bps"])
+	}
+}
+
 func TestHandleExecutionQualityRiskBlocked(t *testing.T) {
 	state := &mockAppState{
 		tradingMode: "paper",
@@ -2193,36 +2793,349 @@ func TestHandleEmergencyStopMethodNotAllowed(t *testing.T) {
 	}
 }
 
-func TestHandleHealth(t *testing.T) {
-	s := NewServer(":0", &mockAppState{}, nil, nil)
+func TestHandleEmergencyStopClear(t *testing.T) {
+	state := &mockAppState{riskSnapshot: risk.Snapshot{EmergencyStop: true, DailyPnL: -1, DailyLossLimitUSDC: 10}}
+	s := NewServer(":0", state, nil, nil)
 
-	req := httptest.NewRequest(http.MethodGet, "/api/health", nil)
+	req := httptest.NewRequest(http.MethodPost, "/api/emergency-stop/clear", strings.NewReader(`{"confirm": "RESUME_TRADING"}`))
 	w := httptest.NewRecorder()
-	s.handleHealth(w, req)
+	s.handleEmergencyStopClear(w, req)
 
 	if w.Code != http.StatusOK {
-		t.Fatalf("expected 200, got %d", w.Code)
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
 	}
-
-	var resp map[string]interface{}
+	var resp EmergencyStopClearResponse
 	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
 		t.Fatalf("decode: %v", err)
 	}
-	if resp["ok"] != true {
-		t.Fatalf("expected ok=true, got %v", resp["ok"])
+	if !resp.Cleared || len(resp.Reasons) != 0 {
+		t.Fatalf("expected a clean clear, got %+v", resp)
 	}
-	if resp["uptime_s"] == nil {
-		t.Fatal("expected uptime_s in response")
+	if len(state.stopCalls) != 1 || state.stopCalls[0] {
+		t.Fatalf("expected SetEmergencyStop(false) once, got %v", state.stopCalls)
 	}
 }
 
-func TestHandleReady(t *testing.T) {
-	t.Run("running app is ready", func(t *testing.T) {
-		s := NewServer(":0", &mockAppState{running: true}, nil, nil)
+func TestHandleEmergencyStopClearRefusesWhileBlocked(t *testing.T) {
+	state := &mockAppState{riskSnapshot: risk.Snapshot{
+		EmergencyStop:      true,
+		InCooldown:         true,
+		DailyPnL:           -12,
+		DailyLossLimitUSDC: 10,
+	}}
+	s := NewServer(":0", state, nil, nil)
 
-		req := httptest.NewRequest(http.MethodGet, "/api/ready", nil)
-		w := httptest.NewRecorder()
-		s.handleReady(w, req)
+	req := httptest.NewRequest(http.MethodPost, "/api/emergency-stop/clear", strings.NewReader(`{"confirm": "RESUME_TRADING"}`))
+	w := httptest.NewRecorder()
+	s.handleEmergencyStopClear(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp EmergencyStopClearResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := []string{"daily_loss_limit_reached", "loss_cooldown_active"}
+	if resp.Cleared || strings.Join(resp.Reasons, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected refusal with %v, got %+v", want, resp)
+	}
+
+	for _, body := range []string{"", "{}", `{"confirm": "yes"}`} {
+		req := httptest.NewRequest(http.MethodPost, "/api/emergency-stop/clear", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		s.handleEmergencyStopClear(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("body %q: expected 400, got %d", body, w.Code)
+		}
+	}
+	if len(state.stopCalls) != 0 {
+		t.Fatalf("expected the stop left engaged, got %v", state.stopCalls)
+	}
+}
+
+func TestHandleEmergencyStopClearChecksTakerAccount(t *testing.T) {
+	state := &mockAppState{
+		riskSnapshot: risk.Snapshot{EmergencyStop: true, DailyLossLimitUSDC: 10},
+		takerRisk:    &risk.Snapshot{EmergencyStop: true, InCooldown: true, DailyLossLimitUSDC: 10},
+	}
+	s := NewServer(":0", state, nil, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/emergency-stop/clear", strings.NewReader(`{"confirm": "RESUME_TRADING"}`))
+	w := httptest.NewRecorder()
+	s.handleEmergencyStopClear(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected 409 while the taker account is cooling down, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp EmergencyStopClearResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Cleared || strings.Join(resp.Reasons, ",") != "loss_cooldown_active" {
+		t.Fatalf("expected refusal with the taker account's cooldown, got %+v", resp)
+	}
+	if len(state.stopCalls) != 0 {
+		t.Fatalf("expected the stop left engaged, got %v", state.stopCalls)
+	}
+}
+
+func TestHandleRescan(t *testing.T) {
+	state := &mockAppState{rescanAdded: []string{"tok-b", "tok-a"}}
+	s := NewServer(":0", state, nil, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/rescan", nil)
+	w := httptest.NewRecorder()
+	s.handleRescan(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if state.rescanCalls != 1 {
+		t.Fatalf("expected one rescan, got %d", state.rescanCalls)
+	}
+	var resp struct {
+		Added   []string `json:"added"`
+		Removed []string `json:"removed"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Added) != 2 || resp.Added[0] != "tok-a" || resp.Added[1] != "tok-b" {
+		t.Fatalf("expected sorted added assets, got %v", resp.Added)
+	}
+	if resp.Removed == nil || len(resp.Removed) != 0 {
+		t.Fatalf("expected empty removed list, got %v", resp.Removed)
+	}
+}
+
+func TestHandleRescanError(t *testing.T) {
+	state := &mockAppState{rescanErr: errors.New("rescan: trading loop is not running")}
+	s := NewServer(":0", state, nil, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/rescan", nil)
+	w := httptest.NewRecorder()
+	s.handleRescan(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", w.Code)
+	}
+}
+
+func TestHandleRescanMethodNotAllowed(t *testing.T) {
+	state := &mockAppState{}
+	s := NewServer(":0", state, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/rescan", nil)
+	w := httptest.NewRecorder()
+	s.handleRescan(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", w.Code)
+	}
+	if state.rescanCalls != 0 {
+		t.Fatalf("expected no rescan on GET, got %d", state.rescanCalls)
+	}
+}
+
+func TestHandleStrategyToggle(t *testing.T) {
+	state := &mockAppState{makerEnabled: true, takerEnabled: true}
+	s := NewServer(":0", state, nil, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/strategy/toggle", strings.NewReader(`{"taker": false}`))
+	w := httptest.NewRecorder()
+	s.handleStrategyToggle(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp StrategyToggleResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !resp.Maker || resp.Taker {
+		t.Fatalf("expected maker left on and taker off, got %+v", resp)
+	}
+}
+
+func TestHandleSimulateQuote(t *testing.T) {
+	state := &mockAppState{
+		makerParams: strategy.MakerConfig{MinSpreadBps: 20, SpreadMultiplier: 1, OrderSizeUSDC: 5},
+		simFeeRate:  300,
+	}
+	s := NewServer(":0", state, nil, nil)
+
+	body := `{"asset_id": "a1", "bids": [{"price": 0.50, "size": 100}], "asks": [{"price": 0.52, "size": 80}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/simulate/quote", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	s.handleSimulateQuote(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp SimulateQuoteResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	// The market spread sets the raw quote at the touch; a 300 bps fee
+	// floor widens it to 6% of the 0.51 mid.
+	near := func(got, want float64) bool { return math.Abs(got-want) < 1e-9 }
+	if !near(resp.RawBuyPrice, 0.50) || !near(resp.RawSellPrice, 0.52) {
+		t.Fatalf("expected raw quote 0.50/0.52, got %+v", resp)
+	}
+	if !resp.FeeAdjusted || resp.FeeRateBps != 300 || !near(resp.BuyPrice, 0.4947) || !near(resp.SellPrice, 0.5253) {
+		t.Fatalf("expected fee-widened quote 0.4947/0.5253, got %+v", resp)
+	}
+	if resp.Size != 5 || resp.AssetID != "a1" {
+		t.Fatalf("expected size 5 for a1, got %+v", resp)
+	}
+
+	for _, tc := range []struct {
+		method string
+		body   string
+		want   int
+	}{
+		{http.MethodGet, "", http.StatusMethodNotAllowed},
+		{http.MethodPost, "not json", http.StatusBadRequest},
+		{http.MethodPost, `{"bids": [{"price": 0.5, "size": 1}]}`, http.StatusBadRequest},
+		{http.MethodPost, `{"bids": [{"price": 0.55, "size": 1}], "asks": [{"price": 0.50, "size": 1}]}`, http.StatusBadRequest},
+	} {
+		req := httptest.NewRequest(tc.method, "/api/simulate/quote", strings.NewReader(tc.body))
+		w := httptest.NewRecorder()
+		s.handleSimulateQuote(w, req)
+		if w.Code != tc.want {
+			t.Fatalf("%s %q: expected %d, got %d", tc.method, tc.body, tc.want, w.Code)
+		}
+	}
+}
+
+func TestHandleStrategyToggleRejectsBadRequests(t *testing.T) {
+	state := &mockAppState{}
+	s := NewServer(":0", state, nil, nil)
+
+	for _, tc := range []struct {
+		method string
+		body   string
+		want   int
+	}{
+		{http.MethodGet, "", http.StatusMethodNotAllowed},
+		{http.MethodPost, "not json", http.StatusBadRequest},
+		{http.MethodPost, "{}", http.StatusBadRequest},
+	} {
+		req := httptest.NewRequest(tc.method, "/api/strategy/toggle", strings.NewReader(tc.body))
+		w := httptest.NewRecorder()
+		s.handleStrategyToggle(w, req)
+		if w.Code != tc.want {
+			t.Fatalf("%s %q: expected %d, got %d", tc.method, tc.body, tc.want, w.Code)
+		}
+	}
+	if state.toggleCalls != 0 {
+		t.Fatalf("expected no toggle for rejected requests, got %d", state.toggleCalls)
+	}
+
+	state.toggleErr = errors.New("reload timed out waiting for the trading loop")
+	req := httptest.NewRequest(http.MethodPost, "/api/strategy/toggle", strings.NewReader(`{"maker": false}`))
+	w := httptest.NewRecorder()
+	s.handleStrategyToggle(w, req)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 when the toggle fails, got %d", w.Code)
+	}
+}
+
+func TestHandleExportBundlesReports(t *testing.T) {
+	state := &mockAppState{
+		running:      true,
+		tradingMode:  "paper",
+		fills:        3,
+		pnl:          1.5,
+		riskSnapshot: risk.Snapshot{DailyLossLimitUSDC: 100},
+		paperSnapshot: paper.Snapshot{
+			InitialBalanceUSDC: 1000,
+			BalanceUSDC:        1001.5,
+		},
+	}
+	s := NewServer(":0", state, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/export?window=30d", nil)
+	w := httptest.NewRecorder()
+	s.handleExport(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp map[string]json.RawMessage
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	for _, key := range []string{
+		"status", "paper", "stage_report", "grant_report", "execution_quality",
+		"daily_report", "coach", "checksum_sha256", "generated_at", "window",
+	} {
+		if len(resp[key]) == 0 {
+			t.Fatalf("expected %q in export, got keys %v", key, mapKeys(resp))
+		}
+	}
+	for _, key := range []string{"status", "paper", "stage_report", "grant_report", "execution_quality", "daily_report", "coach"} {
+		var obj map[string]interface{}
+		if err := json.Unmarshal(resp[key], &obj); err != nil || len(obj) == 0 {
+			t.Fatalf("expected %s to be a non-empty object, got %s", key, resp[key])
+		}
+	}
+	var checksum string
+	if err := json.Unmarshal(resp["checksum_sha256"], &checksum); err != nil || len(checksum) != 64 {
+		t.Fatalf("expected a sha256 checksum, got %s", resp["checksum_sha256"])
+	}
+	var window struct {
+		Label string `json:"label"`
+	}
+	if err := json.Unmarshal(resp["window"], &window); err != nil || window.Label != "30d" {
+		t.Fatalf("expected the 30d window to be passed through, got %s", resp["window"])
+	}
+	var stage map[string]interface{}
+	_ = json.Unmarshal(resp["stage_report"], &stage)
+	if sw, _ := stage["window"].(map[string]interface{}); sw["label"] != "30d" {
+		t.Fatalf("expected the stage report to use the export window, got %v", stage["window"])
+	}
+}
+
+func mapKeys(m map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestHandleHealth(t *testing.T) {
+	s := NewServer(":0", &mockAppState{}, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/health", nil)
+	w := httptest.NewRecorder()
+	s.handleHealth(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	var resp map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp["ok"] != true {
+		t.Fatalf("expected ok=true, got %v", resp["ok"])
+	}
+	if resp["uptime_s"] == nil {
+		t.Fatal("expected uptime_s in response")
+	}
+}
+
+func TestHandleReady(t *testing.T) {
+	t.Run("running app is ready", func(t *testing.T) {
+		s := NewServer(":0", &mockAppState{running: true}, nil, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/ready", nil)
+		w := httptest.NewRecorder()
+		s.handleReady(w, req)
 
 		if w.Code != http.StatusOK {
 			t.Fatalf("expected 200, got %d", w.Code)
@@ -2259,6 +3172,60 @@ func TestHandleReady(t *testing.T) {
 	})
 }
 
+func TestHandleReadyReportsDependencyHealth(t *testing.T) {
+	healthy := func() map[string]error {
+		return map[string]error{"clob": nil, "data": nil, "ws": nil, "rtds": nil}
+	}
+	for _, down := range []string{"clob", "data", "ws", "rtds"} {
+		t.Run(down+" degraded", func(t *testing.T) {
+			deps := healthy()
+			deps[down] = errors.New(down + " unreachable")
+			s := NewServer(":0", &mockAppState{running: true, dependencies: deps}, nil, nil)
+
+			w := httptest.NewRecorder()
+			s.handleReady(w, httptest.NewRequest(http.MethodGet, "/api/ready", nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("a degraded dependency should not fail readiness, got %d", w.Code)
+			}
+			var resp struct {
+				Dependencies map[string]DependencyStatus `json:"dependencies"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			for name, got := range resp.Dependencies {
+				want := DependencyStatus{Status: "ok"}
+				if name == down {
+					want = DependencyStatus{Status: "degraded", Error: down + " unreachable"}
+				}
+				if got != want {
+					t.Errorf("%s: expected %+v, got %+v", name, want, got)
+				}
+			}
+			if len(resp.Dependencies) != 4 {
+				t.Errorf("expected 4 dependencies, got %v", resp.Dependencies)
+			}
+		})
+		t.Run(down+" not configured", func(t *testing.T) {
+			deps := healthy()
+			delete(deps, down)
+			s := NewServer(":0", &mockAppState{running: true, dependencies: deps}, nil, nil)
+
+			w := httptest.NewRecorder()
+			s.handleReady(w, httptest.NewRequest(http.MethodGet, "/api/ready", nil))
+			var resp struct {
+				Dependencies map[string]DependencyStatus `json:"dependencies"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if got := resp.Dependencies[down]; got != (DependencyStatus{Status: "not_configured"}) {
+				t.Errorf("expected %s not_configured, got %+v", down, got)
+			}
+		})
+	}
+}
+
 func TestHandleBuilder(t *testing.T) {
 	builder := &mockBuilder{
 		lastSync:    time.Now().Add(-2 * time.Minute),
@@ -2301,6 +3268,65 @@ func TestHandleBuilder(t *testing.T) {
 	}
 }
 
+func TestHandleBuilderDegraded(t *testing.T) {
+	builder := &mockBuilder{
+		lastSync: time.Now().Add(-2 * time.Minute),
+		err:      errors.New("data api: 503"),
+	}
+	s := NewServer(":0", &mockAppState{}, nil, builder)
+
+	w := httptest.NewRecorder()
+	s.handleBuilder(w, httptest.NewRequest(http.MethodGet, "/api/builder", nil))
+	var resp map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp["status"] != "degraded" || resp["configured"] != true {
+		t.Fatalf("expected a configured, degraded builder, got status=%v configured=%v", resp["status"], resp["configured"])
+	}
+	if resp["error"] != "data api: 503" {
+		t.Errorf("expected the sync error, got %v", resp["error"])
+	}
+
+	builder.err = nil
+	w = httptest.NewRecorder()
+	s.handleBuilder(w, httptest.NewRequest(http.MethodGet, "/api/builder", nil))
+	resp = nil
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp["status"] != "ok" || resp["error"] != nil {
+		t.Errorf("expected status=ok without an error, got %v %v", resp["status"], resp["error"])
+	}
+}
+
+func TestHandleStatusPortfolioHealth(t *testing.T) {
+	cases := []struct {
+		name      string
+		portfolio PortfolioProvider
+		status    string
+		err       string
+	}{
+		{"not configured", nil, "not_configured", ""},
+		{"ok", &mockPortfolio{value: 10}, "ok", ""},
+		{"degraded", &mockPortfolio{value: 10, err: errors.New("data api: timeout")}, "degraded", "data api: timeout"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewServer(":0", &mockAppState{}, tc.portfolio, nil)
+			w := httptest.NewRecorder()
+			s.handleStatus(w, httptest.NewRequest(http.MethodGet, "/api/status", nil))
+			var resp StatusResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if resp.PortfolioStatus != tc.status || resp.PortfolioError != tc.err {
+				t.Errorf("expected %s %q, got %s %q", tc.status, tc.err, resp.PortfolioStatus, resp.PortfolioError)
+			}
+		})
+	}
+}
+
 func TestHandleBuilderNotConfigured(t *testing.T) {
 	s := NewServer(":0", &mockAppState{}, nil, nil)
 
@@ -2388,7 +3414,7 @@ func TestHandleBuilderStaleAndNeverSynced(t *testing.T) {
 }
 
 func TestHandleMarkets(t *testing.T) {
-	state := &mockAppState{assets: []string{"a1", "a2", "a3"}}
+	state := &mockAppState{assets: []string{"a1", "a2", "a3"}, staleAssets: []string{"a2"}, coolingAssets: []string{"a3"}}
 	s := NewServer(":0", state, nil, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/markets", nil)
@@ -2402,6 +3428,220 @@ func TestHandleMarkets(t *testing.T) {
 	if int(resp["count"].(float64)) != 3 {
 		t.Errorf("expected count=3, got %v", resp["count"])
 	}
+	if int(resp["stale_count"].(float64)) != 1 {
+		t.Errorf("expected stale_count=1, got %v", resp["stale_count"])
+	}
+	cooling, _ := resp["cooldown_assets"].([]interface{})
+	if len(cooling) != 1 || cooling[0] != "a3" || int(resp["cooldown_count"].(float64)) != 1 {
+		t.Errorf("expected cooldown_assets=[a3], got %v", resp["cooldown_assets"])
+	}
+}
+
+func TestHandleConfigRedactsSecrets(t *testing.T) {
+	cfg := config.Default()
+	cfg.PrivateKey = "0xdeadbeef"
+	cfg.APISecret = "api-secret"
+	cfg.APIPassphrase = "api-pass"
+	cfg.BuilderSecret = "builder-secret"
+	cfg.Telegram.BotToken = "123:abc"
+	cfg.Maker.MinSpreadBps = 42
+	s := NewServer(":0", &mockAppState{cfg: cfg}, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/config", nil)
+	w := httptest.NewRecorder()
+	s.handleConfig(w, req)
+	body := w.Body.String()
+	for _, secret := range []string{"0xdeadbeef", "api-secret", "api-pass", "builder-secret", "123:abc"} {
+		if strings.Contains(body, secret) {
+			t.Fatalf("secret %q leaked in /api/config: %s", secret, body)
+		}
+	}
+
+	var resp map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp["private_key"] != "***" || resp["api_secret"] != "***" {
+		t.Errorf("expected redacted credentials, got private_key=%v api_secret=%v", resp["private_key"], resp["api_secret"])
+	}
+	if tg := resp["telegram"].(map[string]interface{}); tg["bot_token"] != "***" {
+		t.Errorf("expected redacted bot token, got %v", tg["bot_token"])
+	}
+	if resp["api_key"] != "" {
+		t.Errorf("expected unset api_key to stay empty, got %v", resp["api_key"])
+	}
+	if maker := resp["maker"].(map[string]interface{}); maker["min_spread_bps"] != float64(42) {
+		t.Errorf("expected maker.min_spread_bps=42, got %v", maker["min_spread_bps"])
+	}
+	if resp["scan_interval"] != "10s" || resp["trading_mode"] != "paper" {
+		t.Errorf("expected non-secret fields, got scan_interval=%v trading_mode=%v", resp["scan_interval"], resp["trading_mode"])
+	}
+}
+
+func TestHandleBook(t *testing.T) {
+	books := feed.NewBookSnapshot()
+	books.Update(ws.OrderbookEvent{
+		AssetID: "a1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.49", Size: "100"}, {Price: "0.48", Size: "50"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.51", Size: "30"}},
+	})
+	s := NewServer(":0", &mockAppState{books: books}, nil, nil)
+
+	w := httptest.NewRecorder()
+	s.handleBook(w, httptest.NewRequest(http.MethodGet, "/api/book/a1", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var resp feed.BookView
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.AssetID != "a1" || len(resp.Bids) != 2 || len(resp.Asks) != 1 {
+		t.Fatalf("unexpected levels: %+v", resp)
+	}
+	if resp.Bids[1] != (feed.Level{Price: 0.48, Size: 50}) || resp.Asks[0] != (feed.Level{Price: 0.51, Size: 30}) {
+		t.Fatalf("unexpected level contents: %+v", resp)
+	}
+	if math.Abs(resp.Mid-0.50) > 1e-9 || math.Abs(resp.Spread-0.02) > 1e-9 || resp.UpdatedAt.IsZero() {
+		t.Fatalf("unexpected summary: %+v", resp)
+	}
+
+	w = httptest.NewRecorder()
+	s.handleBook(w, httptest.NewRequest(http.MethodGet, "/api/book/unknown", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown asset, got %d", w.Code)
+	}
+}
+
+func TestHandleStrategy(t *testing.T) {
+	state := &mockAppState{
+		makerParams: strategy.MakerConfig{
+			MinSpreadBps: 20, SpreadMultiplier: 1.5, OrderSizeUSDC: 10, MinOrderSizeUSDC: 2,
+			InventorySkewBps: 30, InventoryWidenFactor: 0.5, PostFillPause: 1500 * time.Millisecond,
+		},
+		takerParams: strategy.TakerConfig{
+			MinImbalance: 0.15, MaxSlippageBps: 30, Cooldown: time.Minute,
+			FlowWeight: 0.3, ImbalanceWeight: 0.5, ConvergenceWeight: 0.2, MinCompositeScore: 0.4,
+		},
+		strategyAssetID: "a1",
+		overrideMaker:   strategy.MakerConfig{MinSpreadBps: 80},
+	}
+	s := NewServer(":0", state, nil, nil)
+
+	w := httptest.NewRecorder()
+	s.handleStrategy(w, httptest.NewRequest(http.MethodGet, "/api/strategy", nil))
+	var resp StrategyResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	wantMaker := MakerParams{
+		MinSpreadBps: 20, SpreadMultiplier: 1.5, OrderSizeUSDC: 10, MinOrderSizeUSDC: 2,
+		InventorySkewBps: 30, InventoryWidenFactor: 0.5, PostFillPauseMs: 1500,
+	}
+	if resp.Override || resp.Maker != wantMaker {
+		t.Fatalf("unexpected maker params: %+v", resp)
+	}
+	if resp.Taker.MinImbalance != 0.15 || resp.Taker.CooldownS != 60 || resp.Taker.ImbalanceWeight != 0.5 || resp.Taker.MinCompositeScore != 0.4 {
+		t.Fatalf("unexpected taker params: %+v", resp.Taker)
+	}
+
+	w = httptest.NewRecorder()
+	s.handleStrategy(w, httptest.NewRequest(http.MethodGet, "/api/strategy?asset_id=a1", nil))
+	resp = StrategyResponse{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !resp.Override || resp.AssetID != "a1" || resp.Maker.MinSpreadBps != 80 {
+		t.Fatalf("expected the asset's override, got %+v", resp)
+	}
+}
+
+func TestHandleMarketDetail(t *testing.T) {
+	state := &mockAppState{bookMetrics: map[string]feed.BookMetrics{
+		"a1": {AssetID: "a1", Spread: 0.02, Imbalance: 0.25},
+	}}
+	s := NewServer(":0", state, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/markets/a1?levels=3&bps=50", nil)
+	w := httptest.NewRecorder()
+	s.handleMarketDetail(w, req)
+	var resp map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp["spread"].(float64) != 0.02 || resp["imbalance"].(float64) != 0.25 {
+		t.Fatalf("unexpected metrics: %v", resp)
+	}
+	if resp["levels"].(float64) != 3 || resp["depth_bps"].(float64) != 50 {
+		t.Fatalf("expected query params to be forwarded, got %v", resp)
+	}
+
+	w = httptest.NewRecorder()
+	s.handleMarketDetail(w, httptest.NewRequest(http.MethodGet, "/api/markets/unknown", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown asset, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	s.handleMarketDetail(w, httptest.NewRequest(http.MethodGet, "/api/markets/a1?levels=0", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid levels, got %d", w.Code)
+	}
+}
+
+func TestHandleAlertsReportsActiveConditionsBySeverity(t *testing.T) {
+	state := &mockAppState{
+		running:       true,
+		feedConnected: false,
+		riskSnapshot: risk.Snapshot{
+			EmergencyStop:      true,
+			DailyPnL:           -17,
+			DailyLossLimitUSDC: 20,
+			InCooldown:         true,
+			CooldownRemaining:  90 * time.Second,
+		},
+	}
+	builder := &mockBuilder{lastSync: time.Now().Add(-2 * time.Hour)}
+	s := NewServer(":0", state, nil, builder)
+
+	w := httptest.NewRecorder()
+	s.handleAlerts(w, httptest.NewRequest(http.MethodGet, "/api/alerts", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var resp AlertsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	var got []string
+	for _, a := range resp.Alerts {
+		got = append(got, a.Severity+":"+a.Code)
+	}
+	want := []string{
+		"critical:emergency_stop",
+		"critical:feed_disconnected",
+		"warn:in_cooldown",
+		"warn:near_loss_limit",
+		"info:builder_stale",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") || resp.Count != len(want) {
+		t.Fatalf("expected alerts %v, got %v (count %d)", want, got, resp.Count)
+	}
+
+	// Past the loss limit the block replaces the near-limit warning, and a
+	// healthy feed and builder raise nothing.
+	state.riskSnapshot = risk.Snapshot{DailyPnL: -25, DailyLossLimitUSDC: 20}
+	state.feedConnected = true
+	builder.lastSync = time.Now()
+	w = httptest.NewRecorder()
+	s.handleAlerts(w, httptest.NewRequest(http.MethodGet, "/api/alerts", nil))
+	resp = AlertsResponse{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Alerts) != 1 || resp.Alerts[0].Code != "risk_blocked" || !strings.Contains(resp.Alerts[0].Message, "daily_loss_limit_reached") {
+		t.Fatalf("expected a single risk_blocked alert, got %+v", resp.Alerts)
+	}
 }
 
 func TestHandleRisk(t *testing.T) {
@@ -2453,6 +3693,69 @@ func TestHandleRisk(t *testing.T) {
 	}
 }
 
+func TestHandleRiskConcentration(t *testing.T) {
+	state := &mockAppState{
+		riskSnapshot: risk.Snapshot{
+			ConcentrationHHI:     0.82,
+			ConcentrationWarnHHI: 0.5,
+			ConcentrationWarning: true,
+		},
+	}
+	s := NewServer(":0", state, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/risk", nil)
+	w := httptest.NewRecorder()
+	s.handleRisk(w, req)
+
+	var resp map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp["concentration_hhi"].(float64) != 0.82 {
+		t.Fatalf("expected concentration_hhi=0.82, got %v", resp["concentration_hhi"])
+	}
+	if resp["concentration_warn_hhi"].(float64) != 0.5 {
+		t.Fatalf("expected concentration_warn_hhi=0.5, got %v", resp["concentration_warn_hhi"])
+	}
+	if resp["concentration_warning"].(bool) != true {
+		t.Fatalf("expected concentration_warning=true, got %v", resp["concentration_warning"])
+	}
+}
+
+func TestHandleRiskGrossExposure(t *testing.T) {
+	state := &mockAppState{
+		riskSnapshot: risk.Snapshot{
+			GrossExposureUSDC:  25,
+			GrossExposureLimit: 25,
+			PositionsUSDC:      map[string]float64{"asset-1": 15, "asset-2": -10},
+		},
+	}
+	s := NewServer(":0", state, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/risk", nil)
+	w := httptest.NewRecorder()
+	s.handleRisk(w, req)
+
+	var resp map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	positions, ok := resp["positions_usdc"].(map[string]interface{})
+	if !ok || positions["asset-1"].(float64) != 15 || positions["asset-2"].(float64) != -10 {
+		t.Fatalf("unexpected positions_usdc: %v", resp["positions_usdc"])
+	}
+	if resp["gross_exposure_usdc"].(float64) != 25 || resp["gross_exposure_limit_usdc"].(float64) != 25 {
+		t.Fatalf("unexpected gross exposure fields: %v / %v", resp["gross_exposure_usdc"], resp["gross_exposure_limit_usdc"])
+	}
+	if resp["can_trade"].(bool) {
+		t.Fatal("expected can_trade=false at the gross exposure cap")
+	}
+	reasons := resp["blocked_reasons"].([]interface{})
+	if len(reasons) != 1 || reasons[0] != "gross_exposure_limit_reached" {
+		t.Fatalf("expected blocked_reasons=[gross_exposure_limit_reached], got %v", reasons)
+	}
+}
+
 func TestHandleRiskBlockedReasonsMultiple(t *testing.T) {
 	state := &mockAppState{
 		riskSnapshot: risk.Snapshot{
@@ -2548,3 +3851,169 @@ func TestHandlePaper(t *testing.T) {
 		t.Fatalf("expected estimated_equity_usdc 1003.0, got %v", resp["estimated_equity_usdc"])
 	}
 }
+
+func TestHandleExternalSignalPlacesOrder(t *testing.T) {
+	state := &mockAppState{}
+	s := NewServer(":0", state, nil, nil)
+	s.SetAuthToken("secret")
+	s.SetExternalSignals(true)
+
+	body := strings.NewReader(`{"asset_id":"asset-1","side":"buy","amount_usdc":5,"max_price":0.42,"reason":"model"}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/signals/external", body)
+	req.Header.Set("X-API-Key", "secret")
+	w := httptest.NewRecorder()
+	s.httpServer.Handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp["order_id"] != "ext-1" || resp["strategy"] != "external" {
+		t.Fatalf("unexpected response: %v", resp)
+	}
+	if len(state.externalSignals) != 1 {
+		t.Fatalf("expected one submitted signal, got %d", len(state.externalSignals))
+	}
+	got := state.externalSignals[0]
+	if got.Side != "BUY" || got.MaxPrice != 0.42 || got.AmountUSDC != 5 {
+		t.Fatalf("unexpected signal: %+v", got)
+	}
+}
+
+func TestHandleExternalSignalRiskBlocked(t *testing.T) {
+	state := &mockAppState{externalErr: fmt.Errorf("open orders 6 >= max 6")}
+	s := NewServer(":0", state, nil, nil)
+	s.SetAuthToken("secret")
+	s.SetExternalSignals(true)
+
+	body := strings.NewReader(`{"asset_id":"asset-1","side":"SELL","amount_usdc":5}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/signals/external", body)
+	req.Header.Set("Authorization", "Bearer secret")
+	w := httptest.NewRecorder()
+	s.httpServer.Handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d", w.Code)
+	}
+	var resp map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp["status"] != "rejected" {
+		t.Fatalf("expected rejected status, got %v", resp["status"])
+	}
+}
+
+func TestHandleExternalSignalGuards(t *testing.T) {
+	state := &mockAppState{}
+	s := NewServer(":0", state, nil, nil)
+
+	post := func(body string) int {
+		req := httptest.NewRequest(http.MethodPost, "/api/signals/external", strings.NewReader(body))
+		req.Header.Set("X-API-Key", "secret")
+		w := httptest.NewRecorder()
+		s.handleExternalSignal(w, req)
+		return w.Code
+	}
+	valid := `{"asset_id":"asset-1","side":"BUY","amount_usdc":5}`
+
+	if code := post(valid); code != http.StatusNotFound {
+		t.Fatalf("expected 404 while disabled, got %d", code)
+	}
+	s.SetExternalSignals(true)
+	if code := post(valid); code != http.StatusForbidden {
+		t.Fatalf("expected 403 without api token, got %d", code)
+	}
+	s.SetAuthToken("secret")
+	if code := post(`{"asset_id":"asset-1","side":"HOLD","amount_usdc":5}`); code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid side, got %d", code)
+	}
+	if len(state.externalSignals) != 0 {
+		t.Fatalf("expected no signals submitted, got %d", len(state.externalSignals))
+	}
+}
+
+func TestHandleTradesCSV(t *testing.T) {
+	ts := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	fills := make([]execution.Fill, tradesCSVPageSize+2)
+	for i := range fills {
+		fills[i] = execution.Fill{TradeID: fmt.Sprintf("t-%d", i), AssetID: "a", Side: "BUY", Price: 0.5, Size: 10, Fee: 0.01, Timestamp: ts}
+	}
+	state := &mockAppState{recentFills: fills}
+	s := NewServer(":0", state, nil, nil)
+
+	for _, path := range []string{"/api/trades.csv", "/api/trades?format=csv"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		s.httpServer.Handler.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", path, w.Code)
+		}
+		if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+			t.Fatalf("%s: expected text/csv, got %q", path, ct)
+		}
+		rows, err := csv.NewReader(w.Body).ReadAll()
+		if err != nil {
+			t.Fatalf("%s: parse csv: %v", path, err)
+		}
+		if len(rows) != len(fills)+1 {
+			t.Fatalf("%s: expected %d rows, got %d", path, len(fills)+1, len(rows))
+		}
+		if strings.Join(rows[0], ",") != "trade_id,asset_id,side,price,size,fee,notional,timestamp" {
+			t.Fatalf("%s: unexpected header %v", path, rows[0])
+		}
+		want := []string{"t-0", "a", "BUY", "0.500000", "10.000000", "0.010000", "5.000000", "2026-03-01T12:00:00Z"}
+		if strings.Join(rows[1], ",") != strings.Join(want, ",") {
+			t.Fatalf("%s: unexpected first row %v", path, rows[1])
+		}
+		if rows[len(rows)-1][0] != fmt.Sprintf("t-%d", len(fills)-1) {
+			t.Fatalf("%s: expected last row to be the newest fill, got %v", path, rows[len(rows)-1])
+		}
+	}
+}
+
+func TestHandlePnLHistory(t *testing.T) {
+	ts := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	state := &mockAppState{pnlHistory: []map[string]interface{}{
+		{"timestamp": ts, "realized": 1.0, "total": 1.5, "net": 1.4},
+		{"timestamp": ts.Add(time.Minute), "realized": 1.2, "total": 1.6, "net": 1.5},
+	}}
+	s := NewServer(":0", state, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/pnl-history?window=2d&bucket=5m", nil)
+	w := httptest.NewRecorder()
+	s.handlePnLHistory(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if state.pnlHistoryWindow != 48*time.Hour || state.pnlHistoryBucket != 5*time.Minute {
+		t.Fatalf("unexpected window/bucket: %s/%s", state.pnlHistoryWindow, state.pnlHistoryBucket)
+	}
+	var resp map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	points := resp["points"].([]interface{})
+	if len(points) != 2 || resp["count"].(float64) != 2 {
+		t.Fatalf("expected 2 points, got %v", resp)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/pnl-history", nil)
+	w = httptest.NewRecorder()
+	s.handlePnLHistory(w, req)
+	if state.pnlHistoryWindow != 24*time.Hour || state.pnlHistoryBucket != 0 {
+		t.Fatalf("expected 24h default window, got %s/%s", state.pnlHistoryWindow, state.pnlHistoryBucket)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/pnl-history?window=abc", nil)
+	w = httptest.NewRecorder()
+	s.handlePnLHistory(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid window, got %d", w.Code)
+	}
+}
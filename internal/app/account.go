@@ -0,0 +1,147 @@
+package app
+
+import (
+	"context"
+	"log"
+	"math"
+	"path/filepath"
+	"strings"
+
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/auth"
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob"
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/ws"
+
+	"github.com/GoPolymarket/polymarket-trader/internal/execution"
+	"github.com/GoPolymarket/polymarket-trader/internal/risk"
+)
+
+// account is a set of exchange credentials orders are placed through, with
+// the risk manager that gates them and the tracker holding its orders, fills
+// and positions. The maker always trades the primary account; the taker
+// trades its own once SetTakerAccount is called.
+type account struct {
+	name    string
+	clob    clob.Client
+	signer  auth.Signer
+	ws      ws.Client // user order/trade streams; nil for the primary, which uses App.wsClient
+	risk    *risk.Manager
+	tracker *execution.Tracker
+	breaker *placementBreaker
+
+	dailyRealizedBaseline float64 // taker account only; the primary uses App's
+}
+
+func (a *App) primaryAccount() *account {
+	return &account{name: "primary", clob: a.clobClient, signer: a.signer, risk: a.riskMgr, tracker: a.tracker, breaker: a.breaker}
+}
+
+// accounts returns the primary account followed by the taker account, if set.
+func (a *App) accounts() []*account {
+	accts := []*account{a.primaryAccount()}
+	if a.takerAcct != nil {
+		accts = append(accts, a.takerAcct)
+	}
+	return accts
+}
+
+// takerAccount returns the account taker signals are placed through.
+func (a *App) takerAccount() *account {
+	if a.takerAcct != nil {
+		return a.takerAcct
+	}
+	return a.primaryAccount()
+}
+
+// SetTakerAccount routes taker orders through a second wallet. wsClient,
+// authenticated with the same credentials, feeds that account's fills into a
+// tracker of its own, so its positions are never netted against the
+// primary's. The account gets its own risk manager with the same limits,
+// synced from that tracker, and its own placement breaker, so rejections on
+// one wallet do not pause the other.
+func (a *App) SetTakerAccount(clobClient clob.Client, wsClient ws.Client, signer auth.Signer) {
+	tracker := execution.NewTracker()
+	tracker.SetCostBasisMode(a.tracker.CostBasisMode())
+	tracker.SetClock(a.now)
+	tracker.OnFill = func(f execution.Fill) {
+		a.recordFill(tracker, "fill (taker account)", f)
+	}
+	tracker.OnOrderFilled = a.recordOrderFilled
+	a.mu.RLock()
+	for id, rate := range a.feeRates {
+		tracker.SetFeeRate(id, rate)
+	}
+	a.mu.RUnlock()
+	a.takerAcct = &account{
+		name:    "taker",
+		clob:    clobClient,
+		signer:  signer,
+		ws:      wsClient,
+		risk:    risk.New(riskConfig(a.cfg)),
+		tracker: tracker,
+		breaker: newPlacementBreaker(a.cfg.MaxPlacementFailures, a.cfg.PlacementFailureWindow, a.cfg.PlacementBreakerCooldown),
+	}
+	if a.tradingMode == "live" && !a.cfg.DryRun && a.cfg.OrderStateFile != "" {
+		loadTrackerState(tracker, takerOrderStateFile(a.cfg.OrderStateFile))
+	}
+}
+
+// takerOrderStateFile is where the taker account's orders and positions are
+// preserved, next to order_state_file.
+func takerOrderStateFile(path string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + ".taker" + ext
+}
+
+// grossExposure sums the absolute USDC exposure of positions at their entry
+// prices, as the risk manager counts it.
+func grossExposure(positions map[string]execution.Position) float64 {
+	var total float64
+	for _, pos := range positions {
+		total += math.Abs(pos.AvgEntryPrice * pos.NetSize)
+	}
+	return total
+}
+
+// mergePositions combines one asset's positions on the two accounts. The
+// entry price is that of the legs on the net side, weighted by size.
+func mergePositions(x, y execution.Position) execution.Position {
+	merged := execution.Position{
+		AssetID:     x.AssetID,
+		NetSize:     x.NetSize + y.NetSize,
+		RealizedPnL: x.RealizedPnL + y.RealizedPnL,
+		TotalFills:  x.TotalFills + y.TotalFills,
+	}
+	var size, cost float64
+	for _, leg := range []execution.Position{x, y} {
+		if leg.NetSize == 0 || (leg.NetSize > 0) != (merged.NetSize > 0) {
+			continue
+		}
+		size += leg.NetSize
+		cost += leg.NetSize * leg.AvgEntryPrice
+		if merged.OpenedAt.IsZero() || (!leg.OpenedAt.IsZero() && leg.OpenedAt.Before(merged.OpenedAt)) {
+			merged.OpenedAt = leg.OpenedAt
+		}
+	}
+	if size != 0 && merged.NetSize != 0 {
+		merged.AvgEntryPrice = cost / size
+	}
+	return merged
+}
+
+// cancelTakerAccountOrders cancels anything the taker account left resting,
+// such as the remainder of a GTC marketable limit.
+func (a *App) cancelTakerAccountOrders(ctx context.Context) {
+	if a.takerAcct == nil || a.takerAcct.clob == nil {
+		return
+	}
+	if err := a.limiter.Wait(ctx); err != nil {
+		log.Printf("cancel all (taker account) error: %v", err)
+		return
+	}
+	resp, err := a.takerAcct.clob.CancelAll(ctx)
+	if err != nil {
+		log.Printf("cancel all (taker account) error: %v", err)
+		return
+	}
+	log.Printf("cancelled %d taker account orders", resp.Count)
+}
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/clobtypes"
+)
+
+// markFill starts the post-fill pause for assetID.
+func (a *App) markFill(assetID string, at time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.lastFillAt[assetID] = at
+	delete(a.makerCancels, assetID)
+	a.idleSince, a.idleAlerted = at, false
+}
+
+// inPostFillPause reports whether assetID filled within its maker's
+// post-fill pause, during which it is not quoted.
+func (a *App) inPostFillPause(assetID string, now time.Time) bool {
+	pause := a.makerFor(assetID).PostFillPause()
+	if pause <= 0 {
+		return false
+	}
+	a.mu.RLock()
+	last, ok := a.lastFillAt[assetID]
+	a.mu.RUnlock()
+	return ok && now.Sub(last) < pause
+}
+
+// pullQuotes cancels the maker's resting quotes on assetID. A throttled live
+// cancel leaves them in place for the next book update to retry.
+func (a *App) pullQuotes(ctx context.Context, assetID string) {
+	ids := a.activeOrders[assetID]
+	if len(ids) == 0 {
+		return
+	}
+	if a.execMode == "live" && a.clobClient != nil {
+		if !a.limiter.Allow() {
+			return
+		}
+		_, _ = a.clobClient.CancelOrders(ctx, &clobtypes.CancelOrdersRequest{OrderIDs: ids})
+	} else if a.execMode == "paper" {
+		a.cancelPaperOrders(ids)
+	}
+	a.makerMids.forget(ids)
+	delete(a.activeOrders, assetID)
+}
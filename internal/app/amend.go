@@ -0,0 +1,104 @@
+package app
+
+import (
+	"context"
+	"log"
+	"math"
+	"slices"
+
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/clobtypes"
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/ws"
+
+	"github.com/GoPolymarket/polymarket-trader/internal/execution"
+)
+
+// amendableQuotes picks, per side, one of the resting quotes in orderIDs for
+// the requote to amend rather than cancel with the rest. Only live quotes with
+// nothing filled qualify, so partial fills still carry over through a cancel.
+func (a *App) amendableQuotes(orderIDs []string) map[string]execution.OrderState {
+	if a.execMode != "live" || a.clobClient == nil {
+		return nil
+	}
+	quotes := make(map[string]execution.OrderState, 2)
+	for _, id := range orderIDs {
+		o, ok := a.tracker.Order(id)
+		if !ok || o.Status != "LIVE" || o.FilledSize > 0 {
+			continue
+		}
+		if _, taken := quotes[o.Side]; !taken {
+			quotes[o.Side] = o
+		}
+	}
+	return quotes
+}
+
+// withoutQuotes returns orderIDs minus the IDs of quotes.
+func withoutQuotes(orderIDs []string, quotes map[string]execution.OrderState) []string {
+	return slices.DeleteFunc(slices.Clone(orderIDs), func(id string) bool {
+		for _, o := range quotes {
+			if o.ID == id {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// requoteLimit posts one side of a maker quote, amending the side's resting
+// quote when it has one and placing a new one otherwise.
+func (a *App) requoteLimit(ctx context.Context, amendable map[string]execution.OrderState, assetID, side string, price, sizeUSDC float64) clobtypes.OrderResponse {
+	old, ok := amendable[side]
+	if !ok {
+		return a.placeLimit(ctx, assetID, side, price, sizeUSDC)
+	}
+	delete(amendable, side)
+	return a.amendOrder(ctx, old, price, sizeUSDC)
+}
+
+// amendOrder moves the resting quote old to price and sizeUSDC. The CLOB has
+// no in-place amend, so a quote that would not change is left resting to keep
+// its queue priority, and one that would is cancelled and replaced. It returns
+// the replacement, or an empty response when no new order was placed; a quote
+// left resting stays in activeOrders either way.
+func (a *App) amendOrder(ctx context.Context, old execution.OrderState, price, sizeUSDC float64) clobtypes.OrderResponse {
+	if math.Abs(old.Price-price) < 1e-9 && math.Abs(old.OrigSize-sizeUSDC) < 1e-9 {
+		a.activeOrders[old.AssetID] = append(a.activeOrders[old.AssetID], old.ID)
+		return clobtypes.OrderResponse{}
+	}
+	if !a.limiter.Allow() {
+		// Leave the old quote resting and tracked for the next requote.
+		a.activeOrders[old.AssetID] = append(a.activeOrders[old.AssetID], old.ID)
+		return clobtypes.OrderResponse{}
+	}
+	if _, err := a.clobClient.CancelOrders(ctx, &clobtypes.CancelOrdersRequest{OrderIDs: []string{old.ID}}); err != nil {
+		// Placing the replacement now could leave both resting.
+		log.Printf("amend %s %s %s: cancel: %v", old.ID, old.Side, old.AssetID, err)
+		a.activeOrders[old.AssetID] = append(a.activeOrders[old.AssetID], old.ID)
+		return clobtypes.OrderResponse{}
+	}
+	a.tracker.ProcessOrderEvent(ws.OrderEvent{ID: old.ID, Status: "CANCELED"})
+	a.makerMids.forget([]string{old.ID})
+	resp := a.placeLimit(ctx, old.AssetID, old.Side, price, sizeUSDC)
+	if resp.ID != "" {
+		log.Printf("amended %s %s %s: %.4f -> %.4f, id=%s", old.ID, old.Side, old.AssetID, old.Price, price, resp.ID)
+	}
+	return resp
+}
+
+// cancelUnamended cancels the amendable quotes a requote did not reuse,
+// because a side went unquoted or the requote stopped early.
+func (a *App) cancelUnamended(ctx context.Context, assetID string, amendable map[string]execution.OrderState) {
+	if len(amendable) == 0 {
+		return
+	}
+	ids := make([]string, 0, len(amendable))
+	for _, o := range amendable {
+		ids = append(ids, o.ID)
+	}
+	if !a.limiter.Allow() {
+		a.activeOrders[assetID] = append(a.activeOrders[assetID], ids...)
+		return
+	}
+	_, _ = a.clobClient.CancelOrders(ctx, &clobtypes.CancelOrdersRequest{OrderIDs: ids})
+	a.makerMids.forget(ids)
+}
@@ -2,12 +2,18 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"log"
+	"maps"
 	"math"
+	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/GoPolymarket/polymarket-go-sdk/pkg/auth"
@@ -38,6 +44,7 @@ type App struct {
 	signer      auth.Signer
 	gammaClient gamma.Client
 	dataClient  data.Client
+	takerAcct   *account // nil: the taker trades the primary account
 
 	books   *feed.BookSnapshot
 	riskMgr *risk.Manager
@@ -61,10 +68,23 @@ type App struct {
 	BuilderTracker *builder.VolumeTracker
 
 	// Phase 2.4: Telegram notifications.
-	notifier Notifier
+	notifier   Notifier
+	notifyGate *notifyGate
 
 	activeOrders  map[string][]string
-	assetToMarket map[string]string // assetID → market/condition ID
+	makerMids     *makerMids              // maker quote → book mid at placement
+	assetToMarket map[string]string       // assetID → market/condition ID
+	limiter       *orderLimiter           // CLOB order/cancel rate limit
+	breaker       *placementBreaker       // pauses placement after repeated rejections
+	rejections    *execution.RejectionLog // recent blocked or refused orders
+	staleCancels  atomic.Int64            // quotes cancelled for exceeding maker.max_order_age
+	vol           *strategy.VolatilityEstimator
+	lastFillAt    map[string]time.Time   // assetID → last fill, guarded by mu
+	makerCancels  map[string][]time.Time // assetID → requote cancels since the last fill, guarded by mu
+	cooldownUntil map[string]time.Time   // assetID → end of its cancel cooldown, guarded by mu
+	// USDC of the current maker clip filled by since-cancelled quotes; loop
+	// goroutine only.
+	makerClipFilled map[makerClipKey]float64
 
 	gammaSelector *strategy.GammaSelector
 
@@ -79,13 +99,52 @@ type App struct {
 	realizedInitialized   bool
 	dailyRealizedBaseline float64
 	dailyBaselineSet      bool
-	tradingMode           string
+	tradingMode           string // configured mode, live or paper
+	execMode              string // where orders execute: paper (the simulator) under dry-run, else tradingMode
+	midMethod             string // feed.BookMid method from mid_method
 	paperSim              *paper.Simulator
 
-	mu      sync.RWMutex
-	running bool
+	// Risk guardrail state at the last risk sync, for transition alerts.
+	lastRiskState notify.RiskState
+	riskStateSeen bool
+
+	// Per-asset strategy instances built from market_overrides.
+	marketMakers map[string]*strategy.Maker
+	marketTakers map[string]*strategy.Taker
+
+	reloadCh chan reloadRequest
+	clock    Clock
+	recorder *feed.Recorder
+	marks    *markPrices // last trade and mid per asset, see markPrice
+	signals  *signalLog  // nil unless taker.signal_log is set
+
+	rescanReqCh chan rescanRequest
+	rescanMu    sync.Mutex // serializes on-demand rescans
+
+	externalReqCh chan externalSignalRequest
+
+	// Fill count and net PnL at the last hourly summary; loop goroutine only.
+	hourlyFills  int
+	hourlyNetPnL float64
+
+	mu            sync.RWMutex
+	running       bool
+	feedConnected bool
+	lastHeartbeat time.Time
+	heartbeatErr  error
+	depErrs       map[string]error // latest failure per dependency, see DependencyHealth
+	// Start of the current stretch without fills, and whether it has been
+	// alerted.
+	idleSince   time.Time
+	idleAlerted bool
+	// End of the pause on makers and takers a flatten starts; zero when not
+	// paused.
+	flattenPausedUntil time.Time
 }
 
+// paperStateSaveInterval is how often paper account state is flushed to disk.
+const paperStateSaveInterval = time.Minute
+
 // Notifier defines alert methods used by the trading app.
 type Notifier interface {
 	NotifyFill(ctx context.Context, assetID, side string, price, size float64) error
@@ -93,29 +152,34 @@ type Notifier interface {
 	NotifyEmergencyStop(ctx context.Context) error
 	NotifyDailySummary(ctx context.Context, pnl float64, fills int, volume float64) error
 	NotifyRiskCooldown(ctx context.Context, consecutiveLosses, maxConsecutiveLosses int, cooldownRemaining time.Duration) error
+	NotifyRiskStateChange(ctx context.Context, change notify.RiskStateChange) error
+	NotifyAlert(ctx context.Context, title, text string) error
 	NotifyDailyCoachTemplate(ctx context.Context, textHTML string) error
 	NotifyWeeklyReviewTemplate(ctx context.Context, textHTML string) error
 }
 
 func New(cfg config.Config, clobClient clob.Client, wsClient ws.Client, signer auth.Signer, gammaClient gamma.Client, dataClient data.Client, rtdsClient rtds.Client) *App {
 	tracker := execution.NewTracker()
-	riskMgr := risk.New(risk.Config{
-		MaxOpenOrders:           cfg.Risk.MaxOpenOrders,
-		MaxDailyLossUSDC:        cfg.Risk.MaxDailyLossUSDC,
-		MaxDailyLossPct:         cfg.Risk.MaxDailyLossPct,
-		AccountCapitalUSDC:      cfg.Risk.AccountCapitalUSDC,
-		MaxPositionPerMarket:    cfg.Risk.MaxPositionPerMarket,
-		StopLossPerMarket:       cfg.Risk.StopLossPerMarket,
-		MaxDrawdownPct:          cfg.Risk.MaxDrawdownPct,
-		RiskSyncInterval:        cfg.Risk.RiskSyncInterval,
-		MaxConsecutiveLosses:    cfg.Risk.MaxConsecutiveLosses,
-		ConsecutiveLossCooldown: cfg.Risk.ConsecutiveLossCooldown,
-	})
+	tracker.SetCostBasisMode(execution.CostBasisMode(strings.ToLower(strings.TrimSpace(cfg.CostBasisMode))))
+	riskMgr := risk.New(riskConfig(cfg))
 
-	// Phase 2.4: Telegram notifier.
-	var notifier Notifier
+	// Phase 2.4: alert channels (Telegram, Discord, Slack, webhook), fanned out to all enabled.
+	var channels []notify.Channel
 	if cfg.Telegram.Enabled {
-		notifier = notify.NewNotifier(cfg.Telegram.BotToken, cfg.Telegram.ChatID)
+		channels = append(channels, notify.NewNotifier(cfg.Telegram.BotToken, cfg.Telegram.ChatID))
+	}
+	if cfg.Discord.Enabled {
+		channels = append(channels, notify.NewDiscordNotifier(cfg.Discord.WebhookURL))
+	}
+	if cfg.Slack.Enabled {
+		channels = append(channels, notify.NewSlackNotifier(cfg.Slack.WebhookURL, cfg.Slack.Channel))
+	}
+	if cfg.Webhook.Enabled {
+		channels = append(channels, notify.NewWebhookNotifier(cfg.Webhook.URL))
+	}
+	var notifier Notifier
+	if len(channels) > 0 {
+		notifier = notify.NewMultiNotifier(channels...)
 	}
 
 	// Phase 1.1: FlowTracker.
@@ -131,51 +195,52 @@ func New(cfg config.Config, clobClient clob.Client, wsClient ws.Client, signer a
 	if tradingMode != "live" && tradingMode != "paper" {
 		tradingMode = "paper"
 	}
+	// Dry-run keeps the configured mode but executes like a thin paper mode:
+	// orders go through a simulator whose account lives in memory only, so
+	// projected fills, PnL and fees show up in the logs and stats without
+	// touching the exchange or paper.state_file.
+	execMode := tradingMode
+	if cfg.DryRun {
+		execMode = "paper"
+	}
 
 	a := &App{
-		cfg:         cfg,
-		clobClient:  clobClient,
-		wsClient:    wsClient,
-		signer:      signer,
-		gammaClient: gammaClient,
-		dataClient:  dataClient,
-		books:       feed.NewBookSnapshot(),
-		riskMgr:     riskMgr,
-		maker: strategy.NewMaker(strategy.MakerConfig{
-			MinSpreadBps:         cfg.Maker.MinSpreadBps,
-			SpreadMultiplier:     cfg.Maker.SpreadMultiplier,
-			OrderSizeUSDC:        cfg.Maker.OrderSizeUSDC,
-			MaxOrdersPerMarket:   cfg.Maker.MaxOrdersPerMarket,
-			InventorySkewBps:     cfg.Maker.InventorySkewBps,
-			InventoryWidenFactor: cfg.Maker.InventoryWidenFactor,
-			MinOrderSizeUSDC:     cfg.Maker.MinOrderSizeUSDC,
-		}),
-		taker: strategy.NewTaker(strategy.TakerConfig{
-			MinImbalance:      cfg.Taker.MinImbalance,
-			DepthLevels:       cfg.Taker.DepthLevels,
-			AmountUSDC:        cfg.Taker.AmountUSDC,
-			MaxSlippageBps:    cfg.Taker.MaxSlippageBps,
-			Cooldown:          cfg.Taker.Cooldown,
-			MinConfidenceBps:  cfg.Taker.MinConfidenceBps,
-			FlowWeight:        cfg.Taker.FlowWeight,
-			ImbalanceWeight:   cfg.Taker.ImbalanceWeight,
-			ConvergenceWeight: cfg.Taker.ConvergenceWeight,
-			MinConvergenceBps: cfg.Taker.MinConvergenceBps,
-			FlowWindow:        cfg.Taker.FlowWindow,
-			MinCompositeScore: cfg.Taker.MinCompositeScore,
-		}),
-		tracker:       tracker,
-		kpi:           newKPICollector(),
-		flowTracker:   flowTracker,
-		tokenPairs:    make(map[string]string),
-		notifier:      notifier,
-		activeOrders:  make(map[string][]string),
-		assetToMarket: make(map[string]string),
-		feeRates:      make(map[string]float64),
-		rtdsClient:    rtdsClient,
+		cfg:             cfg,
+		clobClient:      clobClient,
+		wsClient:        wsClient,
+		signer:          signer,
+		gammaClient:     gammaClient,
+		dataClient:      dataClient,
+		books:           feed.NewBookSnapshot(),
+		marks:           newMarkPrices(),
+		riskMgr:         riskMgr,
+		maker:           strategy.NewMaker(makerConfig(cfg.Maker)),
+		taker:           strategy.NewTaker(takerConfig(cfg.Taker)),
+		tracker:         tracker,
+		kpi:             newKPICollector(cfg.Taker.RealizationWindow, systemClock{}),
+		flowTracker:     flowTracker,
+		tokenPairs:      make(map[string]string),
+		notifier:        notifier,
+		notifyGate:      newNotifyGate(cfg.Notify),
+		reloadCh:        make(chan reloadRequest),
+		rescanReqCh:     make(chan rescanRequest),
+		clock:           systemClock{},
+		marketMakers:    make(map[string]*strategy.Maker),
+		marketTakers:    make(map[string]*strategy.Taker),
+		activeOrders:    make(map[string][]string),
+		makerMids:       newMakerMids(),
+		rejections:      execution.NewRejectionLog(maxRejections),
+		assetToMarket:   make(map[string]string),
+		lastFillAt:      make(map[string]time.Time),
+		makerCancels:    make(map[string][]time.Time),
+		cooldownUntil:   make(map[string]time.Time),
+		makerClipFilled: make(map[makerClipKey]float64),
+		feeRates:        make(map[string]float64),
+		rtdsClient:      rtdsClient,
+		externalReqCh:   make(chan externalSignalRequest),
 		cryptoTracker: strategy.NewCryptoSignalTracker(strategy.CryptoSignalConfig{
-			MinPriceChangePct: 0.02,
-			Cooldown:          5 * time.Minute,
+			MinPriceChangePct: cfg.Crypto.MinPriceChangePct,
+			Cooldown:          cfg.Crypto.Cooldown,
 			DefaultAmountUSDC: cfg.Taker.AmountUSDC,
 		}),
 		gammaSelector: strategy.NewGammaSelector(gammaClient, strategy.SelectorConfig{
@@ -184,17 +249,74 @@ func New(cfg config.Config, clobClient clob.Client, wsClient ws.Client, signer a
 			MinVolume24hr:  cfg.Selector.MinVolume24hr,
 			MaxSpread:      cfg.Selector.MaxSpread,
 			MinDaysToEnd:   cfg.Selector.MinDaysToEnd,
+
+			ProfitabilityWeight: cfg.Selector.ProfitabilityWeight,
+			MinVolatility:       cfg.Selector.MinVolatility,
+			MaxVolatility:       cfg.Selector.MaxVolatility,
 		}),
 		tradingMode: tradingMode,
+		execMode:    execMode,
+		midMethod:   strings.ToLower(strings.TrimSpace(cfg.MidMethod)),
+	}
+	a.vol = strategy.NewVolatilityEstimator(cfg.Maker.VolWindow)
+	a.maker.SetVolatility(a.vol)
+	a.maker.SetMidMethod(a.midMethod)
+	a.books.SetMidMethod(a.midMethod)
+	if cfg.Taker.SignalLog != "" {
+		signals, err := openSignalLog(cfg.Taker.SignalLog)
+		if err != nil {
+			log.Printf("warning: taker signal log disabled: %v", err)
+		} else {
+			a.signals = signals
+			a.taker.SetSignalObserver(signals.record)
+		}
+	}
+	a.applyMarketOverrides(cfg.MarketOverrides)
+	if len(cfg.Crypto.Mapping) > 0 {
+		a.cryptoTracker.SetMapping(maps.Clone(cfg.Crypto.Mapping))
+	}
+	// A data client that serves price history takes precedence; otherwise the
+	// CLOB prices-history endpoint is used. Without either, the selector
+	// falls back to a Gamma client that serves it.
+	if h, ok := dataClient.(strategy.PriceHistorySource); ok {
+		a.gammaSelector.SetPriceHistory(h)
+	} else if clobClient != nil {
+		a.gammaSelector.SetPriceHistory(clobPriceHistory{client: clobClient})
+	}
+	if (cfg.Selector.MinVolatility > 0 || cfg.Selector.MaxVolatility > 0) && !a.gammaSelector.HasPriceHistory() {
+		log.Println("warning: selector volatility band ignored, no client serves price history")
+	}
+	a.books.SetStaleAfter(cfg.BookStaleAfter)
+	a.limiter = newOrderLimiter(cfg.MaxOrdersPerSecond, a.now)
+	a.breaker = newPlacementBreaker(cfg.MaxPlacementFailures, cfg.PlacementFailureWindow, cfg.PlacementBreakerCooldown)
+	if cfg.Record.Enabled {
+		recorder, err := feed.NewRecorder(feed.RecorderConfig{
+			Dir:          cfg.Record.Dir,
+			MaxFileBytes: int64(cfg.Record.MaxFileMB) << 20,
+		})
+		if err != nil {
+			log.Printf("warning: event recording disabled: %v", err)
+		} else {
+			a.recorder = recorder
+		}
 	}
-	if tradingMode == "paper" {
+	if execMode == "paper" {
 		allowShort := cfg.Paper.AllowShort
 		a.paperSim = paper.NewSimulator(paper.Config{
 			InitialBalanceUSDC: cfg.Paper.InitialBalanceUSDC,
 			FeeBps:             cfg.Paper.FeeBps,
 			SlippageBps:        cfg.Paper.SlippageBps,
 			AllowShort:         &allowShort,
+			LatencyMs:          cfg.Paper.LatencyMs,
+			SlippageModel:      cfg.Paper.SlippageModel,
+			MaxSlippageBps:     cfg.Paper.MaxSlippageBps,
 		})
+		if !cfg.DryRun {
+			a.loadPaperState()
+		}
+	}
+	if tradingMode == "live" && !cfg.DryRun {
+		a.loadOrderState()
 	}
 
 	// Phase 2.1: Portfolio tracker.
@@ -214,19 +336,41 @@ func New(cfg config.Config, clobClient clob.Client, wsClient ws.Client, signer a
 
 	// OnFill callback: record flow + notify.
 	tracker.OnFill = func(f execution.Fill) {
-		riskMgr.RecordPnL(0)
-		if a.kpi != nil {
-			a.kpi.recordFill(time.Now().UTC())
+		a.recordFill(tracker, "fill", f)
+	}
+	tracker.OnOrderFilled = a.recordOrderFilled
+
+	return a
+}
+
+// recordOrderFilled counts an order on either account's tracker toward the
+// daily fill ratio the first time it matches, however many fills it takes.
+func (a *App) recordOrderFilled(execution.OrderState) {
+	if a.kpi != nil {
+		a.kpi.recordOrderFilled(a.now())
+	}
+}
+
+// recordFill handles a fill on either account's tracker. Fills from both
+// accounts count toward the fill-rate breaker, adverse-selection marks,
+// flow and notifications.
+func (a *App) recordFill(tracker *execution.Tracker, label string, f execution.Fill) {
+	if a.riskMgr.RecordFill(a.now()) && !a.riskMgr.EmergencyStop() {
+		a.tripFillRateBreaker()
+	}
+	a.markFill(f.AssetID, a.now())
+	log.Printf("%s: %s %s %s price=%.4f size=%.2f", label, f.Side, f.AssetID, f.TradeID, f.Price, f.Size)
+	// Phase 1.1: Record flow for EvaluateEnhanced.
+	a.flowTracker.Record(f.AssetID, f.Side, f.Size, f.Price)
+	if a.notifier != nil {
+		var realized float64
+		if pos := tracker.Position(f.AssetID); pos != nil {
+			realized = pos.RealizedPnL
 		}
-		log.Printf("fill: %s %s %s price=%.4f size=%.2f", f.Side, f.AssetID, f.TradeID, f.Price, f.Size)
-		// Phase 1.1: Record flow for EvaluateEnhanced.
-		a.flowTracker.Record(f.AssetID, f.Side, f.Size, f.Price)
-		if a.notifier != nil {
+		if a.notifyGate.allowFill(a.now(), f.AssetID, f.Price*f.Size, realized) {
 			_ = a.notifier.NotifyFill(context.Background(), f.AssetID, f.Side, f.Price, f.Size)
 		}
 	}
-
-	return a
 }
 
 func (a *App) Run(ctx context.Context) error {
@@ -256,32 +400,18 @@ func (a *App) Run(ctx context.Context) error {
 	// Phase 3.3: Fetch fee rates for fee-aware maker pricing.
 	a.fetchFeeRates(ctx, assetIDs)
 
-	bookCh, err := a.wsClient.SubscribeOrderbook(ctx, assetIDs)
-	if err != nil {
-		return err
-	}
-
-	// Subscribe to user order and trade streams for fill tracking.
-	marketIDs := a.collectMarketIDs(assetIDs)
-	var orderCh <-chan ws.OrderEvent
-	var tradeCh <-chan ws.TradeEvent
-	if a.tradingMode == "live" && len(marketIDs) > 0 {
-		orderCh, err = a.wsClient.SubscribeUserOrders(ctx, marketIDs)
-		if err != nil {
-			log.Printf("warning: user orders subscription failed: %v", err)
-		}
-		tradeCh, err = a.wsClient.SubscribeUserTrades(ctx, marketIDs)
-		if err != nil {
-			log.Printf("warning: user trades subscription failed: %v", err)
-		}
-	}
+	a.ReconcileOnStart(ctx)
 
-	// Phase 1.5: Subscribe to market resolutions.
-	var resolutionCh <-chan ws.MarketResolvedEvent
-	resolutionCh, err = a.wsClient.SubscribeMarketResolutions(ctx, assetIDs)
+	subs, err := a.subscribeFeeds(ctx, assetIDs)
 	if err != nil {
-		log.Printf("warning: market resolutions subscription failed: %v", err)
+		return err
 	}
+	feeds := newFeedMux(ctx)
+	feeds.add(subs)
+	defer func() { feeds.stop() }()
+	bookCh, orderCh, tradeCh, resolutionCh := feeds.book, feeds.orders, feeds.trades, feeds.resolutions
+	takerOrderCh, takerTradeCh := feeds.takerOrders, feeds.takerTrades
+	defer a.setFeedConnected(false)
 
 	// Phase 2.1: Start portfolio sync in background.
 	if a.Portfolio != nil {
@@ -302,18 +432,7 @@ func (a *App) Run(ctx context.Context) error {
 	}
 
 	// Phase 3.2: Start RTDS crypto price subscription if configured.
-	var cryptoCh <-chan rtds.CryptoPriceEvent
-	if a.rtdsClient != nil && a.cryptoTracker != nil {
-		symbols := a.cryptoTracker.TrackedSymbols()
-		if len(symbols) > 0 {
-			cryptoCh, err = a.rtdsClient.SubscribeCryptoPrices(ctx, symbols)
-			if err != nil {
-				log.Printf("warning: rtds crypto prices subscription failed: %v", err)
-			} else {
-				log.Printf("rtds: subscribed to %d crypto symbols", len(symbols))
-			}
-		}
-	}
+	cryptoCh := a.subscribeCrypto(ctx)
 
 	log.Println("trading loop started")
 
@@ -337,6 +456,49 @@ func (a *App) Run(ctx context.Context) error {
 	dailyResetTimer := time.NewTimer(timeUntilMidnightUTC())
 	defer dailyResetTimer.Stop()
 
+	// Flatten inventory at the end of the trading day.
+	var flattenCh <-chan time.Time
+	var flattenTimer *time.Timer
+	if a.cfg.FlattenAtWindowClose {
+		flattenTimer = time.NewTimer(untilFlatten(a.now(), a.cfg.FlattenTime))
+		flattenCh = flattenTimer.C
+		defer flattenTimer.Stop()
+	}
+
+	// Persist paper account state so multi-day paper runs survive restarts.
+	var paperSaveCh <-chan time.Time
+	if a.paperSim != nil && a.cfg.Paper.StateFile != "" && !a.cfg.DryRun {
+		paperSaveTicker := time.NewTicker(paperStateSaveInterval)
+		defer paperSaveTicker.Stop()
+		paperSaveCh = paperSaveTicker.C
+	}
+
+	var feeRefreshCh <-chan time.Time
+	if a.clobClient != nil && a.cfg.FeeRefreshInterval > 0 {
+		feeRefreshTicker := time.NewTicker(a.cfg.FeeRefreshInterval)
+		defer feeRefreshTicker.Stop()
+		feeRefreshCh = feeRefreshTicker.C
+	}
+
+	// Dead-man's switch: the loop pets the watchdog a few times per timeout;
+	// a handler that blocks stops the petting and the watchdog cancels out.
+	var watchdogCh <-chan time.Time
+	var dog *watchdog
+	if a.cfg.WatchdogTimeout > 0 {
+		dog = newWatchdog(a.cfg.WatchdogTimeout, time.Now, a.tripWatchdog)
+		go dog.run(ctx)
+		petTicker := time.NewTicker(max(a.cfg.WatchdogTimeout/4, 10*time.Millisecond))
+		defer petTicker.Stop()
+		watchdogCh = petTicker.C
+	}
+
+	var hourlyCh <-chan time.Time
+	if a.cfg.Notify.HourlySummary && a.notifier != nil {
+		hourlyTicker := time.NewTicker(hourlySummaryInterval)
+		defer hourlyTicker.Stop()
+		hourlyCh = hourlyTicker.C
+	}
+
 	// Phase 1.2: GammaSelector rescan ticker.
 	var rescanCh <-chan time.Time
 	var rescanTicker *time.Ticker
@@ -354,11 +516,14 @@ func (a *App) Run(ctx context.Context) error {
 		case event, ok := <-bookCh:
 			if !ok {
 				log.Println("book channel closed, reconnecting...")
-				time.Sleep(2 * time.Second)
-				bookCh, err = a.wsClient.SubscribeOrderbook(ctx, assetIDs)
+				subs, err = a.reconnectFeeds(ctx, assetIDs)
 				if err != nil {
 					return err
 				}
+				feeds = newFeedMux(ctx)
+				feeds.add(subs)
+				bookCh, orderCh, tradeCh, resolutionCh = feeds.book, feeds.orders, feeds.trades, feeds.resolutions
+				takerOrderCh, takerTradeCh = feeds.takerOrders, feeds.takerTrades
 				continue
 			}
 			a.HandleBookEvent(ctx, event)
@@ -368,6 +533,10 @@ func (a *App) Run(ctx context.Context) error {
 				orderCh = nil
 				continue
 			}
+			if a.recorder != nil {
+				a.recorder.RecordOrder(orderEv)
+			}
+			a.observeMakerFill(orderEv)
 			a.tracker.ProcessOrderEvent(orderEv)
 			a.riskMgr.SetOpenOrders(a.tracker.OpenOrderCount())
 
@@ -376,23 +545,54 @@ func (a *App) Run(ctx context.Context) error {
 				tradeCh = nil
 				continue
 			}
+			if a.recorder != nil {
+				a.recorder.RecordTrade(tradeEv)
+			}
+			a.observeTradePrice(tradeEv)
 			a.tracker.ProcessTradeEvent(tradeEv)
 
+		// The taker account's user streams feed its own tracker.
+		case orderEv, ok := <-takerOrderCh:
+			if !ok {
+				takerOrderCh = nil
+				continue
+			}
+			if a.recorder != nil {
+				a.recorder.RecordOrder(orderEv)
+			}
+			a.takerAcct.tracker.ProcessOrderEvent(orderEv)
+			a.takerAcct.risk.SetOpenOrders(a.takerAcct.tracker.OpenOrderCount())
+
+		case tradeEv, ok := <-takerTradeCh:
+			if !ok {
+				takerTradeCh = nil
+				continue
+			}
+			if a.recorder != nil {
+				a.recorder.RecordTrade(tradeEv)
+			}
+			a.observeTradePrice(tradeEv)
+			a.takerAcct.tracker.ProcessTradeEvent(tradeEv)
+
 		case <-riskTicker.C:
 			a.riskSync(ctx)
+			a.cancelStaleOrders(ctx)
+			a.checkIdle(ctx)
+
+		case <-paperSaveCh:
+			a.savePaperState()
+
+		case req := <-a.reloadCh:
+			req.done <- a.applyConfig(ctx, req.cfg)
 
 		// Phase 1.4: Heartbeat.
 		case <-heartbeatTicker.C:
-			if a.heartbeatClient != nil {
-				if _, hbErr := a.heartbeatClient.Heartbeat(ctx, nil); hbErr != nil {
-					log.Printf("heartbeat: %v", hbErr)
-				}
-			}
+			a.sendHeartbeat(ctx)
 
 		// Phase 1.3: Daily PnL reset at UTC midnight.
 		case <-dailyResetTimer.C:
 			if a.notifier != nil {
-				a.sendScheduledTelegramReports(ctx, time.Now().UTC())
+				a.sendScheduledTelegramReports(ctx, a.now())
 				_, fills, pnl := a.Stats()
 				_ = a.notifier.NotifyDailySummary(ctx, pnl, fills, 0)
 			}
@@ -400,6 +600,20 @@ func (a *App) Run(ctx context.Context) error {
 			log.Println("daily PnL reset")
 			dailyResetTimer.Reset(timeUntilMidnightUTC())
 
+		case <-feeRefreshCh:
+			a.fetchFeeRates(ctx, assetIDs)
+
+		case <-hourlyCh:
+			a.sendHourlySummary(ctx)
+
+		case <-watchdogCh:
+			dog.pet()
+
+		case <-flattenCh:
+			log.Println("trading window closed, flattening")
+			a.FlattenAll(ctx)
+			flattenTimer.Reset(untilFlatten(a.now(), a.cfg.FlattenTime))
+
 		// Phase 1.5: Market resolution handling.
 		case resEv, ok := <-resolutionCh:
 			if !ok {
@@ -410,12 +624,24 @@ func (a *App) Run(ctx context.Context) error {
 
 		// Phase 1.2: Periodic market rescan via GammaSelector.
 		case <-rescanCh:
-			a.rescanMarkets(ctx, &assetIDs, &bookCh)
+			_, _, _ = a.rescanMarkets(ctx, &assetIDs, feeds)
+
+		case req := <-a.rescanReqCh:
+			added, removed, err := a.rescanMarkets(ctx, &assetIDs, feeds)
+			req.done <- rescanResult{added: added, removed: removed, err: err}
+
+		case req := <-a.externalReqCh:
+			id, err := a.placeExternalSignal(ctx, req.sig)
+			req.done <- externalSignalResult{orderID: id, err: err}
 
 		// Phase 3.2: RTDS crypto price events → correlated trading signals.
 		case cryptoEv, ok := <-cryptoCh:
 			if !ok {
 				cryptoCh = nil
+				if ctx.Err() == nil {
+					log.Printf("warning: rtds crypto prices stream closed")
+					a.setDependencyErr("rtds", errors.New("crypto prices stream closed"))
+				}
 				continue
 			}
 			a.handleCryptoPrice(ctx, cryptoEv)
@@ -425,9 +651,38 @@ func (a *App) Run(ctx context.Context) error {
 
 func (a *App) HandleBookEvent(ctx context.Context, event ws.OrderbookEvent) {
 	a.books.Update(event)
-	now := time.Now().UTC()
+	if a.recorder != nil {
+		a.recorder.RecordBook(event)
+	}
+	if a.books.IsStale(event.AssetID) {
+		log.Printf("skipping stale book for %s (timestamp %s)", event.AssetID, event.Timestamp)
+		return
+	}
+	now := a.now()
+	if mid := eventMidPrice(event); mid > 0 {
+		a.vol.Observe(event.AssetID, mid)
+	}
+	if mid, err := feed.BookMid(event, a.midMethod); err == nil && mid > 0 {
+		a.marks.recordMid(event.AssetID, mid, now)
+	}
+
+	// Progress resting paper limits before the maker requotes.
+	if a.execMode == "paper" && a.paperSim != nil {
+		for _, fill := range a.paperSim.ProcessBook(event) {
+			a.applyPaperFill(fill)
+		}
+	}
 
-	if a.cfg.Maker.Enabled {
+	paused := a.flattenPaused(now)
+	if a.cfg.Maker.Enabled && paused {
+		// Flattened for the window; stay flat until it closes.
+		a.pullQuotes(ctx, event.AssetID)
+	} else if a.cfg.Maker.Enabled && a.inPostFillPause(event.AssetID, now) {
+		// A fill often precedes a move against us; stay out until it passes.
+		a.pullQuotes(ctx, event.AssetID)
+	} else if a.cfg.Maker.Enabled && a.inCancelCooldown(event.AssetID, now) {
+		a.pullQuotes(ctx, event.AssetID)
+	} else if a.cfg.Maker.Enabled {
 		// Build inventory state from tracker.
 		var inv strategy.InventoryState
 		if pos := a.tracker.Position(event.AssetID); pos != nil {
@@ -439,105 +694,149 @@ func (a *App) HandleBookEvent(ctx context.Context, event ws.OrderbookEvent) {
 		}
 
 		// Phase 3.3: Fee-aware maker pricing — ensure spread covers fees.
-		quote, err := a.maker.ComputeQuote(event, inv)
+		quote, err := a.makerFor(event.AssetID).ComputeQuoteWithCounterpart(event, a.getCounterpartMid(event.AssetID), inv)
 		if err != nil {
 			return
 		}
-		if feeRate, ok := a.feeRates[event.AssetID]; ok && feeRate > 0 {
-			minSpread := feeRate * 2 / 10000 // 2x fee to ensure profitability
-			actualSpread := quote.SellPrice - quote.BuyPrice
-			if quote.BuyPrice+quote.SellPrice > 0 {
-				mid := (quote.BuyPrice + quote.SellPrice) / 2
-				if actualSpread/mid < minSpread {
-					halfMin := mid * minSpread / 2
-					quote.BuyPrice = mid - halfMin
-					quote.SellPrice = mid + halfMin
-				}
-			}
-		}
+		quote, _ = strategy.ApplyFeeFloor(quote, a.feeRate(event.AssetID))
+		quote = strategy.SnapToTick(quote, a.cfg.Maker.TickSize)
 		if a.kpi != nil {
-			mid := (quote.BuyPrice + quote.SellPrice) / 2
-			spreadCaptureBps := 0.0
-			if mid > 0 {
-				spreadCaptureBps = (quote.SellPrice - quote.BuyPrice) / mid * 10000
-			}
-			a.kpi.recordMakerSignal(now, spreadCaptureBps)
+			a.kpi.recordMakerSignal(now)
 		}
 
+		// Quotes being replaced free their slots under max_orders_per_market.
+		room := a.marketOrderRoom(event.AssetID, a.activeOrders[event.AssetID])
+		var amendable map[string]execution.OrderState
 		if old, has := a.activeOrders[event.AssetID]; has && len(old) > 0 {
-			if a.tradingMode == "live" && a.clobClient != nil {
+			// One unfilled quote per side stays resting until placement
+			// amends it; whatever is left unused is cancelled on the way out.
+			amendable = a.amendableQuotes(old)
+			old = withoutQuotes(old, amendable)
+			if a.execMode == "live" && a.clobClient != nil && len(old) > 0 {
+				if !a.limiter.Allow() {
+					// Keep the old quotes resting rather than stacking new ones.
+					return
+				}
 				_, _ = a.clobClient.CancelOrders(ctx, &clobtypes.CancelOrdersRequest{OrderIDs: old})
-			} else if a.tradingMode == "paper" {
+			} else if a.execMode == "paper" {
 				a.cancelPaperOrders(old)
 			}
+			defer a.cancelUnamended(ctx, event.AssetID, amendable)
+			a.carryPartialFills(old)
+			a.makerMids.forget(old)
 			delete(a.activeOrders, event.AssetID)
+			if a.recordMakerCancel(event.AssetID, now) {
+				// The book is churning without fills; leave it alone for a while.
+				return
+			}
 		}
 
-		if !a.cfg.DryRun {
-			if err := a.riskMgr.Allow(event.AssetID, quote.Size); err != nil {
-				if a.kpi != nil {
-					a.kpi.recordRiskBlock(now, classifyRiskAllowError(err))
-				}
+		if a.cfg.DryRun {
+			log.Printf("[DRY] maker %s: buy=%.4f sell=%.4f size=%.2f",
+				event.AssetID, quote.BuyPrice, quote.SellPrice, quote.Size)
+		}
+		// Size the bid to the exposure the risk manager still allows rather
+		// than posting a full clip that would be refused. With too little
+		// room left only the ask is quoted, reduce-only, to work inventory
+		// down. A side whose last quote was partly filled before being
+		// replaced only posts what is left of its clip.
+		buySize := math.Min(a.makerClipSize(event.AssetID, "BUY", quote.Size), a.riskMgr.RemainingCapacity(event.AssetID))
+		buySize = a.roundOrderSize(buySize, quote.BuyPrice)
+		quoteBuy := buySize > 0 && buySize >= a.cfg.Maker.MinOrderSizeUSDC
+		sellSize := a.roundOrderSize(a.makerClipSize(event.AssetID, "SELL", quote.Size), quote.SellPrice)
+		quoteSell := sellSize > 0
+		switch {
+		case room == 0:
+			return
+		case room == 1 && quoteBuy:
+			// One slot left: keep the side that works inventory down.
+			if pos := a.tracker.Position(event.AssetID); pos != nil && pos.NetSize > 0 {
+				quoteBuy = false
+			} else {
+				quoteSell = false
+			}
+		}
+		gateSide, gateSize := "BUY", buySize
+		if !quoteBuy {
+			size, err := a.reduceOnlyAmount(event.AssetID, "SELL", sellSize, quote.SellPrice)
+			if err != nil {
 				return
 			}
-			buyResp := a.placeLimit(ctx, event.AssetID, "BUY", quote.BuyPrice, quote.Size)
+			sellSize = a.roundOrderSize(size, quote.SellPrice)
+			if sellSize <= 0 {
+				return
+			}
+			gateSide, gateSize = "SELL", sellSize
+		}
+		if err := a.riskMgr.Allow(event.AssetID, gateSide, gateSize); err != nil {
+			a.riskBlocked(now, event.AssetID, gateSide, gateSize, err)
+			return
+		}
+		placementMid := eventMidPrice(event)
+		if quoteBuy {
+			buyResp := a.requoteLimit(ctx, amendable, event.AssetID, "BUY", quote.BuyPrice, buySize)
 			if buyResp.ID != "" {
-				if a.tradingMode == "live" {
+				a.makerMids.set(buyResp.ID, placementMid)
+				if a.execMode == "live" {
 					a.activeOrders[event.AssetID] = append(a.activeOrders[event.AssetID], buyResp.ID)
-					a.tracker.RegisterOrder(buyResp.ID, event.AssetID, event.Market, "BUY", quote.BuyPrice, quote.Size)
+					a.tracker.RegisterOrder(buyResp.ID, event.AssetID, event.Market, "BUY", quote.BuyPrice, buySize)
 				} else if strings.EqualFold(buyResp.Status, "LIVE") {
 					a.activeOrders[event.AssetID] = append(a.activeOrders[event.AssetID], buyResp.ID)
 				}
 			}
-			sellResp := a.placeLimit(ctx, event.AssetID, "SELL", quote.SellPrice, quote.Size)
+		}
+		if quoteSell {
+			sellResp := a.requoteLimit(ctx, amendable, event.AssetID, "SELL", quote.SellPrice, sellSize)
 			if sellResp.ID != "" {
-				if a.tradingMode == "live" {
+				a.makerMids.set(sellResp.ID, placementMid)
+				if a.execMode == "live" {
 					a.activeOrders[event.AssetID] = append(a.activeOrders[event.AssetID], sellResp.ID)
-					a.tracker.RegisterOrder(sellResp.ID, event.AssetID, event.Market, "SELL", quote.SellPrice, quote.Size)
+					a.tracker.RegisterOrder(sellResp.ID, event.AssetID, event.Market, "SELL", quote.SellPrice, sellSize)
 				} else if strings.EqualFold(sellResp.Status, "LIVE") {
 					a.activeOrders[event.AssetID] = append(a.activeOrders[event.AssetID], sellResp.ID)
 				}
 			}
-		} else {
-			log.Printf("[DRY] maker %s: buy=%.4f sell=%.4f size=%.2f",
-				event.AssetID, quote.BuyPrice, quote.SellPrice, quote.Size)
 		}
 	}
 
-	if a.cfg.Taker.Enabled {
+	if a.cfg.Taker.Enabled && !paused {
 		// Phase 1.1: Use EvaluateEnhanced with flow + convergence signals.
 		counterpartPrice := a.getCounterpartMid(event.AssetID)
-		sig, err := a.taker.EvaluateEnhanced(event, a.flowTracker, counterpartPrice)
+		taker := a.takerFor(event.AssetID)
+		sig, err := taker.EvaluateEnhanced(event, a.flowTracker, counterpartPrice, a.feeRate(event.AssetID))
 		if err != nil || sig == nil {
 			return
 		}
 		if a.kpi != nil {
 			if mid := eventMidPrice(event); mid > 0 {
-				a.kpi.recordTakerSignal(now, sig.AssetID, sig.Side, mid, defaultTakerRealizationWindow)
+				a.kpi.recordTakerSignal(now, sig.AssetID, sig.Side, mid, a.cfg.Taker.RealizationWindow)
 			}
 		}
-		if !a.cfg.DryRun {
-			if err := a.riskMgr.Allow(event.AssetID, sig.AmountUSDC); err != nil {
-				if a.kpi != nil {
-					a.kpi.recordRiskBlock(now, classifyRiskAllowError(err))
-				}
-				return
-			}
-			resp := a.placeMarket(ctx, sig.AssetID, sig.Side, sig.AmountUSDC)
-			if resp.ID != "" {
-				a.taker.RecordTrade(sig.AssetID)
-				if a.tradingMode == "live" {
-					a.tracker.RegisterOrder(resp.ID, sig.AssetID, event.Market, sig.Side, sig.MaxPrice, sig.AmountUSDC)
-				}
-			}
-		} else {
+		if a.cfg.DryRun {
 			log.Printf("[DRY] taker %s: side=%s amount=%.2f imbalance=%.4f",
 				sig.AssetID, sig.Side, sig.AmountUSDC, sig.Imbalance)
 		}
+		if sig.AmountUSDC = a.roundOrderSize(sig.AmountUSDC, sig.MaxPrice); sig.AmountUSDC <= 0 {
+			return
+		}
+		acct := a.takerAccount()
+		if err := acct.risk.Allow(event.AssetID, sig.Side, sig.AmountUSDC); err != nil {
+			a.riskBlocked(now, event.AssetID, sig.Side, sig.AmountUSDC, err)
+			return
+		}
+		resp := a.placeTaker(ctx, acct, sig)
+		if resp.ID != "" {
+			taker.RecordTrade(sig.AssetID, sig.Side, sig.AmountUSDC)
+			if a.execMode == "live" {
+				acct.tracker.RegisterOrder(resp.ID, sig.AssetID, event.Market, sig.Side, sig.MaxPrice, sig.AmountUSDC)
+			}
+		}
 	}
 
 	// Phase 3.1: Convergence arbitrage — buy both YES+NO when sum deviates from $1.
-	a.checkConvergenceArbitrage(ctx, event)
+	if !paused {
+		a.checkConvergenceArbitrage(ctx, event)
+	}
 
 	if a.kpi != nil {
 		if mid := eventMidPrice(event); mid > 0 {
@@ -548,27 +847,50 @@ func (a *App) HandleBookEvent(ctx context.Context, event ws.OrderbookEvent) {
 
 func (a *App) Shutdown(ctx context.Context) {
 	log.Println("shutting down...")
-	if !a.cfg.DryRun && a.tradingMode == "live" {
+	if !a.cfg.DryRun && a.execMode == "live" && a.cfg.PreserveOrdersOnShutdown {
+		a.saveOrderState()
+	} else if !a.cfg.DryRun && a.execMode == "live" {
 		log.Println("cancelling all open orders...")
-		resp, err := a.clobClient.CancelAll(ctx)
-		if err != nil {
+		if err := a.limiter.Wait(ctx); err != nil {
+			log.Printf("cancel all error: %v", err)
+		} else if resp, err := a.clobClient.CancelAll(ctx); err != nil {
 			log.Printf("cancel all error: %v", err)
 		} else {
 			log.Printf("cancelled %d orders", resp.Count)
 		}
+		a.cancelTakerAccountOrders(ctx)
 	}
 	if a.wsClient != nil {
 		_ = a.wsClient.Close()
 	}
+	if a.takerAcct != nil && a.takerAcct.ws != nil {
+		_ = a.takerAcct.ws.Close()
+	}
+	a.savePaperState()
+	if a.recorder != nil {
+		_ = a.recorder.Close()
+		if dropped := a.recorder.Dropped(); dropped > 0 {
+			log.Printf("recorder dropped %d events (write queue full)", dropped)
+		}
+	}
+	if a.signals != nil {
+		_ = a.signals.Close()
+	}
 	orders := a.tracker.OpenOrderCount()
 	fills := a.tracker.TotalFills()
 	pnl := a.tracker.TotalRealizedPnL()
 	log.Printf("session complete: orders=%d fills=%d pnl=%.2f", orders, fills, pnl)
 }
 
-// Stats returns current open orders, total fills, and realized PnL.
+// Stats returns current open orders, total fills, and realized PnL across
+// both accounts.
 func (a *App) Stats() (orders int, fills int, pnl float64) {
-	return a.tracker.OpenOrderCount(), a.tracker.TotalFills(), a.tracker.TotalRealizedPnL()
+	for _, acct := range a.accounts() {
+		orders += acct.tracker.OpenOrderCount()
+		fills += acct.tracker.TotalFills()
+		pnl += acct.tracker.TotalRealizedPnL()
+	}
+	return orders, fills, pnl
 }
 
 // IsRunning reports whether the trading loop is active.
@@ -584,30 +906,121 @@ func (a *App) IsDryRun() bool { return a.cfg.DryRun }
 // MonitoredAssets returns the list of currently monitored asset IDs.
 func (a *App) MonitoredAssets() []string { return a.books.AssetIDs() }
 
+// Flows returns the taker's order-flow inputs for every monitored asset and
+// the window they cover.
+func (a *App) Flows() (time.Duration, map[string]strategy.FlowStat) {
+	out := make(map[string]strategy.FlowStat)
+	for _, assetID := range a.books.AssetIDs() {
+		out[assetID] = a.flowTracker.Stat(assetID)
+	}
+	return a.flowTracker.Window(), out
+}
+
+// StaleAssets returns monitored assets whose books are older than book_stale_after.
+func (a *App) StaleAssets() []string { return a.books.StaleAssets() }
+
+// BookMetrics summarizes one asset's book for the dashboard.
+func (a *App) BookMetrics(assetID string, levels int, depthBps float64) (feed.BookMetrics, bool) {
+	return a.books.Metrics(assetID, levels, depthBps)
+}
+
+// tripFillRateBreaker halts trading after fills outpaced
+// risk.max_fills_per_minute, which usually means a strategy is looping.
+func (a *App) tripFillRateBreaker() {
+	n := a.riskMgr.FillsLastMinute()
+	log.Printf("EMERGENCY: %d fills in the last minute exceeds max_fills_per_minute=%d, triggering emergency stop", n, a.cfg.Risk.MaxFillsPerMinute)
+	a.SetEmergencyStop(true)
+	if a.notifier != nil {
+		_ = a.notifier.NotifyAlert(context.Background(), "Runaway Trading",
+			fmt.Sprintf("%d fills in the last minute (limit %d). Emergency stop engaged.", n, a.cfg.Risk.MaxFillsPerMinute))
+	}
+}
+
+// Book returns a copy of the stored book the strategies see for assetID.
+func (a *App) Book(assetID string) (feed.BookView, bool) {
+	return a.books.Book(assetID)
+}
+
 // SetEmergencyStop activates or deactivates the emergency stop.
 func (a *App) SetEmergencyStop(stop bool) {
 	a.riskMgr.SetEmergencyStop(stop)
+	if a.takerAcct != nil {
+		a.takerAcct.risk.SetEmergencyStop(stop)
+	}
 	if a.kpi != nil {
-		a.kpi.setEmergencyStop(time.Now().UTC(), stop)
+		a.kpi.setEmergencyStop(a.now(), stop)
 	}
 	if stop && a.notifier != nil {
 		_ = a.notifier.NotifyEmergencyStop(context.Background())
 	}
 }
 
-// RecentFills returns the last N trade fills.
+// RecentFills returns the last N trade fills across both accounts, newest
+// first.
 func (a *App) RecentFills(limit int) []execution.Fill {
-	return a.tracker.RecentFills(limit)
+	if a.takerAcct == nil {
+		return a.tracker.RecentFills(limit)
+	}
+	fills := append(a.tracker.RecentFills(limit), a.takerAcct.tracker.RecentFills(limit)...)
+	slices.SortStableFunc(fills, func(x, y execution.Fill) int { return y.Timestamp.Compare(x.Timestamp) })
+	if limit > 0 && len(fills) > limit {
+		fills = fills[:limit]
+	}
+	return fills
 }
 
-// ActiveOrders returns all currently LIVE orders.
+// RoundTrips returns the tracker's closed round-trips, oldest first.
+func (a *App) RoundTrips() []execution.RoundTrip {
+	return a.tracker.RoundTrips()
+}
+
+// FillHistory returns a chronological page of the full fill history across
+// both accounts.
+func (a *App) FillHistory(offset, limit int) []execution.Fill {
+	if a.takerAcct == nil {
+		return a.tracker.FillsRange(offset, limit)
+	}
+	fills := append(a.tracker.FillsRange(0, a.tracker.TotalFills()),
+		a.takerAcct.tracker.FillsRange(0, a.takerAcct.tracker.TotalFills())...)
+	slices.SortStableFunc(fills, func(x, y execution.Fill) int { return x.Timestamp.Compare(y.Timestamp) })
+	if offset < 0 || offset >= len(fills) || limit <= 0 {
+		return nil
+	}
+	return fills[offset:min(offset+limit, len(fills))]
+}
+
+// ActiveOrders returns all currently LIVE orders across both accounts.
 func (a *App) ActiveOrders() []execution.OrderState {
-	return a.tracker.ActiveOrders()
+	orders := a.tracker.ActiveOrders()
+	if a.takerAcct != nil {
+		orders = append(orders, a.takerAcct.tracker.ActiveOrders()...)
+	}
+	return orders
 }
 
-// Positions returns a snapshot of all tracked positions.
+// TrackedPositions returns a snapshot of all tracked positions, with an
+// asset both accounts hold merged into one.
 func (a *App) TrackedPositions() map[string]execution.Position {
-	return a.tracker.Positions()
+	positions := a.tracker.Positions()
+	if a.takerAcct == nil {
+		return positions
+	}
+	for assetID, pos := range a.takerAcct.tracker.Positions() {
+		if held, ok := positions[assetID]; ok {
+			pos = mergePositions(held, pos)
+		}
+		positions[assetID] = pos
+	}
+	return positions
+}
+
+// RiskSnapshots returns the risk state of each account, primary first.
+func (a *App) RiskSnapshots() []risk.Snapshot {
+	snaps := make([]risk.Snapshot, 0, 2)
+	for _, acct := range a.accounts() {
+		snaps = append(snaps, acct.risk.Snapshot())
+	}
+	return snaps
 }
 
 // RiskSnapshot returns the current risk state used by the dashboard API.
@@ -615,11 +1028,26 @@ func (a *App) RiskSnapshot() risk.Snapshot {
 	return a.riskMgr.Snapshot()
 }
 
-// TradingMode returns the effective execution mode: live or paper.
+// TradingMode returns the configured trading mode, live or paper. Under
+// dry-run it stays the configured mode although orders are simulated.
 func (a *App) TradingMode() string {
 	return a.tradingMode
 }
 
+// ExecMode returns where orders execute: paper, the simulator, under dry-run
+// or paper trading, else live.
+func (a *App) ExecMode() string {
+	return a.execMode
+}
+
+// EffectiveConfig returns the running configuration, including env overrides
+// and hot reloads, with secrets redacted.
+func (a *App) EffectiveConfig() config.Config {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.cfg.Redacted()
+}
+
 // PaperSnapshot returns current paper account metrics (empty in live mode).
 func (a *App) PaperSnapshot() paper.Snapshot {
 	if a.paperSim == nil {
@@ -633,12 +1061,17 @@ func (a *App) KPIStats() map[string]interface{} {
 	if a.kpi == nil {
 		return map[string]interface{}{}
 	}
-	now := time.Now().UTC()
-	realized := a.tracker.TotalRealizedPnL()
+	now := a.now()
+	var realized, fees float64
+	var malformed int
+	for _, acct := range a.accounts() {
+		realized += acct.tracker.TotalRealizedPnL()
+		fees += acct.tracker.TotalFees()
+		malformed += acct.tracker.MalformedTradeCount()
+	}
 	unrealized := a.UnrealizedPnL()
 	total := realized + unrealized
-	fees := 0.0
-	if a.tradingMode == "paper" && a.paperSim != nil {
+	if a.execMode == "paper" && a.paperSim != nil {
 		fees = a.paperSim.Snapshot().FeesPaidUSDC
 	}
 	a.kpi.recordPnLSample(now, realized, total, fees)
@@ -648,30 +1081,78 @@ func (a *App) KPIStats() map[string]interface{} {
 	stats["total_pnl_usdc"] = round6(total)
 	stats["fees_paid_usdc"] = round6(fees)
 	stats["net_pnl_after_fees_usdc"] = round6(total - fees)
+	daily, session := a.kpi.drawdowns(now)
+	capital := a.drawdownCapital()
+	stats["max_drawdown_usdc"] = round6(daily.max)
+	stats["max_drawdown_pct"] = round6(daily.pct(capital))
+	stats["session_max_drawdown_usdc"] = round6(session.max)
+	stats["session_max_drawdown_pct"] = round6(session.pct(capital))
+	stats["malformed_trade_events"] = malformed
+	stats["throttled_order_calls"] = a.limiter.Throttled()
+	var trips int64
+	for _, acct := range a.accounts() {
+		trips += acct.breaker.Trips()
+	}
+	stats["placement_breaker_trips"] = trips
+	stats["stale_order_cancels"] = a.staleCancels.Load()
 	return stats
 }
 
+// drawdownCapital is the equity base drawdown percentages are taken against:
+// the paper starting balance in paper mode, risk.account_capital_usdc
+// otherwise.
+func (a *App) drawdownCapital() float64 {
+	if a.execMode == "paper" && a.paperSim != nil {
+		return a.paperSim.Snapshot().InitialBalanceUSDC
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.cfg.Risk.AccountCapitalUSDC
+}
+
+// PnLHistory returns PnL samples from the last window, optionally downsampled
+// into bucket-sized intervals. Samples are recorded on every risk sync.
+func (a *App) PnLHistory(window, bucket time.Duration) []map[string]interface{} {
+	if a.kpi == nil {
+		return []map[string]interface{}{}
+	}
+	return a.kpi.pnlHistory(a.now().Add(-window), bucket)
+}
+
 // UnrealizedPnL computes unrealized PnL across all positions.
 func (a *App) UnrealizedPnL() float64 {
-	positions := a.tracker.Positions()
 	var total float64
-	for assetID, pos := range positions {
-		if pos.NetSize == 0 {
-			continue
-		}
-		mid, err := a.books.Mid(assetID)
-		if err != nil {
-			continue
-		}
-		total += (mid - pos.AvgEntryPrice) * pos.NetSize
+	for _, pnl := range a.UnrealizedPnLByMarket() {
+		total += pnl
 	}
 	return total
 }
 
+// UnrealizedPnLByMarket marks each open position, on either account, to its
+// book mid, or to the last trade price or mid seen when the book has none.
+// Assets that cannot be priced are left out; see UnpricedPositions.
+func (a *App) UnrealizedPnLByMarket() map[string]float64 {
+	out := make(map[string]float64)
+	for _, acct := range a.accounts() {
+		for assetID, pos := range acct.tracker.Positions() {
+			if pos.NetSize == 0 {
+				continue
+			}
+			mid, ok := a.markPrice(assetID)
+			if !ok {
+				continue
+			}
+			out[assetID] += (mid - pos.AvgEntryPrice) * pos.NetSize
+		}
+	}
+	return out
+}
+
 // autoSelectMarkets uses GammaSelector first, falling back to CLOB depth-based selection.
 func (a *App) autoSelectMarkets(ctx context.Context) ([]string, error) {
 	// Phase 1.2: Try GammaSelector first.
 	if a.gammaSelector != nil {
+		a.gammaSelector.SetProfitScores(a.marketProfitScores())
 		candidates, err := a.gammaSelector.Select(ctx, a.cfg.Maker.AutoSelectTop)
 		if err == nil && len(candidates) > 0 {
 			var ids []string
@@ -690,7 +1171,9 @@ func (a *App) autoSelectMarkets(ctx context.Context) ([]string, error) {
 	}
 
 	// Fallback: CLOB depth-based selection.
-	resp, err := a.clobClient.Markets(ctx, &clobtypes.MarketsRequest{Active: boolPtr(true), Limit: 50})
+	resp, err := withRetry(ctx, a.cfg.HTTPRetries, a.cfg.HTTPRetryBackoff, func() (clobtypes.MarketsResponse, error) {
+		return a.clobClient.Markets(ctx, &clobtypes.MarketsRequest{Active: boolPtr(true), Limit: 50})
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -698,7 +1181,9 @@ func (a *App) autoSelectMarkets(ctx context.Context) ([]string, error) {
 	for _, m := range resp.Data {
 		tokens := m.Tokens
 		for _, tok := range tokens {
-			book, bErr := a.clobClient.OrderBook(ctx, &clobtypes.BookRequest{TokenID: tok.TokenID})
+			book, bErr := withRetry(ctx, a.cfg.HTTPRetries, a.cfg.HTTPRetryBackoff, func() (clobtypes.OrderBookResponse, error) {
+				return a.clobClient.OrderBook(ctx, &clobtypes.BookRequest{TokenID: tok.TokenID})
+			})
 			if bErr != nil {
 				continue
 			}
@@ -707,19 +1192,42 @@ func (a *App) autoSelectMarkets(ctx context.Context) ([]string, error) {
 		}
 		// Build token pairs for binary markets.
 		if len(tokens) == 2 {
+			a.mu.Lock()
 			a.tokenPairs[tokens[0].TokenID] = tokens[1].TokenID
 			a.tokenPairs[tokens[1].TokenID] = tokens[0].TokenID
+			a.mu.Unlock()
 		}
 	}
 	return strategy.SelectMarkets(resp.Data, booksMap, a.cfg.Maker.AutoSelectTop, 50), nil
 }
 
+// marketProfitScores rates every traded asset with strategy.MarketProfitScore,
+// the same score /api/insights reports, for profitability-weighted selection.
+func (a *App) marketProfitScores() map[string]float64 {
+	positions := a.tracker.Positions()
+	totalFills := 0
+	for _, pos := range positions {
+		totalFills += max(pos.TotalFills, 0)
+	}
+	scores := make(map[string]float64, len(positions))
+	for assetID, pos := range positions {
+		if pos.TotalFills <= 0 {
+			continue
+		}
+		share := float64(pos.TotalFills) / float64(totalFills) * 100
+		scores[assetID] = strategy.MarketProfitScore(pos.RealizedPnL, pos.TotalFills, share)
+	}
+	return scores
+}
+
 // buildTokenPairsFromCandidates maps YES↔NO token pairs from gamma candidates.
 func (a *App) buildTokenPairsFromCandidates(candidates []strategy.MarketCandidate) {
 	byMarket := make(map[string][]string) // marketID → tokenIDs
 	for _, c := range candidates {
 		byMarket[c.MarketID] = append(byMarket[c.MarketID], c.TokenID)
 	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
 	for _, tokens := range byMarket {
 		if len(tokens) == 2 {
 			a.tokenPairs[tokens[0]] = tokens[1]
@@ -728,6 +1236,14 @@ func (a *App) buildTokenPairsFromCandidates(candidates []strategy.MarketCandidat
 	}
 }
 
+// TokenPairs returns a copy of the known YES↔NO pairs of binary markets,
+// keyed both ways.
+func (a *App) TokenPairs() map[string]string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return maps.Clone(a.tokenPairs)
+}
+
 // collectMarketIDs returns unique market/condition IDs for the given asset IDs.
 func (a *App) collectMarketIDs(assetIDs []string) []string {
 	seen := make(map[string]bool)
@@ -747,7 +1263,7 @@ func (a *App) getCounterpartMid(assetID string) float64 {
 	if !ok {
 		return 0
 	}
-	mid, err := a.books.Mid(counterpart)
+	mid, err := a.books.MidFresh(counterpart)
 	if err != nil {
 		return 0
 	}
@@ -768,7 +1284,7 @@ func (a *App) checkConvergenceArbitrage(ctx context.Context, event ws.OrderbookE
 	if err != nil || yesMid == 0 {
 		return
 	}
-	noMid, err := a.books.Mid(counterpartID)
+	noMid, err := a.books.MidFresh(counterpartID)
 	if err != nil || noMid == 0 {
 		return
 	}
@@ -787,30 +1303,38 @@ func (a *App) checkConvergenceArbitrage(ctx context.Context, event ws.OrderbookE
 		return
 	}
 
-	amount := a.cfg.Taker.AmountUSDC
 	sum := yesMid + noMid
+	// When overpriced, sell the more expensive token against the other's mid.
+	targetID, targetPrice, otherMid := event.AssetID, yesMid, noMid
+	if noMid > yesMid {
+		targetID, targetPrice, otherMid = counterpartID, noMid, yesMid
+	}
 
-	if a.cfg.DryRun {
-		log.Printf("[DRY] convergence arb: YES=%.4f NO=%.4f sum=%.4f edge=%.1fbps signal=%s",
-			yesMid, noMid, sum, edgeBps, signal)
+	// Size to the depth that keeps the edge above the minimum.
+	amount := a.convergenceArbSize(event.AssetID, counterpartID, targetID, sum, otherMid, minEdgeBps, a.cfg.Taker.AmountUSDC)
+	if amount < a.cfg.Taker.MinArbSizeUSDC || amount <= 0 {
 		return
 	}
 
+	if a.cfg.DryRun {
+		log.Printf("[DRY] convergence arb: YES=%.4f NO=%.4f sum=%.4f edge=%.1fbps signal=%s amount=%.2f",
+			yesMid, noMid, sum, edgeBps, signal, amount)
+	}
+
 	if sum < 1.0 {
 		// Underpriced: buy both tokens. At resolution, one pays $1.
 		// Cost = sum, Payout = $1, Profit = 1 - sum.
 		halfAmount := amount / 2
 
-		if err := a.riskMgr.Allow(event.AssetID, halfAmount); err != nil {
-			if a.kpi != nil {
-				a.kpi.recordRiskBlock(time.Now().UTC(), classifyRiskAllowError(err))
-			}
+		if !a.marketsHaveRoom(event.AssetID, counterpartID) {
 			return
 		}
-		if err := a.riskMgr.Allow(counterpartID, halfAmount); err != nil {
-			if a.kpi != nil {
-				a.kpi.recordRiskBlock(time.Now().UTC(), classifyRiskAllowError(err))
-			}
+		if err := a.riskMgr.Allow(event.AssetID, "BUY", halfAmount); err != nil {
+			a.riskBlocked(a.now(), event.AssetID, "BUY", halfAmount, err)
+			return
+		}
+		if err := a.riskMgr.Allow(counterpartID, "BUY", halfAmount); err != nil {
+			a.riskBlocked(a.now(), counterpartID, "BUY", halfAmount, err)
 			return
 		}
 
@@ -818,36 +1342,30 @@ func (a *App) checkConvergenceArbitrage(ctx context.Context, event ws.OrderbookE
 		resp2 := a.placeMarket(ctx, counterpartID, "BUY", halfAmount)
 
 		if resp1.ID != "" {
-			if a.tradingMode == "live" {
+			if a.execMode == "live" {
 				a.tracker.RegisterOrder(resp1.ID, event.AssetID, event.Market, "BUY", yesMid, halfAmount)
 			}
 			log.Printf("convergence arb: bought YES %s @ %.4f", event.AssetID, yesMid)
 		}
 		if resp2.ID != "" {
-			if a.tradingMode == "live" {
+			if a.execMode == "live" {
 				a.tracker.RegisterOrder(resp2.ID, counterpartID, event.Market, "BUY", noMid, halfAmount)
 			}
 			log.Printf("convergence arb: bought NO %s @ %.4f", counterpartID, noMid)
 		}
 	} else {
 		// Overpriced: sell the more expensive token.
-		targetID := event.AssetID
-		targetPrice := yesMid
-		if noMid > yesMid {
-			targetID = counterpartID
-			targetPrice = noMid
+		if !a.marketsHaveRoom(targetID) {
+			return
 		}
-
-		if err := a.riskMgr.Allow(targetID, amount); err != nil {
-			if a.kpi != nil {
-				a.kpi.recordRiskBlock(time.Now().UTC(), classifyRiskAllowError(err))
-			}
+		if err := a.riskMgr.Allow(targetID, "SELL", amount); err != nil {
+			a.riskBlocked(a.now(), targetID, "SELL", amount, err)
 			return
 		}
 
 		resp := a.placeMarket(ctx, targetID, "SELL", amount)
 		if resp.ID != "" {
-			if a.tradingMode == "live" {
+			if a.execMode == "live" {
 				a.tracker.RegisterOrder(resp.ID, targetID, event.Market, "SELL", targetPrice, amount)
 			}
 			log.Printf("convergence arb: sold %s @ %.4f (sum=%.4f)", targetID, targetPrice, sum)
@@ -870,23 +1388,28 @@ func (a *App) handleCryptoPrice(ctx context.Context, ev rtds.CryptoPriceEvent) {
 
 	signals := a.cryptoTracker.ProcessPrice(update)
 	for _, sig := range signals {
+		sig.AmountUSDC = a.cryptoSignalAmount(sig)
+		if sig.AmountUSDC <= 0 {
+			log.Printf("crypto signal %s %s skipped: at position limit", sig.Side, sig.MarketAssetID)
+			continue
+		}
+		if a.flattenPaused(a.now()) {
+			continue
+		}
 		if a.cfg.DryRun {
 			log.Printf("[DRY] crypto signal: %s %s amount=%.2f reason=%s",
 				sig.Side, sig.MarketAssetID, sig.AmountUSDC, sig.Reason)
-			continue
 		}
 
-		if err := a.riskMgr.Allow(sig.MarketAssetID, sig.AmountUSDC); err != nil {
-			if a.kpi != nil {
-				a.kpi.recordRiskBlock(time.Now().UTC(), classifyRiskAllowError(err))
-			}
+		if err := a.riskMgr.Allow(sig.MarketAssetID, sig.Side, sig.AmountUSDC); err != nil {
+			a.riskBlocked(a.now(), sig.MarketAssetID, sig.Side, sig.AmountUSDC, err)
 			continue
 		}
 
 		resp := a.placeMarket(ctx, sig.MarketAssetID, sig.Side, sig.AmountUSDC)
 		if resp.ID != "" {
 			market := a.assetToMarket[sig.MarketAssetID]
-			if a.tradingMode == "live" {
+			if a.execMode == "live" {
 				a.tracker.RegisterOrder(resp.ID, sig.MarketAssetID, market, sig.Side, 0, sig.AmountUSDC)
 			}
 			log.Printf("crypto trade: %s %s (triggered by %s)", sig.Side, sig.MarketAssetID, sig.Reason)
@@ -894,6 +1417,45 @@ func (a *App) handleCryptoPrice(ctx context.Context, ev rtds.CryptoPriceEvent) {
 	}
 }
 
+// cryptoSignalAmount scales a crypto signal to the inventory already held in
+// its market, so repeated moves in one direction do not keep adding to a
+// position that is close to its limit.
+func (a *App) cryptoSignalAmount(sig strategy.CryptoSignal) float64 {
+	pos := a.tracker.Position(sig.MarketAssetID)
+	if pos == nil {
+		return sig.AmountUSDC
+	}
+	limit := a.cfg.Risk.MaxPositionPerMarket
+	if sig.Side == "BUY" && a.cfg.Risk.MaxLongPerMarketUSDC > 0 {
+		limit = a.cfg.Risk.MaxLongPerMarketUSDC
+	} else if sig.Side == "SELL" && a.cfg.Risk.MaxShortPerMarketUSDC > 0 {
+		limit = a.cfg.Risk.MaxShortPerMarketUSDC
+	}
+	return sig.ScaleForInventory(pos.NetSize*pos.AvgEntryPrice, limit)
+}
+
+// subscribeCrypto subscribes to RTDS prices for the mapped crypto symbols. It
+// returns nil, which never delivers, without an RTDS client or a mapping.
+func (a *App) subscribeCrypto(ctx context.Context) <-chan rtds.CryptoPriceEvent {
+	if a.rtdsClient == nil || a.cryptoTracker == nil {
+		return nil
+	}
+	symbols := a.cryptoTracker.TrackedSymbols()
+	if len(symbols) == 0 {
+		return nil
+	}
+	slices.Sort(symbols)
+	ch, err := a.rtdsClient.SubscribeCryptoPrices(ctx, symbols)
+	if err != nil {
+		log.Printf("warning: rtds crypto prices subscription failed: %v", err)
+		a.setDependencyErr("rtds", err)
+		return nil
+	}
+	a.setDependencyErr("rtds", nil)
+	log.Printf("rtds: subscribed to %d crypto symbols", len(symbols))
+	return ch
+}
+
 // SetCryptoMapping sets the crypto symbol → Polymarket asset mapping for RTDS signals.
 func (a *App) SetCryptoMapping(mapping map[string][]string) {
 	if a.cryptoTracker != nil {
@@ -901,23 +1463,55 @@ func (a *App) SetCryptoMapping(mapping map[string][]string) {
 	}
 }
 
-// fetchFeeRates queries fee rates for all monitored assets.
+// fetchFeeRates queries fee rates for the given assets. An asset whose query
+// fails keeps its cached rate; changed rates are logged.
 func (a *App) fetchFeeRates(ctx context.Context, assetIDs []string) {
+	var lastErr error
 	for _, id := range assetIDs {
-		resp, err := a.clobClient.FeeRate(ctx, &clobtypes.FeeRateRequest{TokenID: id})
+		resp, err := withRetry(ctx, a.cfg.HTTPRetries, a.cfg.HTTPRetryBackoff, func() (clobtypes.FeeRateResponse, error) {
+			return a.clobClient.FeeRate(ctx, &clobtypes.FeeRateRequest{TokenID: id})
+		})
 		if err != nil {
 			log.Printf("fee rate %s: %v", id, err)
+			lastErr = err
 			continue
 		}
 		if rate, pErr := strconv.ParseFloat(resp.FeeRate, 64); pErr == nil {
+			if old, ok := a.feeRates[id]; ok && old != rate {
+				log.Printf("fee rate %s changed: %.2f -> %.2f bps", id, old, rate)
+			}
+			a.mu.Lock()
 			a.feeRates[id] = rate
+			a.mu.Unlock()
+			a.tracker.SetFeeRate(id, rate)
+			if a.takerAcct != nil {
+				a.takerAcct.tracker.SetFeeRate(id, rate)
+			}
 		}
 	}
+	if len(assetIDs) > 0 {
+		a.setDependencyErr("clob", lastErr)
+	}
 	if len(a.feeRates) > 0 {
 		log.Printf("fetched fee rates for %d assets", len(a.feeRates))
 	}
 }
 
+// feeRate returns the fee rate, in bps, fee-aware pricing uses for assetID:
+// its fee_overrides_bps entry, else the fetched CLOB rate, else
+// default_fee_bps.
+func (a *App) feeRate(assetID string) float64 {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if bps, ok := a.cfg.FeeOverridesBps[assetID]; ok {
+		return bps
+	}
+	if bps, ok := a.feeRates[assetID]; ok {
+		return bps
+	}
+	return a.cfg.DefaultFeeBps
+}
+
 // handleMarketResolution processes a market resolution event.
 func (a *App) handleMarketResolution(ctx context.Context, ev ws.MarketResolvedEvent) {
 	log.Printf("market resolved: %s (winner: %s)", ev.Question, ev.WinningOutcome)
@@ -925,9 +1519,13 @@ func (a *App) handleMarketResolution(ctx context.Context, ev ws.MarketResolvedEv
 	// Cancel all orders for resolved market's assets.
 	for _, assetID := range ev.AssetIDs {
 		if ids, has := a.activeOrders[assetID]; has && len(ids) > 0 {
-			if a.tradingMode == "live" && a.clobClient != nil {
-				_, _ = a.clobClient.CancelOrders(ctx, &clobtypes.CancelOrdersRequest{OrderIDs: ids})
-			} else if a.tradingMode == "paper" {
+			if a.execMode == "live" && a.clobClient != nil {
+				if a.limiter.Allow() {
+					_, _ = a.clobClient.CancelOrders(ctx, &clobtypes.CancelOrdersRequest{OrderIDs: ids})
+				} else {
+					log.Printf("cancel %s throttled; relying on market-wide cancel", assetID)
+				}
+			} else if a.execMode == "paper" {
 				a.cancelPaperOrders(ids)
 			}
 			delete(a.activeOrders, assetID)
@@ -935,20 +1533,83 @@ func (a *App) handleMarketResolution(ctx context.Context, ev ws.MarketResolvedEv
 	}
 
 	// Also cancel by market.
-	if a.tradingMode == "live" && ev.Market != "" {
+	if a.execMode == "live" && ev.Market != "" {
 		_, _ = a.clobClient.CancelMarketOrders(ctx, &clobtypes.CancelMarketOrdersRequest{Market: ev.Market})
 	}
+
+	if a.execMode == "paper" && a.paperSim != nil {
+		a.settlePaperResolution(ev)
+	}
 }
 
-// rescanMarkets periodically rescans markets using GammaSelector.
-func (a *App) rescanMarkets(ctx context.Context, assetIDs *[]string, bookCh *<-chan ws.OrderbookEvent) {
-	if a.gammaSelector == nil {
+// settlePaperResolution redeems paper inventory in a resolved market at $1 for
+// the winning token and $0 for the others.
+func (a *App) settlePaperResolution(ev ws.MarketResolvedEvent) {
+	winner := a.winningAssetID(ev)
+	if winner == "" {
+		log.Printf("paper settle %s: cannot map winning outcome %q to a token; inventory left open", ev.Market, ev.WinningOutcome)
 		return
 	}
+	assets := append([]string(nil), ev.AssetIDs...)
+	if counterpart, ok := a.tokenPairs[winner]; ok && !slices.Contains(assets, counterpart) {
+		assets = append(assets, counterpart)
+	}
+	for _, assetID := range assets {
+		res, ok := a.paperSim.Settle(assetID, assetID == winner)
+		if !ok {
+			continue
+		}
+		a.tracker.SettlePosition(assetID, res.TradeID, res.Price, res.FeeUSDC)
+		log.Printf("paper settle %s: %s %.4f @ %.2f", assetID, res.Side, res.Size, res.Price)
+	}
+}
+
+// winningAssetID maps a resolution's winning outcome to a token ID. The outcome
+// may name the token directly; otherwise "Yes"/"No" select the first/second
+// token of a binary market, which is the order Polymarket lists them in.
+func (a *App) winningAssetID(ev ws.MarketResolvedEvent) string {
+	outcome := strings.TrimSpace(ev.WinningOutcome)
+	if outcome == "" {
+		return ""
+	}
+	if slices.Contains(ev.AssetIDs, outcome) {
+		return outcome
+	}
+	if _, ok := a.tokenPairs[outcome]; ok {
+		return outcome
+	}
+	if len(ev.AssetIDs) != 2 {
+		return ""
+	}
+	switch strings.ToLower(outcome) {
+	case "yes":
+		return ev.AssetIDs[0]
+	case "no":
+		return ev.AssetIDs[1]
+	}
+	return ""
+}
+
+// SelectorCandidates returns when the market selector last ran and every
+// market it evaluated, with the filters each passed.
+func (a *App) SelectorCandidates() (time.Time, []strategy.CandidateEvaluation) {
+	if a.gammaSelector == nil {
+		return time.Time{}, nil
+	}
+	return a.gammaSelector.LastEvaluation()
+}
+
+// rescanMarkets reselects markets using GammaSelector and returns the assets
+// added to and removed from the feed.
+func (a *App) rescanMarkets(ctx context.Context, assetIDs *[]string, feeds *feedMux) (added, removed []string, err error) {
+	if a.gammaSelector == nil {
+		return nil, nil, nil
+	}
+	a.gammaSelector.SetProfitScores(a.marketProfitScores())
 	candidates, err := a.gammaSelector.Select(ctx, a.cfg.Maker.AutoSelectTop)
 	if err != nil {
 		log.Printf("rescan: gamma selector: %v", err)
-		return
+		return nil, nil, err
 	}
 
 	newIDs := make(map[string]bool)
@@ -964,20 +1625,30 @@ func (a *App) rescanMarkets(ctx context.Context, assetIDs *[]string, bookCh *<-c
 		oldIDs[id] = true
 	}
 
-	var toAdd, toRemove []string
+	var toAdd, toRemove, retained []string
 	for id := range newIDs {
 		if !oldIDs[id] {
 			toAdd = append(toAdd, id)
 		}
 	}
-	for id := range oldIDs {
-		if !newIDs[id] {
-			toRemove = append(toRemove, id)
+	for _, id := range *assetIDs {
+		if newIDs[id] {
+			continue
+		}
+		// Keep watching books we still have orders or inventory on.
+		if len(a.activeOrders[id]) > 0 {
+			retained = append(retained, id)
+			continue
 		}
+		if pos := a.tracker.Position(id); pos != nil && pos.NetSize != 0 {
+			retained = append(retained, id)
+			continue
+		}
+		toRemove = append(toRemove, id)
 	}
 
 	if len(toAdd) == 0 && len(toRemove) == 0 {
-		return
+		return nil, nil, nil
 	}
 
 	// Unsubscribe removed assets.
@@ -988,26 +1659,33 @@ func (a *App) rescanMarkets(ctx context.Context, assetIDs *[]string, bookCh *<-c
 		log.Printf("rescan: removed %d assets", len(toRemove))
 	}
 
-	// Build new full asset list.
-	var updated []string
-	for _, c := range candidates {
-		updated = append(updated, c.TokenID)
-	}
-	*assetIDs = updated
-
-	// Subscribe to new assets by resubscribing to the full list.
+	// Subscribe only the new assets, on every stream the loop reads, and
+	// fold them into its fan-in; stable assets keep their subscriptions. New
+	// assets join the asset list only once subscribed, so the next rescan
+	// retries a failed subscribe.
+	var subErr error
 	if len(toAdd) > 0 {
-		newBookCh, subErr := a.wsClient.SubscribeOrderbook(ctx, toAdd)
-		if subErr != nil {
+		var subs feedSubs
+		if subs, subErr = a.subscribeFeeds(ctx, toAdd); subErr != nil {
 			log.Printf("rescan: subscribe: %v", subErr)
+			toAdd = nil
 		} else {
-			// Merge: we just subscribe to new assets; the old channel keeps delivering.
-			_ = newBookCh // events will be delivered through the existing connection
+			feeds.add(subs)
+			// Fetch fee rates for new assets.
+			a.fetchFeeRates(ctx, toAdd)
+			log.Printf("rescan: added %d assets", len(toAdd))
 		}
-		// Fetch fee rates for new assets.
-		a.fetchFeeRates(ctx, toAdd)
-		log.Printf("rescan: added %d assets", len(toAdd))
 	}
+
+	// Build new full asset list.
+	var updated []string
+	for _, c := range candidates {
+		if oldIDs[c.TokenID] || slices.Contains(toAdd, c.TokenID) {
+			updated = append(updated, c.TokenID)
+		}
+	}
+	*assetIDs = append(updated, retained...)
+	return toAdd, toRemove, subErr
 }
 
 // riskSync periodically syncs risk state from tracker and checks stop-loss.
@@ -1017,7 +1695,7 @@ func (a *App) riskSync(ctx context.Context) {
 		if currentRealized != 0 {
 			if a.riskMgr.RecordTradeResult(currentRealized) {
 				if a.kpi != nil {
-					a.kpi.recordCooldownTrigger(time.Now().UTC())
+					a.kpi.recordCooldownTrigger(a.now())
 				}
 				log.Printf("risk cooldown triggered: consecutive losses=%d", a.riskMgr.ConsecutiveLosses())
 				a.notifyRiskCooldown(ctx)
@@ -1030,7 +1708,7 @@ func (a *App) riskSync(ctx context.Context) {
 		if realizedDelta != 0 {
 			if a.riskMgr.RecordTradeResult(realizedDelta) {
 				if a.kpi != nil {
-					a.kpi.recordCooldownTrigger(time.Now().UTC())
+					a.kpi.recordCooldownTrigger(a.now())
 				}
 				log.Printf("risk cooldown triggered: consecutive losses=%d", a.riskMgr.ConsecutiveLosses())
 				a.notifyRiskCooldown(ctx)
@@ -1045,38 +1723,53 @@ func (a *App) riskSync(ctx context.Context) {
 	}
 	dailyRealized := currentRealized - a.dailyRealizedBaseline
 
-	positions := a.tracker.Positions()
-	a.riskMgr.SyncFromTracker(a.tracker.OpenOrderCount(), positions, dailyRealized)
-
-	// Per-market stop-loss checks.
-	for assetID, pos := range positions {
-		if pos.NetSize == 0 {
-			continue
-		}
-		mid, err := a.books.Mid(assetID)
-		if err != nil {
-			continue
-		}
-		if a.riskMgr.EvaluateStopLoss(assetID, pos, mid) {
-			log.Printf("STOP-LOSS triggered for %s: unwinding position", assetID)
-			if a.notifier != nil {
-				_ = a.notifier.NotifyStopLoss(ctx, assetID, pos.RealizedPnL)
+	a.riskMgr.SyncFromTracker(a.tracker.OpenOrderCount(), a.tracker.Positions(), dailyRealized)
+	if acct := a.takerAcct; acct != nil {
+		takerRealized := acct.tracker.TotalRealizedPnL()
+		acct.risk.SyncFromTracker(acct.tracker.OpenOrderCount(), acct.tracker.Positions(), takerRealized-acct.dailyRealizedBaseline)
+		currentRealized += takerRealized
+		// The gross exposure limit caps both accounts together.
+		a.riskMgr.SetSharedExposure(grossExposure(acct.tracker.Positions()))
+		acct.risk.SetSharedExposure(grossExposure(a.tracker.Positions()))
+	}
+
+	// Per-market stop-loss checks, each exit through the account holding
+	// the position.
+	for _, acct := range a.accounts() {
+		for assetID, pos := range acct.tracker.Positions() {
+			if pos.NetSize == 0 {
+				continue
+			}
+			// A position whose book went empty is still stopped out at its
+			// last trade or mid.
+			mark, ok := a.markPrice(assetID)
+			if !ok {
+				continue
+			}
+			if acct.risk.EvaluateStopLoss(assetID, pos, mark) {
+				log.Printf("STOP-LOSS triggered for %s (%s account): unwinding position", assetID, acct.name)
+				if a.notifier != nil {
+					_ = a.notifier.NotifyStopLoss(ctx, assetID, pos.RealizedPnL)
+				}
+				a.unwindPosition(ctx, acct, assetID, pos)
 			}
-			a.unwindPosition(ctx, assetID, pos)
 		}
 	}
+	a.unwindAgedPositions(ctx)
 
-	// Global drawdown check.
+	// Global drawdown check, across both accounts.
 	var totalUnrealized float64
-	for assetID, pos := range positions {
-		if pos.NetSize == 0 {
-			continue
-		}
-		mid, err := a.books.Mid(assetID)
-		if err != nil {
-			continue
+	for _, acct := range a.accounts() {
+		for assetID, pos := range acct.tracker.Positions() {
+			if pos.NetSize == 0 {
+				continue
+			}
+			mark, ok := a.markPrice(assetID)
+			if !ok {
+				continue
+			}
+			totalUnrealized += (mark - pos.AvgEntryPrice) * pos.NetSize
 		}
-		totalUnrealized += (mid - pos.AvgEntryPrice) * pos.NetSize
 	}
 	capital := a.cfg.Risk.AccountCapitalUSDC
 	if capital <= 0 {
@@ -1086,9 +1779,13 @@ func (a *App) riskSync(ctx context.Context) {
 		log.Println("EMERGENCY: max drawdown exceeded, triggering emergency stop")
 		a.SetEmergencyStop(true)
 	}
+	if a.riskMgr.RecordPnLSample(a.now(), currentRealized+totalUnrealized) && !a.riskMgr.EmergencyStop() {
+		log.Printf("EMERGENCY: drawdown velocity %.2f USDC/min exceeded, triggering emergency stop", a.riskMgr.DrawdownVelocity())
+		a.SetEmergencyStop(true)
+	}
 
 	if a.kpi != nil {
-		now := time.Now().UTC()
+		now := a.now()
 		snap := a.riskMgr.Snapshot()
 		canTrade := len(riskBlockedReasonsFromSnapshot(snap)) == 0
 		a.kpi.recordRiskCompliance(now, canTrade)
@@ -1096,11 +1793,39 @@ func (a *App) riskSync(ctx context.Context) {
 
 		totalPnL := currentRealized + totalUnrealized
 		fees := 0.0
-		if a.tradingMode == "paper" && a.paperSim != nil {
+		if a.execMode == "paper" && a.paperSim != nil {
 			fees = a.paperSim.Snapshot().FeesPaidUSDC
 		}
 		a.kpi.recordPnLSample(now, currentRealized, totalPnL, fees)
 	}
+
+	a.notifyRiskStateChange(ctx)
+}
+
+// notifyRiskStateChange compares the risk guardrails with the previous sync
+// and alerts on any transition. The first sync only sets the baseline.
+func (a *App) notifyRiskStateChange(ctx context.Context) {
+	snap := a.riskMgr.Snapshot()
+	reasons := riskBlockedReasonsFromSnapshot(snap)
+	cur := notify.RiskState{
+		CanTrade:       len(reasons) == 0,
+		BlockedReasons: reasons,
+		InCooldown:     snap.InCooldown,
+		EmergencyStop:  snap.EmergencyStop,
+	}
+	prev, seen := a.lastRiskState, a.riskStateSeen
+	a.lastRiskState, a.riskStateSeen = cur, true
+	if !seen {
+		return
+	}
+	change, changed := notify.DiffRiskState(prev, cur, a.now())
+	if !changed {
+		return
+	}
+	log.Printf("risk state change: %s (can_trade %t → %t, blocked: %v)", strings.Join(change.Transitions, ", "), change.OldCanTrade, change.NewCanTrade, change.BlockedReasons)
+	if a.notifier != nil {
+		_ = a.notifier.NotifyRiskStateChange(ctx, change)
+	}
 }
 
 func (a *App) notifyRiskCooldown(ctx context.Context) {
@@ -1131,6 +1856,12 @@ func classifyRiskAllowError(err error) string {
 		return "emergency_stop"
 	case strings.Contains(msg, "position limit"):
 		return "position_limit"
+	case strings.Contains(msg, "gross exposure"):
+		return "gross_exposure"
+	case strings.Contains(msg, "group exposure"):
+		return "group_exposure"
+	case strings.Contains(msg, "max order notional"):
+		return "order_notional"
 	default:
 		return "unknown"
 	}
@@ -1235,6 +1966,29 @@ func estimateDailyProfitFocus(canTrade bool, riskMode string, fills int, netPnLA
 	return action, round2App(estimatedUplift), profitFocusConfidence(fills)
 }
 
+// sessionPnL returns realized plus unrealized PnL, the paper fees paid and
+// the PnL net of those fees.
+func (a *App) sessionPnL() (total, fees, net float64) {
+	_, _, realized := a.Stats()
+	total = realized + a.UnrealizedPnL()
+	if a.execMode == "paper" {
+		fees = a.PaperSnapshot().FeesPaidUSDC
+	}
+	return total, fees, total - fees
+}
+
+func (a *App) worstRealizedMarket() string {
+	worstAsset := ""
+	worstPnL := 1e18
+	for assetID, pos := range a.tracker.Positions() {
+		if pos.RealizedPnL < worstPnL {
+			worstPnL = pos.RealizedPnL
+			worstAsset = assetID
+		}
+	}
+	return worstAsset
+}
+
 func (a *App) bestRealizedMarket() string {
 	positions := a.tracker.Positions()
 	bestAsset := ""
@@ -1266,14 +2020,8 @@ func shouldSendWeeklyTemplate(now time.Time) bool {
 
 func (a *App) buildDailyTelegramTemplate() string {
 	mode := strings.ToUpper(a.tradingMode)
-	_, fills, realized := a.Stats()
-	unrealized := a.UnrealizedPnL()
-	totalPnL := realized + unrealized
-	fees := 0.0
-	if a.tradingMode == "paper" {
-		fees = a.PaperSnapshot().FeesPaidUSDC
-	}
-	netPnL := totalPnL - fees
+	_, fills, _ := a.Stats()
+	totalPnL, fees, netPnL := a.sessionPnL()
 
 	snap := a.riskMgr.Snapshot()
 	reasons := riskBlockedReasonsFromSnapshot(snap)
@@ -1338,7 +2086,7 @@ func (a *App) buildWeeklyTelegramTemplate(windowDays int) string {
 	totalPnL := realized + unrealized
 	fees := 0.0
 	volume := 0.0
-	if a.tradingMode == "paper" {
+	if a.execMode == "paper" {
 		snap := a.PaperSnapshot()
 		fees = snap.FeesPaidUSDC
 		volume = snap.TotalVolumeUSDC
@@ -1378,6 +2126,14 @@ func (a *App) buildWeeklyTelegramTemplate(windowDays int) string {
 
 func (a *App) resetDailyRisk() {
 	a.riskMgr.ResetDaily()
+	if a.takerAcct != nil {
+		a.takerAcct.risk.ResetDaily()
+		a.takerAcct.dailyRealizedBaseline = a.takerAcct.tracker.TotalRealizedPnL()
+	}
+	a.taker.ResetDaily()
+	for _, tk := range a.marketTakers {
+		tk.ResetDaily()
+	}
 	currentRealized := a.tracker.TotalRealizedPnL()
 	a.lastRealizedPnL = currentRealized
 	a.realizedInitialized = true
@@ -1385,89 +2141,139 @@ func (a *App) resetDailyRisk() {
 	a.dailyBaselineSet = true
 }
 
-// unwindPosition cancels all orders for an asset and places a market order to close.
-func (a *App) unwindPosition(ctx context.Context, assetID string, pos execution.Position) {
+// unwindPosition cancels all orders for an asset and places a market order
+// through acct, the account holding pos, to close it.
+func (a *App) unwindPosition(ctx context.Context, acct *account, assetID string, pos execution.Position) {
 	if ids, has := a.activeOrders[assetID]; has && len(ids) > 0 {
-		if a.tradingMode == "live" && a.clobClient != nil {
+		if a.execMode == "live" && a.clobClient != nil {
+			if !a.limiter.Allow() {
+				log.Printf("unwind %s throttled; retrying on next risk sync", assetID)
+				return
+			}
 			_, _ = a.clobClient.CancelOrders(ctx, &clobtypes.CancelOrdersRequest{OrderIDs: ids})
-		} else if a.tradingMode == "paper" {
+		} else if a.execMode == "paper" {
 			a.cancelPaperOrders(ids)
 		}
 		delete(a.activeOrders, assetID)
 	}
 
 	if a.cfg.DryRun {
-		log.Printf("[DRY] would unwind %s: size=%.4f", assetID, pos.NetSize)
-		return
+		log.Printf("[DRY] unwind %s: size=%.4f", assetID, pos.NetSize)
 	}
 
-	if pos.NetSize > 0 {
-		a.placeMarket(ctx, assetID, "SELL", pos.NetSize*pos.AvgEntryPrice)
-	} else if pos.NetSize < 0 {
-		a.placeMarket(ctx, assetID, "BUY", -pos.NetSize*pos.AvgEntryPrice)
+	side := "SELL"
+	if pos.NetSize < 0 {
+		side = "BUY"
+	}
+	// Reduce-only so a stale snapshot or a price move cannot flip the
+	// position instead of closing it.
+	amount, err := a.reduceOnlyAmountFor(acct, assetID, side, math.Abs(pos.NetSize)*pos.AvgEntryPrice, 0)
+	if err != nil {
+		log.Printf("unwind %s: %v", assetID, err)
+		return
 	}
+	a.placeMarketAs(ctx, acct, assetID, side, amount, true)
 }
 
 func (a *App) placeLimit(ctx context.Context, tokenID, side string, price, sizeUSDC float64) clobtypes.OrderResponse {
-	if a.tradingMode == "paper" {
+	if a.execMode == "paper" {
 		resp := a.placePaperLimit(tokenID, side, price, sizeUSDC)
 		if a.kpi != nil && resp.ID != "" {
-			a.kpi.recordOrderSubmitted(time.Now().UTC())
+			a.kpi.recordOrderSubmitted(a.now())
 		}
 		return resp
 	}
 
+	acct := a.primaryAccount()
+	if !acct.breaker.Allow(a.now()) {
+		log.Printf("place limit %s %s: circuit breaker open", side, tokenID)
+		return clobtypes.OrderResponse{}
+	}
 	builder := clob.NewOrderBuilder(a.clobClient, a.signer).
 		TokenID(tokenID).
 		Side(side).
 		Price(price).
-		AmountUSDC(sizeUSDC).
+		Size(limitShares(sizeUSDC, price)).
 		OrderType(clobtypes.OrderTypeGTC)
 
 	signable, err := builder.BuildSignableWithContext(ctx)
 	if err != nil {
 		log.Printf("build limit %s %s: %v", side, tokenID, err)
+		a.placementRejected(tokenID, side, sizeUSDC, err)
+		return clobtypes.OrderResponse{}
+	}
+	if !a.limiter.Allow() {
+		log.Printf("place limit %s %s: throttled", side, tokenID)
 		return clobtypes.OrderResponse{}
 	}
 	resp, err := a.clobClient.CreateOrderFromSignable(ctx, signable)
+	a.recordPlacement(ctx, acct, err)
 	if err != nil {
 		log.Printf("place limit %s %s: %v", side, tokenID, err)
+		a.placementRejected(tokenID, side, sizeUSDC, err)
 		return clobtypes.OrderResponse{}
 	}
 	if a.kpi != nil && resp.ID != "" {
-		a.kpi.recordOrderSubmitted(time.Now().UTC())
+		a.kpi.recordOrderSubmitted(a.now())
 	}
 	log.Printf("limit %s %s @ %.4f: id=%s", side, tokenID, price, resp.ID)
 	return resp
 }
 
 func (a *App) placeMarket(ctx context.Context, tokenID, side string, amountUSDC float64) clobtypes.OrderResponse {
-	if a.tradingMode == "paper" {
+	return a.placeMarketAs(ctx, a.primaryAccount(), tokenID, side, amountUSDC, false)
+}
+
+// placeMarketAs places a FAK market order through acct. The CLOB sizes buys
+// in USDC and sells in shares, so a sell is converted at the best bid.
+// reduceOnly orders close risk rather than add it, so an open placement
+// breaker does not hold them back.
+func (a *App) placeMarketAs(ctx context.Context, acct *account, tokenID, side string, amountUSDC float64, reduceOnly bool) clobtypes.OrderResponse {
+	if a.execMode == "paper" {
 		resp := a.placePaperMarket(tokenID, side, amountUSDC)
 		if a.kpi != nil && resp.ID != "" {
-			a.kpi.recordOrderSubmitted(time.Now().UTC())
+			a.kpi.recordOrderSubmitted(a.now())
 		}
 		return resp
 	}
 
-	builder := clob.NewOrderBuilder(a.clobClient, a.signer).
+	if !reduceOnly && !acct.breaker.Allow(a.now()) {
+		log.Printf("place market %s %s: circuit breaker open", side, tokenID)
+		return clobtypes.OrderResponse{}
+	}
+	builder := clob.NewOrderBuilder(acct.clob, acct.signer).
 		TokenID(tokenID).
 		Side(side).
-		AmountUSDC(amountUSDC).
 		OrderType(clobtypes.OrderTypeFAK)
+	if side == "SELL" {
+		var bid float64
+		if book, ok := a.books.Get(tokenID); ok {
+			bid, _, _ = feed.BookTop(book)
+		}
+		builder.AmountShares(limitShares(amountUSDC, bid))
+	} else {
+		builder.AmountUSDC(math.Floor(amountUSDC*1e6) / 1e6)
+	}
 
 	signable, err := builder.BuildMarketWithContext(ctx)
 	if err != nil {
 		log.Printf("build market %s %s: %v", side, tokenID, err)
+		a.placementRejected(tokenID, side, amountUSDC, err)
 		return clobtypes.OrderResponse{}
 	}
-	resp, err := a.clobClient.CreateOrderFromSignable(ctx, signable)
+	if !a.limiter.Allow() {
+		log.Printf("place market %s %s: throttled", side, tokenID)
+		return clobtypes.OrderResponse{}
+	}
+	resp, err := acct.clob.CreateOrderFromSignable(ctx, signable)
+	a.recordPlacement(ctx, acct, err)
 	if err != nil {
 		log.Printf("place market %s %s: %v", side, tokenID, err)
+		a.placementRejected(tokenID, side, amountUSDC, err)
 		return clobtypes.OrderResponse{}
 	}
 	if a.kpi != nil && resp.ID != "" {
-		a.kpi.recordOrderSubmitted(time.Now().UTC())
+		a.kpi.recordOrderSubmitted(a.now())
 	}
 	log.Printf("market %s %s amount=%.2f: id=%s", side, tokenID, amountUSDC, resp.ID)
 	return resp
@@ -1511,7 +2317,9 @@ func (a *App) placePaperMarket(tokenID, side string, amountUSDC float64) clobtyp
 
 func (a *App) applyPaperFill(fill paper.FillResult) {
 	market := a.assetToMarket[fill.AssetID]
-	a.tracker.RegisterOrder(fill.OrderID, fill.AssetID, market, fill.Side, fill.Price, fill.AmountUSDC)
+	if _, tracked := a.tracker.Order(fill.OrderID); !tracked {
+		a.tracker.RegisterOrder(fill.OrderID, fill.AssetID, market, fill.Side, fill.Price, fill.AmountUSDC)
+	}
 	matchedSize := "0"
 	if fill.Filled {
 		matchedSize = fmt.Sprintf("%.8f", fill.Size)
@@ -1522,11 +2330,16 @@ func (a *App) applyPaperFill(fill paper.FillResult) {
 		Market:       market,
 		Side:         fill.Side,
 		Price:        fmt.Sprintf("%.8f", fill.Price),
-		OriginalSize: fmt.Sprintf("%.8f", fill.AmountUSDC),
+		OriginalSize: fmt.Sprintf("%.8f", fill.Size),
 		SizeMatched:  matchedSize,
 		Status:       fill.Status,
 	})
+	if fill.Filled && a.cfg.DryRun {
+		log.Printf("[DRY] simulated fill %s %s price=%.4f size=%.2f fee=%.4f",
+			fill.Side, fill.AssetID, fill.Price, fill.Size, fill.FeeUSDC)
+	}
 	if fill.Filled {
+		a.recordMakerCapture(fill.OrderID, fill.Side, fill.Price)
 		a.tracker.ProcessTradeEvent(ws.TradeEvent{
 			ID:      fill.TradeID,
 			AssetID: fill.AssetID,
@@ -1538,11 +2351,111 @@ func (a *App) applyPaperFill(fill paper.FillResult) {
 	}
 }
 
+// loadPaperState restores the paper account from paper.state_file. A missing or
+// unreadable file leaves the simulator at its initial balance.
+func (a *App) loadPaperState() {
+	path := a.cfg.Paper.StateFile
+	if a.paperSim == nil || path == "" {
+		return
+	}
+	err := a.paperSim.LoadState(path)
+	switch {
+	case err == nil:
+		st := a.paperSim.Export()
+		log.Printf("paper state restored from %s: balance=%.2f trades=%d", path, st.BalanceUSDC, st.TotalTrades)
+		// The tracker starts empty; rebuild its positions from the inventory
+		// so risk limits, stop-losses and PnL see what the account holds.
+		for assetID, size := range st.Inventory {
+			entry, ok := st.EntryPrices[assetID]
+			if !ok {
+				log.Printf("paper state %s: no entry price saved for %s; its PnL counts from zero cost", path, assetID)
+			}
+			a.tracker.SeedPosition(assetID, size, entry)
+		}
+	case errors.Is(err, fs.ErrNotExist):
+		log.Printf("paper state %s not found, starting fresh", path)
+	default:
+		log.Printf("warning: paper state %s unusable, starting fresh: %v", path, err)
+	}
+}
+
+// loadOrderState re-adopts the orders and positions left behind by a shutdown
+// with preserve_orders_on_shutdown. The file is removed once adopted so that a
+// later cold start does not resurrect orders that have since been cancelled.
+func (a *App) loadOrderState() {
+	path := a.cfg.OrderStateFile
+	if path == "" {
+		return
+	}
+	if !loadTrackerState(a.tracker, path) {
+		return
+	}
+	for _, o := range a.tracker.ActiveOrders() {
+		if o.Strategy == "" {
+			a.activeOrders[o.AssetID] = append(a.activeOrders[o.AssetID], o.ID)
+		}
+	}
+}
+
+// loadTrackerState restores tracker from path and removes the file,
+// reporting whether there was a state to restore.
+func loadTrackerState(tracker *execution.Tracker, path string) bool {
+	err := tracker.LoadState(path)
+	switch {
+	case err == nil:
+		log.Printf("order state restored from %s: orders=%d positions=%d", path, tracker.OpenOrderCount(), len(tracker.Positions()))
+		if err := os.Remove(path); err != nil {
+			log.Printf("warning: remove order state %s: %v", path, err)
+		}
+		return true
+	case errors.Is(err, fs.ErrNotExist):
+		// Normal after a full stop: orders were cancelled, nothing to adopt.
+	default:
+		log.Printf("warning: order state %s unusable, starting fresh: %v", path, err)
+	}
+	return false
+}
+
+// saveOrderState writes the live orders and positions to order_state_file
+// instead of cancelling them, and the taker account's next to it.
+func (a *App) saveOrderState() {
+	path := a.cfg.OrderStateFile
+	if path == "" {
+		log.Println("warning: preserve_orders_on_shutdown set without order_state_file; orders left resting untracked")
+		return
+	}
+	for _, acct := range a.accounts() {
+		file := path
+		if acct == a.takerAcct {
+			file = takerOrderStateFile(path)
+		}
+		if err := acct.tracker.SaveState(file); err != nil {
+			log.Printf("save order state (%s account): %v", acct.name, err)
+			continue
+		}
+		log.Printf("preserved %d %s account open orders in %s", acct.tracker.OpenOrderCount(), acct.name, file)
+	}
+}
+
+// savePaperState writes the paper account to paper.state_file, if configured.
+func (a *App) savePaperState() {
+	if a.paperSim == nil || a.cfg.Paper.StateFile == "" || a.cfg.DryRun {
+		return
+	}
+	if err := a.paperSim.SaveState(a.cfg.Paper.StateFile); err != nil {
+		log.Printf("save paper state: %v", err)
+	}
+}
+
 func (a *App) cancelPaperOrders(orderIDs []string) {
-	if a.tradingMode != "paper" {
+	if a.execMode != "paper" {
 		return
 	}
 	for _, orderID := range orderIDs {
+		if a.paperSim != nil && !a.paperSim.CancelOrder(orderID) {
+			// Already filled while resting; leave its state alone.
+			continue
+		}
 		a.tracker.ProcessOrderEvent(ws.OrderEvent{
 			ID:     orderID,
 			Status: "CANCELED",
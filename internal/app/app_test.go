@@ -2,17 +2,41 @@ package app
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
 	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/auth"
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob"
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/clobtypes"
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/heartbeat"
 	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/ws"
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/data"
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/gamma"
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/rtds"
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/GoPolymarket/polymarket-trader/internal/config"
+	"github.com/GoPolymarket/polymarket-trader/internal/execution"
+	"github.com/GoPolymarket/polymarket-trader/internal/feed"
+	"github.com/GoPolymarket/polymarket-trader/internal/notify"
+	"github.com/GoPolymarket/polymarket-trader/internal/paper"
+	"github.com/GoPolymarket/polymarket-trader/internal/strategy"
 )
 
 type mockNotifier struct {
+	fillCalls           int
 	riskCooldownCalls   int
 	lastConsecutive     int
 	lastMax             int
@@ -21,13 +45,19 @@ type mockNotifier struct {
 	weeklyTemplateCalls int
 	lastDailyTemplate   string
 	lastWeeklyTemplate  string
+	riskChanges         []notify.RiskStateChange
+	alerts              []string
+	alertTexts          []string
+	stopLosses          []string
 }
 
 func (m *mockNotifier) NotifyFill(_ context.Context, _ string, _ string, _ float64, _ float64) error {
+	m.fillCalls++
 	return nil
 }
 
-func (m *mockNotifier) NotifyStopLoss(_ context.Context, _ string, _ float64) error {
+func (m *mockNotifier) NotifyStopLoss(_ context.Context, assetID string, _ float64) error {
+	m.stopLosses = append(m.stopLosses, assetID)
 	return nil
 }
 
@@ -47,6 +77,17 @@ func (m *mockNotifier) NotifyRiskCooldown(_ context.Context, consecutiveLosses,
 	return nil
 }
 
+func (m *mockNotifier) NotifyRiskStateChange(_ context.Context, change notify.RiskStateChange) error {
+	m.riskChanges = append(m.riskChanges, change)
+	return nil
+}
+
+func (m *mockNotifier) NotifyAlert(_ context.Context, title, text string) error {
+	m.alerts = append(m.alerts, title)
+	m.alertTexts = append(m.alertTexts, text)
+	return nil
+}
+
 func (m *mockNotifier) NotifyDailyCoachTemplate(_ context.Context, textHTML string) error {
 	m.dailyTemplateCalls++
 	m.lastDailyTemplate = textHTML
@@ -83,6 +124,177 @@ func TestNewApp(t *testing.T) {
 	}
 }
 
+func TestNewAppFansOutToEnabledNotifiers(t *testing.T) {
+	cfg := testConfig()
+	if a := New(cfg, nil, nil, nil, nil, nil, nil); a.notifier != nil {
+		t.Fatalf("expected no notifier without enabled channels, got %T", a.notifier)
+	}
+
+	cfg.Telegram.Enabled = true
+	cfg.Discord.Enabled = true
+	cfg.Discord.WebhookURL = "https://discord.example/webhook"
+	cfg.Slack.Enabled = true
+	cfg.Slack.WebhookURL = "https://hooks.slack.example/services/x"
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	multi, ok := a.notifier.(*notify.MultiNotifier)
+	if !ok {
+		t.Fatalf("expected MultiNotifier, got %T", a.notifier)
+	}
+	if multi.Len() != 3 {
+		t.Fatalf("expected 3 channels, got %d", multi.Len())
+	}
+}
+
+func TestFillNotificationsRespectThresholds(t *testing.T) {
+	cfg := testConfig()
+	cfg.Notify.MinFillNotifyUSDC = 5
+	cfg.Notify.LargeFillNotifyUSDC = 50
+	cfg.Notify.NotifyCooldown = time.Hour
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	n := &mockNotifier{}
+	a.notifier = n
+
+	// $2 notional: below the minimum.
+	a.tracker.ProcessTradeEvent(ws.TradeEvent{ID: "t-1", AssetID: "asset-1", Side: "BUY", Price: "0.50", Size: "4"})
+	if n.fillCalls != 0 {
+		t.Fatalf("expected small fill to be suppressed, got %d alerts", n.fillCalls)
+	}
+	// $10 notional: delivered, starts the cooldown.
+	a.tracker.ProcessTradeEvent(ws.TradeEvent{ID: "t-2", AssetID: "asset-1", Side: "BUY", Price: "0.50", Size: "20"})
+	if n.fillCalls != 1 {
+		t.Fatalf("expected fill above threshold to alert, got %d alerts", n.fillCalls)
+	}
+	// $10 notional inside the cooldown: suppressed.
+	a.tracker.ProcessTradeEvent(ws.TradeEvent{ID: "t-3", AssetID: "asset-1", Side: "BUY", Price: "0.50", Size: "20"})
+	if n.fillCalls != 1 {
+		t.Fatalf("expected cooldown to suppress fill, got %d alerts", n.fillCalls)
+	}
+	// $60 notional: large fills bypass the cooldown.
+	a.tracker.ProcessTradeEvent(ws.TradeEvent{ID: "t-4", AssetID: "asset-1", Side: "BUY", Price: "0.50", Size: "120"})
+	if n.fillCalls != 2 {
+		t.Fatalf("expected large fill to alert, got %d alerts", n.fillCalls)
+	}
+}
+
+func TestFillNotificationsOnlyLosses(t *testing.T) {
+	cfg := testConfig()
+	cfg.Notify.NotifyOnlyLosses = true
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	n := &mockNotifier{}
+	a.notifier = n
+
+	a.tracker.ProcessTradeEvent(ws.TradeEvent{ID: "b-1", AssetID: "asset-1", Side: "BUY", Price: "0.60", Size: "20"})
+	a.tracker.ProcessTradeEvent(ws.TradeEvent{ID: "s-1", AssetID: "asset-1", Side: "SELL", Price: "0.70", Size: "10"})
+	if n.fillCalls != 0 {
+		t.Fatalf("expected opening and winning fills to be suppressed, got %d alerts", n.fillCalls)
+	}
+	a.tracker.ProcessTradeEvent(ws.TradeEvent{ID: "s-2", AssetID: "asset-1", Side: "SELL", Price: "0.50", Size: "10"})
+	if n.fillCalls != 1 {
+		t.Fatalf("expected losing fill to alert, got %d alerts", n.fillCalls)
+	}
+}
+
+func TestReloadConfigUpdatesMakerSpread(t *testing.T) {
+	cfg := testConfig()
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	event := ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "100"}},
+	}
+	before, err := a.maker.ComputeQuote(event)
+	if err != nil {
+		t.Fatalf("compute quote: %v", err)
+	}
+
+	next := cfg
+	next.Maker.MinSpreadBps = 1000
+	next.Risk.MaxOpenOrders = 12
+	if err := a.ReloadConfig(next); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	after, err := a.maker.ComputeQuote(event)
+	if err != nil {
+		t.Fatalf("compute quote: %v", err)
+	}
+	if after.SellPrice-after.BuyPrice <= before.SellPrice-before.BuyPrice {
+		t.Fatalf("expected wider spread after reload: before=%+v after=%+v", before, after)
+	}
+	if a.cfg.Risk.MaxOpenOrders != 12 {
+		t.Fatalf("expected risk config to be reloaded, got max_open_orders=%d", a.cfg.Risk.MaxOpenOrders)
+	}
+}
+
+func TestReloadConfigRejectsImmutableFields(t *testing.T) {
+	cfg := testConfig()
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+
+	next := cfg
+	next.TradingMode = "live"
+	next.PrivateKey = "0xabc"
+	next.Maker.MinSpreadBps = 99
+	err := a.ReloadConfig(next)
+	if err == nil || !strings.Contains(err.Error(), "trading_mode") || !strings.Contains(err.Error(), "private_key") {
+		t.Fatalf("expected rejection naming immutable fields, got %v", err)
+	}
+	if a.cfg.Maker.MinSpreadBps != cfg.Maker.MinSpreadBps {
+		t.Fatal("expected rejected reload to leave config untouched")
+	}
+
+	next = cfg
+	next.Maker.OrderSizeUSDC = 0
+	if err := a.ReloadConfig(next); err == nil {
+		t.Fatal("expected invalid config to be rejected")
+	}
+}
+
+func TestMarketOverrideQuotesDifferentSpread(t *testing.T) {
+	cfg := testConfig()
+	wide := cfg.Maker
+	wide.MinSpreadBps = 1000
+	cfg.MarketOverrides = map[string]config.MarketOverride{
+		"asset-2": {Maker: wide, Taker: cfg.Taker},
+	}
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+
+	book := func(assetID string) ws.OrderbookEvent {
+		return ws.OrderbookEvent{
+			AssetID: assetID,
+			Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+			Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "100"}},
+		}
+	}
+	global, err := a.makerFor("asset-1").ComputeQuote(book("asset-1"))
+	if err != nil {
+		t.Fatalf("compute quote: %v", err)
+	}
+	overridden, err := a.makerFor("asset-2").ComputeQuote(book("asset-2"))
+	if err != nil {
+		t.Fatalf("compute quote: %v", err)
+	}
+	if overridden.SellPrice-overridden.BuyPrice <= global.SellPrice-global.BuyPrice {
+		t.Fatalf("expected overridden asset to quote wider: global=%+v overridden=%+v", global, overridden)
+	}
+	if a.takerFor("asset-1") != a.taker {
+		t.Fatal("expected asset without override to use the global taker")
+	}
+	if maker, _, override := a.StrategyParams("asset-2"); !override || maker.MinSpreadBps != 1000 {
+		t.Fatalf("expected override params for asset-2, got %+v override=%v", maker, override)
+	}
+	if maker, _, override := a.StrategyParams("asset-1"); override || maker.MinSpreadBps != cfg.Maker.MinSpreadBps {
+		t.Fatalf("expected global params for asset-1, got %+v override=%v", maker, override)
+	}
+
+	next := cfg
+	next.MarketOverrides = nil
+	if err := a.ReloadConfig(next); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if a.makerFor("asset-2") != a.maker {
+		t.Fatal("expected removed override to fall back to the global maker")
+	}
+}
+
 func TestHandleBookEventDryRunMaker(t *testing.T) {
 	cfg := testConfig()
 	cfg.Maker.Enabled = true
@@ -98,9 +310,10 @@ func TestHandleBookEventDryRunMaker(t *testing.T) {
 
 	a.HandleBookEvent(context.Background(), event)
 
+	// Both quotes rest in the dry-run simulator without crossing the book.
 	orders, fills, _ := a.Stats()
-	if orders != 0 {
-		t.Fatalf("dry run should produce 0 orders, got %d", orders)
+	if orders != 2 {
+		t.Fatalf("dry run should rest 2 simulated orders, got %d", orders)
 	}
 	if fills != 0 {
 		t.Fatalf("dry run should produce 0 fills, got %d", fills)
@@ -120,6 +333,8 @@ func TestHandleBookEventDryRunTaker(t *testing.T) {
 	cfg.Maker.Enabled = false
 	cfg.Taker.Enabled = true
 	cfg.Taker.MinImbalance = 0.10
+	cfg.TradingMode = "live"
+	cfg.Paper.StateFile = filepath.Join(t.TempDir(), "paper.json")
 
 	a := New(cfg, nil, nil, nil, nil, nil, nil)
 
@@ -132,8 +347,201 @@ func TestHandleBookEventDryRunTaker(t *testing.T) {
 	a.HandleBookEvent(context.Background(), event)
 
 	_, fills, _ := a.Stats()
-	if fills != 0 {
-		t.Fatalf("dry run should produce 0 fills, got %d", fills)
+	if fills != 1 {
+		t.Fatalf("dry run should produce 1 simulated fill, got %d", fills)
+	}
+	snap := a.PaperSnapshot()
+	if snap.TotalTrades != 1 || snap.FeesPaidUSDC <= 0 {
+		t.Fatalf("expected simulated trade with fees, got trades=%d fees=%.4f", snap.TotalTrades, snap.FeesPaidUSDC)
+	}
+	// Status reports the configured mode; only execution is simulated.
+	if a.TradingMode() != "live" {
+		t.Fatalf("expected dry run to keep the configured live mode, got %q", a.TradingMode())
+	}
+
+	a.savePaperState()
+	if _, err := os.Stat(cfg.Paper.StateFile); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("dry run must not write the paper state file, stat err=%v", err)
+	}
+}
+
+func TestHandleBookEventSkipsStaleBook(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "paper"
+	cfg.Taker.Enabled = false
+	cfg.BookStaleAfter = 30 * time.Second
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+
+	event := ws.OrderbookEvent{
+		AssetID:   "asset-1",
+		Bids:      []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:      []ws.OrderbookLevel{{Price: "0.54", Size: "100"}},
+		Timestamp: strconv.FormatInt(time.Now().Add(-5*time.Minute).UnixMilli(), 10),
+	}
+	a.HandleBookEvent(context.Background(), event)
+	if len(a.activeOrders["asset-1"]) != 0 {
+		t.Fatalf("expected no quotes on stale book, got %v", a.activeOrders["asset-1"])
+	}
+
+	event.Timestamp = strconv.FormatInt(time.Now().UnixMilli(), 10)
+	a.HandleBookEvent(context.Background(), event)
+	if len(a.activeOrders["asset-1"]) == 0 {
+		t.Fatal("expected quotes once the book is fresh")
+	}
+}
+
+func TestFillPausesQuotingUntilWindowElapses(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "paper"
+	cfg.Taker.Enabled = false
+	cfg.Maker.PostFillPauseMs = 2000
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	clock := &fixedClock{t: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	a.SetClock(clock)
+
+	event := ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.54", Size: "100"}},
+	}
+	a.HandleBookEvent(context.Background(), event)
+	if len(a.activeOrders["asset-1"]) == 0 {
+		t.Fatal("expected quotes before any fill")
+	}
+
+	a.tracker.ProcessTradeEvent(ws.TradeEvent{ID: "t1", AssetID: "asset-1", Side: "BUY", Price: "0.51", Size: "2"})
+	clock.t = clock.t.Add(time.Second)
+	a.HandleBookEvent(context.Background(), event)
+	if got := a.activeOrders["asset-1"]; len(got) != 0 {
+		t.Fatalf("expected quotes pulled during the post-fill pause, got %v", got)
+	}
+	if n := a.PaperSnapshot().RestingOrders; n != 0 {
+		t.Fatalf("expected paper quotes cancelled, got %d resting", n)
+	}
+
+	clock.t = clock.t.Add(1500 * time.Millisecond)
+	a.HandleBookEvent(context.Background(), event)
+	if len(a.activeOrders["asset-1"]) == 0 {
+		t.Fatal("expected quoting to resume after the pause")
+	}
+}
+
+func TestRepeatedCancelsTriggerCooldown(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "paper"
+	cfg.Taker.Enabled = false
+	cfg.Maker.MaxCancelsBeforeCooldown = 3
+	cfg.Maker.CancelWindow = time.Minute
+	cfg.Maker.CancelCooldown = 30 * time.Second
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	clock := &fixedClock{t: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	a.SetClock(clock)
+
+	event := ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.54", Size: "100"}},
+	}
+	// The first update quotes, the next two requote.
+	for i := 0; i < 3; i++ {
+		a.HandleBookEvent(context.Background(), event)
+		clock.t = clock.t.Add(time.Second)
+	}
+	if len(a.activeOrders["asset-1"]) == 0 {
+		t.Fatal("expected quotes below the cancel threshold")
+	}
+	if got := a.CancelCooldownAssets(); len(got) != 0 {
+		t.Fatalf("expected no cooldown yet, got %v", got)
+	}
+
+	a.HandleBookEvent(context.Background(), event)
+	if got := a.activeOrders["asset-1"]; len(got) != 0 {
+		t.Fatalf("expected no quotes once the cooldown starts, got %v", got)
+	}
+	if got := a.CancelCooldownAssets(); len(got) != 1 || got[0] != "asset-1" {
+		t.Fatalf("expected asset-1 cooling down, got %v", got)
+	}
+
+	clock.t = clock.t.Add(10 * time.Second)
+	a.HandleBookEvent(context.Background(), event)
+	if got := a.activeOrders["asset-1"]; len(got) != 0 {
+		t.Fatalf("expected no quotes during the cooldown, got %v", got)
+	}
+
+	clock.t = clock.t.Add(25 * time.Second)
+	a.HandleBookEvent(context.Background(), event)
+	if len(a.activeOrders["asset-1"]) == 0 {
+		t.Fatal("expected quoting to resume after the cooldown")
+	}
+	if got := a.CancelCooldownAssets(); len(got) != 0 {
+		t.Fatalf("expected cooldown cleared, got %v", got)
+	}
+}
+
+func TestFillResetsCancelCount(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "paper"
+	cfg.Taker.Enabled = false
+	cfg.Maker.MaxCancelsBeforeCooldown = 2
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	clock := &fixedClock{t: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	a.SetClock(clock)
+
+	event := ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.54", Size: "100"}},
+	}
+	a.HandleBookEvent(context.Background(), event)
+	a.HandleBookEvent(context.Background(), event)
+	a.tracker.ProcessTradeEvent(ws.TradeEvent{ID: "t1", AssetID: "asset-1", Side: "BUY", Price: "0.51", Size: "2"})
+	a.HandleBookEvent(context.Background(), event)
+	if got := a.CancelCooldownAssets(); len(got) != 0 {
+		t.Fatalf("expected the fill to reset the cancel count, got %v", got)
+	}
+	if len(a.activeOrders["asset-1"]) == 0 {
+		t.Fatal("expected quotes after a fill")
+	}
+}
+
+func TestSimulateQuoteMatchesMakerPath(t *testing.T) {
+	cfg := testConfig()
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	a.feeRates["asset-1"] = 500
+
+	book := ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.5", Size: "100"}, {Price: "0.48", Size: "50"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "100"}, {Price: "0.55", Size: "50"}},
+	}
+	inv := strategy.InventoryState{NetPosition: 20, AvgEntryPrice: 0.45, MaxPosition: cfg.Risk.MaxPositionPerMarket}
+	raw, err := a.maker.ComputeQuote(book, inv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := strategy.ApplyFeeFloor(raw, 500)
+
+	// Levels arrive worst first; the simulation orders them like the feed.
+	preview, err := a.SimulateQuote("asset-1",
+		[]feed.Level{{Price: 0.48, Size: 50}, {Price: 0.50, Size: 100}},
+		[]feed.Level{{Price: 0.55, Size: 50}, {Price: 0.52, Size: 100}},
+		&strategy.InventoryState{NetPosition: 20, AvgEntryPrice: 0.45})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Quote != want || preview.RawQuote != raw {
+		t.Fatalf("expected quote %+v (raw %+v), got %+v", want, raw, preview)
+	}
+	if !preview.FeeAdjusted || preview.FeeRateBps != 500 {
+		t.Fatalf("expected the 500 bps fee floor applied, got %+v", preview)
+	}
+
+	if _, err := a.SimulateQuote("asset-1", []feed.Level{{Price: 0.5, Size: 1}}, nil, nil); err == nil {
+		t.Fatal("expected an error for a one-sided book")
 	}
 }
 
@@ -186,9 +594,10 @@ func TestHandleBookEventMultipleUpdates(t *testing.T) {
 		a.HandleBookEvent(context.Background(), event)
 	}
 
+	// Each requote cancels the previous simulated pair.
 	orders, _, _ := a.Stats()
-	if orders != 0 {
-		t.Fatalf("dry run should produce 0 orders, got %d", orders)
+	if orders != 2 {
+		t.Fatalf("dry run should leave 2 simulated orders, got %d", orders)
 	}
 }
 
@@ -256,6 +665,106 @@ func TestKPIStatsTracksSubmittedAndFilledOrders(t *testing.T) {
 	}
 }
 
+func TestKPIFillRatioDaily(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	c := newKPICollector(0, &fixedClock{t: now})
+	if got := c.snapshot(now)["fill_ratio_daily"]; got != 0.0 {
+		t.Fatalf("expected 0 fill ratio before any order, got %v", got)
+	}
+	for i := 0; i < 10; i++ {
+		c.recordOrderSubmitted(now)
+	}
+	for i := 0; i < 3; i++ {
+		c.recordOrderFilled(now)
+	}
+	if got := c.snapshot(now)["fill_ratio_daily"]; got != 0.3 {
+		t.Fatalf("expected fill_ratio_daily 0.3, got %v", got)
+	}
+}
+
+func TestKPIFillRatioCountsOrdersNotFills(t *testing.T) {
+	a := New(testConfig(), nil, nil, nil, nil, nil, nil)
+	a.tracker.RegisterOrder("ord-1", "asset-1", "", "BUY", 0.5, 10)
+	a.kpi.recordOrderSubmitted(a.now())
+
+	// One order filled in two pieces.
+	for i, matched := range []string{"4", "10"} {
+		a.tracker.ProcessOrderEvent(ws.OrderEvent{ID: "ord-1", AssetID: "asset-1", OriginalSize: "10", SizeMatched: matched, Status: "LIVE"})
+		a.tracker.ProcessTradeEvent(ws.TradeEvent{ID: fmt.Sprintf("t-%d", i), AssetID: "asset-1", Side: "BUY", Price: "0.5", Size: "5"})
+	}
+
+	stats := a.KPIStats()
+	if got := intFromAny(stats["filled_orders_daily"]); got != 1 {
+		t.Fatalf("expected 1 filled order, got %v", stats["filled_orders_daily"])
+	}
+	if got := stats["fill_ratio_daily"]; got != 1.0 {
+		t.Fatalf("expected fill_ratio_daily 1, got %v", got)
+	}
+}
+
+func TestKPITakerRealizationWindowFromConfig(t *testing.T) {
+	cfg := testConfig()
+	cfg.Taker.RealizationWindow = 10 * time.Minute
+
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	start := startOfUTCDay(time.Now()).Add(time.Hour)
+	if got := intFromAny(a.kpi.snapshot(start)["taker_signal_realization_window_minutes"]); got != 10 {
+		t.Fatalf("expected configured window of 10 minutes, got %d", got)
+	}
+
+	a.kpi.recordTakerSignal(start, "asset-1", "BUY", 0.50, cfg.Taker.RealizationWindow)
+
+	a.kpi.evaluateTakerRealization(start.Add(9*time.Minute), "asset-1", 0.55)
+	if got := a.kpi.takerRealizationEvaluatedDaily; got != 0 {
+		t.Fatalf("expected no evaluation before window elapses, got %d", got)
+	}
+
+	a.kpi.evaluateTakerRealization(start.Add(10*time.Minute), "asset-1", 0.55)
+	if got := a.kpi.takerRealizationEvaluatedDaily; got != 1 {
+		t.Fatalf("expected one evaluation after 10 minutes, got %d", got)
+	}
+	stats := a.kpi.snapshot(start.Add(10 * time.Minute))
+	if got, _ := stats["taker_signal_realization_rate"].(float64); got != 1 {
+		t.Fatalf("expected realization rate 1, got %v", stats["taker_signal_realization_rate"])
+	}
+	if got := intFromAny(stats["taker_signal_realization_window_minutes"]); got != 10 {
+		t.Fatalf("expected window minutes to stay at 10, got %d", got)
+	}
+}
+
+func TestKPIMaxDrawdownFromPeakToTrough(t *testing.T) {
+	a := New(testConfig(), nil, nil, nil, nil, nil, nil)
+	start := startOfUTCDay(time.Now()).Add(time.Hour)
+
+	for i, net := range []float64{0, 50, -30, 20} {
+		a.kpi.recordPnLSample(start.Add(time.Duration(i)*time.Minute), net, net, 0)
+	}
+	daily, session := a.kpi.drawdowns(start.Add(5 * time.Minute))
+	if daily.max != 80 || session.max != 80 {
+		t.Fatalf("expected an 80 USDC drawdown from the 50 peak to -30, got daily=%v session=%v", daily.max, session.max)
+	}
+	if got, want := session.pct(1000), 80.0/1050*100; math.Abs(got-want) > 1e-9 {
+		t.Fatalf("expected drawdown of %.4f%% of peak equity, got %.4f%%", want, got)
+	}
+
+	// The next UTC day starts a fresh daily curve from the current level;
+	// the session keeps the deeper fall.
+	nextDay := start.Add(24 * time.Hour)
+	a.kpi.recordPnLSample(nextDay, 0, 0, 0)
+	daily, session = a.kpi.drawdowns(nextDay)
+	if daily.max != 20 {
+		t.Fatalf("expected today's drawdown of 20 from the carried-over level, got %v", daily.max)
+	}
+	if session.max != 80 {
+		t.Fatalf("expected the session drawdown to stay at 80, got %v", session.max)
+	}
+
+	daily, _ = a.kpi.drawdowns(nextDay.Add(24 * time.Hour))
+	if daily.max != 0 {
+		t.Fatalf("expected a rollover without samples to reset today's drawdown, got %v", daily.max)
+	}
+}
+
 func intFromAny(v interface{}) int {
 	switch t := v.(type) {
 	case int:
@@ -299,7 +808,7 @@ func TestRiskSyncTracksRealizedDeltas(t *testing.T) {
 	if !a.riskMgr.InCooldown() {
 		t.Fatal("expected cooldown after second consecutive realized loss")
 	}
-	if err := a.riskMgr.Allow("asset-1", 1); err == nil {
+	if err := a.riskMgr.Allow("asset-1", "BUY", 1); err == nil {
 		t.Fatal("expected risk manager to block new orders during cooldown")
 	}
 }
@@ -337,6 +846,24 @@ func TestRiskSyncSendsCooldownNotification(t *testing.T) {
 	}
 }
 
+func TestResetDailyRiskResetsTakerCounters(t *testing.T) {
+	cfg := testConfig()
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+
+	a.taker.RecordTrade("asset-1", "BUY", 2)
+	if got := a.taker.DailyTradeCount("asset-1"); got != 1 {
+		t.Fatalf("expected 1 taker trade before reset, got %d", got)
+	}
+
+	a.resetDailyRisk()
+	if got := a.taker.DailyTradeCount("asset-1"); got != 0 {
+		t.Fatalf("expected taker daily trades reset at day boundary, got %d", got)
+	}
+	if got := a.taker.DailyNotional("asset-1"); got != 0 {
+		t.Fatalf("expected taker daily notional reset at day boundary, got %f", got)
+	}
+}
+
 func TestSendScheduledTelegramReportsDailyAndWeekly(t *testing.T) {
 	cfg := testConfig()
 	a := New(cfg, nil, nil, nil, nil, nil, nil)
@@ -481,7 +1008,2776 @@ func TestPlacePaperLimitUnfilledKeepsOrderMetadata(t *testing.T) {
 	if math.Abs(orders[0].Price-0.51) > 1e-9 {
 		t.Fatalf("expected order price 0.51, got %f", orders[0].Price)
 	}
-	if math.Abs(orders[0].OrigSize-20) > 1e-9 {
-		t.Fatalf("expected order orig size 20, got %f", orders[0].OrigSize)
+	// Sizes are in shares, as the exchange reports them: 20 USDC at 0.51.
+	if want := 20 / 0.51; math.Abs(orders[0].OrigSize-want) > 1e-6 {
+		t.Fatalf("expected order orig size %f shares, got %f", want, orders[0].OrigSize)
+	}
+}
+
+func TestSubmitExternalSignalPlacesTaggedOrder(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "paper"
+	cfg.Paper.SlippageBps = 0
+
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	a.books.Update(ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.51", Size: "100"}},
+	})
+
+	id, err := a.SubmitExternalSignal(context.Background(), strategy.ExternalSignal{
+		AssetID:    "asset-1",
+		Side:       "buy",
+		AmountUSDC: 1,
+		Reason:     "model edge",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	order, ok := a.tracker.Order(id)
+	if !ok {
+		t.Fatalf("expected order %q to be tracked", id)
+	}
+	if order.Strategy != strategy.ExternalStrategyTag {
+		t.Fatalf("expected strategy tag %q, got %q", strategy.ExternalStrategyTag, order.Strategy)
+	}
+	if a.tracker.TotalFills() != 1 {
+		t.Fatalf("expected one paper fill, got %d", a.tracker.TotalFills())
+	}
+}
+
+func TestSubmitExternalSignalDryRunFillsAgainstSimulator(t *testing.T) {
+	cfg := testConfig()
+	cfg.TradingMode = "live"
+	cfg.Paper.SlippageBps = 0
+
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	a.books.Update(ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.51", Size: "100"}},
+	})
+
+	id, err := a.SubmitExternalSignal(context.Background(), strategy.ExternalSignal{
+		AssetID:    "asset-1",
+		Side:       "buy",
+		AmountUSDC: 1,
+		Reason:     "model edge",
+	})
+	if err != nil || id == "" {
+		t.Fatalf("expected a simulated order, got id=%q err=%v", id, err)
+	}
+	if a.PaperSnapshot().TotalTrades != 1 {
+		t.Fatalf("expected one simulated trade, got %d", a.PaperSnapshot().TotalTrades)
+	}
+}
+
+func TestSubmitExternalSignalRunsOnTradingLoop(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "paper"
+
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	a.books.Update(ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.51", Size: "100"}},
+	})
+	a.running = true
+
+	// Stand in for the loop: nothing is placed until it takes the request.
+	served := make(chan struct{})
+	go func() {
+		defer close(served)
+		req := <-a.externalReqCh
+		if got := a.tracker.TotalFills(); got != 0 {
+			t.Errorf("expected nothing placed off the loop, got %d fills", got)
+		}
+		id, err := a.placeExternalSignal(context.Background(), req.sig)
+		req.done <- externalSignalResult{orderID: id, err: err}
+	}()
+
+	id, err := a.SubmitExternalSignal(context.Background(), strategy.ExternalSignal{
+		AssetID:    "asset-1",
+		Side:       "BUY",
+		AmountUSDC: 1,
+	})
+	<-served
+	if err != nil || id == "" {
+		t.Fatalf("expected the loop to place the order, got id=%q err=%v", id, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := a.SubmitExternalSignal(ctx, strategy.ExternalSignal{AssetID: "asset-1", Side: "BUY", AmountUSDC: 1}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a cancelled context to abandon the request, got %v", err)
+	}
+}
+
+func TestSubmitExternalSignalRiskBlocked(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "paper"
+	cfg.Risk.MaxOpenOrders = 0
+
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	a.books.Update(ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.51", Size: "100"}},
+	})
+
+	id, err := a.SubmitExternalSignal(context.Background(), strategy.ExternalSignal{
+		AssetID:    "asset-1",
+		Side:       "BUY",
+		AmountUSDC: 1,
+	})
+	if err == nil {
+		t.Fatalf("expected risk rejection, got order %q", id)
+	}
+	if a.tracker.TotalFills() != 0 {
+		t.Fatalf("expected no fills after rejection, got %d", a.tracker.TotalFills())
+	}
+	if got := intFromAny(a.KPIStats()["risk_block_events_daily"]); got != 1 {
+		t.Fatalf("expected one risk block recorded, got %d", got)
+	}
+	rejections := a.Rejections(10)
+	if len(rejections) != 1 {
+		t.Fatalf("expected one logged rejection, got %+v", rejections)
+	}
+	if r := rejections[0]; r.AssetID != "asset-1" || r.Side != "BUY" || r.SizeUSDC != 1 || r.Source != execution.RejectedByRisk || r.Reason != err.Error() {
+		t.Fatalf("unexpected rejection %+v", r)
+	}
+}
+
+func TestKPIPnLHistoryWindowAndBuckets(t *testing.T) {
+	c := newKPICollector(0, systemClock{})
+	start := startOfUTCDay(time.Now()).Add(time.Hour)
+	for i := 0; i < 6; i++ {
+		at := start.Add(time.Duration(i) * 10 * time.Minute)
+		c.recordPnLSample(at, float64(i), float64(i)*2, 0.1)
+	}
+
+	points := c.pnlHistory(start.Add(25*time.Minute), 0)
+	if len(points) != 3 {
+		t.Fatalf("expected 3 samples inside window, got %d", len(points))
+	}
+	var prev time.Time
+	for _, p := range points {
+		ts := p["timestamp"].(time.Time)
+		if !ts.After(prev) {
+			t.Fatalf("expected chronological samples, got %v after %v", ts, prev)
+		}
+		prev = ts
+	}
+	if points[0]["realized"].(float64) != 3 || points[2]["total"].(float64) != 10 {
+		t.Fatalf("unexpected samples: %v", points)
+	}
+
+	bucketed := c.pnlHistory(start, 30*time.Minute)
+	if len(bucketed) != 2 {
+		t.Fatalf("expected 2 buckets, got %d", len(bucketed))
+	}
+	if bucketed[0]["realized"].(float64) != 2 || bucketed[1]["realized"].(float64) != 5 {
+		t.Fatalf("expected last sample per bucket, got %v", bucketed)
+	}
+	if got := bucketed[1]["net"].(float64); got != 9.9 {
+		t.Fatalf("expected net 9.9 in last bucket, got %v", got)
+	}
+}
+
+func TestKPIWeeklyNetPnL(t *testing.T) {
+	c := newKPICollector(0, systemClock{})
+	now := startOfUTCDay(time.Now()).Add(12 * time.Hour)
+	day := 24 * time.Hour
+	for _, s := range []struct {
+		ago      time.Duration
+		realized float64
+		fees     float64
+	}{
+		{10 * day, 0, 0},
+		{8 * day, 10, 1},
+		{6 * day, 20, 2},
+		{3 * day, 30, 3},
+		{0, 50, 4},
+	} {
+		c.recordPnLSample(now.Add(-s.ago), s.realized, s.realized*2, s.fees)
+	}
+
+	stats := c.snapshot(now)
+	// The 7d window starts from the day -8 sample, the last one before it opened.
+	if got := stats["net_pnl_7d_realized_usdc"].(float64); got != 40 {
+		t.Fatalf("expected 7d realized 40, got %v", got)
+	}
+	if got := stats["net_pnl_7d_total_usdc"].(float64); got != 80 {
+		t.Fatalf("expected 7d total 80, got %v", got)
+	}
+	if got := stats["net_pnl_7d_after_fees_usdc"].(float64); got != 77 {
+		t.Fatalf("expected 7d after fees 77, got %v", got)
+	}
+	if got := stats["net_pnl_7d_window_effective_days"].(int); got != 7 {
+		t.Fatalf("expected 7 effective days, got %d", got)
+	}
+	if got := stats["net_pnl_30d_realized_usdc"].(float64); got != 50 {
+		t.Fatalf("expected 30d realized 50, got %v", got)
+	}
+	if got := stats["net_pnl_30d_window_effective_days"].(int); got != 10 {
+		t.Fatalf("expected 10 effective days in the 30d window, got %d", got)
+	}
+
+	// With under a week of history the window covers what there is.
+	short := newKPICollector(0, systemClock{})
+	short.recordPnLSample(now.Add(-3*day), 5, 5, 0)
+	short.recordPnLSample(now, 8, 9, 0)
+	stats = short.snapshot(now)
+	if got := stats["net_pnl_7d_realized_usdc"].(float64); got != 3 {
+		t.Fatalf("expected short-history 7d realized 3, got %v", got)
+	}
+	if got := stats["net_pnl_7d_window_effective_days"].(int); got != 3 {
+		t.Fatalf("expected 3 effective days, got %d", got)
+	}
+}
+
+func TestPaperRestingLimitFillsOnLaterBook(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "paper"
+	cfg.Maker.Enabled = false
+	cfg.Taker.Enabled = false
+
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	a.books.Update(ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.53", Size: "100"}},
+	})
+
+	resp := a.placeLimit(context.Background(), "asset-1", "BUY", 0.52, 5.2)
+	if resp.ID == "" || resp.Status != "LIVE" {
+		t.Fatalf("expected resting paper limit, got %+v", resp)
+	}
+
+	a.HandleBookEvent(context.Background(), ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.51", Size: "100"}},
+	})
+
+	if a.tracker.TotalFills() != 1 {
+		t.Fatalf("expected resting order to fill, got %d fills", a.tracker.TotalFills())
+	}
+	order, ok := a.tracker.Order(resp.ID)
+	if !ok || order.Status != "MATCHED" {
+		t.Fatalf("expected order %s to be MATCHED, got %+v", resp.ID, order)
+	}
+	pos := a.tracker.Position("asset-1")
+	if pos == nil || math.Abs(pos.NetSize-10) > 1e-6 {
+		t.Fatalf("expected 10 token position, got %+v", pos)
+	}
+}
+
+func TestMarketResolutionSettlesPaperPositions(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "paper"
+	cfg.Paper.FeeBps = 0
+	cfg.Paper.SlippageBps = 0
+
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	a.tokenPairs["yes-1"] = "no-1"
+	a.tokenPairs["no-1"] = "yes-1"
+	a.books.Update(ws.OrderbookEvent{
+		AssetID: "yes-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.59", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.60", Size: "100"}},
+	})
+	a.books.Update(ws.OrderbookEvent{
+		AssetID: "no-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.39", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.40", Size: "100"}},
+	})
+	a.placeMarket(context.Background(), "yes-1", "BUY", 6) // 10 YES @ 0.60
+	a.placeMarket(context.Background(), "no-1", "BUY", 4)  // 10 NO @ 0.40
+
+	a.handleMarketResolution(context.Background(), ws.MarketResolvedEvent{
+		Market:         "m-1",
+		AssetIDs:       []string{"yes-1", "no-1"},
+		WinningOutcome: "Yes",
+	})
+
+	yes := a.tracker.Position("yes-1")
+	if yes == nil || yes.NetSize != 0 || math.Abs(yes.RealizedPnL-4) > 1e-6 {
+		t.Fatalf("expected YES settled at $1 for +4, got %+v", yes)
+	}
+	no := a.tracker.Position("no-1")
+	if no == nil || no.NetSize != 0 || math.Abs(no.RealizedPnL+4) > 1e-6 {
+		t.Fatalf("expected NO settled at $0 for -4, got %+v", no)
+	}
+	snap := a.paperSim.Snapshot()
+	if math.Abs(snap.BalanceUSDC-1000) > 1e-6 {
+		t.Fatalf("expected balance back to 1000 after $10 payout, got %f", snap.BalanceUSDC)
+	}
+	if len(snap.InventoryByAsset) != 0 {
+		t.Fatalf("expected no paper inventory after settlement, got %v", snap.InventoryByAsset)
+	}
+}
+
+func TestMarketResolutionChargesSettlementFee(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "paper"
+	cfg.Paper.FeeBps = 100
+	cfg.Paper.SlippageBps = 0
+
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	a.books.Update(ws.OrderbookEvent{
+		AssetID: "yes-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.59", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.60", Size: "100"}},
+	})
+	a.placeMarket(context.Background(), "yes-1", "BUY", 6) // 10 YES @ 0.60
+	feesBefore := a.tracker.TotalFees()
+
+	a.handleMarketResolution(context.Background(), ws.MarketResolvedEvent{
+		Market:         "m-1",
+		AssetIDs:       []string{"yes-1"},
+		WinningOutcome: "yes-1",
+	})
+
+	// 1% of the $10 redemption.
+	if got := a.tracker.TotalFees() - feesBefore; math.Abs(got-0.1) > 1e-6 {
+		t.Fatalf("expected the 0.10 settlement fee in tracker fees, got %f", got)
+	}
+	if last := a.tracker.RecentFills(1)[0]; math.Abs(last.Fee-0.1) > 1e-6 {
+		t.Fatalf("expected the settlement fill to carry its fee, got %+v", last)
+	}
+}
+
+func TestMarketResolutionUnknownOutcomeLeavesPaperInventory(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "paper"
+
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	a.books.Update(ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.51", Size: "100"}},
+	})
+	a.placeMarket(context.Background(), "asset-1", "BUY", 5)
+
+	a.handleMarketResolution(context.Background(), ws.MarketResolvedEvent{
+		AssetIDs:       []string{"asset-1"},
+		WinningOutcome: "Maybe",
+	})
+	if pos := a.tracker.Position("asset-1"); pos == nil || pos.NetSize == 0 {
+		t.Fatalf("expected position left open for unmapped outcome, got %+v", pos)
+	}
+}
+
+func TestPaperStatePersistsAcrossRestart(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "paper"
+	cfg.Paper.StateFile = filepath.Join(t.TempDir(), "paper.json")
+
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	a.books.Update(ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.51", Size: "100"}},
+	})
+	a.placeMarket(context.Background(), "asset-1", "BUY", 10)
+	a.Shutdown(context.Background())
+	want := a.PaperSnapshot()
+
+	restarted := New(cfg, nil, nil, nil, nil, nil, nil)
+	got := restarted.PaperSnapshot()
+	if got.BalanceUSDC != want.BalanceUSDC || got.TotalTrades != 1 {
+		t.Fatalf("expected restored balance %f with 1 trade, got %+v", want.BalanceUSDC, got)
+	}
+	if got.InventoryByAsset["asset-1"] != want.InventoryByAsset["asset-1"] {
+		t.Fatalf("expected restored inventory, got %v", got.InventoryByAsset)
+	}
+	entry := a.tracker.Position("asset-1").AvgEntryPrice
+	pos := restarted.tracker.Position("asset-1")
+	if pos == nil || math.Abs(pos.NetSize-want.InventoryByAsset["asset-1"]) > 1e-9 || math.Abs(pos.AvgEntryPrice-entry) > 1e-9 {
+		t.Fatalf("expected the tracker position rebuilt at %f, got %+v", entry, pos)
+	}
+}
+
+// mockCLOB records cancel and order calls; any other method panics via the
+// nil embed.
+type mockCLOB struct {
+	clob.Client
+	cancelAllCalls int
+	cancelled      []string
+	openOrders     []clobtypes.OrderResponse
+	openOrdersErr  error
+	feeRateErrs    []error // returned in order before FeeRate succeeds
+	feeRate        string  // FeeRate response, "20" when empty
+	feeRateCalls   int
+	idPrefix       string // prefixes the IDs of created orders
+	created        []string
+}
+
+func (m *mockCLOB) CreateOrderFromSignable(_ context.Context, _ *clobtypes.SignableOrder) (clobtypes.OrderResponse, error) {
+	id := fmt.Sprintf("%s-%d", m.idPrefix, len(m.created)+1)
+	m.created = append(m.created, id)
+	return clobtypes.OrderResponse{ID: id, Status: "LIVE"}, nil
+}
+
+func (m *mockCLOB) TickSize(_ context.Context, _ *clobtypes.TickSizeRequest) (clobtypes.TickSizeResponse, error) {
+	return clobtypes.TickSizeResponse{MinimumTickSize: 0.01}, nil
+}
+
+// OrderBook is the book market orders are priced against.
+func (m *mockCLOB) OrderBook(_ context.Context, _ *clobtypes.BookRequest) (clobtypes.OrderBookResponse, error) {
+	return clobtypes.OrderBookResponse{
+		Bids: []clobtypes.PriceLevel{{Price: "0.50", Size: "1000"}},
+		Asks: []clobtypes.PriceLevel{{Price: "0.52", Size: "1000"}},
+	}, nil
+}
+
+func (m *mockCLOB) FeeRate(_ context.Context, _ *clobtypes.FeeRateRequest) (clobtypes.FeeRateResponse, error) {
+	m.feeRateCalls++
+	if len(m.feeRateErrs) > 0 {
+		err := m.feeRateErrs[0]
+		m.feeRateErrs = m.feeRateErrs[1:]
+		return clobtypes.FeeRateResponse{}, err
+	}
+	if m.feeRate != "" {
+		return clobtypes.FeeRateResponse{FeeRate: m.feeRate}, nil
+	}
+	return clobtypes.FeeRateResponse{FeeRate: "20"}, nil
+}
+
+// httpStatusErr stands in for the SDK's unexported error for a 429 or 5xx
+// it gave up retrying.
+type httpStatusErr int
+
+func (e httpStatusErr) Error() string   { return "http " + strconv.Itoa(int(e)) }
+func (e httpStatusErr) StatusCode() int { return int(e) }
+
+func (m *mockCLOB) CancelOrders(_ context.Context, req *clobtypes.CancelOrdersRequest) (clobtypes.CancelResponse, error) {
+	m.cancelled = append(m.cancelled, req.OrderIDs...)
+	return clobtypes.CancelResponse{Status: "ok"}, nil
+}
+
+func (m *mockCLOB) Orders(_ context.Context, _ *clobtypes.OrdersRequest) (clobtypes.OrdersResponse, error) {
+	return clobtypes.OrdersResponse{Data: m.openOrders, Count: len(m.openOrders)}, m.openOrdersErr
+}
+
+func (m *mockCLOB) OrdersAll(_ context.Context, _ *clobtypes.OrdersRequest) ([]clobtypes.OrderResponse, error) {
+	return m.openOrders, m.openOrdersErr
+}
+
+func (m *mockCLOB) CancelAll(_ context.Context) (clobtypes.CancelAllResponse, error) {
+	m.cancelAllCalls++
+	return clobtypes.CancelAllResponse{Status: "ok"}, nil
+}
+
+func (m *mockCLOB) Heartbeat() heartbeat.Client { return nil }
+
+// addrSigner is a signer with a fixed address, enough for the order builder
+// to build orders; signing itself is never reached in these tests.
+type addrSigner struct {
+	auth.Signer
+	addr common.Address
+}
+
+func (s addrSigner) Address() common.Address { return s.addr }
+func (s addrSigner) ChainID() *big.Int       { return big.NewInt(137) }
+
+type stubHeartbeat struct{ err error }
+
+func (h *stubHeartbeat) Heartbeat(context.Context, *heartbeat.HeartbeatRequest) (heartbeat.HeartbeatResponse, error) {
+	return heartbeat.HeartbeatResponse{}, h.err
+}
+
+func TestHeartbeatStatusRecordsLastResult(t *testing.T) {
+	a := New(testConfig(), nil, nil, nil, nil, nil, nil)
+	if last, _ := a.HeartbeatStatus(); !last.IsZero() {
+		t.Fatalf("expected no heartbeat before the first send, got %v", last)
+	}
+
+	sent := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	a.SetClock(&fixedClock{t: sent})
+	hb := &stubHeartbeat{err: errors.New("connection reset")}
+	a.heartbeatClient = hb
+	a.sendHeartbeat(context.Background())
+
+	last, err := a.HeartbeatStatus()
+	if !last.Equal(sent) {
+		t.Fatalf("expected last heartbeat %v, got %v", sent, last)
+	}
+	if err == nil {
+		t.Fatal("expected the failed heartbeat to be reported")
+	}
+
+	hb.err = nil
+	a.sendHeartbeat(context.Background())
+	if _, err := a.HeartbeatStatus(); err != nil {
+		t.Fatalf("expected a successful heartbeat to clear the error, got %v", err)
+	}
+}
+
+func TestShutdownPreservesOrdersForRestart(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "live"
+	cfg.PreserveOrdersOnShutdown = true
+	cfg.OrderStateFile = filepath.Join(t.TempDir(), "orders.json")
+
+	client := &mockCLOB{}
+	a := New(cfg, client, nil, nil, nil, nil, nil)
+	a.tracker.RegisterOrder("order-1", "asset-1", "market-1", "BUY", 0.50, 10)
+	a.tracker.ProcessTradeEvent(ws.TradeEvent{ID: "trade-1", AssetID: "asset-1", Side: "BUY", Price: "0.50", Size: "4"})
+	a.Shutdown(context.Background())
+	if client.cancelAllCalls != 0 {
+		t.Fatalf("expected no CancelAll with preserve_orders_on_shutdown, got %d calls", client.cancelAllCalls)
+	}
+
+	restarted := New(cfg, &mockCLOB{}, nil, nil, nil, nil, nil)
+	if got := restarted.activeOrders["asset-1"]; len(got) != 1 || got[0] != "order-1" {
+		t.Fatalf("expected order-1 re-adopted, got %v", got)
+	}
+	if pos := restarted.tracker.Position("asset-1"); pos == nil || pos.NetSize != 4 {
+		t.Fatalf("expected restored position of 4, got %+v", pos)
+	}
+
+	cfg.PreserveOrdersOnShutdown = false
+	client = &mockCLOB{}
+	New(cfg, client, nil, nil, nil, nil, nil).Shutdown(context.Background())
+	if client.cancelAllCalls != 1 {
+		t.Fatalf("expected CancelAll on a full stop, got %d calls", client.cancelAllCalls)
+	}
+}
+
+func TestReconcileOnStartSeedsTrackerFromOpenOrders(t *testing.T) {
+	newClient := func() *mockCLOB {
+		return &mockCLOB{openOrders: []clobtypes.OrderResponse{
+			{ID: "known", AssetID: "asset-1", Side: "BUY", Price: "0.40", OriginalSize: "10", SizeMatched: "3", Status: "LIVE"},
+			{ID: "orphan", AssetID: "asset-2", Side: "SELL", Price: "0.70", OriginalSize: "5", SizeMatched: "0", Status: "LIVE"},
+		}}
+	}
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "live"
+
+	client := newClient()
+	a := New(cfg, client, nil, nil, nil, nil, nil)
+	a.tracker.RegisterOrder("known", "asset-1", "market-1", "BUY", 0.40, 10)
+	a.tracker.RegisterOrder("gone", "asset-1", "market-1", "SELL", 0.60, 10)
+	a.activeOrders["asset-1"] = []string{"known", "gone"}
+	a.ReconcileOnStart(context.Background())
+
+	if len(client.cancelled) != 1 || client.cancelled[0] != "orphan" {
+		t.Fatalf("expected only the unknown order cancelled, got %v", client.cancelled)
+	}
+	if o, ok := a.tracker.Order("known"); !ok || o.Status != "LIVE" || o.FilledSize != 3 {
+		t.Fatalf("expected known order live with fills applied, got %+v", o)
+	}
+	if o, _ := a.tracker.Order("gone"); o.Status != "CANCELED" {
+		t.Fatalf("expected order missing from exchange marked cancelled, got %+v", o)
+	}
+	if got := a.activeOrders["asset-1"]; len(got) != 1 || got[0] != "known" {
+		t.Fatalf("expected only known order active, got %v", got)
+	}
+
+	cfg.OrphanOrders = "adopt"
+	client = newClient()
+	a = New(cfg, client, nil, nil, nil, nil, nil)
+	a.ReconcileOnStart(context.Background())
+	if len(client.cancelled) != 0 {
+		t.Fatalf("expected no cancels when adopting, got %v", client.cancelled)
+	}
+	if a.tracker.OpenOrderCount() != 2 {
+		t.Fatalf("expected both exchange orders adopted, got %d", a.tracker.OpenOrderCount())
+	}
+	if o, _ := a.tracker.Order("orphan"); o.AssetID != "asset-2" || o.Price != 0.70 || o.OrigSize != 5 {
+		t.Fatalf("expected adopted order details, got %+v", o)
+	}
+	if got := a.activeOrders["asset-2"]; len(got) != 1 || got[0] != "orphan" {
+		t.Fatalf("expected adopted order handed to the maker, got %v", got)
+	}
+}
+
+type fixedClock struct{ t time.Time }
+
+func (c *fixedClock) Now() time.Time { return c.t }
+
+func TestOrderLimiterDropsCallsOverRate(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "live"
+	cfg.MaxOrdersPerSecond = 2
+
+	client := &mockCLOB{}
+	a := New(cfg, client, nil, nil, nil, nil, nil)
+	clock := &fixedClock{t: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	a.SetClock(clock)
+	for _, assetID := range []string{"asset-1", "asset-2", "asset-3"} {
+		a.activeOrders[assetID] = []string{assetID + "-order"}
+		a.unwindPosition(context.Background(), a.primaryAccount(), assetID, execution.Position{})
+	}
+
+	if len(client.cancelled) != 2 {
+		t.Fatalf("expected 2 cancels within the burst, got %v", client.cancelled)
+	}
+	if len(a.activeOrders["asset-3"]) != 1 {
+		t.Fatalf("expected throttled asset to keep its orders for retry, got %v", a.activeOrders)
+	}
+	if got := a.KPIStats()["throttled_order_calls"]; got != int64(1) {
+		t.Fatalf("expected 1 throttled call, got %v", got)
+	}
+
+	clock.t = clock.t.Add(500 * time.Millisecond)
+	a.unwindPosition(context.Background(), a.primaryAccount(), "asset-3", execution.Position{})
+	if len(client.cancelled) != 3 || len(a.activeOrders["asset-3"]) != 0 {
+		t.Fatalf("expected deferred cancel once the bucket refilled, got %v", client.cancelled)
+	}
+}
+
+func TestFetchFeeRatesRetriesTransientErrors(t *testing.T) {
+	cfg := testConfig()
+	cfg.HTTPRetries = 3
+	cfg.HTTPRetryBackoff = time.Millisecond
+
+	client := &mockCLOB{feeRateErrs: []error{errors.New("connection reset"), errors.New("failed to unmarshal response")}}
+	a := New(cfg, client, nil, nil, nil, nil, nil)
+	a.fetchFeeRates(context.Background(), []string{"asset-1"})
+	if client.feeRateCalls != 3 || a.feeRates["asset-1"] != 20 {
+		t.Fatalf("expected success on third attempt, got calls=%d rates=%v", client.feeRateCalls, a.feeRates)
+	}
+
+	client = &mockCLOB{feeRateErrs: []error{fmt.Errorf("fee rate: %w", &types.Error{Status: 404, Message: "market not found"})}}
+	a = New(cfg, client, nil, nil, nil, nil, nil)
+	a.fetchFeeRates(context.Background(), []string{"asset-1"})
+	if client.feeRateCalls != 1 || len(a.feeRates) != 0 {
+		t.Fatalf("expected no retry on 4xx, got calls=%d rates=%v", client.feeRateCalls, a.feeRates)
+	}
+
+	// The SDK has already retried a 429 or 5xx before returning it.
+	for _, code := range []int{429, 503} {
+		client = &mockCLOB{feeRateErrs: []error{httpStatusErr(code)}}
+		a = New(cfg, client, nil, nil, nil, nil, nil)
+		a.fetchFeeRates(context.Background(), []string{"asset-1"})
+		if client.feeRateCalls != 1 {
+			t.Fatalf("expected no second retry loop on %d, got calls=%d", code, client.feeRateCalls)
+		}
+	}
+}
+
+// flakyOrdersCLOB fails OrdersAll with errs, in order, before listing the
+// open orders.
+type flakyOrdersCLOB struct {
+	mockCLOB
+	errs  []error
+	calls int
+}
+
+func (m *flakyOrdersCLOB) OrdersAll(ctx context.Context, req *clobtypes.OrdersRequest) ([]clobtypes.OrderResponse, error) {
+	m.calls++
+	if len(m.errs) > 0 {
+		err := m.errs[0]
+		m.errs = m.errs[1:]
+		return nil, err
+	}
+	return m.mockCLOB.OrdersAll(ctx, req)
+}
+
+func TestReconcileOrdersRetriesTransientErrors(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "live"
+	cfg.HTTPRetries = 2
+	cfg.HTTPRetryBackoff = time.Millisecond
+
+	client := &flakyOrdersCLOB{
+		mockCLOB: mockCLOB{openOrders: []clobtypes.OrderResponse{
+			{ID: "orphan", AssetID: "asset-2", Side: "SELL", Price: "0.70", OriginalSize: "5", SizeMatched: "0", Status: "LIVE"},
+		}},
+		errs: []error{errors.New("connection reset")},
+	}
+	a := New(cfg, client, nil, nil, nil, nil, nil)
+	a.reconcileOrders(context.Background())
+	if client.calls != 2 || len(client.cancelled) != 1 {
+		t.Fatalf("expected the listing retried and the orphan cancelled, got calls=%d cancelled=%v", client.calls, client.cancelled)
+	}
+}
+
+func TestDefaultFeeWidensQuoteWhenFeeRateUnavailable(t *testing.T) {
+	cfg := testConfig()
+	cfg.DefaultFeeBps = 500
+	client := &mockCLOB{feeRateErrs: []error{&types.Error{Status: 404, Message: "market not found"}}}
+	a := New(cfg, client, nil, nil, nil, nil, nil)
+	a.fetchFeeRates(context.Background(), []string{"asset-1"})
+	if len(a.feeRates) != 0 {
+		t.Fatalf("expected no fetched rate, got %v", a.feeRates)
+	}
+	if got := a.feeRate("asset-1"); got != 500 {
+		t.Fatalf("expected default fee 500 bps, got %f", got)
+	}
+
+	preview, err := a.SimulateQuote("asset-1",
+		[]feed.Level{{Price: 0.50, Size: 100}},
+		[]feed.Level{{Price: 0.52, Size: 100}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !preview.FeeAdjusted || preview.FeeRateBps != 500 {
+		t.Fatalf("expected the default fee to adjust the quote, got %+v", preview)
+	}
+	spread := preview.Quote.SellPrice - preview.Quote.BuyPrice
+	rawSpread := preview.RawQuote.SellPrice - preview.RawQuote.BuyPrice
+	if spread <= rawSpread {
+		t.Fatalf("expected spread widened past %.4f, got %.4f", rawSpread, spread)
+	}
+}
+
+func TestFeeOverrideTakesPrecedence(t *testing.T) {
+	cfg := testConfig()
+	cfg.DefaultFeeBps = 10
+	cfg.FeeOverridesBps = map[string]float64{"asset-1": 0}
+	a := New(cfg, &mockCLOB{}, nil, nil, nil, nil, nil)
+	a.fetchFeeRates(context.Background(), []string{"asset-1", "asset-2"})
+	if got := a.feeRate("asset-1"); got != 0 {
+		t.Fatalf("expected override 0 bps over the fetched rate, got %f", got)
+	}
+	if got := a.feeRate("asset-2"); got != 20 {
+		t.Fatalf("expected fetched rate 20 bps, got %f", got)
+	}
+	if got := a.feeRate("asset-3"); got != 10 {
+		t.Fatalf("expected default 10 bps for an unfetched asset, got %f", got)
+	}
+}
+
+func TestFeeRateRefreshPicksUpChangedRate(t *testing.T) {
+	client := &mockCLOB{}
+	a := New(testConfig(), client, nil, nil, nil, nil, nil)
+	a.fetchFeeRates(context.Background(), []string{"asset-1", "asset-2"})
+	if a.feeRates["asset-1"] != 20 {
+		t.Fatalf("expected initial rate 20, got %v", a.feeRates)
+	}
+
+	client.feeRate = "35"
+	a.fetchFeeRates(context.Background(), []string{"asset-1", "asset-2"})
+	if a.feeRates["asset-1"] != 35 || a.feeRates["asset-2"] != 35 {
+		t.Fatalf("expected refreshed rate 35, got %v", a.feeRates)
+	}
+
+	// A failed query leaves the cached rate in place.
+	client.feeRateErrs = []error{&types.Error{Status: 404, Message: "market not found"}}
+	client.feeRate = "50"
+	a.fetchFeeRates(context.Background(), []string{"asset-1", "asset-2"})
+	if a.feeRates["asset-1"] != 35 || a.feeRates["asset-2"] != 50 {
+		t.Fatalf("expected asset-1 to keep its rate after a failure, got %v", a.feeRates)
+	}
+}
+
+func TestReplayYieldsDeterministicFills(t *testing.T) {
+	books := []struct {
+		ts       string
+		bid, ask string
+	}{
+		{"1700000000000", "0.50", "0.54"},
+		{"1700000001000", "0.50", "0.51"},
+		{"1700000002000", "0.53", "0.56"},
+		{"1700000010000", "0.49", "0.52"},
+		{"1700000011000", "0.54", "0.57"},
+	}
+	events := make([]ws.OrderbookEvent, 0, len(books))
+	for _, b := range books {
+		events = append(events, ws.OrderbookEvent{
+			AssetID:   "asset-1",
+			Timestamp: b.ts,
+			Bids:      []ws.OrderbookLevel{{Price: b.bid, Size: "100"}},
+			Asks:      []ws.OrderbookLevel{{Price: b.ask, Size: "100"}},
+		})
+	}
+
+	run := func() ([]execution.Fill, paper.Snapshot) {
+		cfg := testConfig()
+		cfg.DryRun = false
+		cfg.TradingMode = "paper"
+		cfg.Taker.Enabled = false
+		a := New(cfg, nil, nil, nil, nil, nil, nil)
+		replayer := feed.NewReplayer(events, 0)
+		if err := a.Replay(context.Background(), replayer.Events(context.Background())); err != nil {
+			t.Fatalf("replay: %v", err)
+		}
+		return a.RecentFills(100), a.PaperSnapshot()
+	}
+
+	fills, snap := run()
+	if len(fills) == 0 {
+		t.Fatal("expected replay to produce paper fills")
+	}
+	start := time.UnixMilli(1700000000000)
+	for _, f := range fills {
+		if f.Timestamp.Before(start) || f.Timestamp.After(start.Add(11*time.Second)) {
+			t.Fatalf("expected fill stamped with replayed time, got %v", f.Timestamp)
+		}
+	}
+
+	again, againSnap := run()
+	if len(again) != len(fills) {
+		t.Fatalf("expected %d fills on second replay, got %d", len(fills), len(again))
+	}
+	for i := range fills {
+		a, b := fills[i], again[i]
+		if a.TradeID != b.TradeID || a.Side != b.Side || a.Price != b.Price || a.Size != b.Size || !a.Timestamp.Equal(b.Timestamp) {
+			t.Fatalf("fill %d differs between replays: %+v vs %+v", i, a, b)
+		}
+	}
+	if snap.BalanceUSDC != againSnap.BalanceUSDC || snap.TotalTrades != againSnap.TotalTrades {
+		t.Fatalf("expected identical paper accounts, got %+v vs %+v", snap, againSnap)
+	}
+}
+
+func TestConvergenceArbSizedToProfitableDepth(t *testing.T) {
+	run := func(askSize string) float64 {
+		cfg := testConfig()
+		cfg.DryRun = false
+		cfg.TradingMode = "paper"
+		cfg.Paper.FeeBps = 0
+		cfg.Paper.SlippageBps = 0
+		cfg.Taker.AmountUSDC = 10
+		cfg.Risk.MaxPositionPerMarket = 100
+		cfg.Risk.MaxGrossExposureUSDC = 0
+
+		a := New(cfg, nil, nil, nil, nil, nil, nil)
+		a.tokenPairs["yes-1"] = "no-1"
+		a.tokenPairs["no-1"] = "yes-1"
+		a.books.Update(ws.OrderbookEvent{
+			AssetID: "no-1",
+			Bids:    []ws.OrderbookLevel{{Price: "0.45", Size: "100"}},
+			Asks:    []ws.OrderbookLevel{{Price: "0.50", Size: askSize}, {Price: "0.60", Size: "100"}},
+		})
+		yes := ws.OrderbookEvent{
+			AssetID: "yes-1",
+			Bids:    []ws.OrderbookLevel{{Price: "0.40", Size: "100"}},
+			Asks:    []ws.OrderbookLevel{{Price: "0.45", Size: askSize}, {Price: "0.60", Size: "100"}},
+		}
+		a.books.Update(yes)
+		a.checkConvergenceArbitrage(context.Background(), yes)
+		return a.PaperSnapshot().TotalVolumeUSDC
+	}
+
+	// Two pairs at 0.45+0.50 are profitable; the 0.60 levels are not.
+	if got := run("2"); math.Abs(got-1.9) > 1e-6 {
+		t.Fatalf("expected shallow book to cap arb at 1.90 USDC, got %f", got)
+	}
+	if got := run("100"); math.Abs(got-10) > 1e-6 {
+		t.Fatalf("expected deep book to allow the full 10 USDC, got %f", got)
+	}
+	if got := run("0.4"); got != 0 {
+		t.Fatalf("expected arb below min_arb_size_usdc to be skipped, got %f", got)
+	}
+}
+
+func TestMaxOrdersPerMarketSkipsMarketAtCap(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "live"
+	cfg.Taker.Enabled = false
+	cfg.Maker.MaxOrdersPerMarket = 2
+	cfg.Risk.MaxPositionPerMarket = 50
+
+	clobClient := &mockCLOB{}
+	a := New(cfg, clobClient, nil, addrSigner{}, nil, nil, nil)
+	// Two orders placed outside the maker already fill 1001's slots.
+	a.tracker.RegisterOrder("ext-1", "1001", "", "BUY", 0.40, 5)
+	a.tracker.RegisterOrder("ext-2", "1001", "", "SELL", 0.60, 5)
+
+	book := func(assetID string) ws.OrderbookEvent {
+		return ws.OrderbookEvent{
+			AssetID: assetID,
+			Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+			Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "100"}},
+		}
+	}
+	a.HandleBookEvent(context.Background(), book("1001"))
+	if len(clobClient.created) != 0 {
+		t.Fatalf("expected no quotes on a market at its cap, got %v", clobClient.created)
+	}
+
+	a.HandleBookEvent(context.Background(), book("1002"))
+	if got := len(a.activeOrders["1002"]); got != 2 || len(clobClient.created) != 2 {
+		t.Fatalf("expected 1002 to quote both sides, got %d orders, created %v", got, clobClient.created)
+	}
+
+	// Requoting replaces the maker's own quotes rather than counting them.
+	a.HandleBookEvent(context.Background(), book("1002"))
+	if got := len(a.activeOrders["1002"]); got != 2 {
+		t.Fatalf("expected the requote to replace both quotes, got %d orders", got)
+	}
+
+	// With one slot free only one side is quoted, the one that works a long
+	// position down.
+	a.tracker.ProcessOrderEvent(ws.OrderEvent{ID: "ext-2", Status: "CANCELED"})
+	a.tracker.SeedPosition("1001", 10, 0.5)
+	placed := len(clobClient.created)
+	a.HandleBookEvent(context.Background(), book("1001"))
+	if got := len(clobClient.created) - placed; got != 1 {
+		t.Fatalf("expected one order placed into the last slot, got %d", got)
+	}
+	var sides []string
+	for _, id := range a.activeOrders["1001"] {
+		o, _ := a.tracker.Order(id)
+		sides = append(sides, o.Side)
+	}
+	if !slices.Equal(sides, []string{"SELL"}) {
+		t.Fatalf("expected a lone ask in the last slot, got %v", sides)
+	}
+}
+
+func TestConvergenceArbRespectsMaxOrdersPerMarket(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "paper"
+	cfg.Taker.AmountUSDC = 10
+	cfg.Maker.MaxOrdersPerMarket = 1
+	cfg.Risk.MaxPositionPerMarket = 100
+	cfg.Risk.MaxGrossExposureUSDC = 0
+
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	a.tokenPairs["yes-1"] = "no-1"
+	a.tokenPairs["no-1"] = "yes-1"
+	a.books.Update(ws.OrderbookEvent{
+		AssetID: "no-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.45", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+	})
+	yes := ws.OrderbookEvent{
+		AssetID: "yes-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.40", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.45", Size: "100"}},
+	}
+	a.books.Update(yes)
+	a.tracker.RegisterOrder("resting-no", "no-1", "", "BUY", 0.30, 5)
+
+	a.checkConvergenceArbitrage(context.Background(), yes)
+	if got := a.PaperSnapshot().TotalTrades; got != 0 {
+		t.Fatalf("expected no arb legs while no-1 is at its cap, got %d trades", got)
+	}
+
+	// Once the slot frees up the arb trades both legs.
+	a.tracker.ProcessOrderEvent(ws.OrderEvent{ID: "resting-no", Status: "CANCELED"})
+	a.checkConvergenceArbitrage(context.Background(), yes)
+	if got := a.PaperSnapshot().TotalTrades; got != 2 {
+		t.Fatalf("expected both arb legs placed with the slot free, got %d trades", got)
+	}
+}
+
+func TestFlattenAllClosesLongAndShortPositions(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "paper"
+	cfg.Maker.Enabled = false
+	cfg.Taker.Enabled = false
+	cfg.Paper.InitialBalanceUSDC = 1000
+	cfg.Paper.SlippageBps = 0
+
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	for _, assetID := range []string{"asset-1", "asset-2"} {
+		a.books.Update(ws.OrderbookEvent{
+			AssetID: assetID,
+			Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+			Asks:    []ws.OrderbookLevel{{Price: "0.51", Size: "100"}},
+		})
+	}
+	a.placeMarket(context.Background(), "asset-1", "BUY", 10)
+	a.placeMarket(context.Background(), "asset-2", "SELL", 10)
+	resting := a.placeLimit(context.Background(), "asset-1", "BUY", 0.40, 5)
+	a.activeOrders["asset-1"] = []string{resting.ID}
+	if long, short := a.tracker.Position("asset-1"), a.tracker.Position("asset-2"); long == nil || long.NetSize <= 0 || short == nil || short.NetSize >= 0 {
+		t.Fatalf("expected a long and a short to flatten, got %+v %+v", long, short)
+	}
+	before := a.tracker.TotalFills()
+
+	a.FlattenAll(context.Background())
+
+	closing := make(map[string]string)
+	for _, f := range a.RecentFills(10)[:a.tracker.TotalFills()-before] {
+		closing[f.AssetID] = f.Side
+	}
+	if closing["asset-1"] != "SELL" || closing["asset-2"] != "BUY" {
+		t.Fatalf("expected SELL to close the long and BUY to close the short, got %v", closing)
+	}
+	if len(a.activeOrders) != 0 || a.PaperSnapshot().RestingOrders != 0 {
+		t.Fatalf("expected open orders cancelled, got %v resting=%d", a.activeOrders, a.PaperSnapshot().RestingOrders)
+	}
+}
+
+func TestRiskSyncUnwindsPositionPastMaxHoldingTime(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "paper"
+	cfg.Maker.Enabled = false
+	cfg.Taker.Enabled = false
+	cfg.Paper.SlippageBps = 0
+	cfg.Risk.StopLossPerMarket = 0
+	cfg.Risk.MaxHoldingTime = time.Hour
+
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	clock := &fixedClock{t: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	a.SetClock(clock)
+	a.books.Update(ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.51", Size: "100"}},
+	})
+	a.placeMarket(context.Background(), "asset-1", "BUY", 10)
+	pos := a.tracker.Position("asset-1")
+	if pos == nil || pos.NetSize <= 0 || !pos.OpenedAt.Equal(clock.t) {
+		t.Fatalf("expected a long opened now, got %+v", pos)
+	}
+
+	clock.t = clock.t.Add(59 * time.Minute)
+	a.riskSync(context.Background())
+	if pos := a.tracker.Position("asset-1"); pos.NetSize <= 0 {
+		t.Fatalf("expected the position kept before max_holding_time, got %+v", pos)
+	}
+
+	clock.t = clock.t.Add(2 * time.Minute)
+	a.riskSync(context.Background())
+	pos = a.tracker.Position("asset-1")
+	if math.Abs(pos.NetSize) > 1e-9 || !pos.OpenedAt.IsZero() {
+		t.Fatalf("expected the aged position flattened, got %+v", pos)
+	}
+}
+
+func TestFlattenAllDryRunClosesAgainstSimulator(t *testing.T) {
+	cfg := testConfig()
+	cfg.TradingMode = "live"
+	cfg.Maker.Enabled = false
+	cfg.Taker.Enabled = false
+	cfg.Paper.SlippageBps = 0
+	clob := &mockCLOB{}
+	a := New(cfg, clob, nil, nil, nil, nil, nil)
+	a.books.Update(ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.51", Size: "100"}},
+	})
+	a.placeMarket(context.Background(), "asset-1", "BUY", 10)
+	resting := a.placeLimit(context.Background(), "asset-1", "BUY", 0.40, 5)
+	a.activeOrders["asset-1"] = []string{resting.ID}
+
+	a.FlattenAll(context.Background())
+
+	if pos := a.tracker.Position("asset-1"); pos == nil || math.Abs(pos.NetSize) > 1e-9 {
+		t.Fatalf("expected dry-run to close the simulated position, got %+v", pos)
+	}
+	if a.PaperSnapshot().RestingOrders != 0 {
+		t.Fatalf("expected the simulated resting order cancelled, got %d", a.PaperSnapshot().RestingOrders)
+	}
+	if len(clob.created) != 0 || len(clob.cancelled) != 0 {
+		t.Fatalf("expected nothing sent to the exchange in dry-run, got created=%v cancelled=%v", clob.created, clob.cancelled)
+	}
+}
+
+func TestFlattenAllPausesStrategiesUntilWindowClose(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "paper"
+	cfg.Taker.MinImbalance = 0.10
+	cfg.FlattenTime = "21:00"
+
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	clock := &fixedClock{t: time.Date(2026, 1, 1, 21, 0, 0, 0, time.UTC)}
+	a.SetClock(clock)
+	event := ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "300"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "50"}},
+	}
+	ctx := context.Background()
+
+	a.FlattenAll(ctx)
+	a.HandleBookEvent(ctx, event)
+	if got := len(a.activeOrders["asset-1"]); got != 0 || a.tracker.TotalFills() != 0 {
+		t.Fatalf("expected no quotes or taker fills while paused, got quotes=%d fills=%d", got, a.tracker.TotalFills())
+	}
+
+	// The UTC midnight session close ends the pause.
+	clock.t = time.Date(2026, 1, 2, 0, 0, 1, 0, time.UTC)
+	a.HandleBookEvent(ctx, event)
+	if got := len(a.activeOrders["asset-1"]); got == 0 || a.tracker.TotalFills() == 0 {
+		t.Fatalf("expected quotes and a taker fill once the window closed, got quotes=%d fills=%d", got, a.tracker.TotalFills())
+	}
+
+	// An operator turning a strategy back on lifts it early.
+	a.FlattenAll(ctx)
+	if !a.flattenPaused(a.now()) {
+		t.Fatal("expected the second flatten to pause the strategies")
+	}
+	on := true
+	if _, _, err := a.SetStrategiesEnabled(&on, nil); err != nil {
+		t.Fatal(err)
+	}
+	a.HandleBookEvent(ctx, event)
+	if got := len(a.activeOrders["asset-1"]); got == 0 {
+		t.Fatal("expected quotes once the operator cleared the pause")
+	}
+}
+
+func TestUntilFlatten(t *testing.T) {
+	now := time.Date(2026, 1, 1, 20, 30, 0, 0, time.UTC)
+	if got := untilFlatten(now, "21:00"); got != 30*time.Minute {
+		t.Fatalf("expected 30m to 21:00, got %s", got)
+	}
+	if got := untilFlatten(now, "20:00"); got != 23*time.Hour+30*time.Minute {
+		t.Fatalf("expected next day's 20:00, got %s", got)
+	}
+	if got := untilFlatten(now, ""); got != 3*time.Hour+30*time.Minute {
+		t.Fatalf("expected midnight session close, got %s", got)
+	}
+}
+
+func TestRiskStateChangeFiresOncePerTransition(t *testing.T) {
+	cfg := testConfig()
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	n := &mockNotifier{}
+	a.notifier = n
+	ctx := context.Background()
+
+	a.riskSync(ctx)
+	if len(n.riskChanges) != 0 {
+		t.Fatalf("expected first sync to set the baseline only, got %+v", n.riskChanges)
+	}
+
+	a.SetEmergencyStop(true)
+	a.riskSync(ctx)
+	a.riskSync(ctx)
+	if len(n.riskChanges) != 1 {
+		t.Fatalf("expected exactly one change for tradable → blocked, got %+v", n.riskChanges)
+	}
+	got := n.riskChanges[0]
+	if !got.OldCanTrade || got.NewCanTrade {
+		t.Fatalf("expected can_trade true → false, got %+v", got)
+	}
+	if len(got.Transitions) != 2 || got.Transitions[0] != notify.TransitionBecameBlocked || got.Transitions[1] != notify.TransitionEmergencyStopOn {
+		t.Fatalf("unexpected transitions: %v", got.Transitions)
+	}
+	if len(got.BlockedReasons) != 1 || got.BlockedReasons[0] != "emergency_stop" || len(got.OldBlockedReasons) != 0 {
+		t.Fatalf("unexpected blocked reasons: old=%v new=%v", got.OldBlockedReasons, got.BlockedReasons)
+	}
+
+	a.SetEmergencyStop(false)
+	a.riskSync(ctx)
+	if len(n.riskChanges) != 2 || n.riskChanges[1].Transitions[0] != notify.TransitionBecameTradable || !n.riskChanges[1].NewCanTrade {
+		t.Fatalf("expected a became_tradable change, got %+v", n.riskChanges)
+	}
+}
+
+func TestIdleAlertFiresOnceUntilNextFill(t *testing.T) {
+	cfg := testConfig()
+	cfg.Notify.IdleAlertAfter = time.Hour
+
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	n := &mockNotifier{}
+	a.notifier = n
+	clock := &fixedClock{t: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	a.SetClock(clock)
+	a.books.Update(ws.OrderbookEvent{AssetID: "asset-1"})
+	ctx := context.Background()
+
+	a.checkIdle(ctx)
+	clock.t = clock.t.Add(59 * time.Minute)
+	a.checkIdle(ctx)
+	if len(n.alerts) != 0 {
+		t.Fatalf("expected no alert before the threshold, got %v", n.alerts)
+	}
+
+	clock.t = clock.t.Add(2 * time.Minute)
+	a.checkIdle(ctx)
+	clock.t = clock.t.Add(time.Hour)
+	a.checkIdle(ctx)
+	if len(n.alerts) != 1 || n.alerts[0] != "No Fills" {
+		t.Fatalf("expected a single idle alert, got %v", n.alerts)
+	}
+	if text := n.alertTexts[0]; !strings.Contains(text, "1h1m0s") || !strings.Contains(text, "asset-1") {
+		t.Fatalf("expected the idle time and monitored assets in the alert, got %q", text)
+	}
+
+	// A fill restarts the idle clock and re-arms the alert.
+	a.markFill("asset-1", clock.t)
+	clock.t = clock.t.Add(30 * time.Minute)
+	a.checkIdle(ctx)
+	if len(n.alerts) != 1 {
+		t.Fatalf("expected the fill to reset the idle clock, got %v", n.alerts)
+	}
+	clock.t = clock.t.Add(31 * time.Minute)
+	a.checkIdle(ctx)
+	if len(n.alerts) != 2 {
+		t.Fatalf("expected a new idle alert after the fill, got %v", n.alerts)
+	}
+}
+
+func TestPlacementBreakerTripsOnFailureStreak(t *testing.T) {
+	cfg := testConfig()
+	cfg.TradingMode = "live"
+	cfg.MaxPlacementFailures = 3
+	cfg.PlacementFailureWindow = time.Minute
+	cfg.PlacementBreakerCooldown = 5 * time.Minute
+
+	a := New(cfg, &mockCLOB{}, nil, nil, nil, nil, nil)
+	n := &mockNotifier{}
+	a.notifier = n
+	clock := &fixedClock{t: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	a.SetClock(clock)
+	ctx := context.Background()
+	rejected := errors.New("not enough balance")
+	primary := a.primaryAccount()
+
+	// A success in the middle resets the streak.
+	a.recordPlacement(ctx, primary, rejected)
+	a.recordPlacement(ctx, primary, rejected)
+	a.recordPlacement(ctx, primary, nil)
+	a.recordPlacement(ctx, primary, rejected)
+	a.recordPlacement(ctx, primary, rejected)
+	if !a.breaker.Allow(clock.t) {
+		t.Fatal("expected breaker closed after the streak was reset")
+	}
+
+	// Failures older than the window drop out of the streak.
+	clock.t = clock.t.Add(2 * time.Minute)
+	a.recordPlacement(ctx, primary, rejected)
+	a.recordPlacement(ctx, primary, rejected)
+	if !a.breaker.Allow(clock.t) {
+		t.Fatal("expected stale failures not to count toward the streak")
+	}
+
+	a.recordPlacement(ctx, primary, rejected)
+	if a.breaker.Allow(clock.t) {
+		t.Fatal("expected breaker open after 3 consecutive failures")
+	}
+	if len(n.alerts) != 1 {
+		t.Fatalf("expected one breaker alert, got %v", n.alerts)
+	}
+	if resp := a.placeLimit(ctx, "asset-1", "BUY", 0.5, 10); resp.ID != "" {
+		t.Fatalf("expected placement refused while open, got %+v", resp)
+	}
+	if got := a.KPIStats()["placement_breaker_trips"]; got != int64(1) {
+		t.Fatalf("expected 1 breaker trip, got %v", got)
+	}
+
+	clock.t = clock.t.Add(5 * time.Minute)
+	if !a.breaker.Allow(clock.t) {
+		t.Fatal("expected breaker to close after the cooldown")
+	}
+}
+
+func TestPlacementBreakerSparesClosesAndOtherAccount(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "live"
+	cfg.Maker.Enabled = false
+	cfg.Taker.Enabled = false
+	cfg.MaxPlacementFailures = 1
+	cfg.PlacementFailureWindow = time.Minute
+	cfg.PlacementBreakerCooldown = 5 * time.Minute
+
+	primary := &mockCLOB{idPrefix: "maker"}
+	takerClient := &mockCLOB{idPrefix: "taker"}
+	a := New(cfg, primary, nil, addrSigner{}, nil, nil, nil)
+	a.SetTakerAccount(takerClient, nil, addrSigner{addr: common.Address{19: 0xbb}})
+	a.books.Update(ws.OrderbookEvent{
+		AssetID: "1001",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "100"}},
+	})
+	a.tracker.SeedPosition("1001", 10, 0.5)
+	ctx := context.Background()
+
+	a.recordPlacement(ctx, a.primaryAccount(), errors.New("not enough balance"))
+	if resp := a.placeLimit(ctx, "1001", "BUY", 0.5, 10); resp.ID != "" {
+		t.Fatalf("expected new risk refused while the primary breaker is open, got %+v", resp)
+	}
+
+	// A stop-loss or flatten close still goes out through the open breaker.
+	a.unwindPosition(ctx, a.primaryAccount(), "1001", *a.tracker.Position("1001"))
+	if len(primary.created) != 1 {
+		t.Fatalf("expected the reduce-only close placed despite the open breaker, got %v", primary.created)
+	}
+
+	// The taker account's breaker is its own.
+	if resp := a.placeMarketAs(ctx, a.takerAcct, "1001", "BUY", 5, false); resp.ID == "" {
+		t.Fatal("expected the taker account unaffected by the primary breaker")
+	}
+	if got := a.KPIStats()["placement_breaker_trips"]; got != int64(1) {
+		t.Fatalf("expected 1 breaker trip, got %v", got)
+	}
+}
+
+func TestTakerOrderForMarketableLimit(t *testing.T) {
+	sig := &strategy.Signal{AssetID: "asset-1", Side: "BUY", AmountUSDC: 5, MaxPrice: 0.52}
+	cases := []struct {
+		name      string
+		useLimit  bool
+		limitType string
+		want      takerOrder
+	}{
+		{"market by default", false, "", takerOrder{Type: clobtypes.OrderTypeFAK}},
+		{"FOK when type unset", true, "", takerOrder{Type: clobtypes.OrderTypeFOK, Price: 0.52}},
+		{"GTC", true, "gtc", takerOrder{Type: clobtypes.OrderTypeGTC, Price: 0.52}},
+	}
+	for _, tc := range cases {
+		cfg := config.Default().Taker
+		cfg.UseMarketableLimit = tc.useLimit
+		cfg.MarketableLimitType = tc.limitType
+		if got := takerOrderFor(cfg, sig); got != tc.want {
+			t.Errorf("%s: expected %+v, got %+v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestLiveMarketableLimitBuildsInShares(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "live"
+	client := &mockCLOB{}
+	a := New(cfg, client, nil, addrSigner{}, nil, nil, nil)
+
+	resp := a.placeMarketableLimit(context.Background(), a.takerAccount(), "1001", "BUY", 0.53, 5, clobtypes.OrderTypeFOK)
+	if resp.ID == "" || len(client.created) != 1 {
+		t.Fatalf("expected the marketable limit placed, got %+v created=%v", resp, client.created)
+	}
+}
+
+func TestLiveMakerQuotesBuildOnTickInShares(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "live"
+	cfg.Taker.Enabled = false
+	cfg.Risk.MaxPositionPerMarket = 50
+	client := &mockCLOB{}
+	a := New(cfg, client, nil, addrSigner{}, nil, nil, nil)
+
+	// A mid of 0.505 quotes off the 0.01 grid unless snapped.
+	a.HandleBookEvent(context.Background(), ws.OrderbookEvent{
+		AssetID: "1001",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.51", Size: "100"}},
+	})
+	if len(client.created) != 2 {
+		t.Fatalf("expected both quotes placed, got %v", client.created)
+	}
+	for _, id := range client.created {
+		o, _ := a.tracker.Order(id)
+		if ticks := o.Price / strategy.DefaultTickSize; math.Abs(ticks-math.Round(ticks)) > 1e-9 {
+			t.Fatalf("expected %s on the tick grid, got %.4f", id, o.Price)
+		}
+	}
+}
+
+func TestPaperMarketableLimitFOKKillsUnfilledOrder(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "paper"
+	cfg.Paper.InitialBalanceUSDC = 1000
+	cfg.Paper.SlippageBps = 0
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	a.books.Update(ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.54", Size: "100"}},
+	})
+	ctx := context.Background()
+
+	if resp := a.placeMarketableLimit(ctx, a.takerAccount(), "asset-1", "BUY", 0.52, 5, clobtypes.OrderTypeFOK); resp.ID != "" {
+		t.Fatalf("expected FOK below the ask to be killed, got %+v", resp)
+	}
+	if got := a.PaperSnapshot().RestingOrders; got != 0 {
+		t.Fatalf("expected no resting FOK remainder, got %d", got)
+	}
+
+	resp := a.placeMarketableLimit(ctx, a.takerAccount(), "asset-1", "BUY", 0.55, 5, clobtypes.OrderTypeFOK)
+	if resp.ID == "" || resp.Status != "MATCHED" {
+		t.Fatalf("expected FOK through the ask to fill, got %+v", resp)
+	}
+
+	if resp := a.placeMarketableLimit(ctx, a.takerAccount(), "asset-1", "BUY", 0.52, 5, clobtypes.OrderTypeGTC); resp.Status != "LIVE" {
+		t.Fatalf("expected GTC remainder to rest at the limit, got %+v", resp)
+	}
+}
+
+// flakyWS fails the first failures order book subscriptions, then succeeds.
+type flakyWS struct {
+	ws.Client
+	failures       int
+	bookCalls      int
+	resolutionSubs [][]string
+}
+
+func (f *flakyWS) SubscribeOrderbook(ctx context.Context, assetIDs []string) (<-chan ws.OrderbookEvent, error) {
+	f.bookCalls++
+	if f.bookCalls <= f.failures {
+		return nil, errors.New("dial: connection refused")
+	}
+	return make(chan ws.OrderbookEvent), nil
+}
+
+func (f *flakyWS) SubscribeMarketResolutions(ctx context.Context, assetIDs []string) (<-chan ws.MarketResolvedEvent, error) {
+	f.resolutionSubs = append(f.resolutionSubs, assetIDs)
+	return make(chan ws.MarketResolvedEvent), nil
+}
+
+func TestReconnectFeedsRetriesAndResubscribes(t *testing.T) {
+	cfg := testConfig()
+	cfg.WSReconnectBackoff = time.Millisecond
+	cfg.WSReconnectMaxBackoff = 2 * time.Millisecond
+	cfg.WSMaxReconnectAttempts = 5
+	wsClient := &flakyWS{failures: 3}
+	a := New(cfg, nil, wsClient, nil, nil, nil, nil)
+
+	subs, err := a.reconnectFeeds(context.Background(), []string{"asset-1", "asset-2"})
+	if err != nil {
+		t.Fatalf("reconnectFeeds: %v", err)
+	}
+	if subs.book == nil || subs.resolutions == nil {
+		t.Fatal("expected book and resolution streams after reconnect")
+	}
+	if wsClient.bookCalls != 4 {
+		t.Fatalf("expected 4 book subscribe attempts, got %d", wsClient.bookCalls)
+	}
+	if len(wsClient.resolutionSubs) != 1 || len(wsClient.resolutionSubs[0]) != 2 {
+		t.Fatalf("expected one resolution resubscribe for both assets, got %v", wsClient.resolutionSubs)
+	}
+	if !a.FeedConnected() {
+		t.Fatal("expected feed to be connected")
+	}
+}
+
+func TestReconnectFeedsGivesUpAfterMaxAttempts(t *testing.T) {
+	cfg := testConfig()
+	cfg.WSReconnectBackoff = time.Millisecond
+	cfg.WSMaxReconnectAttempts = 3
+	wsClient := &flakyWS{failures: 10}
+	a := New(cfg, nil, wsClient, nil, nil, nil, nil)
+	a.setFeedConnected(true)
+
+	if _, err := a.reconnectFeeds(context.Background(), []string{"asset-1"}); err == nil {
+		t.Fatal("expected reconnect to give up")
+	}
+	if wsClient.bookCalls != 3 {
+		t.Fatalf("expected 3 attempts, got %d", wsClient.bookCalls)
+	}
+	if a.FeedConnected() {
+		t.Fatal("expected feed to be disconnected")
+	}
+}
+
+func TestTakerAccountPlacesThroughSeparateCredentials(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "live"
+	cfg.Taker.MinImbalance = 0.10
+
+	primary := &mockCLOB{idPrefix: "maker"}
+	takerClient := &mockCLOB{idPrefix: "taker"}
+	a := New(cfg, primary, nil, addrSigner{}, nil, nil, nil)
+	a.SetTakerAccount(takerClient, nil, addrSigner{addr: common.Address{19: 0xbb}})
+
+	a.HandleBookEvent(context.Background(), ws.OrderbookEvent{
+		AssetID: "1001",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "300"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "50"}},
+	})
+
+	if len(primary.created) != 2 {
+		t.Fatalf("expected both maker quotes on the primary account, got %v", primary.created)
+	}
+	if len(takerClient.created) != 1 {
+		t.Fatalf("expected the taker order on the taker account, got %v", takerClient.created)
+	}
+	if _, ok := a.takerAcct.tracker.Order(takerClient.created[0]); !ok {
+		t.Fatal("expected the taker order in the taker account's tracker")
+	}
+	if _, ok := a.tracker.Order(takerClient.created[0]); ok {
+		t.Fatal("expected the taker order kept out of the primary tracker")
+	}
+	if got := a.takerAcct.tracker.OpenOrderCount(); got != 1 {
+		t.Fatalf("expected the taker account to count only its own order, got %d", got)
+	}
+
+	// The taker account's risk manager is independent of the primary one.
+	a.takerAcct.risk.SetEmergencyStop(true)
+	if a.riskMgr.EmergencyStop() {
+		t.Fatal("expected the primary risk manager to be unaffected")
+	}
+}
+
+func TestTakerAccountPositionsExitThroughTakerAccount(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "live"
+	cfg.Maker.Enabled = false
+	cfg.Taker.Enabled = false
+
+	primary := &mockCLOB{idPrefix: "maker"}
+	takerClient := &mockCLOB{idPrefix: "taker"}
+	a := New(cfg, primary, nil, addrSigner{}, nil, nil, nil)
+	a.SetTakerAccount(takerClient, nil, addrSigner{addr: common.Address{19: 0xbb}})
+	a.books.Update(ws.OrderbookEvent{
+		AssetID: "1001",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "100"}},
+	})
+	a.takerAcct.tracker.SeedPosition("1001", 10, 0.5)
+
+	if pos := a.tracker.Position("1001"); pos != nil && pos.NetSize != 0 {
+		t.Fatalf("expected the taker position kept out of the primary tracker, got %+v", pos)
+	}
+	a.FlattenAll(context.Background())
+	if len(primary.created) != 0 {
+		t.Fatalf("expected nothing closed through the primary account, got %v", primary.created)
+	}
+	if len(takerClient.created) != 1 {
+		t.Fatalf("expected the position closed through the taker account, got %v", takerClient.created)
+	}
+}
+
+func TestTakerAccountCountsTowardStatsAndLimits(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "live"
+	cfg.Maker.Enabled = false
+	cfg.Taker.Enabled = false
+	cfg.Risk.MaxFillsPerMinute = 1
+	cfg.Risk.MaxGrossExposureUSDC = 5
+
+	a := New(cfg, &mockCLOB{}, nil, addrSigner{}, nil, nil, nil)
+	a.SetTakerAccount(&mockCLOB{}, nil, addrSigner{addr: common.Address{19: 0xbb}})
+	a.tracker.ProcessTradeEvent(ws.TradeEvent{ID: "t-1", AssetID: "1001", Side: "BUY", Price: "0.50", Size: "6"})
+	a.takerAcct.tracker.ProcessTradeEvent(ws.TradeEvent{ID: "t-2", AssetID: "1001", Side: "BUY", Price: "0.50", Size: "4"})
+
+	if _, fills, _ := a.Stats(); fills != 2 {
+		t.Fatalf("expected both accounts' fills counted, got %d", fills)
+	}
+	if got := len(a.RecentFills(10)); got != 2 {
+		t.Fatalf("expected both accounts' recent fills, got %d", got)
+	}
+	if got := len(a.FillHistory(0, 10)); got != 2 {
+		t.Fatalf("expected both accounts in the fill history, got %d", got)
+	}
+	if pos := a.TrackedPositions()["1001"]; pos.NetSize != 10 || pos.AvgEntryPrice != 0.50 {
+		t.Fatalf("expected the asset merged across accounts, got %+v", pos)
+	}
+	// The taker account's fill is the second within the minute.
+	if !a.riskMgr.EmergencyStop() || !a.takerAcct.risk.EmergencyStop() {
+		t.Fatal("expected the taker account's fill to trip the fill-rate breaker")
+	}
+	a.SetEmergencyStop(false)
+
+	// 3 USDC on the primary and 2 on the taker account fill the 5 USDC cap.
+	a.riskSync(context.Background())
+	if err := a.riskMgr.Allow("1002", "BUY", 1); err == nil || !strings.Contains(err.Error(), "gross exposure") {
+		t.Fatalf("expected the primary capped by both accounts' exposure, got %v", err)
+	}
+	if err := a.takerAcct.risk.Allow("1002", "BUY", 1); err == nil || !strings.Contains(err.Error(), "gross exposure") {
+		t.Fatalf("expected the taker account capped by both accounts' exposure, got %v", err)
+	}
+}
+
+func TestShutdownPreservesTakerAccountOrders(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "live"
+	cfg.PreserveOrdersOnShutdown = true
+	cfg.OrderStateFile = filepath.Join(t.TempDir(), "orders.json")
+
+	a := New(cfg, &mockCLOB{}, nil, addrSigner{}, nil, nil, nil)
+	a.SetTakerAccount(&mockCLOB{}, nil, addrSigner{addr: common.Address{19: 0xbb}})
+	a.takerAcct.tracker.RegisterOrder("taker-1", "1001", "market-1", "BUY", 0.50, 10)
+	a.takerAcct.tracker.ProcessTradeEvent(ws.TradeEvent{ID: "trade-1", AssetID: "1001", Side: "BUY", Price: "0.50", Size: "4"})
+	a.Shutdown(context.Background())
+
+	restarted := New(cfg, &mockCLOB{}, nil, addrSigner{}, nil, nil, nil)
+	restarted.SetTakerAccount(&mockCLOB{}, nil, addrSigner{addr: common.Address{19: 0xbb}})
+	if o, ok := restarted.takerAcct.tracker.Order("taker-1"); !ok || o.Status != "LIVE" {
+		t.Fatalf("expected the taker account's order restored, got %+v", o)
+	}
+	if pos := restarted.takerAcct.tracker.Position("1001"); pos == nil || pos.NetSize != 4 {
+		t.Fatalf("expected the taker account's position restored, got %+v", pos)
+	}
+	if pos := restarted.tracker.Position("1001"); pos != nil && pos.NetSize != 0 {
+		t.Fatalf("expected the taker position kept out of the primary tracker, got %+v", pos)
+	}
+	if _, err := os.Stat(takerOrderStateFile(cfg.OrderStateFile)); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected the taker state file removed once adopted, stat err=%v", err)
+	}
+}
+
+func TestCancelStaleOrdersKeepsFreshQuotes(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "paper"
+	cfg.Maker.MaxOrderAge = 90 * time.Second
+
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	clock := &fixedClock{t: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	a.SetClock(clock)
+	for _, assetID := range []string{"asset-1", "asset-2"} {
+		a.books.Update(ws.OrderbookEvent{
+			AssetID: assetID,
+			Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+			Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "100"}},
+		})
+	}
+
+	aged := a.placeLimit(context.Background(), "asset-1", "BUY", 0.45, 5)
+	a.activeOrders["asset-1"] = []string{aged.ID}
+	clock.t = clock.t.Add(2 * time.Minute)
+	fresh := a.placeLimit(context.Background(), "asset-2", "BUY", 0.45, 5)
+	a.activeOrders["asset-2"] = []string{fresh.ID}
+
+	a.cancelStaleOrders(context.Background())
+
+	if _, ok := a.activeOrders["asset-1"]; ok {
+		t.Fatalf("expected aged order to be cancelled, got %v", a.activeOrders)
+	}
+	if ids := a.activeOrders["asset-2"]; len(ids) != 1 || ids[0] != fresh.ID {
+		t.Fatalf("expected fresh order to be kept, got %v", a.activeOrders)
+	}
+	if o, _ := a.tracker.Order(aged.ID); o.Status != "CANCELED" {
+		t.Fatalf("expected aged order status CANCELED, got %q", o.Status)
+	}
+	if got := a.KPIStats()["stale_order_cancels"]; got != int64(1) {
+		t.Fatalf("expected 1 stale cancel, got %v", got)
+	}
+}
+
+type stubGamma struct {
+	gamma.Client
+	markets []gamma.Market
+}
+
+func (g *stubGamma) Markets(_ context.Context, _ *gamma.MarketsRequest) ([]gamma.Market, error) {
+	return g.markets, nil
+}
+
+// rescanWS hands out caller-owned streams and records subscribes and
+// unsubscribes.
+type rescanWS struct {
+	ws.Client
+	book         chan ws.OrderbookEvent
+	resolutions  chan ws.MarketResolvedEvent
+	bookErr      error
+	subscribed   [][]string
+	userMarkets  [][]string
+	unsubscribed []string
+}
+
+func (r *rescanWS) SubscribeOrderbook(_ context.Context, assetIDs []string) (<-chan ws.OrderbookEvent, error) {
+	if r.bookErr != nil {
+		return nil, r.bookErr
+	}
+	r.subscribed = append(r.subscribed, assetIDs)
+	return r.book, nil
+}
+
+func (r *rescanWS) SubscribeUserOrders(_ context.Context, marketIDs []string) (<-chan ws.OrderEvent, error) {
+	r.userMarkets = append(r.userMarkets, marketIDs)
+	return make(chan ws.OrderEvent), nil
+}
+
+func (r *rescanWS) SubscribeUserTrades(_ context.Context, marketIDs []string) (<-chan ws.TradeEvent, error) {
+	r.userMarkets = append(r.userMarkets, marketIDs)
+	return make(chan ws.TradeEvent), nil
+}
+
+func (r *rescanWS) SubscribeMarketResolutions(_ context.Context, _ []string) (<-chan ws.MarketResolvedEvent, error) {
+	return r.resolutions, nil
+}
+
+func (r *rescanWS) UnsubscribeMarketAssets(_ context.Context, assetIDs []string) error {
+	r.unsubscribed = append(r.unsubscribed, assetIDs...)
+	return nil
+}
+
+func TestRescanStreamsAddedAssetsAndKeepsHeldOnes(t *testing.T) {
+	endDate := time.Now().Add(60 * 24 * time.Hour).Format(time.RFC3339)
+	market := func(id, token string) gamma.Market {
+		return gamma.Market{ID: id, ConditionID: "cond-" + id, Volume24hr: "5000", Liquidity: "10000", Spread: "0.02", EndDate: endDate, Active: true, Tokens: []gamma.Token{{TokenID: token}}}
+	}
+	cfg := testConfig()
+	cfg.Maker.Enabled = false
+	cfg.Taker.Enabled = false
+	cfg.Maker.AutoSelectTop = 2
+	cfg.Selector.MinDaysToEnd = 0
+	wsClient := &rescanWS{book: make(chan ws.OrderbookEvent, 1), resolutions: make(chan ws.MarketResolvedEvent, 1)}
+	gammaClient := &stubGamma{markets: []gamma.Market{market("m1", "asset-1"), market("m3", "asset-3")}}
+	a := New(cfg, &mockCLOB{}, wsClient, nil, gammaClient, nil, nil)
+	a.execMode = "live"
+	a.tracker.SeedPosition("asset-2", 5, 0.5)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assetIDs := []string{"asset-1", "asset-2", "asset-4"}
+	feeds := newFeedMux(ctx)
+	feeds.add(feedSubs{book: make(chan ws.OrderbookEvent)})
+	added, removed, err := a.rescanMarkets(ctx, &assetIDs, feeds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(added, []string{"asset-3"}) || !slices.Equal(removed, []string{"asset-4"}) {
+		t.Fatalf("unexpected rescan diff: added=%v removed=%v", added, removed)
+	}
+
+	if len(wsClient.subscribed) != 1 || len(wsClient.subscribed[0]) != 1 || wsClient.subscribed[0][0] != "asset-3" {
+		t.Fatalf("expected only the new asset to be subscribed, got %v", wsClient.subscribed)
+	}
+	wantMarkets := [][]string{{"cond-m3"}, {"cond-m3"}}
+	if !slices.EqualFunc(wsClient.userMarkets, wantMarkets, slices.Equal) {
+		t.Fatalf("expected the new market's user order and trade streams, got %v", wsClient.userMarkets)
+	}
+	if len(wsClient.unsubscribed) != 1 || wsClient.unsubscribed[0] != "asset-4" {
+		t.Fatalf("expected only the flat, deselected asset to be unsubscribed, got %v", wsClient.unsubscribed)
+	}
+	if !slices.Contains(assetIDs, "asset-2") || !slices.Contains(assetIDs, "asset-3") || slices.Contains(assetIDs, "asset-4") {
+		t.Fatalf("unexpected asset list after rescan: %v", assetIDs)
+	}
+
+	wsClient.book <- ws.OrderbookEvent{
+		AssetID: "asset-3",
+		Bids:    []ws.OrderbookLevel{{Price: "0.40", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.42", Size: "100"}},
+	}
+	select {
+	case ev := <-feeds.book:
+		a.HandleBookEvent(ctx, ev)
+	case <-time.After(time.Second):
+		t.Fatal("expected the added asset's book event on the loop's stream")
+	}
+	if _, ok := a.books.Get("asset-3"); !ok {
+		t.Fatal("expected the added asset's book to reach HandleBookEvent")
+	}
+
+	wsClient.resolutions <- ws.MarketResolvedEvent{AssetIDs: []string{"asset-3"}}
+	select {
+	case <-feeds.resolutions:
+	case <-time.After(time.Second):
+		t.Fatal("expected the added asset's resolution event on the loop's stream")
+	}
+}
+
+func TestRescanLeavesAssetsOutWhenSubscribeFails(t *testing.T) {
+	endDate := time.Now().Add(60 * 24 * time.Hour).Format(time.RFC3339)
+	cfg := testConfig()
+	cfg.Maker.Enabled = false
+	cfg.Taker.Enabled = false
+	cfg.Maker.AutoSelectTop = 2
+	cfg.Selector.MinDaysToEnd = 0
+	wsClient := &rescanWS{bookErr: errors.New("ws down")}
+	gammaClient := &stubGamma{markets: []gamma.Market{
+		{ID: "m1", ConditionID: "cond-m1", Volume24hr: "5000", Liquidity: "10000", Spread: "0.02", EndDate: endDate, Active: true, Tokens: []gamma.Token{{TokenID: "asset-1"}}},
+		{ID: "m3", ConditionID: "cond-m3", Volume24hr: "5000", Liquidity: "10000", Spread: "0.02", EndDate: endDate, Active: true, Tokens: []gamma.Token{{TokenID: "asset-3"}}},
+	}}
+	a := New(cfg, &mockCLOB{}, wsClient, nil, gammaClient, nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assetIDs := []string{"asset-1"}
+	feeds := newFeedMux(ctx)
+	added, _, err := a.rescanMarkets(ctx, &assetIDs, feeds)
+	if err == nil {
+		t.Fatal("expected the subscribe error")
+	}
+	if len(added) != 0 {
+		t.Fatalf("expected nothing added, got %v", added)
+	}
+	if !slices.Equal(assetIDs, []string{"asset-1"}) {
+		t.Fatalf("expected the unsubscribed asset left out for the next rescan, got %v", assetIDs)
+	}
+}
+
+// historyCLOB serves a fixed price history, newest point first.
+type historyCLOB struct {
+	mockCLOB
+	req *clobtypes.PricesHistoryRequest
+}
+
+func (m *historyCLOB) PricesHistory(_ context.Context, req *clobtypes.PricesHistoryRequest) (clobtypes.PricesHistoryResponse, error) {
+	m.req = req
+	return clobtypes.PricesHistoryResponse{{Timestamp: 300, Price: 0.52}, {Timestamp: 100, Price: 0.50}, {Timestamp: 200, Price: 0.51}}, nil
+}
+
+func TestCLOBPriceHistoryFeedsSelector(t *testing.T) {
+	client := &historyCLOB{}
+	a := New(testConfig(), client, nil, nil, nil, nil, nil)
+	if !a.gammaSelector.HasPriceHistory() {
+		t.Fatal("expected the CLOB client to serve the selector's price history")
+	}
+
+	prices, err := clobPriceHistory{client: client}.PriceHistory(context.Background(), "1001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(prices, []float64{0.50, 0.51, 0.52}) {
+		t.Fatalf("expected prices oldest first, got %v", prices)
+	}
+	if client.req.Market != "1001" || client.req.Interval != clobtypes.PriceHistoryInterval1d || client.req.Fidelity != priceHistoryFidelity {
+		t.Fatalf("unexpected prices-history request %+v", client.req)
+	}
+}
+
+func TestRescanRequiresRunningLoop(t *testing.T) {
+	a := New(testConfig(), &mockCLOB{}, nil, nil, &stubGamma{}, nil, nil)
+	if _, _, err := a.Rescan(context.Background()); err == nil {
+		t.Fatal("expected an error when the trading loop is not running")
+	}
+	b := New(testConfig(), &mockCLOB{}, nil, nil, nil, nil, nil)
+	if _, _, err := b.Rescan(context.Background()); err == nil {
+		t.Fatal("expected an error without a gamma client")
+	}
+}
+
+func TestMakerQuoteShrinksToRemainingRiskCapacity(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "live"
+	cfg.Taker.Enabled = false
+	cfg.Maker.OrderSizeUSDC = 10
+	cfg.Maker.MinOrderSizeUSDC = 1
+	cfg.Risk.MaxPositionPerMarket = 50
+
+	clobClient := &mockCLOB{}
+	a := New(cfg, clobClient, nil, addrSigner{}, nil, nil, nil)
+	a.riskMgr.AddPosition("1001", 44)
+	event := ws.OrderbookEvent{
+		AssetID: "1001",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "100"}},
+	}
+	a.HandleBookEvent(context.Background(), event)
+	if len(clobClient.created) != 2 {
+		t.Fatalf("expected both quotes placed, got %v", clobClient.created)
+	}
+
+	sizes := map[string]float64{}
+	for _, o := range a.tracker.ActiveOrders() {
+		sizes[o.Side] = o.OrigSize
+	}
+	if sizes["BUY"] != 6 || sizes["SELL"] != 10 {
+		t.Fatalf("expected the bid clamped to 6 USDC of room and a full ask, got %v", sizes)
+	}
+
+	// Under the minimum order size the bid is dropped and only the ask
+	// rests, reduce-only against the long it works down.
+	a.riskMgr.AddPosition("1001", 5.5)
+	a.tracker.SeedPosition("1001", 99, 0.5)
+	a.HandleBookEvent(context.Background(), event)
+	var sides []string
+	for _, id := range a.activeOrders["1001"] {
+		if o, ok := a.tracker.Order(id); ok {
+			sides = append(sides, o.Side)
+		}
+	}
+	if !slices.Equal(sides, []string{"SELL"}) {
+		t.Fatalf("expected only an ask near the position limit, got %v", sides)
+	}
+	if len(clobClient.created) != 3 {
+		t.Fatalf("expected only the ask placed on the requote, got %v", clobClient.created)
+	}
+}
+
+func TestMakerRequoteAmendsRestingQuotes(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "live"
+	cfg.Taker.Enabled = false
+	cfg.Maker.OrderSizeUSDC = 10
+	cfg.Risk.MaxPositionPerMarket = 50
+
+	clobClient := &mockCLOB{}
+	a := New(cfg, clobClient, nil, addrSigner{}, nil, nil, nil)
+	event := ws.OrderbookEvent{
+		AssetID: "1001",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "100"}},
+	}
+	a.HandleBookEvent(context.Background(), event)
+	if len(clobClient.created) != 2 {
+		t.Fatalf("expected the first quotes to be created, got %v", clobClient.created)
+	}
+	first := slices.Clone(clobClient.created)
+
+	// An unchanged quote keeps resting: nothing is cancelled or created.
+	a.HandleBookEvent(context.Background(), event)
+	if len(clobClient.created) != 2 || len(clobClient.cancelled) != 0 {
+		t.Fatalf("expected the quotes left resting, got created=%v cancelled=%v", clobClient.created, clobClient.cancelled)
+	}
+	active := slices.Clone(a.activeOrders["1001"])
+	slices.Sort(active)
+	if !slices.Equal(active, first) {
+		t.Fatalf("expected the resting quotes to stay active, got %v", active)
+	}
+
+	// The book moves: each quote is cancelled and replaced.
+	a.HandleBookEvent(context.Background(), ws.OrderbookEvent{
+		AssetID: "1001",
+		Bids:    []ws.OrderbookLevel{{Price: "0.54", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.56", Size: "100"}},
+	})
+	cancelled := slices.Clone(clobClient.cancelled)
+	slices.Sort(cancelled)
+	if !slices.Equal(cancelled, first) || len(clobClient.created) != 4 {
+		t.Fatalf("expected both quotes cancelled and replaced, got cancelled=%v created=%v", clobClient.cancelled, clobClient.created)
+	}
+	for _, id := range first {
+		if o, _ := a.tracker.Order(id); o.Status != "CANCELED" {
+			t.Fatalf("expected %s marked cancelled, got %q", id, o.Status)
+		}
+	}
+	active = slices.Clone(a.activeOrders["1001"])
+	slices.Sort(active)
+	if !slices.Equal(active, clobClient.created[2:]) {
+		t.Fatalf("expected the replacements active, got %v", active)
+	}
+}
+
+func TestMakerRequoteCancelsPartlyFilledQuote(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "live"
+	cfg.Taker.Enabled = false
+	cfg.Maker.OrderSizeUSDC = 10
+	cfg.Risk.MaxPositionPerMarket = 50
+
+	clobClient := &mockCLOB{}
+	a := New(cfg, clobClient, nil, addrSigner{}, nil, nil, nil)
+	event := ws.OrderbookEvent{
+		AssetID: "1001",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "100"}},
+	}
+	a.HandleBookEvent(context.Background(), event)
+	if len(clobClient.created) != 2 {
+		t.Fatalf("expected the first quotes to be created, got %v", clobClient.created)
+	}
+	var bidID string
+	for _, id := range clobClient.created {
+		if o, _ := a.tracker.Order(id); o.Side == "BUY" {
+			bidID = id
+		}
+	}
+	a.tracker.ProcessOrderEvent(ws.OrderEvent{ID: bidID, Status: "LIVE", SizeMatched: "2"})
+
+	// The partly filled bid goes out with the bulk cancel; the ask rests.
+	a.HandleBookEvent(context.Background(), event)
+	if !slices.Equal(clobClient.cancelled, []string{bidID}) || len(clobClient.created) != 3 {
+		t.Fatalf("expected only the filled bid replaced, got cancelled=%v created=%v", clobClient.cancelled, clobClient.created)
+	}
+}
+
+func TestMakerRoundsQuotesToSizeIncrement(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "live"
+	cfg.Taker.Enabled = false
+	cfg.Maker.OrderSizeUSDC = 10
+	cfg.Maker.MinOrderSizeUSDC = 1
+	cfg.Risk.MaxPositionPerMarket = 50
+	cfg.SizeIncrement = 5
+	event := ws.OrderbookEvent{
+		AssetID: "1001",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "100"}},
+	}
+
+	clobClient := &mockCLOB{}
+	a := New(cfg, clobClient, nil, addrSigner{}, nil, nil, nil)
+	a.HandleBookEvent(context.Background(), event)
+	orders := a.tracker.ActiveOrders()
+	if len(orders) != 2 || len(clobClient.created) != 2 {
+		t.Fatalf("expected a bid and an ask placed, got %+v, created %v", orders, clobClient.created)
+	}
+	for _, o := range orders {
+		want := math.Floor(10/o.Price/5) * 5
+		if shares := o.OrigSize / o.Price; math.Abs(shares-want) > 1e-6 {
+			t.Fatalf("expected %s of 10 USDC @ %.4f rounded down to %.0f shares, got %f", o.Side, o.Price, want, shares)
+		}
+	}
+
+	// An increment larger than a whole clip leaves nothing to quote.
+	cfg.SizeIncrement = 50
+	clob := &mockCLOB{}
+	a = New(cfg, clob, nil, addrSigner{}, nil, nil, nil)
+	a.HandleBookEvent(context.Background(), event)
+	if len(clob.created) != 0 {
+		t.Fatalf("expected quotes that round to zero to be skipped, got %v", clob.created)
+	}
+}
+
+func TestMakerRequoteSizesReplacementForPartialFill(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "live"
+	cfg.Taker.Enabled = false
+	cfg.Maker.OrderSizeUSDC = 10
+	cfg.Maker.MinOrderSizeUSDC = 1
+	cfg.Risk.MaxPositionPerMarket = 50
+
+	clobClient := &mockCLOB{}
+	a := New(cfg, clobClient, nil, addrSigner{}, nil, nil, nil)
+	event := ws.OrderbookEvent{
+		AssetID: "1001",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "100"}},
+	}
+	a.HandleBookEvent(context.Background(), event)
+
+	var bid execution.OrderState
+	for _, o := range a.tracker.ActiveOrders() {
+		if o.Side == "BUY" {
+			bid = o
+		}
+	}
+	if bid.ID == "" || len(clobClient.created) != 2 {
+		t.Fatalf("expected a bid and an ask placed, got %v", clobClient.created)
+	}
+	// The exchange reports the bid half filled.
+	shares := 10 / bid.Price
+	a.tracker.ProcessOrderEvent(ws.OrderEvent{
+		ID:           bid.ID,
+		AssetID:      "1001",
+		Side:         "BUY",
+		Price:        fmt.Sprintf("%.4f", bid.Price),
+		OriginalSize: fmt.Sprintf("%.8f", shares),
+		SizeMatched:  fmt.Sprintf("%.8f", shares/2),
+		Status:       "LIVE",
+	})
+	if o, _ := a.tracker.Order(bid.ID); math.Abs(o.RemainingSize()-shares/2) > 1e-6 || !o.PartiallyFilled() {
+		t.Fatalf("expected half the bid left resting, got %+v", o)
+	}
+
+	a.HandleBookEvent(context.Background(), event)
+	sizes := map[string]float64{}
+	for _, id := range a.activeOrders["1001"] {
+		if o, ok := a.tracker.Order(id); ok {
+			sizes[o.Side] = o.OrigSize
+			if o.Side == "BUY" && (id == bid.ID || !slices.Contains(clobClient.created, id)) {
+				t.Fatalf("expected a replacement bid placed, got %s", id)
+			}
+		}
+	}
+	if math.Abs(sizes["BUY"]-5) > 1e-6 || sizes["SELL"] != 10 {
+		t.Fatalf("expected the replacement bid to post the remaining 5 USDC and a full ask, got %v", sizes)
+	}
+
+	// With the carried clip unfilled, the next requote keeps posting only
+	// the rest of it; a full fill starts a fresh clip.
+	a.HandleBookEvent(context.Background(), event)
+	for _, id := range a.activeOrders["1001"] {
+		if o, _ := a.tracker.Order(id); o.Side == "BUY" && math.Abs(o.OrigSize-5) > 1e-6 {
+			t.Fatalf("expected the carried clip to stay at 5 USDC, got %f", o.OrigSize)
+		}
+		if o, _ := a.tracker.Order(id); o.Side == "BUY" {
+			a.tracker.ProcessOrderEvent(ws.OrderEvent{ID: id, OriginalSize: "10", SizeMatched: "10", Status: "MATCHED"})
+		}
+	}
+	a.HandleBookEvent(context.Background(), event)
+	for _, id := range a.activeOrders["1001"] {
+		if o, _ := a.tracker.Order(id); o.Side == "BUY" && o.OrigSize != 10 {
+			t.Fatalf("expected a full 10 USDC clip after the carried one filled, got %f", o.OrigSize)
+		}
+	}
+}
+
+func TestReduceOnlyAllowsOnlyShrinkingOrders(t *testing.T) {
+	a := New(testConfig(), nil, nil, nil, nil, nil, nil)
+	a.books.Update(ws.OrderbookEvent{
+		AssetID: "short",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "100"}},
+	})
+	a.tracker.SeedPosition("short", -10, 0.5)
+	a.tracker.SeedPosition("long", 10, 0.5)
+
+	amount, err := a.reduceOnlyAmount("short", "BUY", 3, 0)
+	if err != nil || amount != 3 {
+		t.Fatalf("expected a reduce-only BUY on a short allowed, got %f, %v", amount, err)
+	}
+	// Covering more than the short would flip it long; the market order is
+	// clipped to the 10 shares at the 0.52 ask.
+	if amount, err := a.reduceOnlyAmount("short", "BUY", 20, 0); err != nil || math.Abs(amount-5.2) > 1e-9 {
+		t.Fatalf("expected the BUY clipped to 5.2 USDC, got %f, %v", amount, err)
+	}
+	if amount, err := a.reduceOnlyAmount("long", "SELL", 20, 0.6); err != nil || math.Abs(amount-6) > 1e-9 {
+		t.Fatalf("expected the SELL limit clipped to 6 USDC, got %f, %v", amount, err)
+	}
+
+	if _, err := a.reduceOnlyAmount("long", "BUY", 3, 0); err == nil {
+		t.Fatal("expected a reduce-only BUY on a long rejected")
+	}
+	if _, err := a.reduceOnlyAmount("short", "SELL", 3, 0); err == nil {
+		t.Fatal("expected a reduce-only SELL on a short rejected")
+	}
+	if _, err := a.reduceOnlyAmount("flat", "SELL", 3, 0); err == nil {
+		t.Fatal("expected a reduce-only order from flat rejected")
+	}
+}
+
+func TestMakerAtLimitSkipsAskThatWouldGrowPosition(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "live"
+	cfg.Taker.Enabled = false
+	cfg.Maker.OrderSizeUSDC = 10
+	cfg.Risk.MaxPositionPerMarket = 50
+
+	clob := &mockCLOB{}
+	a := New(cfg, clob, nil, nil, nil, nil, nil)
+	// The risk manager has no room left to buy, but the tracked position is
+	// short, so an ask would only deepen it.
+	a.riskMgr.AddPosition("asset-1", 50)
+	a.tracker.SeedPosition("asset-1", -20, 0.5)
+	a.HandleBookEvent(context.Background(), ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "100"}},
+	})
+	if len(clob.created) != 0 {
+		t.Fatalf("expected no reduce-only ask against a short, got %d orders", len(clob.created))
+	}
+}
+
+func TestDisablingMakerCancelsQuotesAndStopsQuoting(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "live"
+	cfg.Taker.Enabled = false
+	clob := &mockCLOB{}
+	a := New(cfg, clob, nil, addrSigner{}, nil, nil, nil)
+	event := ws.OrderbookEvent{
+		AssetID: "1001",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "100"}},
+	}
+	a.HandleBookEvent(context.Background(), event)
+	quoted := slices.Clone(a.activeOrders["1001"])
+	if len(quoted) == 0 {
+		t.Fatal("expected maker quotes while enabled")
+	}
+
+	off := false
+	makerOn, takerOn, err := a.SetStrategiesEnabled(&off, nil)
+	if err != nil {
+		t.Fatalf("toggle: %v", err)
+	}
+	if makerOn || takerOn {
+		t.Fatalf("expected both strategies off, got maker=%v taker=%v", makerOn, takerOn)
+	}
+	if len(a.activeOrders) != 0 {
+		t.Fatalf("expected maker quotes dropped, got %v", a.activeOrders)
+	}
+	for _, id := range quoted {
+		if !slices.Contains(clob.cancelled, id) {
+			t.Fatalf("expected quote %s cancelled, got %v", id, clob.cancelled)
+		}
+	}
+
+	created := len(clob.created)
+	a.HandleBookEvent(context.Background(), event)
+	if len(clob.created) != created {
+		t.Fatalf("expected no new quotes with the maker disabled, got %d more", len(clob.created)-created)
+	}
+}
+
+func TestCryptoSignalShrinksAgainstExistingLong(t *testing.T) {
+	cfg := testConfig()
+	cfg.Risk.MaxPositionPerMarket = 100
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	sig := strategy.CryptoSignal{MarketAssetID: "btc-yes", Side: "BUY", AmountUSDC: 10}
+
+	if got := a.cryptoSignalAmount(sig); got != 10 {
+		t.Fatalf("expected the full amount without a position, got %f", got)
+	}
+	// 160 shares at 0.50 is an 80 USDC long, 80% of the limit.
+	a.tracker.SeedPosition("btc-yes", 160, 0.50)
+	if got := a.cryptoSignalAmount(sig); math.Abs(got-2) > 1e-9 {
+		t.Fatalf("expected a heavy long to cut the BUY to 2, got %f", got)
+	}
+	sig.Side = "SELL"
+	if got := a.cryptoSignalAmount(sig); got != 10 {
+		t.Fatalf("expected a SELL that reduces the long to keep its size, got %f", got)
+	}
+	a.tracker.SeedPosition("btc-yes", 200, 0.50)
+	sig.Side = "BUY"
+	if got := a.cryptoSignalAmount(sig); got != 0 {
+		t.Fatalf("expected no BUY at the position limit, got %f", got)
+	}
+}
+
+// stubRTDS records the symbols it was asked to stream, failing with err when
+// set.
+type stubRTDS struct {
+	rtds.Client
+	symbols []string
+	err     error
+}
+
+func (s *stubRTDS) SubscribeCryptoPrices(_ context.Context, symbols []string) (<-chan rtds.CryptoPriceEvent, error) {
+	s.symbols = symbols
+	if s.err != nil {
+		return nil, s.err
+	}
+	return make(chan rtds.CryptoPriceEvent), nil
+}
+
+func TestCryptoMappingFromConfig(t *testing.T) {
+	cfg := testConfig()
+	cfg.Crypto.Mapping = map[string][]string{
+		"BTCUSDT": {"btc-yes"},
+		"ETHUSDT": {"eth-yes", "eth-no"},
+	}
+	cfg.Crypto.MinPriceChangePct = 0.01
+	stream := &stubRTDS{}
+	a := New(cfg, nil, nil, nil, nil, nil, stream)
+
+	if ch := a.subscribeCrypto(context.Background()); ch == nil {
+		t.Fatal("expected a crypto price stream for the configured mapping")
+	}
+	if !slices.Equal(stream.symbols, []string{"BTCUSDT", "ETHUSDT"}) {
+		t.Fatalf("expected subscriptions for the mapped symbols, got %v", stream.symbols)
+	}
+
+	now := time.Now()
+	a.cryptoTracker.ProcessPrice(strategy.CryptoPriceUpdate{Symbol: "ETHUSDT", Price: 2000, Timestamp: now})
+	// A 1.5% move clears the configured 1% threshold but not the 2% default.
+	signals := a.cryptoTracker.ProcessPrice(strategy.CryptoPriceUpdate{Symbol: "ETHUSDT", Price: 2030, Timestamp: now})
+	var assets []string
+	for _, sig := range signals {
+		assets = append(assets, sig.MarketAssetID)
+	}
+	if !slices.Equal(assets, []string{"eth-yes", "eth-no"}) {
+		t.Fatalf("expected signals on the mapped ETH markets, got %v", assets)
+	}
+}
+
+func TestCryptoSubscriptionSkippedWithoutMapping(t *testing.T) {
+	stream := &stubRTDS{}
+	a := New(testConfig(), nil, nil, nil, nil, nil, stream)
+	if ch := a.subscribeCrypto(context.Background()); ch != nil || stream.symbols != nil {
+		t.Fatalf("expected no crypto subscription without a mapping, got %v", stream.symbols)
+	}
+}
+
+func TestSpreadCaptureBps(t *testing.T) {
+	if got := spreadCaptureBps("BUY", 0.49, 0.50); math.Abs(got-200) > 1e-9 {
+		t.Fatalf("expected a buy 1c below a 0.50 mid to capture 200 bps, got %f", got)
+	}
+	if got := spreadCaptureBps("SELL", 0.51, 0.50); math.Abs(got-200) > 1e-9 {
+		t.Fatalf("expected a sell 1c above mid to capture 200 bps, got %f", got)
+	}
+	if got := spreadCaptureBps("BUY", 0.52, 0.50); got >= 0 {
+		t.Fatalf("expected a buy above mid to give up edge, got %f", got)
+	}
+}
+
+func TestMakerFillRecordsSpreadCapture(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "live"
+	cfg.Taker.Enabled = false
+	clobClient := &mockCLOB{}
+	a := New(cfg, clobClient, nil, addrSigner{}, nil, nil, nil)
+	a.HandleBookEvent(context.Background(), ws.OrderbookEvent{
+		AssetID: "1001",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "100"}},
+	})
+
+	var bid execution.OrderState
+	for _, o := range a.tracker.ActiveOrders() {
+		if o.Side == "BUY" {
+			bid = o
+		}
+	}
+	if len(clobClient.created) != 2 {
+		t.Fatalf("expected a bid and an ask placed, got %v", clobClient.created)
+	}
+	if bid.ID == "" || bid.Price >= 0.51 {
+		t.Fatalf("expected a bid below the 0.51 mid, got %+v", bid)
+	}
+	a.observeMakerFill(ws.OrderEvent{ID: bid.ID, SizeMatched: "2", Status: "MATCHED"})
+
+	stats := a.kpi.snapshot(time.Now())
+	want := spreadCaptureBps("BUY", bid.Price, 0.51)
+	if got := stats["maker_spread_capture_bps"].(float64); got <= 0 || math.Abs(got-want) > 1e-4 {
+		t.Fatalf("expected positive capture %.4f bps for a bid filled below mid, got %v", want, got)
+	}
+	if n := stats["maker_spread_capture_samples_daily"]; n != 1 {
+		t.Fatalf("expected one capture sample, got %v", n)
+	}
+
+	// Only the first fill of an order is scored.
+	a.observeMakerFill(ws.OrderEvent{ID: bid.ID, SizeMatched: "4", Status: "MATCHED"})
+	if n := a.kpi.snapshot(time.Now())["maker_spread_capture_samples_daily"]; n != 1 {
+		t.Fatalf("expected a later partial fill not to add a sample, got %v", n)
+	}
+}
+
+func TestWatchdogFiresOnceWhenLoopStalls(t *testing.T) {
+	clock := &fixedClock{t: time.Unix(1_700_000_000, 0)}
+	var fired []time.Duration
+	dog := newWatchdog(5*time.Second, clock.Now, func(stalled time.Duration) { fired = append(fired, stalled) })
+
+	clock.t = clock.t.Add(4 * time.Second)
+	if dog.check() {
+		t.Fatal("expected no trip inside the timeout")
+	}
+	dog.pet()
+	clock.t = clock.t.Add(6 * time.Second)
+	if !dog.check() || len(fired) != 1 || fired[0] != 6*time.Second {
+		t.Fatalf("expected one trip after a 6s stall, got %v", fired)
+	}
+	clock.t = clock.t.Add(time.Minute)
+	if dog.check() {
+		t.Fatal("expected a single trip per stall")
+	}
+
+	// A loop that recovers re-arms the switch.
+	dog.pet()
+	clock.t = clock.t.Add(10 * time.Second)
+	if !dog.check() || len(fired) != 2 {
+		t.Fatalf("expected a second trip after re-arming, got %v", fired)
+	}
+}
+
+func TestWatchdogCancelsOrdersWhenLoopStalls(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "live"
+	cfg.WatchdogTimeout = 20 * time.Millisecond
+	clob := &mockCLOB{}
+	a := New(cfg, clob, nil, nil, nil, nil, nil)
+	n := &mockNotifier{}
+	a.notifier = n
+
+	// Nothing pets the watchdog, as if the loop were blocked in a handler.
+	tripped := make(chan struct{})
+	dog := newWatchdog(cfg.WatchdogTimeout, time.Now, func(stalled time.Duration) {
+		a.tripWatchdog(stalled)
+		close(tripped)
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go dog.run(ctx)
+
+	select {
+	case <-tripped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the watchdog to fire for a stalled loop")
+	}
+	if clob.cancelAllCalls != 1 {
+		t.Fatalf("expected one cancel-all, got %d", clob.cancelAllCalls)
+	}
+	if !a.riskMgr.EmergencyStop() {
+		t.Fatal("expected emergency stop after the watchdog fired")
+	}
+	if !slices.Contains(n.alerts, "Trading Loop Stalled") {
+		t.Fatalf("expected a stall alert, got %v", n.alerts)
+	}
+}
+
+func TestHourlySummaryFromSeededFills(t *testing.T) {
+	cfg := testConfig()
+	cfg.Notify.HourlySummary = true
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	n := &mockNotifier{}
+	a.notifier = n
+
+	a.tracker.ProcessTradeEvent(ws.TradeEvent{ID: "t-1", AssetID: "asset-1", Side: "BUY", Price: "0.50", Size: "20"})
+	a.tracker.ProcessTradeEvent(ws.TradeEvent{ID: "t-2", AssetID: "asset-1", Side: "SELL", Price: "0.55", Size: "20"})
+	a.tracker.ProcessTradeEvent(ws.TradeEvent{ID: "t-3", AssetID: "asset-2", Side: "BUY", Price: "0.40", Size: "10"})
+	a.tracker.ProcessTradeEvent(ws.TradeEvent{ID: "t-4", AssetID: "asset-2", Side: "SELL", Price: "0.30", Size: "10"})
+
+	text, ok := a.buildHourlySummary()
+	if !ok || text == "" {
+		t.Fatal("expected a summary after fills")
+	}
+	for _, want := range []string{"Fills: 4", "Best: asset-1", "Worst: asset-2", "Can trade: true"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in summary:\n%s", want, text)
+		}
+	}
+
+	// No fills since the last summary while blocked: stay quiet.
+	a.riskMgr.SetEmergencyStop(true)
+	a.sendHourlySummary(context.Background())
+	if len(n.alerts) != 0 {
+		t.Fatalf("expected idle blocked hour to be skipped, got %v", n.alerts)
+	}
+	a.tracker.ProcessTradeEvent(ws.TradeEvent{ID: "t-5", AssetID: "asset-1", Side: "BUY", Price: "0.50", Size: "2"})
+	a.sendHourlySummary(context.Background())
+	if len(n.alerts) != 1 || n.alerts[0] != "Hourly Summary" {
+		t.Fatalf("expected an hourly summary once there was activity, got %v", n.alerts)
+	}
+}
+
+func TestFillRateBreakerTripsEmergencyStop(t *testing.T) {
+	cfg := testConfig()
+	cfg.Risk.MaxFillsPerMinute = 3
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	n := &mockNotifier{}
+	a.notifier = n
+	clock := &fixedClock{t: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	a.SetClock(clock)
+
+	fill := func(i int) {
+		a.tracker.ProcessTradeEvent(ws.TradeEvent{ID: fmt.Sprintf("t-%d", i), AssetID: "asset-1", Side: "BUY", Price: "0.50", Size: "2"})
+	}
+	// One fill every 30s stays under the limit.
+	for i := 0; i < 6; i++ {
+		fill(i)
+		clock.t = clock.t.Add(30 * time.Second)
+	}
+	if a.riskMgr.EmergencyStop() {
+		t.Fatal("expected a normal fill rate not to trip the breaker")
+	}
+
+	for i := 6; i < 10; i++ {
+		fill(i)
+	}
+	if !a.riskMgr.EmergencyStop() {
+		t.Fatal("expected a burst of fills to trigger emergency stop")
+	}
+	if !slices.Contains(n.alerts, "Runaway Trading") {
+		t.Fatalf("expected a runaway trading alert, got %v", n.alerts)
+	}
+}
+
+func TestTakerSignalLogWritesJSONL(t *testing.T) {
+	cfg := testConfig()
+	cfg.Taker.MinCompositeScore = 0.9
+	cfg.Taker.SignalLog = filepath.Join(t.TempDir(), "signals.jsonl")
+
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	book := ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "300"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "100"}},
+	}
+	if sig, err := a.taker.EvaluateEnhanced(book, nil, 0, 0); err != nil || sig != nil {
+		t.Fatalf("expected suppressed signal, got %+v (%v)", sig, err)
+	}
+	if err := a.signals.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(cfg.Taker.SignalLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line, got %d: %q", len(lines), data)
+	}
+	var rec strategy.SignalRecord
+	if err := json.Unmarshal([]byte(lines[0]), &rec); err != nil {
+		t.Fatal(err)
+	}
+	if rec.AssetID != "asset-1" || rec.Outcome != strategy.SignalBelowThreshold || rec.Imbalance != 0.5 {
+		t.Fatalf("unexpected record %+v", rec)
+	}
+}
+
+func TestUnrealizedPnLFallsBackToLastTradePrice(t *testing.T) {
+	cfg := testConfig()
+	cfg.Maker.Enabled = false
+	cfg.Taker.Enabled = false
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	clock := &fixedClock{t: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	a.SetClock(clock)
+	buy := ws.TradeEvent{ID: "t-1", AssetID: "asset-1", Side: "BUY", Price: "0.40", Size: "10"}
+	a.observeTradePrice(buy)
+	a.tracker.ProcessTradeEvent(buy)
+
+	// One-sided book: no mid.
+	a.books.Update(ws.OrderbookEvent{AssetID: "asset-1", Bids: []ws.OrderbookLevel{{Price: "0.39", Size: "10"}}})
+	if unpriced := a.UnpricedPositions(); len(unpriced) != 0 {
+		t.Fatalf("expected position priced from last trade, got unpriced %v", unpriced)
+	}
+	if u := a.UnrealizedPnL(); math.Abs(u) > 1e-9 {
+		t.Fatalf("expected zero unrealized PnL at the entry trade price, got %f", u)
+	}
+
+	clock.t = clock.t.Add(time.Minute)
+	a.observeTradePrice(ws.TradeEvent{AssetID: "asset-1", Price: "0.50"})
+	if u := a.UnrealizedPnLByMarket()["asset-1"]; math.Abs(u-1.0) > 1e-9 {
+		t.Fatalf("expected 10 x (0.50-0.40) = 1.0 from the last trade, got %f", u)
+	}
+
+	// A later two-sided book sets the last known mid, which outranks the
+	// older trade once the book goes one-sided again.
+	clock.t = clock.t.Add(time.Minute)
+	a.HandleBookEvent(context.Background(), ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.54", Size: "10"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.56", Size: "10"}},
+	})
+	a.books.Update(ws.OrderbookEvent{AssetID: "asset-1", Asks: []ws.OrderbookLevel{{Price: "0.56", Size: "10"}}})
+	if u := a.UnrealizedPnLByMarket()["asset-1"]; math.Abs(u-1.5) > 1e-9 {
+		t.Fatalf("expected 10 x (0.55-0.40) = 1.5 from the last mid, got %f", u)
+	}
+}
+
+func TestStopLossMarksPositionWithoutBookMid(t *testing.T) {
+	cfg := testConfig()
+	cfg.Maker.Enabled = false
+	cfg.Taker.Enabled = false
+	cfg.Risk.StopLossPerMarket = 1
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	n := &mockNotifier{}
+	a.notifier = n
+	buy := ws.TradeEvent{ID: "t-1", AssetID: "asset-1", Side: "BUY", Price: "0.40", Size: "10"}
+	a.observeTradePrice(buy)
+	a.tracker.ProcessTradeEvent(buy)
+
+	// The book went one-sided after the last trade printed well below entry.
+	a.observeTradePrice(ws.TradeEvent{AssetID: "asset-1", Price: "0.20"})
+	a.books.Update(ws.OrderbookEvent{AssetID: "asset-1", Bids: []ws.OrderbookLevel{{Price: "0.19", Size: "10"}}})
+
+	a.riskSync(context.Background())
+	if !slices.Equal(n.stopLosses, []string{"asset-1"}) {
+		t.Fatalf("expected the stop-loss to fire at the last trade price, got %v", n.stopLosses)
+	}
+}
+
+func TestUnpricedPositionsWithoutBookOrLastPrice(t *testing.T) {
+	a := New(testConfig(), nil, nil, nil, nil, nil, nil)
+	a.tracker.ProcessTradeEvent(ws.TradeEvent{ID: "t-1", AssetID: "asset-1", Side: "BUY", Price: "0.40", Size: "10"})
+
+	unpriced := a.UnpricedPositions()
+	if len(unpriced) != 1 || unpriced[0] != "asset-1" {
+		t.Fatalf("expected asset-1 unpriced, got %v", unpriced)
+	}
+	if _, ok := a.UnrealizedPnLByMarket()["asset-1"]; ok {
+		t.Fatal("expected unpriced position left out of unrealized PnL")
+	}
+}
+
+// preflightConfig is testConfig with a CLOB-style numeric token ID, which the
+// order builder requires.
+func preflightConfig() config.Config {
+	cfg := testConfig()
+	cfg.Maker.Markets = []string{"1001"}
+	return cfg
+}
+
+func TestPreflightReportsBadSigner(t *testing.T) {
+	cfg := preflightConfig()
+	ctx := context.Background()
+
+	a := New(cfg, &mockCLOB{}, nil, nil, nil, nil, nil)
+	err := a.Preflight(ctx)
+	if err == nil || !strings.Contains(err.Error(), "primary account: no signer configured") {
+		t.Fatalf("expected missing signer error, got %v", err)
+	}
+
+	a = New(cfg, &mockCLOB{}, nil, addrSigner{}, nil, nil, nil)
+	err = a.Preflight(ctx)
+	if err == nil || !strings.Contains(err.Error(), "primary account: signer has the zero address") {
+		t.Fatalf("expected zero address error, got %v", err)
+	}
+
+	good := addrSigner{addr: common.Address{19: 0xaa}}
+	a = New(cfg, &mockCLOB{}, nil, good, nil, nil, nil)
+	a.SetTakerAccount(&mockCLOB{}, nil, addrSigner{})
+	err = a.Preflight(ctx)
+	if err == nil || !strings.Contains(err.Error(), "taker account: signer has the zero address") || strings.Contains(err.Error(), "primary") {
+		t.Fatalf("expected only the taker account to fail, got %v", err)
+	}
+}
+
+func TestPreflightChecksAPIKeyWithoutPlacing(t *testing.T) {
+	cfg := preflightConfig()
+	good := addrSigner{addr: common.Address{19: 0xaa}}
+
+	client := &mockCLOB{}
+	a := New(cfg, client, nil, good, nil, nil, nil)
+	if err := a.Preflight(context.Background()); err != nil {
+		t.Fatalf("expected preflight to pass, got %v", err)
+	}
+	if len(client.created) != 0 {
+		t.Fatalf("expected nothing submitted, got %v", client.created)
+	}
+
+	client = &mockCLOB{openOrdersErr: &types.Error{Status: 401, Message: "Unauthorized/Invalid api key"}}
+	a = New(cfg, client, nil, good, nil, nil, nil)
+	err := a.Preflight(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "API key check") || !strings.Contains(err.Error(), "status=401") {
+		t.Fatalf("expected API key error, got %v", err)
+	}
+}
+
+func TestUnrealizedPnLUsesConfiguredMidMethod(t *testing.T) {
+	cfg := testConfig()
+	cfg.MidMethod = "microprice"
+	a := New(cfg, nil, nil, nil, nil, nil, nil)
+	a.tracker.ProcessTradeEvent(ws.TradeEvent{ID: "t-1", AssetID: "asset-1", Side: "BUY", Price: "0.50", Size: "10"})
+	a.books.Update(ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "300"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "100"}},
+	})
+	// Microprice (0.50*100 + 0.52*300) / 400 = 0.515, not the simple 0.51.
+	if u := a.UnrealizedPnL(); math.Abs(u-0.15) > 1e-9 {
+		t.Fatalf("expected 10 x (0.515-0.50) = 0.15, got %f", u)
+	}
+}
+
+// downData is a Data API client whose position and value queries fail with
+// err.
+type downData struct {
+	data.Client
+	err error
+}
+
+func (d *downData) Positions(context.Context, *data.PositionsRequest) (data.PositionsResponse, error) {
+	return nil, d.err
+}
+
+func (d *downData) Value(context.Context, *data.ValueRequest) (data.ValueResponse, error) {
+	return nil, d.err
+}
+
+// heldData serves each address's positions from a JSON data API response.
+type heldData struct {
+	data.Client
+	held map[common.Address]string
+}
+
+func (d *heldData) Positions(_ context.Context, req *data.PositionsRequest) (data.PositionsResponse, error) {
+	var resp data.PositionsResponse
+	err := json.Unmarshal([]byte(d.held[req.User]), &resp)
+	return resp, err
+}
+
+func TestReconcilePositionsSeedsEachAccountAtAvgPrice(t *testing.T) {
+	cfg := testConfig()
+	cfg.DryRun = false
+	cfg.TradingMode = "live"
+	primaryAddr, takerAddr := common.Address{19: 0xaa}, common.Address{19: 0xbb}
+	dataClient := &heldData{held: map[common.Address]string{
+		primaryAddr: `[{"asset":"1001","size":"10","avgPrice":"0.42"}]`,
+		takerAddr:   `[{"asset":"1002","size":"5","avgPrice":"0"}]`,
+	}}
+	a := New(cfg, &mockCLOB{}, nil, addrSigner{addr: primaryAddr}, nil, dataClient, nil)
+	a.SetTakerAccount(&mockCLOB{}, nil, addrSigner{addr: takerAddr})
+	a.books.Update(ws.OrderbookEvent{
+		AssetID: "1002",
+		Bids:    []ws.OrderbookLevel{{Price: "0.60", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.62", Size: "100"}},
+	})
+
+	a.ReconcileOnStart(context.Background())
+
+	if pos := a.tracker.Position("1001"); pos == nil || pos.NetSize != 10 || pos.AvgEntryPrice != 0.42 {
+		t.Fatalf("expected the primary position seeded at its 0.42 average price, got %+v", pos)
+	}
+	if pos := a.tracker.Position("1002"); pos != nil && pos.NetSize != 0 {
+		t.Fatalf("expected the taker account's position kept out of the primary tracker, got %+v", pos)
+	}
+	// Without an average price the position is adopted at the mid.
+	if pos := a.takerAcct.tracker.Position("1002"); pos == nil || pos.NetSize != 5 || math.Abs(pos.AvgEntryPrice-0.61) > 1e-9 {
+		t.Fatalf("expected the taker position seeded at the 0.61 mid, got %+v", pos)
+	}
+}
+
+func TestDependencyHealthNotConfigured(t *testing.T) {
+	a := New(testConfig(), nil, nil, nil, nil, nil, nil)
+	for _, name := range []string{"clob", "data", "ws", "rtds"} {
+		if configured, err := a.DependencyHealth(name); configured || err != nil {
+			t.Errorf("%s: expected not configured, got configured=%v err=%v", name, configured, err)
+		}
+	}
+}
+
+func TestDependencyHealthCLOBDegraded(t *testing.T) {
+	client := &mockCLOB{feeRateErrs: []error{&types.Error{Status: 404, Message: "market not found"}}}
+	a := New(testConfig(), client, nil, nil, nil, nil, nil)
+
+	a.fetchFeeRates(context.Background(), []string{"asset-1"})
+	if configured, err := a.DependencyHealth("clob"); !configured || err == nil {
+		t.Fatalf("expected clob degraded after a failed fee rate fetch, got configured=%v err=%v", configured, err)
+	}
+	a.fetchFeeRates(context.Background(), []string{"asset-1"})
+	if _, err := a.DependencyHealth("clob"); err != nil {
+		t.Fatalf("expected clob healthy after a successful fetch, got %v", err)
+	}
+}
+
+func TestDependencyHealthDataDegraded(t *testing.T) {
+	client := &downData{err: errors.New("data api: 502")}
+	a := New(testConfig(), nil, nil, addrSigner{addr: common.Address{19: 0xaa}}, nil, client, nil)
+
+	if configured, err := a.DependencyHealth("data"); !configured || err != nil {
+		t.Fatalf("expected data healthy before any call, got configured=%v err=%v", configured, err)
+	}
+	a.reconcilePositions(context.Background(), a.primaryAccount())
+	if _, err := a.DependencyHealth("data"); err == nil {
+		t.Fatal("expected data degraded after a failed positions fetch")
+	}
+
+	// A failed portfolio sync alone degrades the data client too.
+	a.setDependencyErr("data", nil)
+	if err := a.Portfolio.Sync(context.Background()); err == nil {
+		t.Fatal("expected portfolio sync to fail")
+	}
+	if _, err := a.DependencyHealth("data"); err == nil {
+		t.Fatal("expected data degraded after a failed portfolio sync")
+	}
+}
+
+func TestDependencyHealthWSDegraded(t *testing.T) {
+	wsClient := &flakyWS{failures: 1}
+	a := New(testConfig(), nil, wsClient, nil, nil, nil, nil)
+	a.running = true
+
+	if _, err := a.subscribeFeeds(context.Background(), []string{"asset-1"}); err == nil {
+		t.Fatal("expected the first book subscription to fail")
+	}
+	if configured, err := a.DependencyHealth("ws"); !configured || err == nil {
+		t.Fatalf("expected ws degraded after a failed subscription, got configured=%v err=%v", configured, err)
+	}
+	if _, err := a.subscribeFeeds(context.Background(), []string{"asset-1"}); err != nil {
+		t.Fatalf("subscribeFeeds: %v", err)
+	}
+	if _, err := a.DependencyHealth("ws"); err != nil {
+		t.Fatalf("expected ws healthy once subscribed, got %v", err)
+	}
+
+	a.setFeedConnected(false)
+	if _, err := a.DependencyHealth("ws"); !errors.Is(err, errFeedDisconnected) {
+		t.Fatalf("expected ws degraded while the feed is disconnected, got %v", err)
+	}
+}
+
+func TestDependencyHealthRTDSDegraded(t *testing.T) {
+	cfg := testConfig()
+	cfg.Crypto.Mapping = map[string][]string{"BTCUSDT": {"btc-yes"}}
+	stream := &stubRTDS{err: errors.New("rtds: handshake failed")}
+	a := New(cfg, nil, nil, nil, nil, nil, stream)
+
+	if ch := a.subscribeCrypto(context.Background()); ch != nil {
+		t.Fatal("expected no crypto stream when the subscription fails")
+	}
+	if configured, err := a.DependencyHealth("rtds"); !configured || err == nil {
+		t.Fatalf("expected rtds degraded, got configured=%v err=%v", configured, err)
+	}
+	stream.err = nil
+	if ch := a.subscribeCrypto(context.Background()); ch == nil {
+		t.Fatal("expected a crypto stream")
+	}
+	if _, err := a.DependencyHealth("rtds"); err != nil {
+		t.Fatalf("expected rtds healthy after resubscribing, got %v", err)
 	}
 }
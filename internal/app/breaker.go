@@ -0,0 +1,89 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// placementBreaker pauses live order placement after a streak of failed
+// submissions. max consecutive failures, each within window of the previous
+// ones, open the breaker for cooldown; any success resets the streak. A
+// non-positive max disables it.
+type placementBreaker struct {
+	mu        sync.Mutex
+	max       int
+	window    time.Duration
+	cooldown  time.Duration
+	streak    []time.Time // consecutive failure times, oldest first
+	openUntil time.Time
+	trips     int64
+}
+
+func newPlacementBreaker(max int, window, cooldown time.Duration) *placementBreaker {
+	return &placementBreaker{max: max, window: window, cooldown: cooldown}
+}
+
+// Allow reports whether placement may proceed at now.
+func (b *placementBreaker) Allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.max <= 0 || !now.Before(b.openUntil)
+}
+
+// Failure records a failed placement and reports whether it tripped the
+// breaker.
+func (b *placementBreaker) Failure(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.max <= 0 {
+		return false
+	}
+	cutoff := now.Add(-b.window)
+	i := 0
+	for i < len(b.streak) && b.streak[i].Before(cutoff) {
+		i++
+	}
+	b.streak = append(b.streak[i:], now)
+	if len(b.streak) < b.max {
+		return false
+	}
+	b.streak = nil
+	b.openUntil = now.Add(b.cooldown)
+	b.trips++
+	return true
+}
+
+// Success resets the failure streak.
+func (b *placementBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.streak = nil
+}
+
+// Trips returns how many times the breaker has opened.
+func (b *placementBreaker) Trips() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.trips
+}
+
+// recordPlacement feeds a live submission result through acct to its breaker
+// and alerts when it trips.
+func (a *App) recordPlacement(ctx context.Context, acct *account, err error) {
+	if err == nil {
+		acct.breaker.Success()
+		return
+	}
+	if !acct.breaker.Failure(a.now()) {
+		return
+	}
+	cooldown := a.cfg.PlacementBreakerCooldown
+	log.Printf("placement circuit breaker open on the %s account for %s after %d consecutive failures: %v", acct.name, cooldown, a.cfg.MaxPlacementFailures, err)
+	if a.notifier != nil {
+		_ = a.notifier.NotifyAlert(ctx, "Placement Circuit Breaker",
+			fmt.Sprintf("%d consecutive order placements on the %s account failed; pausing its placement for %s. Last error: %v", a.cfg.MaxPlacementFailures, acct.name, cooldown, err))
+	}
+}
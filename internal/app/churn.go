@@ -0,0 +1,58 @@
+package app
+
+import (
+	"log"
+	"slices"
+	"time"
+)
+
+// recordMakerCancel counts a requote that cancelled assetID's quotes. Once
+// maker.max_cancels_before_cooldown such cancels land within
+// maker.cancel_window with no fill in between, the asset is put on cooldown
+// and true is returned.
+func (a *App) recordMakerCancel(assetID string, now time.Time) bool {
+	limit := a.cfg.Maker.MaxCancelsBeforeCooldown
+	if limit <= 0 {
+		return false
+	}
+	cutoff := now.Add(-a.cfg.Maker.CancelWindow)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	cancels := slices.DeleteFunc(a.makerCancels[assetID], func(t time.Time) bool { return !t.After(cutoff) })
+	cancels = append(cancels, now)
+	if len(cancels) < limit {
+		a.makerCancels[assetID] = cancels
+		return false
+	}
+	delete(a.makerCancels, assetID)
+	a.cooldownUntil[assetID] = now.Add(a.cfg.Maker.CancelCooldown)
+	log.Printf("maker %s: %d cancels without a fill in %s, cooling down for %s",
+		assetID, len(cancels), a.cfg.Maker.CancelWindow, a.cfg.Maker.CancelCooldown)
+	return true
+}
+
+// inCancelCooldown reports whether assetID is sitting out a cancel cooldown.
+func (a *App) inCancelCooldown(assetID string, now time.Time) bool {
+	a.mu.RLock()
+	until, ok := a.cooldownUntil[assetID]
+	a.mu.RUnlock()
+	return ok && now.Before(until)
+}
+
+// CancelCooldownAssets lists, sorted, the assets the maker is not quoting
+// because their quotes churned without fills.
+func (a *App) CancelCooldownAssets() []string {
+	now := a.now()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var out []string
+	for assetID, until := range a.cooldownUntil {
+		if now.Before(until) {
+			out = append(out, assetID)
+		} else {
+			delete(a.cooldownUntil, assetID)
+		}
+	}
+	slices.Sort(out)
+	return out
+}
@@ -0,0 +1,30 @@
+package app
+
+import "time"
+
+// Clock supplies the current time. Live runs use the wall clock; backtests
+// substitute the timestamp of the event being replayed.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// SetClock replaces the app's time source. It resets KPI collection so the
+// reporting day starts at the new clock's date, and must be called before the
+// first event is handled.
+func (a *App) SetClock(c Clock) {
+	a.clock = c
+	a.kpi = newKPICollector(a.cfg.Taker.RealizationWindow, c)
+	a.tracker.SetClock(c.Now)
+	a.books.SetClock(c.Now)
+	if a.paperSim != nil {
+		a.paperSim.SetClock(c.Now)
+	}
+}
+
+func (a *App) now() time.Time {
+	return a.clock.Now().UTC()
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"math"
+
+	"github.com/GoPolymarket/polymarket-trader/internal/feed"
+)
+
+// profitablePairCost walks the YES and NO ask ladders together and returns
+// how many YES+NO pairs can be bought while each pair costs at most maxSum,
+// and the USDC that buying them would spend.
+func profitablePairCost(yesAsks, noAsks []feed.Level, maxSum float64) (pairs, costUSDC float64) {
+	i, j := 0, 0
+	var yesUsed, noUsed float64
+	for i < len(yesAsks) && j < len(noAsks) {
+		y, n := yesAsks[i], noAsks[j]
+		if y.Price+n.Price > maxSum {
+			break
+		}
+		qty := math.Min(y.Size-yesUsed, n.Size-noUsed)
+		pairs += qty
+		costUSDC += qty * (y.Price + n.Price)
+		yesUsed += qty
+		noUsed += qty
+		if yesUsed >= y.Size {
+			i++
+			yesUsed = 0
+		}
+		if noUsed >= n.Size {
+			j++
+			noUsed = 0
+		}
+	}
+	return pairs, costUSDC
+}
+
+// convergenceArbSize caps the configured arb amount at what the books can
+// absorb while the edge still clears minEdgeBps. For an underpriced pair
+// (sum < 1) that is the cost of YES+NO pairs priced below 1-minEdge; for an
+// overpriced pair it is the target's bid notional above 1+minEdge minus the
+// other leg's mid.
+func (a *App) convergenceArbSize(yesID, noID, targetID string, sum, otherMid, minEdgeBps, amount float64) float64 {
+	minEdge := minEdgeBps / 10000
+	var available float64
+	if sum < 1 {
+		_, available = profitablePairCost(a.books.Levels(yesID, "SELL"), a.books.Levels(noID, "SELL"), 1-minEdge)
+	} else {
+		_, available = a.books.DepthAtOrBetter(targetID, "BUY", 1+minEdge-otherMid)
+	}
+	return math.Min(amount, available)
+}
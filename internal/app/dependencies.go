@@ -0,0 +1,57 @@
+package app
+
+import "errors"
+
+// errFeedDisconnected is the ws health error while the book stream is down
+// and being reconnected.
+var errFeedDisconnected = errors.New("order book feed disconnected")
+
+// setDependencyErr records the outcome of the latest call to a dependency;
+// nil marks it healthy again.
+func (a *App) setDependencyErr(name string, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err == nil {
+		delete(a.depErrs, name)
+		return
+	}
+	if a.depErrs == nil {
+		a.depErrs = make(map[string]error)
+	}
+	a.depErrs[name] = err
+}
+
+// DependencyHealth reports whether the named integration — "clob", "data",
+// "ws" or "rtds" — was given a client, and the error from its latest failed
+// call, or nil while it is working. Unknown names are not configured.
+//
+// The data client is degraded while the portfolio or builder tracker's last
+// sync failed, and ws while the order book feed is disconnected.
+func (a *App) DependencyHealth(name string) (configured bool, err error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	err = a.depErrs[name]
+	switch name {
+	case "clob":
+		if err == nil {
+			err = a.heartbeatErr
+		}
+		return a.clobClient != nil, err
+	case "data":
+		if err == nil && a.Portfolio != nil {
+			err = a.Portfolio.LastError()
+		}
+		if err == nil && a.BuilderTracker != nil {
+			err = a.BuilderTracker.LastError()
+		}
+		return a.dataClient != nil, err
+	case "ws":
+		if err == nil && a.running && !a.feedConnected {
+			err = errFeedDisconnected
+		}
+		return a.wsClient != nil, err
+	case "rtds":
+		return a.rtdsClient != nil, err
+	}
+	return false, nil
+}
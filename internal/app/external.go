@@ -0,0 +1,90 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/clobtypes"
+
+	"github.com/GoPolymarket/polymarket-trader/internal/strategy"
+)
+
+// externalSignalTimeout bounds how long SubmitExternalSignal waits for the
+// trading loop to pick up a signal.
+const externalSignalTimeout = 10 * time.Second
+
+type externalSignalResult struct {
+	orderID string
+	err     error
+}
+
+type externalSignalRequest struct {
+	sig  strategy.ExternalSignal
+	done chan externalSignalResult
+}
+
+// SubmitExternalSignal places an order for a signal from an external feed after
+// the usual risk checks. A positive MaxPrice places a GTC limit at that price;
+// otherwise a market order is sent. The order is tagged "external". While the
+// trading loop is running the order is placed on the loop goroutine, which
+// owns the order and market state.
+func (a *App) SubmitExternalSignal(ctx context.Context, sig strategy.ExternalSignal) (string, error) {
+	sig.Normalize()
+	if err := sig.Validate(); err != nil {
+		return "", err
+	}
+
+	a.mu.RLock()
+	running := a.running
+	a.mu.RUnlock()
+	if !running {
+		return a.placeExternalSignal(ctx, sig)
+	}
+
+	req := externalSignalRequest{sig: sig, done: make(chan externalSignalResult, 1)}
+	select {
+	case a.externalReqCh <- req:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case <-time.After(externalSignalTimeout):
+		return "", errors.New("external signal: timed out waiting for the trading loop")
+	}
+	select {
+	case res := <-req.done:
+		return res.orderID, res.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// placeExternalSignal risk-checks and places a validated external signal.
+func (a *App) placeExternalSignal(ctx context.Context, sig strategy.ExternalSignal) (string, error) {
+	if a.cfg.DryRun {
+		log.Printf("[DRY] external signal: %s %s amount=%.2f reason=%s",
+			sig.Side, sig.AssetID, sig.AmountUSDC, sig.Reason)
+	}
+	if err := a.riskMgr.Allow(sig.AssetID, sig.Side, sig.AmountUSDC); err != nil {
+		a.riskBlocked(a.now(), sig.AssetID, sig.Side, sig.AmountUSDC, err)
+		return "", err
+	}
+
+	var resp clobtypes.OrderResponse
+	if sig.MaxPrice > 0 {
+		resp = a.placeLimit(ctx, sig.AssetID, sig.Side, sig.MaxPrice, sig.AmountUSDC)
+	} else {
+		resp = a.placeMarket(ctx, sig.AssetID, sig.Side, sig.AmountUSDC)
+	}
+	if resp.ID == "" {
+		return "", fmt.Errorf("external signal %s %s: order not placed", sig.Side, sig.AssetID)
+	}
+	if a.execMode == "live" {
+		a.tracker.RegisterOrder(resp.ID, sig.AssetID, a.assetToMarket[sig.AssetID], sig.Side, sig.MaxPrice, sig.AmountUSDC)
+	}
+	a.tracker.TagOrder(resp.ID, strategy.ExternalStrategyTag)
+	log.Printf("external trade: %s %s amount=%.2f id=%s reason=%s",
+		sig.Side, sig.AssetID, sig.AmountUSDC, resp.ID, sig.Reason)
+	return resp.ID, nil
+}
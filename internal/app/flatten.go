@@ -0,0 +1,92 @@
+package app
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// FlattenAll cancels every open order and market-closes every non-zero
+// position, leaving the book flat. Paper mode and dry-run cancel and close
+// against the simulator. The maker and taker then stay paused until the
+// trading window closes, or an operator turns a strategy back on.
+func (a *App) FlattenAll(ctx context.Context) {
+	now := a.now()
+	a.mu.Lock()
+	a.flattenPausedUntil = windowClose(now, a.cfg.FlattenTime)
+	a.mu.Unlock()
+
+	var orderIDs []string
+	for _, ids := range a.activeOrders {
+		orderIDs = append(orderIDs, ids...)
+	}
+	switch {
+	case a.execMode == "paper":
+		a.cancelPaperOrders(orderIDs)
+	case a.execMode == "live" && a.clobClient != nil:
+		if err := a.limiter.Wait(ctx); err != nil {
+			log.Printf("flatten: cancel all: %v", err)
+		} else if resp, err := a.clobClient.CancelAll(ctx); err != nil {
+			log.Printf("flatten: cancel all: %v", err)
+		} else {
+			log.Printf("flatten: cancelled %d orders", resp.Count)
+		}
+		a.cancelTakerAccountOrders(ctx)
+	}
+	clear(a.activeOrders)
+
+	closed := 0
+	for _, acct := range a.accounts() {
+		for assetID, pos := range acct.tracker.Positions() {
+			if pos.NetSize == 0 {
+				continue
+			}
+			a.unwindPosition(ctx, acct, assetID, pos)
+			closed++
+		}
+	}
+	log.Printf("flatten: closing %d positions", closed)
+}
+
+// flattenPaused reports whether a flatten is keeping the strategies out at
+// now.
+func (a *App) flattenPaused(now time.Time) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return now.Before(a.flattenPausedUntil)
+}
+
+// clearFlattenPause lets the strategies trade again before the window closes.
+func (a *App) clearFlattenPause() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.flattenPausedUntil.IsZero() {
+		log.Println("flatten pause cleared")
+	}
+	a.flattenPausedUntil = time.Time{}
+}
+
+// windowClose returns the UTC midnight session close following a flatten at
+// now with daily flatten time hhmm. Without hhmm the flatten runs at the close
+// itself, which has then passed, so it returns now.
+func windowClose(now time.Time, hhmm string) time.Time {
+	if hhmm == "" {
+		return now
+	}
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+}
+
+// untilFlatten returns the time from now to the next daily flatten, at
+// hhmm UTC or, when hhmm is empty, at the UTC midnight session close.
+func untilFlatten(now time.Time, hhmm string) time.Duration {
+	now = now.UTC()
+	next := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if t, err := time.Parse("15:04", hhmm); err == nil {
+		next = next.Add(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute)
+	}
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next.Sub(now)
+}
@@ -0,0 +1,29 @@
+package app
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// sendHeartbeat sends one keepalive and records its outcome for /api/status.
+func (a *App) sendHeartbeat(ctx context.Context) {
+	if a.heartbeatClient == nil {
+		return
+	}
+	_, err := a.heartbeatClient.Heartbeat(ctx, nil)
+	if err != nil {
+		log.Printf("heartbeat: %v", err)
+	}
+	a.mu.Lock()
+	a.lastHeartbeat, a.heartbeatErr = a.now(), err
+	a.mu.Unlock()
+}
+
+// HeartbeatStatus returns when the last keepalive was sent and the error it
+// returned, if any. last is zero until the first heartbeat goes out.
+func (a *App) HeartbeatStatus() (last time.Time, err error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.lastHeartbeat, a.heartbeatErr
+}
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// unwindAgedPositions market-closes positions that have stayed open longer
+// than risk.max_holding_time, measured from when each last left flat. Like
+// the stop-loss, an unwind that does not fill is retried on the next sync.
+// Each position is closed through the account holding it.
+func (a *App) unwindAgedPositions(ctx context.Context) {
+	maxHold := a.cfg.Risk.MaxHoldingTime
+	if maxHold <= 0 {
+		return
+	}
+	now := a.now()
+	for _, acct := range a.accounts() {
+		for assetID, pos := range acct.tracker.Positions() {
+			if pos.NetSize == 0 || pos.OpenedAt.IsZero() {
+				continue
+			}
+			if held := now.Sub(pos.OpenedAt); held >= maxHold {
+				log.Printf("max holding time: %s open for %s (size=%.4f), unwinding", assetID, held.Round(time.Second), pos.NetSize)
+				a.unwindPosition(ctx, acct, assetID, pos)
+			}
+		}
+	}
+}
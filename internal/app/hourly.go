@@ -0,0 +1,50 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// hourlySummaryInterval is how often notify.hourly_summary reports.
+const hourlySummaryInterval = time.Hour
+
+// buildHourlySummary renders the fills and net PnL since the previous summary
+// along with the session totals, best and worst market and whether trading is
+// allowed. ok is false when nothing filled and the risk manager is blocking
+// trading, so a long cooldown does not produce a stream of empty reports.
+func (a *App) buildHourlySummary() (text string, ok bool) {
+	_, fills, _ := a.Stats()
+	_, _, netPnL := a.sessionPnL()
+	hourFills := fills - a.hourlyFills
+	hourPnL := netPnL - a.hourlyNetPnL
+
+	reasons := riskBlockedReasonsFromSnapshot(a.riskMgr.Snapshot())
+	canTrade := len(reasons) == 0
+	if hourFills == 0 && !canTrade {
+		return "", false
+	}
+	a.hourlyFills, a.hourlyNetPnL = fills, netPnL
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Fills: %d (%d total)\n", hourFills, fills)
+	fmt.Fprintf(&b, "Net PnL: %+.2f USDC (%.2f session)\n", hourPnL, netPnL)
+	if best, worst := a.bestRealizedMarket(), a.worstRealizedMarket(); best != "" {
+		fmt.Fprintf(&b, "Best: %s\nWorst: %s\n", best, worst)
+	}
+	fmt.Fprintf(&b, "Can trade: %t", canTrade)
+	if !canTrade {
+		fmt.Fprintf(&b, " (%s)", strings.Join(reasons, ", "))
+	}
+	return b.String(), true
+}
+
+func (a *App) sendHourlySummary(ctx context.Context) {
+	if a.notifier == nil {
+		return
+	}
+	if text, ok := a.buildHourlySummary(); ok {
+		_ = a.notifier.NotifyAlert(ctx, "Hourly Summary", text)
+	}
+}
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// checkIdle alerts once when nothing has filled for notify.idle_alert_after,
+// counting from the first check or the last fill, whichever is later. A
+// fill re-arms it through markFill.
+func (a *App) checkIdle(ctx context.Context) {
+	after := a.cfg.Notify.IdleAlertAfter
+	if after <= 0 || a.notifier == nil {
+		return
+	}
+	now := a.now()
+	a.mu.Lock()
+	if a.idleSince.IsZero() {
+		a.idleSince = now
+	}
+	idle := now.Sub(a.idleSince)
+	if a.idleAlerted || idle < after {
+		a.mu.Unlock()
+		return
+	}
+	a.idleAlerted = true
+	a.mu.Unlock()
+
+	assets := a.MonitoredAssets()
+	list := "none"
+	if len(assets) > 0 {
+		list = strings.Join(assets, ", ")
+	}
+	_ = a.notifier.NotifyAlert(ctx, "No Fills",
+		fmt.Sprintf("No fills for %s across %d monitored assets (%s). Review market selection and quoting parameters such as spread and order size.",
+			idle.Round(time.Minute), len(assets), list))
+}
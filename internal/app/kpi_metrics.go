@@ -8,6 +8,7 @@ import (
 )
 
 const (
+	kpiWindow7d                   = 7 * 24 * time.Hour
 	kpiWindow30d                  = 30 * 24 * time.Hour
 	defaultTakerRealizationWindow = 5 * time.Minute
 )
@@ -24,6 +25,34 @@ type kpiPnLSample struct {
 	net      float64
 }
 
+// kpiDrawdown follows the peak of net PnL after fees and the deepest fall
+// from a peak seen since it was last reset.
+type kpiDrawdown struct {
+	set       bool
+	peak      float64
+	max       float64 // USDC, >= 0
+	peakAtMax float64 // the peak the deepest fall was measured from
+}
+
+func (d *kpiDrawdown) observe(net float64) {
+	if !d.set || net > d.peak {
+		d.peak, d.set = net, true
+	}
+	if dd := d.peak - net; dd > d.max {
+		d.max, d.peakAtMax = dd, d.peak
+	}
+}
+
+// pct is the max drawdown as a percentage of equity at its peak, taking
+// equity as capital plus net PnL. It is 0 without a positive base.
+func (d kpiDrawdown) pct(capital float64) float64 {
+	base := capital + d.peakAtMax
+	if d.max <= 0 || base <= 0 {
+		return 0
+	}
+	return d.max / base * 100
+}
+
 type kpiPendingTakerSignal struct {
 	assetID    string
 	side       string
@@ -55,6 +84,7 @@ type kpiCollector struct {
 	makerSpreadCaptureSamplesDaily     int
 	takerRealizationCorrectDaily       int
 	takerRealizationEvaluatedDaily     int
+	takerRealizationWindow             time.Duration
 	takerRealizationWindowMinutes      int
 	pendingTakerSignals                []kpiPendingTakerSignal
 	riskComplianceSamples              []kpiRiskSample
@@ -67,15 +97,24 @@ type kpiCollector struct {
 	dailyBaselineTotalPnL              float64
 	dailyBaselineNetPnLAfterFees       float64
 	netPnL30dWindowEffectiveDaysCached int
+	drawdownDaily                      kpiDrawdown
+	drawdownSession                    kpiDrawdown
 }
 
-func newKPICollector() *kpiCollector {
-	now := time.Now().UTC()
+// newKPICollector creates a collector whose taker realization window
+// defaults to defaultTakerRealizationWindow when realizationWindow <= 0. The
+// first reporting day starts at clock's current UTC day.
+func newKPICollector(realizationWindow time.Duration, clock Clock) *kpiCollector {
+	if realizationWindow <= 0 {
+		realizationWindow = defaultTakerRealizationWindow
+	}
+	now := clock.Now().UTC()
 	return &kpiCollector{
 		dayStartUTC:                   startOfUTCDay(now),
 		lastUpdated:                   now,
 		riskBlockEventsDailyByReason:  make(map[string]int),
-		takerRealizationWindowMinutes: int(defaultTakerRealizationWindow / time.Minute),
+		takerRealizationWindow:        realizationWindow,
+		takerRealizationWindowMinutes: int(realizationWindow / time.Minute),
 	}
 }
 
@@ -131,6 +170,10 @@ func (c *kpiCollector) ensureDayLocked(now time.Time) {
 	c.dailyBaselineTotalPnL = c.currentTotalPnL
 	c.dailyBaselineNetPnLAfterFees = c.currentNetPnLAfterFees
 	c.dailyBaselineSet = true
+	c.drawdownDaily = kpiDrawdown{}
+	if c.drawdownSession.set {
+		c.drawdownDaily.observe(c.currentNetPnLAfterFees)
+	}
 }
 
 func (c *kpiCollector) pruneLocked(now time.Time) {
@@ -179,11 +222,20 @@ func round6(v float64) float64 {
 	return math.Round(v*1e6) / 1e6
 }
 
-func (c *kpiCollector) recordMakerSignal(now time.Time, spreadCaptureBps float64) {
+func (c *kpiCollector) recordMakerSignal(now time.Time) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.ensureDayLocked(now)
 	c.makerSignalCountDaily++
+	c.lastUpdated = now
+}
+
+// recordMakerSpreadCapture adds one realized maker fill, scored in bps
+// against the mid when its quote was placed.
+func (c *kpiCollector) recordMakerSpreadCapture(now time.Time, spreadCaptureBps float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ensureDayLocked(now)
 	if !math.IsNaN(spreadCaptureBps) && !math.IsInf(spreadCaptureBps, 0) {
 		c.makerSpreadCaptureBpsSumDaily += spreadCaptureBps
 		c.makerSpreadCaptureSamplesDaily++
@@ -197,7 +249,7 @@ func (c *kpiCollector) recordTakerSignal(now time.Time, assetID, side string, tr
 	c.ensureDayLocked(now)
 	c.takerSignalCountDaily++
 	if horizon <= 0 {
-		horizon = defaultTakerRealizationWindow
+		horizon = c.takerRealizationWindow
 	}
 	side = normalizeSide(side)
 	if side != "" && assetID != "" && triggerMid > 0 {
@@ -249,7 +301,9 @@ func (c *kpiCollector) recordOrderSubmitted(now time.Time) {
 	c.lastUpdated = now
 }
 
-func (c *kpiCollector) recordFill(now time.Time) {
+// recordOrderFilled counts an order that matched for the first time, so an
+// order filled in pieces counts once against the orders submitted.
+func (c *kpiCollector) recordOrderFilled(now time.Time) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.ensureDayLocked(now)
@@ -325,6 +379,9 @@ func (c *kpiCollector) recordPnLSample(now time.Time, realizedPnL, totalPnL, fee
 		c.dailyBaselineSet = true
 	}
 
+	c.drawdownDaily.observe(net)
+	c.drawdownSession.observe(net)
+
 	c.pnlSamples = append(c.pnlSamples, kpiPnLSample{
 		at:       now,
 		realized: realizedPnL,
@@ -335,6 +392,91 @@ func (c *kpiCollector) recordPnLSample(now time.Time, realizedPnL, totalPnL, fee
 	c.lastUpdated = now
 }
 
+// kpiPnLWindow is the PnL change over a rolling window and how many days of
+// samples it actually spans.
+type kpiPnLWindow struct {
+	realized      float64
+	total         float64
+	net           float64
+	effectiveDays int
+}
+
+// pnlWindowLocked measures PnL from the last sample at or before now-window
+// (or the first sample, if history is shorter) to the latest one.
+func (c *kpiCollector) pnlWindowLocked(now time.Time, window time.Duration) kpiPnLWindow {
+	var out kpiPnLWindow
+	if len(c.pnlSamples) == 0 {
+		return out
+	}
+	cutoff := now.Add(-window)
+	base := c.pnlSamples[0]
+	for _, sample := range c.pnlSamples[1:] {
+		if sample.at.After(cutoff) {
+			break
+		}
+		base = sample
+	}
+	latest := c.pnlSamples[len(c.pnlSamples)-1]
+	out.realized = latest.realized - base.realized
+	out.total = latest.total - base.total
+	out.net = latest.net - base.net
+
+	windowStart := base.at
+	if windowStart.Before(cutoff) {
+		windowStart = cutoff
+	}
+	if latest.at.After(windowStart) {
+		out.effectiveDays = int(math.Ceil(latest.at.Sub(windowStart).Hours() / 24))
+	}
+	if out.effectiveDays <= 0 {
+		out.effectiveDays = 1
+	}
+	return out
+}
+
+// pnlHistory returns PnL samples taken at or after since, oldest first. With a
+// positive bucket the samples are downsampled to the last one in each bucket,
+// stamped with the bucket start.
+func (c *kpiCollector) pnlHistory(since time.Time, bucket time.Duration) []map[string]interface{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var selected []kpiPnLSample
+	for _, sample := range c.pnlSamples {
+		if sample.at.Before(since) {
+			continue
+		}
+		at := sample.at
+		if bucket > 0 {
+			at = at.Truncate(bucket)
+			if n := len(selected); n > 0 && selected[n-1].at.Equal(at) {
+				selected = selected[:n-1]
+			}
+		}
+		sample.at = at
+		selected = append(selected, sample)
+	}
+
+	out := make([]map[string]interface{}, 0, len(selected))
+	for _, sample := range selected {
+		out = append(out, map[string]interface{}{
+			"timestamp": sample.at.UTC(),
+			"realized":  round6(sample.realized),
+			"total":     round6(sample.total),
+			"net":       round6(sample.net),
+		})
+	}
+	return out
+}
+
+// drawdowns returns today's and the session's drawdown trackers.
+func (c *kpiCollector) drawdowns(now time.Time) (daily, session kpiDrawdown) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ensureDayLocked(now)
+	return c.drawdownDaily, c.drawdownSession
+}
+
 func (c *kpiCollector) snapshot(now time.Time) map[string]interface{} {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -346,6 +488,12 @@ func (c *kpiCollector) snapshot(now time.Time) map[string]interface{} {
 	if c.makerSpreadCaptureSamplesDaily > 0 {
 		makerSpreadCaptureBps = c.makerSpreadCaptureBpsSumDaily / float64(c.makerSpreadCaptureSamplesDaily)
 	}
+	// Share of today's submitted orders that filled, each counted once however
+	// many fills it took.
+	fillRatio := 0.0
+	if c.submittedOrdersDaily > 0 {
+		fillRatio = float64(c.filledOrdersDaily) / float64(c.submittedOrdersDaily)
+	}
 	takerSignalRealizationRate := 0.0
 	if c.takerRealizationEvaluatedDaily > 0 {
 		takerSignalRealizationRate = float64(c.takerRealizationCorrectDaily) / float64(c.takerRealizationEvaluatedDaily)
@@ -373,28 +521,12 @@ func (c *kpiCollector) snapshot(now time.Time) map[string]interface{} {
 		riskCompliance30d = float64(riskSamplesTradable) / float64(riskSamplesTotal)
 	}
 
-	netPnL30dRealized := 0.0
-	netPnL30dTotal := 0.0
-	netPnL30dAfterFees := 0.0
-	windowDays := 0
-	if len(c.pnlSamples) > 0 {
-		latest := c.pnlSamples[len(c.pnlSamples)-1]
-		base := c.pnlSamples[0]
-		netPnL30dRealized = latest.realized - base.realized
-		netPnL30dTotal = latest.total - base.total
-		netPnL30dAfterFees = latest.net - base.net
-		windowStart := base.at
-		cutoff := now.Add(-kpiWindow30d)
-		if windowStart.Before(cutoff) {
-			windowStart = cutoff
-		}
-		if latest.at.After(windowStart) {
-			windowDays = int(math.Ceil(latest.at.Sub(windowStart).Hours() / 24))
-		}
-		if windowDays <= 0 {
-			windowDays = 1
-		}
-	}
+	pnl7d := c.pnlWindowLocked(now, kpiWindow7d)
+	pnl30d := c.pnlWindowLocked(now, kpiWindow30d)
+	netPnL30dRealized := pnl30d.realized
+	netPnL30dTotal := pnl30d.total
+	netPnL30dAfterFees := pnl30d.net
+	windowDays := pnl30d.effectiveDays
 	c.netPnL30dWindowEffectiveDaysCached = windowDays
 
 	dailyNet := 0.0
@@ -422,6 +554,7 @@ func (c *kpiCollector) snapshot(now time.Time) map[string]interface{} {
 		"taker_signal_count_daily":                c.takerSignalCountDaily,
 		"submitted_orders_daily":                  c.submittedOrdersDaily,
 		"filled_orders_daily":                     c.filledOrdersDaily,
+		"fill_ratio_daily":                        round6(fillRatio),
 		"risk_block_events_daily":                 c.riskBlockEventsDaily,
 		"risk_block_events_daily_by_reason":       byReason,
 		"risk_block_last_reason":                  c.riskBlockLastReason,
@@ -436,6 +569,10 @@ func (c *kpiCollector) snapshot(now time.Time) map[string]interface{} {
 		"risk_compliance_30d":                     round6(clampFloat(riskCompliance30d, 0, 1)),
 		"risk_compliance_samples_30d":             riskSamplesTotal,
 		"risk_compliance_tradable_samples_30d":    riskSamplesTradable,
+		"net_pnl_7d_realized_usdc":                round6(pnl7d.realized),
+		"net_pnl_7d_total_usdc":                   round6(pnl7d.total),
+		"net_pnl_7d_after_fees_usdc":              round6(pnl7d.net),
+		"net_pnl_7d_window_effective_days":        pnl7d.effectiveDays,
 		"net_pnl_30d_realized_usdc":               round6(netPnL30dRealized),
 		"net_pnl_30d_total_usdc":                  round6(netPnL30dTotal),
 		"net_pnl_30d_after_fees_usdc":             round6(netPnL30dAfterFees),
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"github.com/GoPolymarket/polymarket-trader/internal/config"
+	"github.com/GoPolymarket/polymarket-trader/internal/strategy"
+)
+
+// applyMarketOverrides rebuilds the per-asset maker and taker instances from
+// the configured overrides. Existing takers are reconfigured in place so their
+// cooldown and daily-count state survives a reload.
+func (a *App) applyMarketOverrides(overrides map[string]config.MarketOverride) {
+	makers := make(map[string]*strategy.Maker, len(overrides))
+	takers := make(map[string]*strategy.Taker, len(overrides))
+	for assetID, o := range overrides {
+		if m, ok := a.marketMakers[assetID]; ok {
+			m.SetConfig(makerConfig(o.Maker))
+			makers[assetID] = m
+		} else {
+			m := strategy.NewMaker(makerConfig(o.Maker))
+			m.SetVolatility(a.vol)
+			m.SetMidMethod(a.midMethod)
+			makers[assetID] = m
+		}
+		if tk, ok := a.marketTakers[assetID]; ok {
+			tk.SetConfig(takerConfig(o.Taker))
+			takers[assetID] = tk
+		} else {
+			tk := strategy.NewTaker(takerConfig(o.Taker))
+			tk.ShareGlobalCooldown(a.taker)
+			if a.signals != nil {
+				tk.SetSignalObserver(a.signals.record)
+			}
+			takers[assetID] = tk
+		}
+	}
+	a.marketMakers = makers
+	a.marketTakers = takers
+}
+
+// StrategyParams returns the maker and taker parameters in effect, after
+// reloads, for assetID: its market override when one exists, otherwise the
+// global sections. An empty assetID returns the global sections. override
+// reports whether an override applied.
+func (a *App) StrategyParams(assetID string) (maker strategy.MakerConfig, taker strategy.TakerConfig, override bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if o, ok := a.cfg.MarketOverrides[assetID]; ok && assetID != "" {
+		return makerConfig(o.Maker), takerConfig(o.Taker), true
+	}
+	return makerConfig(a.cfg.Maker), takerConfig(a.cfg.Taker), false
+}
+
+// makerFor returns the maker that quotes assetID, falling back to the global
+// maker when no override exists.
+func (a *App) makerFor(assetID string) *strategy.Maker {
+	if m, ok := a.marketMakers[assetID]; ok {
+		return m
+	}
+	return a.maker
+}
+
+// takerFor returns the taker that evaluates assetID, falling back to the
+// global taker when no override exists.
+func (a *App) takerFor(assetID string) *strategy.Taker {
+	if tk, ok := a.marketTakers[assetID]; ok {
+		return tk
+	}
+	return a.taker
+}
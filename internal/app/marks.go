@@ -0,0 +1,93 @@
+package app
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/ws"
+)
+
+// lastPrice is a price and when it was seen.
+type lastPrice struct {
+	price float64
+	at    time.Time
+}
+
+// markPrices remembers, per asset, the last trade price and the last mid a
+// two-sided book produced, so positions can still be marked when the current
+// book is missing or one-sided.
+type markPrices struct {
+	mu     sync.Mutex
+	trades map[string]lastPrice
+	mids   map[string]lastPrice
+}
+
+func newMarkPrices() *markPrices {
+	return &markPrices{
+		trades: make(map[string]lastPrice),
+		mids:   make(map[string]lastPrice),
+	}
+}
+
+func (m *markPrices) recordTrade(assetID string, price float64, at time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.trades[assetID] = lastPrice{price: price, at: at}
+}
+
+func (m *markPrices) recordMid(assetID string, mid float64, at time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mids[assetID] = lastPrice{price: mid, at: at}
+}
+
+// last returns the more recent of assetID's last trade price and last mid.
+func (m *markPrices) last(assetID string) (float64, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	trade, tradeOK := m.trades[assetID]
+	mid, midOK := m.mids[assetID]
+	switch {
+	case tradeOK && (!midOK || trade.at.After(mid.at)):
+		return trade.price, true
+	case midOK:
+		return mid.price, true
+	}
+	return 0, false
+}
+
+// observeTradePrice remembers the price of a trade event for marking.
+func (a *App) observeTradePrice(ev ws.TradeEvent) {
+	price, err := strconv.ParseFloat(ev.Price, 64)
+	if err != nil || price <= 0 || ev.AssetID == "" {
+		return
+	}
+	a.marks.recordTrade(ev.AssetID, price, a.now())
+}
+
+// markPrice prices assetID at its book mid, falling back to the last trade
+// price or last known mid when the book cannot produce one.
+func (a *App) markPrice(assetID string) (float64, bool) {
+	if mid, err := a.books.Mid(assetID); err == nil {
+		return mid, true
+	}
+	return a.marks.last(assetID)
+}
+
+// UnpricedPositions returns the open positions that could not be marked at
+// all, sorted. Their unrealized PnL is left out of UnrealizedPnL.
+func (a *App) UnpricedPositions() []string {
+	var out []string
+	for assetID, pos := range a.TrackedPositions() {
+		if pos.NetSize == 0 {
+			continue
+		}
+		if _, ok := a.markPrice(assetID); !ok {
+			out = append(out, assetID)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"sync"
+	"time"
+
+	"github.com/GoPolymarket/polymarket-trader/internal/config"
+)
+
+// Alert event types tracked by the notify gate.
+const (
+	notifyEventFill = "fill"
+)
+
+// notifyGate decides which fill alerts reach the notifier so a busy maker
+// does not page on every fill. Risk alerts bypass it entirely.
+type notifyGate struct {
+	mu           sync.Mutex
+	cfg          config.NotifyConfig
+	lastNotified map[string]time.Time // event type → last delivery
+	lastRealized map[string]float64   // assetID → realized PnL seen at the previous fill
+}
+
+func newNotifyGate(cfg config.NotifyConfig) *notifyGate {
+	return &notifyGate{
+		cfg:          cfg,
+		lastNotified: make(map[string]time.Time),
+		lastRealized: make(map[string]float64),
+	}
+}
+
+func (g *notifyGate) setConfig(cfg config.NotifyConfig) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.cfg = cfg
+}
+
+// allowFill reports whether a fill alert should be sent. realizedPnL is the
+// asset's cumulative realized PnL after the fill; the change since the
+// previous fill tells whether this fill locked in a loss.
+func (g *notifyGate) allowFill(now time.Time, assetID string, notional, realizedPnL float64) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delta := realizedPnL - g.lastRealized[assetID]
+	g.lastRealized[assetID] = realizedPnL
+
+	if g.cfg.LargeFillNotifyUSDC > 0 && notional >= g.cfg.LargeFillNotifyUSDC {
+		g.lastNotified[notifyEventFill] = now
+		return true
+	}
+	if notional < g.cfg.MinFillNotifyUSDC {
+		return false
+	}
+	if g.cfg.NotifyOnlyLosses && delta >= 0 {
+		return false
+	}
+	if !g.allowEventLocked(notifyEventFill, now) {
+		return false
+	}
+	g.lastNotified[notifyEventFill] = now
+	return true
+}
+
+func (g *notifyGate) allowEventLocked(event string, now time.Time) bool {
+	if g.cfg.NotifyCooldown <= 0 {
+		return true
+	}
+	last, ok := g.lastNotified[event]
+	return !ok || now.Sub(last) >= g.cfg.NotifyCooldown
+}
@@ -0,0 +1,29 @@
+package app
+
+// marketOrderRoom returns how many more orders assetID may have open under
+// its maker.max_orders_per_market, counting the tracker's LIVE orders less
+// those in cancelling, which are on their way out. It is -1 when the market
+// is uncapped.
+func (a *App) marketOrderRoom(assetID string, cancelling []string) int {
+	limit := a.makerFor(assetID).MaxOrdersPerMarket()
+	if limit <= 0 {
+		return -1
+	}
+	open := a.tracker.OpenOrderCounts()[assetID]
+	for _, id := range cancelling {
+		if o, ok := a.tracker.Order(id); ok && o.Status == "LIVE" {
+			open--
+		}
+	}
+	return max(limit-open, 0)
+}
+
+// marketsHaveRoom reports whether every asset can take one more order.
+func (a *App) marketsHaveRoom(assetIDs ...string) bool {
+	for _, assetID := range assetIDs {
+		if a.marketOrderRoom(assetID, nil) == 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,39 @@
+package app
+
+// makerClipKey identifies one side of an asset's maker quote.
+type makerClipKey struct {
+	assetID string
+	side    string
+}
+
+// carryPartialFills records how much of the current maker clip the quotes
+// about to be cancelled had already filled, so the replacement only posts
+// the rest. A fully filled quote completes its clip.
+func (a *App) carryPartialFills(orderIDs []string) {
+	for _, id := range orderIDs {
+		o, ok := a.tracker.Order(id)
+		if !ok {
+			continue
+		}
+		key := makerClipKey{o.AssetID, o.Side}
+		switch {
+		case o.PartiallyFilled():
+			a.makerClipFilled[key] += o.FilledSize * o.Price
+		case o.FilledSize > 0:
+			delete(a.makerClipFilled, key)
+		}
+	}
+}
+
+// makerClipSize is the size, in USDC, to quote on one side: the clip minus
+// what earlier quotes of it already filled. Once the rest would fall below
+// maker.min_order_size_usdc the clip counts as done and a fresh one starts.
+func (a *App) makerClipSize(assetID, side string, clip float64) float64 {
+	key := makerClipKey{assetID, side}
+	rest := clip - a.makerClipFilled[key]
+	if rest <= 0 || rest < a.cfg.Maker.MinOrderSizeUSDC {
+		delete(a.makerClipFilled, key)
+		return clip
+	}
+	return rest
+}
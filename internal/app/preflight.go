@@ -0,0 +1,82 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob"
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/clobtypes"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// preflightOrderUSDC sizes the test orders Preflight builds. They are priced
+// at the edges of the book (0.01 buys, 0.99 sells) and never submitted.
+const preflightOrderUSDC = 1
+
+// Preflight checks, without placing anything, that live trading can work:
+// each account's signer is usable, its API key is accepted by the CLOB, and
+// a buy and a sell can be built and signed for every asset the bot would
+// monitor. Every problem found is returned, joined.
+func (a *App) Preflight(ctx context.Context) error {
+	assetIDs := a.cfg.Maker.Markets
+	if len(assetIDs) == 0 {
+		var err error
+		if assetIDs, err = a.autoSelectMarkets(ctx); err != nil {
+			return fmt.Errorf("preflight: select markets: %w", err)
+		}
+	}
+	if len(assetIDs) == 0 {
+		return errors.New("preflight: no markets selected")
+	}
+
+	accounts := a.accounts()
+	var errs []error
+	for _, acct := range accounts {
+		if err := a.preflightAccount(ctx, acct, assetIDs); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		log.Printf("preflight: orders build and sign for %d assets on %d account(s)", len(assetIDs), len(accounts))
+	}
+	return errors.Join(errs...)
+}
+
+func (a *App) preflightAccount(ctx context.Context, acct *account, assetIDs []string) error {
+	if acct.signer == nil {
+		return fmt.Errorf("preflight %s account: no signer configured (private key missing)", acct.name)
+	}
+	if acct.signer.Address() == (common.Address{}) {
+		return fmt.Errorf("preflight %s account: signer has the zero address (check the private key)", acct.name)
+	}
+	if acct.clob == nil {
+		return fmt.Errorf("preflight %s account: no CLOB client", acct.name)
+	}
+
+	var errs []error
+	// Listing orders needs L2 auth, so it fails on a bad or missing API key.
+	if _, err := acct.clob.Orders(ctx, &clobtypes.OrdersRequest{Limit: 1}); err != nil {
+		errs = append(errs, fmt.Errorf("preflight %s account: API key check (list orders): %w", acct.name, err))
+	}
+	for _, assetID := range assetIDs {
+		for _, side := range []string{"BUY", "SELL"} {
+			price := 0.01
+			if side == "SELL" {
+				price = 0.99
+			}
+			_, err := clob.NewOrderBuilder(acct.clob, acct.signer).
+				TokenID(assetID).
+				Side(side).
+				Price(price).
+				Size(limitShares(preflightOrderUSDC, price)).
+				OrderType(clobtypes.OrderTypeGTC).
+				BuildSignableWithContext(ctx)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("preflight %s account: build and sign %s %s: %w", acct.name, side, assetID, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
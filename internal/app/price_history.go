@@ -0,0 +1,41 @@
+package app
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob"
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/clobtypes"
+)
+
+// priceHistoryFidelity is the spacing, in minutes, of the points fetched
+// for the selector's volatility band: hourly over the last day.
+const priceHistoryFidelity = 60
+
+// clobPriceHistory serves the selector's price history from the CLOB
+// prices-history endpoint.
+type clobPriceHistory struct {
+	client clob.Client
+}
+
+// PriceHistory returns tokenID's prices over the last day, oldest first.
+func (h clobPriceHistory) PriceHistory(ctx context.Context, tokenID string) ([]float64, error) {
+	points, err := h.client.PricesHistory(ctx, &clobtypes.PricesHistoryRequest{
+		Market:   tokenID,
+		Interval: clobtypes.PriceHistoryInterval1d,
+		Fidelity: priceHistoryFidelity,
+	})
+	if err != nil {
+		return nil, err
+	}
+	points = slices.Clone(points)
+	slices.SortStableFunc(points, func(x, y clobtypes.PriceHistoryPoint) int {
+		return cmp.Compare(x.Timestamp, y.Timestamp)
+	})
+	prices := make([]float64, 0, len(points))
+	for _, p := range points {
+		prices = append(prices, p.Price)
+	}
+	return prices, nil
+}
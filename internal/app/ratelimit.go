@@ -0,0 +1,90 @@
+package app
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// orderLimiter is a token bucket guarding CLOB order placement and cancels.
+// It refills at rate tokens per second up to a burst of one second's worth;
+// a non-positive rate disables limiting.
+type orderLimiter struct {
+	mu        sync.Mutex
+	rate      float64
+	burst     float64
+	tokens    float64
+	last      time.Time
+	throttled int64
+	now       func() time.Time
+}
+
+func newOrderLimiter(ratePerSec float64, now func() time.Time) *orderLimiter {
+	burst := math.Max(1, ratePerSec)
+	return &orderLimiter{
+		rate:   ratePerSec,
+		burst:  burst,
+		tokens: burst,
+		now:    now,
+	}
+}
+
+// Allow takes a token if one is available. A refused call is counted as
+// throttled; callers skip the action and retry on a later tick.
+func (l *orderLimiter) Allow() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.take() {
+		return true
+	}
+	l.throttled++
+	return false
+}
+
+// Wait blocks until a token is available or ctx is done. It is meant for
+// one-off calls outside the trading loop, such as cancel-all on shutdown.
+func (l *orderLimiter) Wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		ok := l.take()
+		var delay time.Duration
+		if !ok {
+			delay = time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		}
+		l.mu.Unlock()
+		if ok {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// Throttled returns how many calls Allow has refused.
+func (l *orderLimiter) Throttled() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.throttled
+}
+
+func (l *orderLimiter) take() bool {
+	if l.rate <= 0 {
+		return true
+	}
+	now := l.now()
+	if !l.last.IsZero() {
+		if elapsed := now.Sub(l.last).Seconds(); elapsed > 0 {
+			l.tokens = math.Min(l.burst, l.tokens+elapsed*l.rate)
+		}
+	}
+	l.last = now
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
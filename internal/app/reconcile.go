@@ -0,0 +1,183 @@
+package app
+
+import (
+	"context"
+	"log"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/clobtypes"
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/ws"
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/data"
+)
+
+// ReconcileOnStart brings the tracker in line with the exchange before any
+// quoting: open orders are matched against the tracker (unknown ones are
+// cancelled or adopted per orphan_orders), each account's held positions are
+// seeded from the data API, and the risk managers are synced from the result.
+// It only runs in live mode.
+func (a *App) ReconcileOnStart(ctx context.Context) {
+	if a.execMode != "live" {
+		return
+	}
+	a.reconcileOrders(ctx)
+	for _, acct := range a.accounts() {
+		a.reconcilePositions(ctx, acct)
+	}
+	a.riskSync(ctx)
+	log.Printf("reconciled with exchange: orders=%d positions=%d", a.tracker.OpenOrderCount(), len(a.tracker.Positions()))
+}
+
+func (a *App) reconcileOrders(ctx context.Context) {
+	if a.clobClient == nil {
+		return
+	}
+	open, err := withRetry(ctx, a.cfg.HTTPRetries, a.cfg.HTTPRetryBackoff, func() ([]clobtypes.OrderResponse, error) {
+		return a.clobClient.OrdersAll(ctx, &clobtypes.OrdersRequest{})
+	})
+	if err != nil {
+		log.Printf("reconcile: list open orders: %v", err)
+		return
+	}
+
+	adopt := strings.EqualFold(strings.TrimSpace(a.cfg.OrphanOrders), "adopt")
+	onExchange := make(map[string]bool, len(open))
+	var orphans []string
+	for _, o := range open {
+		if o.ID == "" {
+			continue
+		}
+		onExchange[o.ID] = true
+		_, known := a.tracker.Order(o.ID)
+		if !known && !adopt {
+			orphans = append(orphans, o.ID)
+			continue
+		}
+		// Unknown orders are created as stubs, known ones pick up fills made
+		// while the bot was down.
+		a.tracker.ProcessOrderEvent(ws.OrderEvent{
+			ID:           o.ID,
+			AssetID:      o.AssetID,
+			Market:       a.assetToMarket[o.AssetID],
+			Side:         strings.ToUpper(o.Side),
+			Price:        o.Price,
+			OriginalSize: o.OriginalSize,
+			SizeMatched:  o.SizeMatched,
+			Status:       "LIVE",
+		})
+		if !known {
+			a.activeOrders[o.AssetID] = append(a.activeOrders[o.AssetID], o.ID)
+		}
+	}
+
+	// Orders the tracker still holds as LIVE but the exchange no longer has
+	// were filled or cancelled while we were away.
+	for _, o := range a.tracker.ActiveOrders() {
+		if onExchange[o.ID] {
+			continue
+		}
+		a.tracker.ProcessOrderEvent(ws.OrderEvent{ID: o.ID, Status: "CANCELED"})
+		a.activeOrders[o.AssetID] = slices.DeleteFunc(a.activeOrders[o.AssetID], func(id string) bool { return id == o.ID })
+		if len(a.activeOrders[o.AssetID]) == 0 {
+			delete(a.activeOrders, o.AssetID)
+		}
+	}
+
+	if len(orphans) == 0 {
+		return
+	}
+	if a.cfg.DryRun {
+		log.Printf("[DRY] reconcile: would cancel %d unknown orders", len(orphans))
+		return
+	}
+	if err := a.limiter.Wait(ctx); err != nil {
+		log.Printf("reconcile: cancel unknown orders: %v", err)
+		return
+	}
+	if _, err := a.clobClient.CancelOrders(ctx, &clobtypes.CancelOrdersRequest{OrderIDs: orphans}); err != nil {
+		log.Printf("reconcile: cancel unknown orders: %v", err)
+		return
+	}
+	log.Printf("reconcile: cancelled %d unknown orders", len(orphans))
+}
+
+// reconcilePositions seeds acct's tracker with the positions its address
+// holds on the exchange.
+func (a *App) reconcilePositions(ctx context.Context, acct *account) {
+	if a.dataClient == nil || acct.signer == nil {
+		return
+	}
+	held, err := withRetry(ctx, a.cfg.HTTPRetries, a.cfg.HTTPRetryBackoff, func() ([]data.Position, error) {
+		return a.dataClient.Positions(ctx, &data.PositionsRequest{User: acct.signer.Address()})
+	})
+	a.setDependencyErr("data", err)
+	if err != nil {
+		log.Printf("reconcile: fetch positions: %v", err)
+		return
+	}
+	seen := make(map[string]bool, len(held))
+	for _, p := range held {
+		size, _ := p.Size.Float64()
+		if p.Asset.Int == nil || size == 0 {
+			continue
+		}
+		assetID := p.Asset.String()
+		seen[assetID] = true
+		known := acct.tracker.Position(assetID)
+		if known != nil && math.Abs(known.NetSize-size) < 1e-9 {
+			continue
+		}
+		// Keep a preserved entry price, else take the exchange's average
+		// price; only without either is the position adopted at the current
+		// mid, so PnL is measured from the restart.
+		var entry float64
+		if known != nil && known.AvgEntryPrice > 0 {
+			entry = known.AvgEntryPrice
+		} else if avg, _ := p.AvgPrice.Float64(); avg > 0 {
+			entry = avg
+		} else {
+			entry = a.referenceMid(ctx, assetID)
+		}
+		acct.tracker.SeedPosition(assetID, size, entry)
+	}
+	for assetID, pos := range acct.tracker.Positions() {
+		if pos.NetSize != 0 && !seen[assetID] {
+			acct.tracker.SeedPosition(assetID, 0, 0)
+		}
+	}
+}
+
+// referenceMid returns the asset's mid from the live book, falling back to a
+// REST snapshot before the WebSocket feed has delivered one. It is 0 when
+// neither is available.
+func (a *App) referenceMid(ctx context.Context, assetID string) float64 {
+	if mid, err := a.books.Mid(assetID); err == nil {
+		return mid
+	}
+	if a.clobClient == nil {
+		return 0
+	}
+	book, err := withRetry(ctx, a.cfg.HTTPRetries, a.cfg.HTTPRetryBackoff, func() (clobtypes.OrderBookResponse, error) {
+		return a.clobClient.OrderBook(ctx, &clobtypes.BookRequest{TokenID: assetID})
+	})
+	if err != nil {
+		return 0
+	}
+	bestBid, bestAsk := 0.0, 0.0
+	for _, l := range book.Bids {
+		if p, err := strconv.ParseFloat(l.Price, 64); err == nil && p > bestBid {
+			bestBid = p
+		}
+	}
+	for _, l := range book.Asks {
+		if p, err := strconv.ParseFloat(l.Price, 64); err == nil && p > 0 && (bestAsk == 0 || p < bestAsk) {
+			bestAsk = p
+		}
+	}
+	if bestBid <= 0 || bestAsk <= 0 {
+		return 0
+	}
+	return (bestBid + bestAsk) / 2
+}
@@ -0,0 +1,201 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/ws"
+)
+
+// feedSubs holds the WebSocket streams the trading loop reads.
+type feedSubs struct {
+	book        <-chan ws.OrderbookEvent
+	orders      <-chan ws.OrderEvent
+	trades      <-chan ws.TradeEvent
+	resolutions <-chan ws.MarketResolvedEvent
+
+	// The taker account's user streams, nil without a taker account.
+	takerOrders <-chan ws.OrderEvent
+	takerTrades <-chan ws.TradeEvent
+}
+
+// subscribeFeeds subscribes to every stream for assetIDs. The order book is
+// required; the user and resolution streams are best effort.
+func (a *App) subscribeFeeds(ctx context.Context, assetIDs []string) (feedSubs, error) {
+	var subs feedSubs
+	var err error
+	subs.book, err = a.wsClient.SubscribeOrderbook(ctx, assetIDs)
+	if err != nil {
+		a.setDependencyErr("ws", err)
+		return feedSubs{}, err
+	}
+	// The last best-effort stream that failed leaves the feed degraded.
+	var degraded error
+
+	// User order and trade streams for fill tracking.
+	marketIDs := a.collectMarketIDs(assetIDs)
+	if a.execMode == "live" && len(marketIDs) > 0 {
+		if subs.orders, err = a.wsClient.SubscribeUserOrders(ctx, marketIDs); err != nil {
+			log.Printf("warning: user orders subscription failed: %v", err)
+			degraded = fmt.Errorf("user orders subscription: %w", err)
+		}
+		if subs.trades, err = a.wsClient.SubscribeUserTrades(ctx, marketIDs); err != nil {
+			log.Printf("warning: user trades subscription failed: %v", err)
+			degraded = fmt.Errorf("user trades subscription: %w", err)
+		}
+		// The taker account's fills arrive on its own authenticated streams.
+		if a.takerAcct != nil && a.takerAcct.ws != nil {
+			if subs.takerOrders, err = a.takerAcct.ws.SubscribeUserOrders(ctx, marketIDs); err != nil {
+				log.Printf("warning: taker account user orders subscription failed: %v", err)
+			}
+			if subs.takerTrades, err = a.takerAcct.ws.SubscribeUserTrades(ctx, marketIDs); err != nil {
+				log.Printf("warning: taker account user trades subscription failed: %v", err)
+			}
+		}
+	}
+
+	// Phase 1.5: market resolutions.
+	if subs.resolutions, err = a.wsClient.SubscribeMarketResolutions(ctx, assetIDs); err != nil {
+		log.Printf("warning: market resolutions subscription failed: %v", err)
+		degraded = fmt.Errorf("market resolutions subscription: %w", err)
+	}
+	a.setDependencyErr("ws", degraded)
+	a.setFeedConnected(true)
+	return subs, nil
+}
+
+// reconnectFeeds resubscribes every stream after the book stream drops,
+// waiting ws_reconnect_backoff before the first attempt and doubling it up to
+// ws_reconnect_max_backoff. It gives up after ws_max_reconnect_attempts
+// (0 retries forever) or when ctx is done.
+func (a *App) reconnectFeeds(ctx context.Context, assetIDs []string) (feedSubs, error) {
+	a.setFeedConnected(false)
+	backoff := a.cfg.WSReconnectBackoff
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-ctx.Done():
+			return feedSubs{}, ctx.Err()
+		case <-time.After(backoff):
+		}
+		subs, err := a.subscribeFeeds(ctx, assetIDs)
+		if err == nil {
+			log.Printf("feed reconnected after %d attempt(s)", attempt)
+			return subs, nil
+		}
+		log.Printf("feed reconnect attempt %d failed: %v", attempt, err)
+		if limit := a.cfg.WSMaxReconnectAttempts; limit > 0 && attempt >= limit {
+			return feedSubs{}, fmt.Errorf("feed reconnect: giving up after %d attempts: %w", attempt, err)
+		}
+		backoff *= 2
+		if limit := a.cfg.WSReconnectMaxBackoff; limit > 0 && backoff > limit {
+			backoff = limit
+		}
+	}
+}
+
+// feedMux fans the streams of every subscription the trading loop holds, the
+// initial one and those rescans add, into one set of channels. Its book
+// channel closes as soon as any book stream closes, so a dropped connection
+// reaches the reconnect path, which resubscribes every asset on a fresh mux.
+// The user and resolution channels never close.
+type feedMux struct {
+	ctx   context.Context
+	stop  context.CancelFunc
+	mu    sync.Mutex // orders add against the book channel closing
+	books sync.WaitGroup
+
+	book        chan ws.OrderbookEvent
+	orders      chan ws.OrderEvent
+	trades      chan ws.TradeEvent
+	resolutions chan ws.MarketResolvedEvent
+	takerOrders chan ws.OrderEvent
+	takerTrades chan ws.TradeEvent
+}
+
+func newFeedMux(ctx context.Context) *feedMux {
+	ctx, stop := context.WithCancel(ctx)
+	m := &feedMux{
+		ctx:         ctx,
+		stop:        stop,
+		book:        make(chan ws.OrderbookEvent),
+		orders:      make(chan ws.OrderEvent),
+		trades:      make(chan ws.TradeEvent),
+		resolutions: make(chan ws.MarketResolvedEvent),
+		takerOrders: make(chan ws.OrderEvent),
+		takerTrades: make(chan ws.TradeEvent),
+	}
+	go func() {
+		<-ctx.Done()
+		// Once the lock is free no add is mid-flight and none will start.
+		m.mu.Lock()
+		m.mu.Unlock()
+		m.books.Wait()
+		close(m.book)
+	}()
+	return m
+}
+
+// add starts forwarding subs' streams. Nil streams are skipped, and nothing
+// is added once the mux has stopped.
+func (m *feedMux) add(subs feedSubs) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.ctx.Err() != nil {
+		return
+	}
+	if subs.book != nil {
+		m.books.Add(1)
+		go forwardFeed(m.ctx, subs.book, m.book, func() {
+			m.stop()
+			m.books.Done()
+		})
+	}
+	startForward(m.ctx, subs.orders, m.orders)
+	startForward(m.ctx, subs.trades, m.trades)
+	startForward(m.ctx, subs.resolutions, m.resolutions)
+	startForward(m.ctx, subs.takerOrders, m.takerOrders)
+	startForward(m.ctx, subs.takerTrades, m.takerTrades)
+}
+
+func startForward[T any](ctx context.Context, in <-chan T, out chan<- T) {
+	if in != nil {
+		go forwardFeed(ctx, in, out, func() {})
+	}
+}
+
+// forwardFeed copies in to out until in closes or ctx is done, then calls
+// done.
+func forwardFeed[T any](ctx context.Context, in <-chan T, out chan<- T, done func()) {
+	defer done()
+	for {
+		select {
+		case ev, ok := <-in:
+			if !ok {
+				return
+			}
+			select {
+			case out <- ev:
+			case <-ctx.Done():
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (a *App) setFeedConnected(connected bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.feedConnected = connected
+}
+
+// FeedConnected reports whether the market data WebSocket is subscribed.
+func (a *App) FeedConnected() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.feedConnected
+}
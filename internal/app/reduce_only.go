@@ -0,0 +1,42 @@
+package app
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/GoPolymarket/polymarket-trader/internal/feed"
+)
+
+// reduceOnlyAmount checks that a side order of amountUSDC on tokenID can only
+// shrink the tracked position, and clips it so it cannot carry the position
+// through flat into the other side. price is the order's limit; 0 means a
+// market order, which is sized against the touch it would take. An order
+// that would open a position from flat or add to one is rejected.
+func (a *App) reduceOnlyAmount(tokenID, side string, amountUSDC, price float64) (float64, error) {
+	return a.reduceOnlyAmountFor(a.primaryAccount(), tokenID, side, amountUSDC, price)
+}
+
+// reduceOnlyAmountFor is reduceOnlyAmount against the position acct holds.
+func (a *App) reduceOnlyAmountFor(acct *account, tokenID, side string, amountUSDC, price float64) (float64, error) {
+	var net float64
+	if pos := acct.tracker.Position(tokenID); pos != nil {
+		net = pos.NetSize
+	}
+	if (side == "BUY" && net >= 0) || (side == "SELL" && net <= 0) {
+		return 0, fmt.Errorf("reduce-only %s %s: would increase position %.4f", side, tokenID, net)
+	}
+	if price <= 0 {
+		if book, ok := a.books.Get(tokenID); ok {
+			if bid, ask, err := feed.BookTop(book); err == nil {
+				price = bid
+				if side == "BUY" {
+					price = ask
+				}
+			}
+		}
+	}
+	if price > 0 {
+		amountUSDC = math.Min(amountUSDC, math.Abs(net)*price)
+	}
+	return amountUSDC, nil
+}
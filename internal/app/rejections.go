@@ -0,0 +1,44 @@
+package app
+
+import (
+	"time"
+
+	"github.com/GoPolymarket/polymarket-trader/internal/execution"
+)
+
+// maxRejections bounds the rejection log served at /api/rejections.
+const maxRejections = 200
+
+// riskBlocked records an order stopped by a risk check, both in the KPI
+// funnel and in the rejection log.
+func (a *App) riskBlocked(now time.Time, assetID, side string, sizeUSDC float64, err error) {
+	if a.kpi != nil {
+		a.kpi.recordRiskBlock(now, classifyRiskAllowError(err))
+	}
+	a.rejections.Add(execution.Rejection{
+		Time:     now,
+		AssetID:  assetID,
+		Side:     side,
+		SizeUSDC: sizeUSDC,
+		Source:   execution.RejectedByRisk,
+		Reason:   err.Error(),
+	})
+}
+
+// placementRejected records an order the CLOB refused or that could not be
+// built for submission.
+func (a *App) placementRejected(assetID, side string, sizeUSDC float64, err error) {
+	a.rejections.Add(execution.Rejection{
+		Time:     a.now(),
+		AssetID:  assetID,
+		Side:     side,
+		SizeUSDC: sizeUSDC,
+		Source:   execution.RejectedByCLOB,
+		Reason:   err.Error(),
+	})
+}
+
+// Rejections returns up to limit recent order rejections, most recent first.
+func (a *App) Rejections(limit int) []execution.Rejection {
+	return a.rejections.Recent(limit)
+}
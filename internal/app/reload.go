@@ -0,0 +1,181 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/GoPolymarket/polymarket-trader/internal/config"
+	"github.com/GoPolymarket/polymarket-trader/internal/risk"
+	"github.com/GoPolymarket/polymarket-trader/internal/strategy"
+)
+
+// reloadTimeout bounds how long ReloadConfig waits for the trading loop.
+const reloadTimeout = 10 * time.Second
+
+// hotReloadSections are the config sections that may change at runtime.
+var hotReloadSections = []string{"maker", "taker", "risk", "notify", "market_overrides"}
+
+// restartOnlyFields are fields inside hot sections that are only read at
+// startup (subscriptions, tickers, the flow window).
+var restartOnlyFields = map[string]bool{
+	"maker.markets":           true,
+	"maker.auto_select_top":   true,
+	"maker.vol_window":        true,
+	"taker.markets":           true,
+	"taker.flow_window":       true,
+	"risk.risk_sync_interval": true,
+}
+
+type reloadRequest struct {
+	cfg  config.Config
+	done chan error
+}
+
+// ReloadConfig applies the hot-reloadable subset of cfg (maker, taker, risk,
+// notify and per-market override settings) without touching subscriptions or positions. The
+// reload is rejected as a whole if any other field changed. While the
+// trading loop is running the update is applied on the loop goroutine.
+func (a *App) ReloadConfig(cfg config.Config) error {
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("reload rejected: %w", err)
+	}
+
+	a.mu.RLock()
+	running := a.running
+	a.mu.RUnlock()
+	if !running {
+		return a.applyConfig(context.Background(), cfg)
+	}
+
+	req := reloadRequest{cfg: cfg, done: make(chan error, 1)}
+	select {
+	case a.reloadCh <- req:
+		return <-req.done
+	case <-time.After(reloadTimeout):
+		return fmt.Errorf("reload timed out waiting for the trading loop")
+	}
+}
+
+func (a *App) applyConfig(ctx context.Context, cfg config.Config) error {
+	prev := a.cfg
+	changed := config.Diff(prev, cfg)
+	if len(changed) == 0 {
+		log.Println("config reload: no changes")
+		return nil
+	}
+
+	var immutable []string
+	for _, field := range changed {
+		if !isHotReloadable(field) {
+			immutable = append(immutable, field)
+		}
+	}
+	if len(immutable) > 0 {
+		return fmt.Errorf("reload rejected: restart required to change %s", strings.Join(immutable, ", "))
+	}
+
+	a.mu.Lock()
+	a.cfg = cfg
+	a.mu.Unlock()
+	a.maker.SetConfig(makerConfig(cfg.Maker))
+	a.taker.SetConfig(takerConfig(cfg.Taker))
+	a.applyMarketOverrides(cfg.MarketOverrides)
+	a.riskMgr.SetConfig(riskConfig(cfg))
+	if a.takerAcct != nil {
+		a.takerAcct.risk.SetConfig(riskConfig(cfg))
+	}
+	a.notifyGate.setConfig(cfg.Notify)
+	// A strategy switched off must not leave orders resting on the book.
+	if prev.Maker.Enabled && !cfg.Maker.Enabled {
+		a.cancelMakerQuotes(ctx)
+	}
+	if prev.Taker.Enabled && !cfg.Taker.Enabled {
+		a.cancelTakerOrders(ctx)
+	}
+	log.Printf("config reloaded: %s", strings.Join(changed, ", "))
+	return nil
+}
+
+func isHotReloadable(field string) bool {
+	if restartOnlyFields[field] {
+		return false
+	}
+	for _, section := range hotReloadSections {
+		if field == section || strings.HasPrefix(field, section+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func riskConfig(cfg config.Config) risk.Config {
+	return risk.Config{
+		MaxOpenOrders:                 cfg.Risk.MaxOpenOrders,
+		MaxDailyLossUSDC:              cfg.Risk.MaxDailyLossUSDC,
+		MaxDailyLossPct:               cfg.Risk.MaxDailyLossPct,
+		AccountCapitalUSDC:            cfg.Risk.AccountCapitalUSDC,
+		MaxPositionPerMarket:          cfg.Risk.MaxPositionPerMarket,
+		MaxLongPerMarketUSDC:          cfg.Risk.MaxLongPerMarketUSDC,
+		MaxShortPerMarketUSDC:         cfg.Risk.MaxShortPerMarketUSDC,
+		StopLossPerMarket:             cfg.Risk.StopLossPerMarket,
+		MaxDrawdownPct:                cfg.Risk.MaxDrawdownPct,
+		MaxDrawdownVelocityUSDCPerMin: cfg.Risk.MaxDrawdownVelocityUSDCPerMin,
+		DrawdownVelocityWindow:        cfg.Risk.DrawdownVelocityWindow,
+		RiskSyncInterval:              cfg.Risk.RiskSyncInterval,
+		MaxConsecutiveLosses:          cfg.Risk.MaxConsecutiveLosses,
+		ConsecutiveLossCooldown:       cfg.Risk.ConsecutiveLossCooldown,
+		CooldownEscalationFactor:      cfg.Risk.CooldownEscalationFactor,
+		MaxCooldown:                   cfg.Risk.MaxCooldown,
+		ConcentrationWarnHHI:          cfg.Risk.ConcentrationWarnHHI,
+		MaxGrossExposureUSDC:          cfg.Risk.MaxGrossExposureUSDC,
+		MaxGrossExposurePct:           cfg.Risk.MaxGrossExposurePct,
+		CorrelationGroups:             cfg.Risk.CorrelationGroups,
+		MaxGroupExposureUSDC:          cfg.Risk.MaxGroupExposureUSDC,
+		MaxOrderNotionalUSDC:          cfg.Risk.MaxOrderNotionalUSDC,
+		MaxFillsPerMinute:             cfg.Risk.MaxFillsPerMinute,
+	}
+}
+
+func makerConfig(m config.MakerConfig) strategy.MakerConfig {
+	return strategy.MakerConfig{
+		MinSpreadBps:         m.MinSpreadBps,
+		SpreadMultiplier:     m.SpreadMultiplier,
+		OrderSizeUSDC:        m.OrderSizeUSDC,
+		MaxOrdersPerMarket:   m.MaxOrdersPerMarket,
+		InventorySkewBps:     m.InventorySkewBps,
+		InventoryWidenFactor: m.InventoryWidenFactor,
+		MinOrderSizeUSDC:     m.MinOrderSizeUSDC,
+		VolSpreadMultiplier:  m.VolSpreadMultiplier,
+		VolSpreadMaxBps:      m.VolSpreadMaxBps,
+		PostFillPause:        time.Duration(m.PostFillPauseMs) * time.Millisecond,
+		UsePairFairValue:     m.UsePairFairValue,
+		QuoteMode:            strings.ToLower(strings.TrimSpace(m.QuoteMode)),
+		TickSize:             m.TickSize,
+	}
+}
+
+func takerConfig(t config.TakerConfig) strategy.TakerConfig {
+	return strategy.TakerConfig{
+		MinImbalance:      t.MinImbalance,
+		DepthLevels:       t.DepthLevels,
+		AmountUSDC:        t.AmountUSDC,
+		MaxSlippageBps:    t.MaxSlippageBps,
+		Cooldown:          t.Cooldown,
+		MinConfidenceBps:  t.MinConfidenceBps,
+		GlobalCooldown:    t.GlobalCooldown,
+		FlowWeight:        t.FlowWeight,
+		ImbalanceWeight:   t.ImbalanceWeight,
+		ConvergenceWeight: t.ConvergenceWeight,
+		MinConvergenceBps: t.MinConvergenceBps,
+		FlowWindow:        t.FlowWindow,
+		MinCompositeScore: t.MinCompositeScore,
+		MomentumWeight:    t.MomentumWeight,
+		MomentumWindow:    t.MomentumWindow,
+
+		ResetCooldownOnDailyReset: t.ResetCooldownOnDailyReset,
+		RequireFeeCoverage:        t.RequireFeeCoverage,
+	}
+}
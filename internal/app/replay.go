@@ -0,0 +1,86 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/ws"
+
+	"github.com/GoPolymarket/polymarket-trader/internal/feed"
+)
+
+// replayClock reports the timestamp of the event currently being replayed.
+type replayClock struct {
+	mu  sync.RWMutex
+	now time.Time
+}
+
+func (c *replayClock) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.now
+}
+
+func (c *replayClock) set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+// Replay drives the strategies from recorded book events instead of the live
+// WebSocket. Time follows the events' own timestamps: risk sync runs every
+// risk.risk_sync_interval of replayed time and daily risk resets at each UTC
+// day boundary, so a replay produces the same KPI and PnL output a live run
+// would. Replay returns when events is closed or ctx is cancelled.
+func (a *App) Replay(ctx context.Context, events <-chan ws.OrderbookEvent) error {
+	a.mu.Lock()
+	a.running = true
+	a.mu.Unlock()
+	defer func() {
+		a.mu.Lock()
+		a.running = false
+		a.mu.Unlock()
+	}()
+
+	riskInterval := a.cfg.Risk.RiskSyncInterval
+	if riskInterval <= 0 {
+		riskInterval = 5 * time.Second
+	}
+
+	clock := &replayClock{}
+	var lastSync time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ev, ok := <-events:
+			if !ok {
+				if !lastSync.IsZero() {
+					a.riskSync(ctx)
+				}
+				return nil
+			}
+			ts, hasTime := feed.EventTime(ev)
+			if lastSync.IsZero() {
+				if !hasTime {
+					ts = time.Now().UTC()
+				}
+				clock.set(ts)
+				a.SetClock(clock)
+				lastSync = ts
+			} else if hasTime && ts.After(clock.Now()) {
+				if startOfUTCDay(ts).After(startOfUTCDay(clock.Now())) {
+					a.riskSync(ctx)
+					a.resetDailyRisk()
+				}
+				clock.set(ts)
+			}
+			a.HandleBookEvent(ctx, ev)
+			if now := clock.Now(); now.Sub(lastSync) >= riskInterval {
+				a.riskSync(ctx)
+				lastSync = now
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+type rescanResult struct {
+	added, removed []string
+	err            error
+}
+
+type rescanRequest struct {
+	done chan rescanResult
+}
+
+// Rescan reselects markets now instead of waiting for the rescan ticker and
+// returns the assets added to and removed from the feed. The rescan runs on
+// the trading loop goroutine, which owns the subscriptions; concurrent calls
+// are serialized.
+func (a *App) Rescan(ctx context.Context) (added, removed []string, err error) {
+	a.rescanMu.Lock()
+	defer a.rescanMu.Unlock()
+	if a.gammaClient == nil {
+		return nil, nil, errors.New("rescan: no gamma client configured")
+	}
+	a.mu.RLock()
+	running := a.running
+	a.mu.RUnlock()
+	if !running {
+		return nil, nil, errors.New("rescan: trading loop is not running")
+	}
+
+	req := rescanRequest{done: make(chan rescanResult, 1)}
+	select {
+	case a.rescanReqCh <- req:
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	case <-time.After(reloadTimeout):
+		return nil, nil, errors.New("rescan: timed out waiting for the trading loop")
+	}
+	select {
+	case res := <-req.done:
+		return res.added, res.removed, res.err
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/types"
+)
+
+// statusCoder is satisfied by the SDK's error for a rate limit (429) or
+// server error (5xx) it gave up retrying.
+type statusCoder interface {
+	StatusCode() int
+}
+
+// retryable reports whether err is worth another attempt. A cancelled
+// context is not, and neither is an HTTP error status: the SDK returns a
+// client error (4xx) as a *types.Error, which a retry would only repeat, and
+// has already retried 429 and 5xx responses itself before returning them.
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErr *types.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Status < 400
+	}
+	var sc statusCoder
+	if errors.As(err, &sc) {
+		return sc.StatusCode() < 400
+	}
+	return true
+}
+
+// withRetry calls fn up to retries+1 times, sleeping an exponentially growing
+// backoff with jitter between attempts. Non-retryable errors return at once.
+func withRetry[T any](ctx context.Context, retries int, backoff time.Duration, fn func() (T, error)) (T, error) {
+	v, err := fn()
+	for attempt := 0; err != nil && attempt < retries && retryable(ctx, err); attempt++ {
+		delay := backoff << attempt
+		// Jitter in [delay/2, delay] keeps concurrent callers apart.
+		delay = delay/2 + time.Duration(rand.Int64N(int64(delay/2)+1))
+		select {
+		case <-ctx.Done():
+			return v, err
+		case <-time.After(delay):
+		}
+		v, err = fn()
+	}
+	return v, err
+}
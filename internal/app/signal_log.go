@@ -0,0 +1,41 @@
+package app
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"sync"
+
+	"github.com/GoPolymarket/polymarket-trader/internal/strategy"
+)
+
+// signalLog appends every scored taker signal, fired or suppressed, to a
+// JSONL file (taker.signal_log) for offline tuning of the taker weights.
+type signalLog struct {
+	mu  sync.Mutex
+	f   *os.File
+	enc *json.Encoder
+}
+
+func openSignalLog(path string) (*signalLog, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &signalLog{f: f, enc: json.NewEncoder(f)}, nil
+}
+
+// record writes rec as one line. It is the taker signal observer.
+func (l *signalLog) record(rec strategy.SignalRecord) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.enc.Encode(rec); err != nil {
+		log.Printf("signal log: %v", err)
+	}
+}
+
+func (l *signalLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.f.Close()
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/ws"
+
+	"github.com/GoPolymarket/polymarket-trader/internal/feed"
+	"github.com/GoPolymarket/polymarket-trader/internal/strategy"
+)
+
+// SimulateQuote computes the maker quote the current config would post for a
+// hypothetical book, without placing anything. assetID selects market
+// overrides, its fee rate and the live volatility estimate; it may be
+// empty. Levels may come in any order. The pair fair value is not applied,
+// since the book has no live counterpart.
+func (a *App) SimulateQuote(assetID string, bids, asks []feed.Level, inv *strategy.InventoryState) (strategy.QuotePreview, error) {
+	params, _, _ := a.StrategyParams(assetID)
+	maker := strategy.NewMaker(params)
+	maker.SetVolatility(a.vol)
+	maker.SetMidMethod(a.midMethod)
+
+	feeRate := a.feeRate(assetID)
+	a.mu.RLock()
+	maxPosition := a.cfg.Risk.MaxPositionPerMarket
+	a.mu.RUnlock()
+
+	var state strategy.InventoryState
+	if inv != nil {
+		state = *inv
+		state.MaxPosition = maxPosition
+	}
+	event := ws.OrderbookEvent{
+		AssetID: assetID,
+		Bids:    simulatedLevels(bids, -1),
+		Asks:    simulatedLevels(asks, 1),
+	}
+	raw, err := maker.ComputeQuote(event, state)
+	if err != nil {
+		return strategy.QuotePreview{}, err
+	}
+	quote, adjusted := strategy.ApplyFeeFloor(raw, feeRate)
+	return strategy.QuotePreview{
+		Quote:       quote,
+		RawQuote:    raw,
+		FeeRateBps:  feeRate,
+		FeeAdjusted: adjusted,
+	}, nil
+}
+
+// simulatedLevels renders levels as a feed would send them, best first: dir
+// -1 sorts prices descending (bids), 1 ascending (asks).
+func simulatedLevels(levels []feed.Level, dir int) []ws.OrderbookLevel {
+	sorted := slices.Clone(levels)
+	slices.SortFunc(sorted, func(x, y feed.Level) int { return dir * cmp.Compare(x.Price, y.Price) })
+	out := make([]ws.OrderbookLevel, 0, len(sorted))
+	for _, l := range sorted {
+		out = append(out, ws.OrderbookLevel{
+			Price: strconv.FormatFloat(l.Price, 'f', -1, 64),
+			Size:  strconv.FormatFloat(l.Size, 'f', -1, 64),
+		})
+	}
+	return out
+}
@@ -0,0 +1,14 @@
+package app
+
+import "github.com/GoPolymarket/polymarket-trader/internal/strategy"
+
+// roundOrderSize rounds an order of sizeUSDC at price down so its share size
+// is a whole multiple of size_increment, returning the USDC left. It is 0
+// when not even one increment fits. Orders without a price are left alone.
+func (a *App) roundOrderSize(sizeUSDC, price float64) float64 {
+	inc := a.cfg.SizeIncrement
+	if inc <= 0 || price <= 0 || sizeUSDC <= 0 {
+		return sizeUSDC
+	}
+	return strategy.RoundSizeDown(sizeUSDC/price, inc) * price
+}
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/ws"
+)
+
+// makerMids remembers the book mid at the time each maker quote was placed,
+// so its first fill can be scored against where the market stood. Paper fills
+// from the API goroutine read it too, hence the lock.
+type makerMids struct {
+	mu   sync.Mutex
+	mids map[string]float64 // orderID → mid at placement
+}
+
+func newMakerMids() *makerMids {
+	return &makerMids{mids: make(map[string]float64)}
+}
+
+func (m *makerMids) set(orderID string, mid float64) {
+	if orderID == "" || mid <= 0 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mids[orderID] = mid
+}
+
+// take returns and forgets the placement mid of orderID.
+func (m *makerMids) take(orderID string) (float64, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	mid, ok := m.mids[orderID]
+	delete(m.mids, orderID)
+	return mid, ok
+}
+
+// forget drops cancelled quotes.
+func (m *makerMids) forget(orderIDs []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, id := range orderIDs {
+		delete(m.mids, id)
+	}
+}
+
+// spreadCaptureBps is how far inside the placement mid a fill landed, in bps
+// of that mid: positive for a buy below it or a sell above it.
+func spreadCaptureBps(side string, fillPrice, placementMid float64) float64 {
+	if placementMid <= 0 {
+		return 0
+	}
+	edge := placementMid - fillPrice
+	if side == "SELL" {
+		edge = -edge
+	}
+	return edge / placementMid * 10000
+}
+
+// recordMakerCapture feeds the spread captured by a maker order's first fill
+// into the KPI collector. Orders placed by other strategies are ignored.
+func (a *App) recordMakerCapture(orderID, side string, fillPrice float64) {
+	if a.kpi == nil {
+		return
+	}
+	mid, ok := a.makerMids.take(orderID)
+	if !ok {
+		return
+	}
+	a.kpi.recordMakerSpreadCapture(a.now(), spreadCaptureBps(side, fillPrice, mid))
+}
+
+// observeMakerFill checks a user order update for a newly matched maker
+// quote before the tracker applies it. Maker quotes fill at their own limit.
+func (a *App) observeMakerFill(ev ws.OrderEvent) {
+	o, ok := a.tracker.Order(ev.ID)
+	if !ok {
+		return
+	}
+	matched, err := strconv.ParseFloat(ev.SizeMatched, 64)
+	if err != nil || matched <= o.FilledSize {
+		return
+	}
+	a.recordMakerCapture(o.ID, o.Side, o.Price)
+}
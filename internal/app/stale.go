@@ -0,0 +1,54 @@
+package app
+
+import (
+	"context"
+	"log"
+	"slices"
+
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/clobtypes"
+)
+
+// cancelStaleOrders cancels maker quotes that have rested longer than
+// maker.max_order_age. Quotes are normally replaced on every book update, so
+// this only catches assets whose book has gone quiet while the fair value
+// moved. A throttled live cancel leaves them for the next risk tick.
+func (a *App) cancelStaleOrders(ctx context.Context) {
+	maxAge := a.cfg.Maker.MaxOrderAge
+	if maxAge <= 0 {
+		return
+	}
+	now := a.now()
+	var stale []string
+	for _, ids := range a.activeOrders {
+		for _, id := range ids {
+			if o, ok := a.tracker.Order(id); ok && now.Sub(o.CreatedAt) > maxAge {
+				stale = append(stale, id)
+			}
+		}
+	}
+	if len(stale) == 0 {
+		return
+	}
+
+	if a.execMode == "live" && a.clobClient != nil {
+		if !a.limiter.Allow() {
+			return
+		}
+		if _, err := a.clobClient.CancelOrders(ctx, &clobtypes.CancelOrdersRequest{OrderIDs: stale}); err != nil {
+			log.Printf("cancel stale orders: %v", err)
+			return
+		}
+	} else if a.execMode == "paper" {
+		a.cancelPaperOrders(stale)
+	}
+	for assetID, ids := range a.activeOrders {
+		ids = slices.DeleteFunc(ids, func(id string) bool { return slices.Contains(stale, id) })
+		if len(ids) == 0 {
+			delete(a.activeOrders, assetID)
+		} else {
+			a.activeOrders[assetID] = ids
+		}
+	}
+	a.staleCancels.Add(int64(len(stale)))
+	log.Printf("cancelled %d stale orders older than %s", len(stale), maxAge)
+}
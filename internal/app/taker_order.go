@@ -0,0 +1,110 @@
+package app
+
+import (
+	"context"
+	"log"
+	"math"
+	"strings"
+
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob"
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/clobtypes"
+
+	"github.com/GoPolymarket/polymarket-trader/internal/config"
+	"github.com/GoPolymarket/polymarket-trader/internal/strategy"
+)
+
+// clobLotSize is the share granularity the CLOB accepts on limit orders.
+const clobLotSize = 0.01
+
+// limitShares converts sizeUSDC at price into the share size a limit order
+// is built with, rounded down to the CLOB lot size. It is 0 without a price.
+func limitShares(sizeUSDC, price float64) float64 {
+	if price <= 0 || sizeUSDC <= 0 {
+		return 0
+	}
+	// The epsilon keeps sizes already on a lot, like 5/0.5, from flooring
+	// one lot short.
+	lots := math.Floor(sizeUSDC/price/clobLotSize + 1e-9)
+	return math.Round(lots*clobLotSize*100) / 100
+}
+
+// takerOrder is how a taker signal goes to the exchange: a FAK market order
+// by default, or, with use_marketable_limit, an aggressive limit at the
+// signal's MaxPrice so a thin book cannot fill it worse than that.
+type takerOrder struct {
+	Type  clobtypes.OrderType
+	Price float64 // limit price; 0 for market orders
+}
+
+func takerOrderFor(t config.TakerConfig, sig *strategy.Signal) takerOrder {
+	if !t.UseMarketableLimit || sig.MaxPrice <= 0 {
+		return takerOrder{Type: clobtypes.OrderTypeFAK}
+	}
+	typ := clobtypes.OrderTypeFOK
+	if strings.EqualFold(strings.TrimSpace(t.MarketableLimitType), "GTC") {
+		typ = clobtypes.OrderTypeGTC
+	}
+	return takerOrder{Type: typ, Price: sig.MaxPrice}
+}
+
+// placeTaker sends a taker signal through acct as a market or marketable
+// limit order.
+func (a *App) placeTaker(ctx context.Context, acct *account, sig *strategy.Signal) clobtypes.OrderResponse {
+	order := takerOrderFor(a.cfg.Taker, sig)
+	if order.Price == 0 {
+		return a.placeMarketAs(ctx, acct, sig.AssetID, sig.Side, sig.AmountUSDC, false)
+	}
+	return a.placeMarketableLimit(ctx, acct, sig.AssetID, sig.Side, order.Price, sig.AmountUSDC, order.Type)
+}
+
+// placeMarketableLimit posts a limit order priced to cross the book. FOK
+// orders fill in full at or better than price or not at all; GTC orders rest
+// any remainder at price.
+func (a *App) placeMarketableLimit(ctx context.Context, acct *account, tokenID, side string, price, amountUSDC float64, orderType clobtypes.OrderType) clobtypes.OrderResponse {
+	if a.execMode == "paper" {
+		resp := a.placePaperLimit(tokenID, side, price, amountUSDC)
+		if orderType == clobtypes.OrderTypeFOK && resp.Status == "LIVE" {
+			// The paper book could not fill it immediately: kill it.
+			a.cancelPaperOrders([]string{resp.ID})
+			return clobtypes.OrderResponse{}
+		}
+		if a.kpi != nil && resp.ID != "" {
+			a.kpi.recordOrderSubmitted(a.now())
+		}
+		return resp
+	}
+
+	if !acct.breaker.Allow(a.now()) {
+		log.Printf("place marketable limit %s %s: circuit breaker open", side, tokenID)
+		return clobtypes.OrderResponse{}
+	}
+	builder := clob.NewOrderBuilder(acct.clob, acct.signer).
+		TokenID(tokenID).
+		Side(side).
+		Price(price).
+		Size(limitShares(amountUSDC, price)).
+		OrderType(orderType)
+
+	signable, err := builder.BuildSignableWithContext(ctx)
+	if err != nil {
+		log.Printf("build marketable limit %s %s: %v", side, tokenID, err)
+		a.placementRejected(tokenID, side, amountUSDC, err)
+		return clobtypes.OrderResponse{}
+	}
+	if !a.limiter.Allow() {
+		log.Printf("place marketable limit %s %s: throttled", side, tokenID)
+		return clobtypes.OrderResponse{}
+	}
+	resp, err := acct.clob.CreateOrderFromSignable(ctx, signable)
+	a.recordPlacement(ctx, acct, err)
+	if err != nil {
+		log.Printf("place marketable limit %s %s: %v", side, tokenID, err)
+		a.placementRejected(tokenID, side, amountUSDC, err)
+		return clobtypes.OrderResponse{}
+	}
+	if a.kpi != nil && resp.ID != "" {
+		a.kpi.recordOrderSubmitted(a.now())
+	}
+	log.Printf("marketable limit %s %s %s @ %.4f amount=%.2f: id=%s", orderType, side, tokenID, price, amountUSDC, resp.ID)
+	return resp
+}
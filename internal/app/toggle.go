@@ -0,0 +1,98 @@
+package app
+
+import (
+	"context"
+	"log"
+
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/clobtypes"
+)
+
+// SetStrategiesEnabled switches the maker and taker on or off at runtime; a
+// nil argument leaves that strategy unchanged. The flip goes through the
+// config reload path, so it lands on the trading loop goroutine and a
+// disabled strategy has its resting orders cancelled there. Turning either on
+// also lifts the pause a flatten leaves behind. It returns the resulting
+// flags.
+func (a *App) SetStrategiesEnabled(maker, taker *bool) (makerOn, takerOn bool, err error) {
+	a.mu.RLock()
+	cfg := a.cfg
+	a.mu.RUnlock()
+	if maker != nil {
+		cfg.Maker.Enabled = *maker
+	}
+	if taker != nil {
+		cfg.Taker.Enabled = *taker
+	}
+	if err := a.ReloadConfig(cfg); err != nil {
+		return false, false, err
+	}
+	if (maker != nil && *maker) || (taker != nil && *taker) {
+		a.clearFlattenPause()
+	}
+	makerOn, takerOn = a.StrategiesEnabled()
+	return makerOn, takerOn, nil
+}
+
+// StrategiesEnabled reports whether the maker and taker are currently on.
+func (a *App) StrategiesEnabled() (maker, taker bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.cfg.Maker.Enabled, a.cfg.Taker.Enabled
+}
+
+// cancelMakerQuotes cancels every resting maker quote. Unlike pullQuotes it
+// waits for the order limiter, since a disabled maker will not get another
+// chance to clean up on a later book update.
+func (a *App) cancelMakerQuotes(ctx context.Context) {
+	var ids []string
+	for _, orders := range a.activeOrders {
+		ids = append(ids, orders...)
+	}
+	clear(a.activeOrders)
+	if len(ids) == 0 {
+		return
+	}
+	a.makerMids.forget(ids)
+	a.cancelOrderIDs(ctx, ids, "maker quotes")
+}
+
+// cancelTakerOrders cancels taker orders that are still resting: those on the
+// dedicated taker account, and any untagged order that is not a maker quote.
+// Orders from the external signal endpoint are left alone.
+func (a *App) cancelTakerOrders(ctx context.Context) {
+	quoted := make(map[string]bool)
+	for _, orders := range a.activeOrders {
+		for _, id := range orders {
+			quoted[id] = true
+		}
+	}
+	var ids []string
+	for _, o := range a.tracker.ActiveOrders() {
+		if o.Strategy == "" && !quoted[o.ID] {
+			ids = append(ids, o.ID)
+		}
+	}
+	if len(ids) > 0 {
+		a.cancelOrderIDs(ctx, ids, "taker orders")
+	}
+	if a.execMode == "live" && !a.cfg.DryRun {
+		a.cancelTakerAccountOrders(ctx)
+	}
+}
+
+func (a *App) cancelOrderIDs(ctx context.Context, ids []string, what string) {
+	switch {
+	case a.execMode == "paper":
+		a.cancelPaperOrders(ids)
+	case a.execMode == "live" && a.clobClient != nil:
+		if err := a.limiter.Wait(ctx); err != nil {
+			log.Printf("cancel %s: %v", what, err)
+			return
+		}
+		if _, err := a.clobClient.CancelOrders(ctx, &clobtypes.CancelOrdersRequest{OrderIDs: ids}); err != nil {
+			log.Printf("cancel %s: %v", what, err)
+			return
+		}
+		log.Printf("cancelled %d %s", len(ids), what)
+	}
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// watchdogCancelTimeout bounds the cancel-all issued when the watchdog fires;
+// the trading loop's own context is of no use while the loop is stuck.
+const watchdogCancelTimeout = 10 * time.Second
+
+// watchdog is a dead-man's switch for the trading loop. The loop pets it on a
+// ticker; if no pet arrives within timeout, fire runs once. A later pet
+// re-arms it.
+type watchdog struct {
+	mu      sync.Mutex
+	timeout time.Duration
+	last    time.Time
+	fired   bool
+	now     func() time.Time
+	fire    func(stalled time.Duration)
+}
+
+func newWatchdog(timeout time.Duration, now func() time.Time, fire func(stalled time.Duration)) *watchdog {
+	return &watchdog{timeout: timeout, last: now(), now: now, fire: fire}
+}
+
+// pet records that the loop is alive.
+func (w *watchdog) pet() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.last = w.now()
+	w.fired = false
+}
+
+// check fires the watchdog if the loop has been silent for longer than the
+// timeout and reports whether it did.
+func (w *watchdog) check() bool {
+	w.mu.Lock()
+	stalled := w.now().Sub(w.last)
+	if w.fired || stalled <= w.timeout {
+		w.mu.Unlock()
+		return false
+	}
+	w.fired = true
+	w.mu.Unlock()
+	w.fire(stalled)
+	return true
+}
+
+// run checks the loop every quarter timeout until ctx is done.
+func (w *watchdog) run(ctx context.Context) {
+	ticker := time.NewTicker(max(w.timeout/4, 10*time.Millisecond))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.check()
+		}
+	}
+}
+
+// tripWatchdog runs off the stalled trading loop: it engages the emergency
+// stop and, in live mode, cancels every resting order on both accounts.
+func (a *App) tripWatchdog(stalled time.Duration) {
+	log.Printf("EMERGENCY: trading loop unresponsive for %s (watchdog_timeout=%s), cancelling all orders and triggering emergency stop",
+		stalled.Round(time.Millisecond), a.cfg.WatchdogTimeout)
+	a.SetEmergencyStop(true)
+	if a.execMode == "live" && !a.cfg.DryRun && a.clobClient != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), watchdogCancelTimeout)
+		defer cancel()
+		if resp, err := a.clobClient.CancelAll(ctx); err != nil {
+			log.Printf("watchdog cancel all error: %v", err)
+		} else {
+			log.Printf("watchdog cancelled %d orders", resp.Count)
+		}
+		a.cancelTakerAccountOrders(ctx)
+	}
+	if a.notifier != nil {
+		_ = a.notifier.NotifyAlert(context.Background(), "Trading Loop Stalled",
+			fmt.Sprintf("No loop activity for %s. Orders cancelled and emergency stop engaged.", stalled.Round(time.Second)))
+	}
+}
@@ -16,6 +16,7 @@ type VolumeTracker struct {
 	dailyVolume  []data.BuilderVolumeEntry
 	leaderboard  []data.BuilderLeaderboardEntry
 	lastSync     time.Time
+	lastErr      error
 	syncInterval time.Duration
 }
 
@@ -29,21 +30,29 @@ func NewVolumeTracker(dataClient data.Client, syncInterval time.Duration) *Volum
 func (t *VolumeTracker) Sync(ctx context.Context) error {
 	vol, err := t.dataClient.BuildersVolume(ctx, &data.BuildersVolumeRequest{})
 	if err != nil {
-		return err
+		return t.failed(err)
 	}
 	lb, err := t.dataClient.BuildersLeaderboard(ctx, &data.BuildersLeaderboardRequest{})
 	if err != nil {
-		return err
+		return t.failed(err)
 	}
 
 	t.mu.Lock()
 	t.dailyVolume = vol
 	t.leaderboard = lb
 	t.lastSync = time.Now()
+	t.lastErr = nil
 	t.mu.Unlock()
 	return nil
 }
 
+func (t *VolumeTracker) failed(err error) error {
+	t.mu.Lock()
+	t.lastErr = err
+	t.mu.Unlock()
+	return err
+}
+
 func (t *VolumeTracker) DailyVolume() []data.BuilderVolumeEntry {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
@@ -72,6 +81,14 @@ func (t *VolumeTracker) LastSync() time.Time {
 	return t.lastSync
 }
 
+// LastError returns the error from the most recent sync, or nil if it
+// succeeded or none has run yet.
+func (t *VolumeTracker) LastError() error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.lastErr
+}
+
 func (t *VolumeTracker) Run(ctx context.Context) error {
 	// Initial sync.
 	if err := t.Sync(ctx); err != nil {
@@ -1,8 +1,12 @@
 package builder
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
+
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/data"
 )
 
 func TestNewVolumeTracker(t *testing.T) {
@@ -43,3 +47,36 @@ func TestVolumeTrackerJSONWrappers(t *testing.T) {
 		t.Error("LeaderboardJSON should not be nil")
 	}
 }
+
+type flakyData struct {
+	data.Client
+	err error
+}
+
+func (d *flakyData) BuildersVolume(context.Context, *data.BuildersVolumeRequest) (data.BuildersVolumeResponse, error) {
+	return nil, d.err
+}
+
+func (d *flakyData) BuildersLeaderboard(context.Context, *data.BuildersLeaderboardRequest) (data.BuildersLeaderboardResponse, error) {
+	return nil, d.err
+}
+
+func TestVolumeTrackerLastErrorTracksSync(t *testing.T) {
+	client := &flakyData{err: errors.New("data api down")}
+	tracker := NewVolumeTracker(client, 10*time.Minute)
+
+	if err := tracker.Sync(context.Background()); err == nil {
+		t.Fatal("expected sync error")
+	}
+	if tracker.LastError() == nil {
+		t.Fatal("expected LastError after a failed sync")
+	}
+
+	client.err = nil
+	if err := tracker.Sync(context.Background()); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if err := tracker.LastError(); err != nil {
+		t.Errorf("expected LastError cleared after a successful sync, got %v", err)
+	}
+}
@@ -18,11 +18,87 @@ type Config struct {
 	BuilderPassphrase   string        `yaml:"builder_passphrase"`
 	BuilderSyncInterval time.Duration `yaml:"builder_sync_interval"`
 
+	// Secret files, e.g. mounted Kubernetes secrets. See ResolveSecrets.
+	PrivateKeyFile        string `yaml:"private_key_file"`
+	APIKeyFile            string `yaml:"api_key_file"`
+	APISecretFile         string `yaml:"api_secret_file"`
+	APIPassphraseFile     string `yaml:"api_passphrase_file"`
+	BuilderKeyFile        string `yaml:"builder_key_file"`
+	BuilderSecretFile     string `yaml:"builder_secret_file"`
+	BuilderPassphraseFile string `yaml:"builder_passphrase_file"`
+
 	ScanInterval      time.Duration `yaml:"scan_interval"`
 	HeartbeatInterval time.Duration `yaml:"heartbeat_interval"`
+	BookStaleAfter    time.Duration `yaml:"book_stale_after"`
 	DryRun            bool          `yaml:"dry_run"`
 	TradingMode       string        `yaml:"trading_mode"`
 	LogLevel          string        `yaml:"log_level"`
+	CostBasisMode     string        `yaml:"cost_basis_mode"`
+
+	// PreserveOrdersOnShutdown leaves live orders resting on shutdown and
+	// saves them to OrderStateFile so the next start can re-adopt them.
+	PreserveOrdersOnShutdown bool   `yaml:"preserve_orders_on_shutdown"`
+	OrderStateFile           string `yaml:"order_state_file"`
+	// OrphanOrders decides what startup reconciliation does with open
+	// exchange orders the tracker does not know: "cancel" or "adopt".
+	OrphanOrders string `yaml:"orphan_orders"`
+
+	// MaxOrdersPerSecond caps CLOB order and cancel calls; calls over the
+	// limit are skipped for the tick. 0 disables the limit.
+	MaxOrdersPerSecond float64 `yaml:"max_orders_per_second"`
+
+	// SizeIncrement is the market's minimum share size step. Maker and taker
+	// orders are rounded down to a multiple of it at their limit price, and
+	// skipped when that leaves nothing. 0 disables the rounding.
+	SizeIncrement float64 `yaml:"size_increment"`
+
+	// MidMethod is how a book's mid is priced for maker quotes and marking
+	// positions: "simple" (best bid/ask average), "microprice" (best bid and
+	// ask weighted by the opposite size) or "weighted" (the same over the
+	// top five levels).
+	MidMethod string `yaml:"mid_method"`
+
+	// HTTPRetries is how many times transient CLOB/data read errors are
+	// retried, waiting HTTPRetryBackoff, then twice that, and so on.
+	HTTPRetries      int           `yaml:"http_retries"`
+	HTTPRetryBackoff time.Duration `yaml:"http_retry_backoff"`
+
+	// MaxPlacementFailures consecutive order rejections on an account, each
+	// within PlacementFailureWindow of the streak, pause that account's live
+	// placement for PlacementBreakerCooldown; reduce-only closes still go
+	// out. 0 disables the breaker.
+	MaxPlacementFailures     int           `yaml:"max_placement_failures"`
+	PlacementFailureWindow   time.Duration `yaml:"placement_failure_window"`
+	PlacementBreakerCooldown time.Duration `yaml:"placement_breaker_cooldown"`
+
+	// WebSocket reconnect after the book stream drops: the delay starts at
+	// WSReconnectBackoff and doubles up to WSReconnectMaxBackoff; the loop
+	// stops after WSMaxReconnectAttempts failures (0 = retry forever).
+	WSReconnectBackoff     time.Duration `yaml:"ws_reconnect_backoff"`
+	WSReconnectMaxBackoff  time.Duration `yaml:"ws_reconnect_max_backoff"`
+	WSMaxReconnectAttempts int           `yaml:"ws_max_reconnect_attempts"`
+
+	// FeeRefreshInterval re-queries CLOB fee rates for every monitored asset
+	// so fee-aware pricing follows intraday changes. 0 fetches them only at
+	// startup and when a rescan adds assets.
+	FeeRefreshInterval time.Duration `yaml:"fee_refresh_interval"`
+
+	// DefaultFeeBps is the fee rate fee-aware pricing assumes for an asset
+	// whose CLOB fee rate could not be fetched. FeeOverridesBps pins the rate
+	// of individual assets, taking precedence over the CLOB rate.
+	DefaultFeeBps   float64            `yaml:"default_fee_bps"`
+	FeeOverridesBps map[string]float64 `yaml:"fee_overrides_bps"`
+
+	// WatchdogTimeout is a dead-man's switch: if the trading loop goes this
+	// long without a heartbeat, all orders are cancelled and the emergency
+	// stop is engaged. 0 disables it.
+	WatchdogTimeout time.Duration `yaml:"watchdog_timeout"`
+
+	// FlattenAtWindowClose cancels all orders and market-closes every
+	// position once a day: at FlattenTime ("HH:MM" UTC) or, when that is
+	// empty, at the UTC midnight session close.
+	FlattenAtWindowClose bool   `yaml:"flatten_at_window_close"`
+	FlattenTime          string `yaml:"flatten_time"`
 
 	Maker    MakerConfig    `yaml:"maker"`
 	Taker    TakerConfig    `yaml:"taker"`
@@ -30,19 +106,98 @@ type Config struct {
 	Paper    PaperConfig    `yaml:"paper"`
 	Selector SelectorConfig `yaml:"selector"`
 	Telegram TelegramConfig `yaml:"telegram"`
+	Discord  DiscordConfig  `yaml:"discord"`
+	Slack    SlackConfig    `yaml:"slack"`
+	Webhook  WebhookConfig  `yaml:"webhook"`
+	Notify   NotifyConfig   `yaml:"notify"`
+	Record   RecordConfig   `yaml:"record"`
 	API      APIConfig      `yaml:"api"`
+	Crypto   CryptoConfig   `yaml:"crypto"`
+
+	// TakerAccount, when set, is a second wallet the taker trades through so
+	// maker and taker run on separate sub-accounts.
+	TakerAccount AccountConfig `yaml:"taker_account"`
+
+	// MarketOverrides replaces the maker/taker parameters for specific asset IDs.
+	MarketOverrides map[string]MarketOverride `yaml:"market_overrides"`
+}
+
+// AccountConfig holds the credentials of an additional trading account.
+type AccountConfig struct {
+	PrivateKey    string `yaml:"private_key"`
+	APIKey        string `yaml:"api_key"`
+	APISecret     string `yaml:"api_secret"`
+	APIPassphrase string `yaml:"api_passphrase"`
+
+	PrivateKeyFile    string `yaml:"private_key_file"`
+	APIKeyFile        string `yaml:"api_key_file"`
+	APISecretFile     string `yaml:"api_secret_file"`
+	APIPassphraseFile string `yaml:"api_passphrase_file"`
+}
+
+// Configured reports whether any credential of the account is set.
+func (a AccountConfig) Configured() bool {
+	return a.PrivateKey != "" || a.APIKey != "" || a.APISecret != "" || a.APIPassphrase != ""
 }
 
 type TelegramConfig struct {
 	Enabled  bool   `yaml:"enabled"`
 	BotToken string `yaml:"bot_token"`
 	ChatID   string `yaml:"chat_id"`
+
+	BotTokenFile string `yaml:"bot_token_file"`
 }
 
-type APIConfig struct {
+type DiscordConfig struct {
+	Enabled    bool   `yaml:"enabled"`
+	WebhookURL string `yaml:"webhook_url"`
+}
+
+type SlackConfig struct {
+	Enabled    bool   `yaml:"enabled"`
+	WebhookURL string `yaml:"webhook_url"`
+	Channel    string `yaml:"channel"`
+}
+
+// WebhookConfig posts every alert as JSON to a generic HTTP endpoint.
+type WebhookConfig struct {
 	Enabled bool   `yaml:"enabled"`
-	Addr    string `yaml:"addr"`
-	Token   string `yaml:"token"`
+	URL     string `yaml:"url"`
+}
+
+// NotifyConfig throttles fill alerts. Risk events are never suppressed.
+type NotifyConfig struct {
+	MinFillNotifyUSDC   float64       `yaml:"min_fill_notify_usdc"`
+	LargeFillNotifyUSDC float64       `yaml:"large_fill_notify_usdc"`
+	NotifyCooldown      time.Duration `yaml:"notify_cooldown"`
+	NotifyOnlyLosses    bool          `yaml:"notify_only_losses"`
+	HourlySummary       bool          `yaml:"hourly_summary"`
+	// IdleAlertAfter sends one alert once the bot has gone this long without
+	// a fill; the next fill re-arms it. 0 disables the alert.
+	IdleAlertAfter time.Duration `yaml:"idle_alert_after"`
+}
+
+// RecordConfig controls the on-disk event recorder used to capture live
+// sessions for later backtests.
+type RecordConfig struct {
+	Enabled   bool   `yaml:"enabled"`
+	Dir       string `yaml:"dir"`
+	MaxFileMB int    `yaml:"max_file_mb"`
+}
+
+// CryptoConfig drives crypto-correlated trading: RTDS prices for each
+// mapped symbol turn into signals on the listed Polymarket asset IDs.
+type CryptoConfig struct {
+	Mapping           map[string][]string `yaml:"mapping"`
+	MinPriceChangePct float64             `yaml:"min_price_change_pct"`
+	Cooldown          time.Duration       `yaml:"cooldown"`
+}
+
+type APIConfig struct {
+	Enabled         bool   `yaml:"enabled"`
+	Addr            string `yaml:"addr"`
+	Token           string `yaml:"token"`
+	ExternalSignals bool   `yaml:"external_signals"`
 }
 
 type PaperConfig struct {
@@ -50,6 +205,27 @@ type PaperConfig struct {
 	FeeBps             float64 `yaml:"fee_bps"`
 	SlippageBps        float64 `yaml:"slippage_bps"`
 	AllowShort         bool    `yaml:"allow_short"`
+	StateFile          string  `yaml:"state_file"`
+	LatencyMs          int     `yaml:"latency_ms"`
+
+	// Slippage model for market orders: "flat" applies slippage_bps, "depth"
+	// walks the book and is capped at max_slippage_bps (0 = uncapped).
+	SlippageModel  string  `yaml:"slippage_model"`
+	MaxSlippageBps float64 `yaml:"max_slippage_bps"`
+}
+
+// MarketOverride holds the strategy parameters used for one asset instead of
+// the global maker/taker sections. When loaded from YAML, fields left out of
+// an override inherit the global values.
+type MarketOverride struct {
+	Maker MakerConfig `yaml:"maker"`
+	Taker TakerConfig `yaml:"taker"`
+
+	// inherit marks an override loaded from YAML; ResolveMarketOverrides
+	// rebuilds it by applying its own maker/taker YAML over the globals.
+	inherit    bool
+	makerDelta []byte
+	takerDelta []byte
 }
 
 type MakerConfig struct {
@@ -65,6 +241,28 @@ type MakerConfig struct {
 	InventorySkewBps     float64 `yaml:"inventory_skew_bps"`
 	InventoryWidenFactor float64 `yaml:"inventory_widen_factor"`
 	MinOrderSizeUSDC     float64 `yaml:"min_order_size_usdc"`
+
+	VolSpreadMultiplier float64 `yaml:"vol_spread_multiplier"`
+	VolSpreadMaxBps     float64 `yaml:"vol_spread_max_bps"`
+	VolWindow           int     `yaml:"vol_window"`
+	PostFillPauseMs     int     `yaml:"post_fill_pause_ms"`
+	UsePairFairValue    bool    `yaml:"use_pair_fair_value"`
+
+	// QuoteMode anchors the quotes: "mid" spreads them about the mid, "join"
+	// rests on the best bid/ask and "improve" one tick_size inside it.
+	QuoteMode string  `yaml:"quote_mode"`
+	TickSize  float64 `yaml:"tick_size"`
+
+	// MaxOrderAge cancels resting quotes older than this on the risk ticker,
+	// even when their book has gone quiet. 0 disables it.
+	MaxOrderAge time.Duration `yaml:"max_order_age"`
+
+	// MaxCancelsBeforeCooldown stops quoting an asset for CancelCooldown once
+	// its quotes have been cancelled and replaced this many times within
+	// CancelWindow without a fill. 0 disables it.
+	MaxCancelsBeforeCooldown int           `yaml:"max_cancels_before_cooldown"`
+	CancelWindow             time.Duration `yaml:"cancel_window"`
+	CancelCooldown           time.Duration `yaml:"cancel_cooldown"`
 }
 
 type TakerConfig struct {
@@ -76,13 +274,34 @@ type TakerConfig struct {
 	MaxSlippageBps   float64       `yaml:"max_slippage_bps"`
 	Cooldown         time.Duration `yaml:"cooldown"`
 	MinConfidenceBps float64       `yaml:"min_confidence_bps"`
+	GlobalCooldown   time.Duration `yaml:"global_cooldown"`
 
 	FlowWeight        float64       `yaml:"flow_weight"`
 	ImbalanceWeight   float64       `yaml:"imbalance_weight"`
 	ConvergenceWeight float64       `yaml:"convergence_weight"`
 	MinConvergenceBps float64       `yaml:"min_convergence_bps"`
+	MinArbSizeUSDC    float64       `yaml:"min_arb_size_usdc"`
 	FlowWindow        time.Duration `yaml:"flow_window"`
 	MinCompositeScore float64       `yaml:"min_composite_score"`
+	MomentumWeight    float64       `yaml:"momentum_weight"`
+	MomentumWindow    time.Duration `yaml:"momentum_window"`
+
+	ResetCooldownOnDailyReset bool          `yaml:"reset_cooldown_on_daily_reset"`
+	RealizationWindow         time.Duration `yaml:"realization_window"`
+
+	// UseMarketableLimit sends taker orders as limits at the signal's max
+	// price instead of FAK market orders. MarketableLimitType is "FOK"
+	// (default) or "GTC", which rests any unfilled remainder.
+	UseMarketableLimit  bool   `yaml:"use_marketable_limit"`
+	MarketableLimitType string `yaml:"marketable_limit_type"`
+
+	// RequireFeeCoverage suppresses signals whose expected edge does not
+	// exceed the round-trip fee for the asset.
+	RequireFeeCoverage bool `yaml:"require_fee_coverage"`
+
+	// SignalLog, when set, is a JSONL file every scored signal is appended
+	// to, with its component scores and whether it fired.
+	SignalLog string `yaml:"signal_log"`
 }
 
 type SelectorConfig struct {
@@ -91,30 +310,82 @@ type SelectorConfig struct {
 	MinVolume24hr  float64       `yaml:"min_volume_24hr"`
 	MaxSpread      float64       `yaml:"max_spread"`
 	MinDaysToEnd   int           `yaml:"min_days_to_end"`
+
+	// ProfitabilityWeight (0–1) blends each asset's realized-PnL market score
+	// into the liquidity ranking so profitable markets survive rescans.
+	ProfitabilityWeight float64 `yaml:"profitability_weight"`
+
+	// MinVolatility and MaxVolatility (bps) exclude markets whose recent
+	// price history is flatter or choppier than this. 0 leaves a side open.
+	MinVolatility float64 `yaml:"min_volatility"`
+	MaxVolatility float64 `yaml:"max_volatility"`
 }
 
 type RiskConfig struct {
-	MaxOpenOrders           int           `yaml:"max_open_orders"`
-	MaxDailyLossUSDC        float64       `yaml:"max_daily_loss_usdc"`
-	MaxDailyLossPct         float64       `yaml:"max_daily_loss_pct"`
-	AccountCapitalUSDC      float64       `yaml:"account_capital_usdc"`
-	MaxPositionPerMarket    float64       `yaml:"max_position_per_market"`
-	EmergencyStop           bool          `yaml:"emergency_stop"`
-	StopLossPerMarket       float64       `yaml:"stop_loss_per_market"`
-	MaxDrawdownPct          float64       `yaml:"max_drawdown_pct"`
-	RiskSyncInterval        time.Duration `yaml:"risk_sync_interval"`
-	MaxConsecutiveLosses    int           `yaml:"max_consecutive_losses"`
-	ConsecutiveLossCooldown time.Duration `yaml:"consecutive_loss_cooldown"`
+	MaxOpenOrders                 int           `yaml:"max_open_orders"`
+	MaxDailyLossUSDC              float64       `yaml:"max_daily_loss_usdc"`
+	MaxDailyLossPct               float64       `yaml:"max_daily_loss_pct"`
+	AccountCapitalUSDC            float64       `yaml:"account_capital_usdc"`
+	MaxPositionPerMarket          float64       `yaml:"max_position_per_market"`
+	MaxLongPerMarketUSDC          float64       `yaml:"max_long_per_market_usdc"`
+	MaxShortPerMarketUSDC         float64       `yaml:"max_short_per_market_usdc"`
+	EmergencyStop                 bool          `yaml:"emergency_stop"`
+	StopLossPerMarket             float64       `yaml:"stop_loss_per_market"`
+	MaxDrawdownPct                float64       `yaml:"max_drawdown_pct"`
+	MaxDrawdownVelocityUSDCPerMin float64       `yaml:"max_drawdown_velocity_usdc_per_min"`
+	DrawdownVelocityWindow        time.Duration `yaml:"drawdown_velocity_window"`
+	RiskSyncInterval              time.Duration `yaml:"risk_sync_interval"`
+	MaxConsecutiveLosses          int           `yaml:"max_consecutive_losses"`
+	ConsecutiveLossCooldown       time.Duration `yaml:"consecutive_loss_cooldown"`
+	CooldownEscalationFactor      float64       `yaml:"cooldown_escalation_factor"`
+	MaxCooldown                   time.Duration `yaml:"max_cooldown"`
+	ConcentrationWarnHHI          float64       `yaml:"concentration_warn_hhi"`
+	MaxGrossExposureUSDC          float64       `yaml:"max_gross_exposure_usdc"`
+	MaxGrossExposurePct           float64       `yaml:"max_gross_exposure_pct"`
+
+	// CorrelationGroups names sets of asset IDs that tend to resolve
+	// together; MaxGroupExposureUSDC caps each set's summed exposure.
+	CorrelationGroups    map[string][]string `yaml:"correlation_groups"`
+	MaxGroupExposureUSDC float64             `yaml:"max_group_exposure_usdc"`
+
+	// MaxOrderNotionalUSDC is a last-line cap on any single order's size,
+	// independent of position and exposure limits.
+	MaxOrderNotionalUSDC float64 `yaml:"max_order_notional_usdc"`
+	// MaxFillsPerMinute trips emergency stop when fills arrive faster than
+	// this, whatever the PnL.
+	MaxFillsPerMinute int `yaml:"max_fills_per_minute"`
+	// MaxHoldingTime unwinds a position once it has been open this long
+	// without returning to flat. 0 disables it.
+	MaxHoldingTime time.Duration `yaml:"max_holding_time"`
 }
 
 func Default() Config {
 	return Config{
 		ScanInterval:        10 * time.Second,
 		HeartbeatInterval:   30 * time.Second,
+		BookStaleAfter:      30 * time.Second,
 		DryRun:              true,
 		TradingMode:         "paper",
 		LogLevel:            "info",
+		CostBasisMode:       "average",
+		OrderStateFile:      "trader-state.json",
+		OrphanOrders:        "cancel",
+		MidMethod:           "simple",
+		MaxOrdersPerSecond:  10,
+		HTTPRetries:         3,
+		HTTPRetryBackoff:    250 * time.Millisecond,
 		BuilderSyncInterval: 10 * time.Minute,
+
+		MaxPlacementFailures:     5,
+		PlacementFailureWindow:   time.Minute,
+		PlacementBreakerCooldown: 5 * time.Minute,
+
+		WSReconnectBackoff:     time.Second,
+		WSReconnectMaxBackoff:  time.Minute,
+		WSMaxReconnectAttempts: 10,
+
+		FeeRefreshInterval: 15 * time.Minute,
+
 		Maker: MakerConfig{
 			Enabled:              true,
 			AutoSelectTop:        2,
@@ -126,6 +397,11 @@ func Default() Config {
 			InventorySkewBps:     30,
 			InventoryWidenFactor: 0.5,
 			MinOrderSizeUSDC:     1,
+			VolWindow:            50,
+			QuoteMode:            "mid",
+			TickSize:             0.01,
+			CancelWindow:         time.Minute,
+			CancelCooldown:       2 * time.Minute,
 		},
 		Taker: TakerConfig{
 			Enabled:           true,
@@ -139,8 +415,11 @@ func Default() Config {
 			ImbalanceWeight:   0.5,
 			ConvergenceWeight: 0.2,
 			MinConvergenceBps: 50,
+			MinArbSizeUSDC:    0.5,
 			FlowWindow:        2 * time.Minute,
 			MinCompositeScore: 0.3,
+			MomentumWindow:    time.Minute,
+			RealizationWindow: 5 * time.Minute,
 		},
 		Risk: RiskConfig{
 			MaxOpenOrders:           6,
@@ -150,9 +429,11 @@ func Default() Config {
 			MaxPositionPerMarket:    3,
 			StopLossPerMarket:       1,
 			MaxDrawdownPct:          0.30,
+			DrawdownVelocityWindow:  5 * time.Minute,
 			RiskSyncInterval:        5 * time.Second,
 			MaxConsecutiveLosses:    3,
 			ConsecutiveLossCooldown: 30 * time.Minute,
+			ConcentrationWarnHHI:    0.5,
 		},
 		Selector: SelectorConfig{
 			RescanInterval: 5 * time.Minute,
@@ -166,10 +447,19 @@ func Default() Config {
 			FeeBps:             10,
 			SlippageBps:        10,
 			AllowShort:         true,
+			SlippageModel:      "flat",
+		},
+		Record: RecordConfig{
+			Dir:       "recordings",
+			MaxFileMB: 100,
 		},
 		API: APIConfig{
 			Addr: ":8080",
 		},
+		Crypto: CryptoConfig{
+			MinPriceChangePct: 0.02,
+			Cooldown:          5 * time.Minute,
+		},
 	}
 }
 
@@ -182,9 +472,70 @@ func LoadFile(path string) (Config, error) {
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return cfg, err
 	}
+	if err := inheritMarketOverrides(data, &cfg); err != nil {
+		return cfg, err
+	}
 	return cfg, nil
 }
 
+// inheritMarketOverrides re-decodes each market override on top of the global
+// maker/taker sections so an override only needs the fields it changes. The
+// fields it does set are kept for ResolveMarketOverrides.
+func inheritMarketOverrides(data []byte, cfg *Config) error {
+	var raw struct {
+		MarketOverrides map[string]struct {
+			Maker yaml.Node `yaml:"maker"`
+			Taker yaml.Node `yaml:"taker"`
+		} `yaml:"market_overrides"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for assetID, node := range raw.MarketOverrides {
+		override := MarketOverride{inherit: true}
+		var err error
+		if !node.Maker.IsZero() {
+			if override.makerDelta, err = yaml.Marshal(&node.Maker); err != nil {
+				return err
+			}
+		}
+		if !node.Taker.IsZero() {
+			if override.takerDelta, err = yaml.Marshal(&node.Taker); err != nil {
+				return err
+			}
+		}
+		cfg.MarketOverrides[assetID] = override
+	}
+	return cfg.ResolveMarketOverrides()
+}
+
+// ResolveMarketOverrides rebuilds each override loaded from YAML from the
+// current global maker/taker sections plus the fields the override sets, so
+// environment overrides and rollout clamps applied to the globals after
+// loading reach the overrides too. Overrides built in code are left as is.
+func (c *Config) ResolveMarketOverrides() error {
+	for assetID, o := range c.MarketOverrides {
+		if !o.inherit {
+			continue
+		}
+		o.Maker, o.Taker = c.Maker, c.Taker
+		o.Maker.Markets = nil
+		o.Taker.Markets = nil
+		if o.makerDelta != nil {
+			if err := yaml.Unmarshal(o.makerDelta, &o.Maker); err != nil {
+				return err
+			}
+		}
+		if o.takerDelta != nil {
+			if err := yaml.Unmarshal(o.takerDelta, &o.Taker); err != nil {
+				return err
+			}
+		}
+		c.MarketOverrides[assetID] = o
+	}
+	return nil
+}
+
 func (c *Config) ApplyEnv() {
 	if v := os.Getenv("POLYMARKET_PK"); v != "" {
 		c.PrivateKey = v
@@ -221,6 +572,12 @@ func (c *Config) ApplyEnv() {
 			c.BuilderSyncInterval = d
 		}
 	}
+	if v := strings.TrimSpace(os.Getenv("TRADER_DISCORD_WEBHOOK_URL")); v != "" {
+		c.Discord.WebhookURL = v
+	}
+	if v := strings.TrimSpace(os.Getenv("TRADER_SLACK_WEBHOOK_URL")); v != "" {
+		c.Slack.WebhookURL = v
+	}
 	if v := strings.TrimSpace(os.Getenv("TRADER_API_TOKEN")); v != "" {
 		c.API.Token = v
 	}
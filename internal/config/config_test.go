@@ -2,6 +2,8 @@ package config
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -126,6 +128,50 @@ paper:
 	}
 }
 
+func TestLoadMarketOverridesInheritGlobals(t *testing.T) {
+	yaml := `
+maker:
+  order_size_usdc: 50
+  min_spread_bps: 20
+taker:
+  amount_usdc: 15
+market_overrides:
+  token-a:
+    maker:
+      min_spread_bps: 80
+`
+	f, err := os.CreateTemp("", "config-*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write([]byte(yaml)); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	cfg, err := LoadFile(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, ok := cfg.MarketOverrides["token-a"]
+	if !ok {
+		t.Fatal("expected override for token-a")
+	}
+	if o.Maker.MinSpreadBps != 80 {
+		t.Fatalf("expected overridden min_spread_bps 80, got %f", o.Maker.MinSpreadBps)
+	}
+	if o.Maker.OrderSizeUSDC != 50 {
+		t.Fatalf("expected inherited order_size_usdc 50, got %f", o.Maker.OrderSizeUSDC)
+	}
+	if o.Taker.AmountUSDC != 15 {
+		t.Fatalf("expected inherited taker amount_usdc 15, got %f", o.Taker.AmountUSDC)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected config with override to be valid, got: %v", err)
+	}
+}
+
 func TestEnvOverride(t *testing.T) {
 	t.Setenv("TRADER_DRY_RUN", "false")
 	cfg := Default()
@@ -244,3 +290,81 @@ func TestApplyEnvBuilderSyncIntervalInvalid(t *testing.T) {
 		t.Fatalf("expected invalid env duration to be ignored, got %v", cfg.BuilderSyncInterval)
 	}
 }
+
+func TestResolveSecretsReadsFilesOverInlineValues(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	cfg := Default()
+	cfg.PrivateKey = "inline-pk"
+	cfg.APIKey = "inline-key"
+	cfg.PrivateKeyFile = write("pk", "  file-pk\n")
+	cfg.APISecretFile = write("secret", "file-secret\n")
+	cfg.Telegram.BotTokenFile = write("bot", "file-bot\n")
+
+	if err := cfg.ResolveSecrets(); err != nil {
+		t.Fatalf("resolve secrets: %v", err)
+	}
+	if cfg.PrivateKey != "file-pk" {
+		t.Fatalf("expected PrivateKey from file, got %q", cfg.PrivateKey)
+	}
+	if cfg.APISecret != "file-secret" {
+		t.Fatalf("expected APISecret from file, got %q", cfg.APISecret)
+	}
+	if cfg.Telegram.BotToken != "file-bot" {
+		t.Fatalf("expected Telegram.BotToken from file, got %q", cfg.Telegram.BotToken)
+	}
+	if cfg.APIKey != "inline-key" {
+		t.Fatalf("expected inline APIKey without a file, got %q", cfg.APIKey)
+	}
+}
+
+func TestResolveSecretsMissingOrEmptyFile(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty")
+	if err := os.WriteFile(empty, []byte(" \n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Default()
+	cfg.APIPassphraseFile = filepath.Join(dir, "missing")
+	if err := cfg.ResolveSecrets(); err == nil || !strings.Contains(err.Error(), "api_passphrase_file") {
+		t.Fatalf("expected api_passphrase_file error for missing file, got %v", err)
+	}
+
+	cfg = Default()
+	cfg.BuilderSecret = "inline"
+	cfg.BuilderSecretFile = empty
+	if err := cfg.ResolveSecrets(); err == nil || !strings.Contains(err.Error(), "is empty") {
+		t.Fatalf("expected empty file error, got %v", err)
+	}
+}
+
+func TestDiffReportsChangedYAMLPaths(t *testing.T) {
+	old := Default()
+	updated := Default()
+	updated.Maker.MinSpreadBps = 40
+	updated.Risk.MaxOpenOrders = 10
+	updated.TradingMode = "live"
+	updated.Maker.Markets = []string{"asset-1"}
+
+	got := Diff(old, updated)
+	want := []string{"trading_mode", "maker.markets", "maker.min_spread_bps", "risk.max_open_orders"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+	if diff := Diff(old, Default()); len(diff) != 0 {
+		t.Fatalf("expected no diff for identical configs, got %v", diff)
+	}
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Diff returns the dotted yaml paths (for example "maker.min_spread_bps") of
+// every field that differs between old and new.
+func Diff(old, new Config) []string {
+	var changed []string
+	diffStruct("", reflect.ValueOf(old), reflect.ValueOf(new), &changed)
+	return changed
+}
+
+func diffStruct(prefix string, old, new reflect.Value, changed *[]string) {
+	t := old.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			name = strings.ToLower(field.Name)
+		}
+		path := prefix + name
+		ov, nv := old.Field(i), new.Field(i)
+		if field.Type.Kind() == reflect.Struct {
+			diffStruct(path+".", ov, nv, changed)
+			continue
+		}
+		if !reflect.DeepEqual(ov.Interface(), nv.Interface()) {
+			*changed = append(*changed, path)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package config
+
+import "gopkg.in/yaml.v3"
+
+// RedactedValue replaces secrets in a redacted config.
+const RedactedValue = "***"
+
+// Redacted returns a copy of the config with credentials, bot tokens and
+// webhook URLs replaced by RedactedValue. Unset secrets stay empty so it is
+// still visible whether one was configured.
+func (c Config) Redacted() Config {
+	for _, s := range []*string{
+		&c.PrivateKey,
+		&c.APIKey,
+		&c.APISecret,
+		&c.APIPassphrase,
+		&c.BuilderSecret,
+		&c.BuilderPassphrase,
+		&c.TakerAccount.PrivateKey,
+		&c.TakerAccount.APIKey,
+		&c.TakerAccount.APISecret,
+		&c.TakerAccount.APIPassphrase,
+		&c.Telegram.BotToken,
+		&c.Discord.WebhookURL,
+		&c.Slack.WebhookURL,
+		&c.Webhook.URL,
+		&c.API.Token,
+	} {
+		if *s != "" {
+			*s = RedactedValue
+		}
+	}
+	return c
+}
+
+// Map renders the config keyed by its yaml field names, with durations as
+// strings such as "10s", ready for JSON encoding.
+func (c Config) Map() (map[string]interface{}, error) {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]interface{})
+	if err := yaml.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
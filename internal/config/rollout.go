@@ -31,6 +31,11 @@ func ApplyRolloutPhase(cfg *Config, phase string) error {
 		clampMaxInt(&cfg.Risk.MaxOpenOrders, 4)
 		clampMaxFloat(&cfg.Maker.OrderSizeUSDC, 1)
 		clampMaxFloat(&cfg.Taker.AmountUSDC, 1)
+		for assetID, o := range cfg.MarketOverrides {
+			clampMaxFloat(&o.Maker.OrderSizeUSDC, 1)
+			clampMaxFloat(&o.Taker.AmountUSDC, 1)
+			cfg.MarketOverrides[assetID] = o
+		}
 		clampMaxFloat(&cfg.Risk.MaxPositionPerMarket, 3)
 		clampMaxFloat(&cfg.Risk.MaxDailyLossPct, 0.01)
 		if cfg.Risk.AccountCapitalUSDC <= 0 {
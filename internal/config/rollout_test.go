@@ -1,6 +1,10 @@
 package config
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
 
 func TestApplyRolloutPhasePaper(t *testing.T) {
 	cfg := Default()
@@ -68,6 +72,53 @@ func TestApplyRolloutPhaseLiveSmallClamps(t *testing.T) {
 	}
 }
 
+func TestApplyRolloutPhaseLiveSmallClampsMarketOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(path, []byte(`
+maker:
+  order_size_usdc: 10
+  min_spread_bps: 20
+taker:
+  amount_usdc: 12
+market_overrides:
+  token-a:
+    maker:
+      min_spread_bps: 80
+  token-b:
+    maker:
+      order_size_usdc: 25
+    taker:
+      amount_usdc: 30
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A global changed after loading, as an env override would, reaches the
+	// overrides that inherit it.
+	cfg.Maker.MinSpreadBps = 30
+	if err := cfg.ResolveMarketOverrides(); err != nil {
+		t.Fatalf("ResolveMarketOverrides: %v", err)
+	}
+	if err := ApplyRolloutPhase(&cfg, "live-small"); err != nil {
+		t.Fatalf("ApplyRolloutPhase: %v", err)
+	}
+
+	a, b := cfg.MarketOverrides["token-a"], cfg.MarketOverrides["token-b"]
+	if a.Maker.MinSpreadBps != 80 || b.Maker.MinSpreadBps != 30 {
+		t.Fatalf("expected token-a to keep its 80 bps and token-b to inherit 30, got %f and %f", a.Maker.MinSpreadBps, b.Maker.MinSpreadBps)
+	}
+	for assetID, o := range cfg.MarketOverrides {
+		if o.Maker.OrderSizeUSDC != 1 || o.Taker.AmountUSDC != 1 {
+			t.Fatalf("expected %s capped at 1 USDC, got maker=%f taker=%f", assetID, o.Maker.OrderSizeUSDC, o.Taker.AmountUSDC)
+		}
+	}
+}
+
 func TestApplyRolloutPhaseLive(t *testing.T) {
 	cfg := Default()
 	cfg.TradingMode = "paper"
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ResolveSecrets reads every secret whose *_file field is set from that file,
+// trimming surrounding whitespace. A file takes precedence over the inline
+// yaml value and its environment variable. A missing, unreadable or empty
+// file is an error rather than a silent fallback.
+func (c *Config) ResolveSecrets() error {
+	for _, s := range []struct {
+		field string
+		path  string
+		dst   *string
+	}{
+		{"private_key_file", c.PrivateKeyFile, &c.PrivateKey},
+		{"api_key_file", c.APIKeyFile, &c.APIKey},
+		{"api_secret_file", c.APISecretFile, &c.APISecret},
+		{"api_passphrase_file", c.APIPassphraseFile, &c.APIPassphrase},
+		{"builder_key_file", c.BuilderKeyFile, &c.BuilderKey},
+		{"builder_secret_file", c.BuilderSecretFile, &c.BuilderSecret},
+		{"builder_passphrase_file", c.BuilderPassphraseFile, &c.BuilderPassphrase},
+		{"taker_account.private_key_file", c.TakerAccount.PrivateKeyFile, &c.TakerAccount.PrivateKey},
+		{"taker_account.api_key_file", c.TakerAccount.APIKeyFile, &c.TakerAccount.APIKey},
+		{"taker_account.api_secret_file", c.TakerAccount.APISecretFile, &c.TakerAccount.APISecret},
+		{"taker_account.api_passphrase_file", c.TakerAccount.APIPassphraseFile, &c.TakerAccount.APIPassphrase},
+		{"telegram.bot_token_file", c.Telegram.BotTokenFile, &c.Telegram.BotToken},
+	} {
+		path := strings.TrimSpace(s.path)
+		if path == "" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", s.field, err)
+		}
+		secret := strings.TrimSpace(string(data))
+		if secret == "" {
+			return fmt.Errorf("%s: %s is empty", s.field, path)
+		}
+		*s.dst = secret
+	}
+	return nil
+}
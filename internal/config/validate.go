@@ -1,69 +1,364 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"net"
+	"sort"
 	"strings"
+	"time"
 )
 
 // Validate checks high-impact runtime configuration constraints.
+// Every problem found is reported; the result is a joined error.
 func (c Config) Validate() error {
+	var errs []error
+
 	mode := strings.ToLower(strings.TrimSpace(c.TradingMode))
 	if mode != "" && mode != "paper" && mode != "live" {
-		return fmt.Errorf("trading_mode must be 'paper' or 'live', got %q", c.TradingMode)
+		errs = append(errs, fmt.Errorf("trading_mode must be 'paper' or 'live', got %q", c.TradingMode))
+	}
+
+	basis := strings.ToLower(strings.TrimSpace(c.CostBasisMode))
+	if basis != "" && basis != "average" && basis != "fifo" {
+		errs = append(errs, fmt.Errorf("cost_basis_mode must be 'average' or 'fifo', got %q", c.CostBasisMode))
 	}
 
 	if c.Paper.InitialBalanceUSDC <= 0 {
-		return fmt.Errorf("paper.initial_balance_usdc must be > 0, got %f", c.Paper.InitialBalanceUSDC)
+		errs = append(errs, fmt.Errorf("paper.initial_balance_usdc must be > 0, got %f", c.Paper.InitialBalanceUSDC))
 	}
 	if c.Paper.FeeBps < 0 {
-		return fmt.Errorf("paper.fee_bps must be >= 0, got %f", c.Paper.FeeBps)
+		errs = append(errs, fmt.Errorf("paper.fee_bps must be >= 0, got %f", c.Paper.FeeBps))
+	}
+	if c.Paper.LatencyMs < 0 {
+		errs = append(errs, fmt.Errorf("paper.latency_ms must be >= 0, got %d", c.Paper.LatencyMs))
 	}
 	if c.Paper.SlippageBps < 0 {
-		return fmt.Errorf("paper.slippage_bps must be >= 0, got %f", c.Paper.SlippageBps)
+		errs = append(errs, fmt.Errorf("paper.slippage_bps must be >= 0, got %f", c.Paper.SlippageBps))
+	}
+	if model := strings.ToLower(strings.TrimSpace(c.Paper.SlippageModel)); model != "" && model != "flat" && model != "depth" {
+		errs = append(errs, fmt.Errorf("paper.slippage_model must be 'flat' or 'depth', got %q", c.Paper.SlippageModel))
+	}
+	if c.Paper.MaxSlippageBps < 0 || c.Paper.MaxSlippageBps > 10000 {
+		errs = append(errs, fmt.Errorf("paper.max_slippage_bps must be within [0,10000], got %f", c.Paper.MaxSlippageBps))
+	}
+	if c.Record.Enabled && strings.TrimSpace(c.Record.Dir) == "" {
+		errs = append(errs, fmt.Errorf("record.dir must be set when record.enabled=true"))
+	}
+	if c.Record.MaxFileMB < 0 {
+		errs = append(errs, fmt.Errorf("record.max_file_mb must be >= 0, got %d", c.Record.MaxFileMB))
+	}
+	if c.Crypto.MinPriceChangePct < 0 {
+		errs = append(errs, fmt.Errorf("crypto.min_price_change_pct must be >= 0, got %f", c.Crypto.MinPriceChangePct))
+	}
+	if c.Crypto.Cooldown < 0 {
+		errs = append(errs, fmt.Errorf("crypto.cooldown must be >= 0, got %s", c.Crypto.Cooldown))
+	}
+	for symbol, assetIDs := range c.Crypto.Mapping {
+		if strings.TrimSpace(symbol) == "" {
+			errs = append(errs, fmt.Errorf("crypto.mapping keys must be non-empty symbols"))
+		}
+		if len(assetIDs) == 0 {
+			errs = append(errs, fmt.Errorf("crypto.mapping.%s must list at least one asset ID", symbol))
+		}
+		for _, assetID := range assetIDs {
+			if strings.TrimSpace(assetID) == "" {
+				errs = append(errs, fmt.Errorf("crypto.mapping.%s contains an empty asset ID", symbol))
+				break
+			}
+		}
+	}
+	if c.PreserveOrdersOnShutdown && strings.TrimSpace(c.OrderStateFile) == "" {
+		errs = append(errs, fmt.Errorf("order_state_file must be set when preserve_orders_on_shutdown=true"))
+	}
+	if orphan := strings.ToLower(strings.TrimSpace(c.OrphanOrders)); orphan != "" && orphan != "cancel" && orphan != "adopt" {
+		errs = append(errs, fmt.Errorf("orphan_orders must be 'cancel' or 'adopt', got %q", c.OrphanOrders))
+	}
+	if c.MaxOrdersPerSecond < 0 {
+		errs = append(errs, fmt.Errorf("max_orders_per_second must be >= 0, got %f", c.MaxOrdersPerSecond))
+	}
+	switch strings.ToLower(strings.TrimSpace(c.MidMethod)) {
+	case "", "simple", "microprice", "weighted":
+	default:
+		errs = append(errs, fmt.Errorf("mid_method must be 'simple', 'microprice' or 'weighted', got %q", c.MidMethod))
+	}
+	if c.SizeIncrement < 0 {
+		errs = append(errs, fmt.Errorf("size_increment must be >= 0, got %f", c.SizeIncrement))
+	}
+	if c.HTTPRetries < 0 {
+		errs = append(errs, fmt.Errorf("http_retries must be >= 0, got %d", c.HTTPRetries))
+	}
+	if c.HTTPRetries > 0 && c.HTTPRetryBackoff <= 0 {
+		errs = append(errs, fmt.Errorf("http_retry_backoff must be > 0 when http_retries > 0, got %s", c.HTTPRetryBackoff))
+	}
+	if c.MaxPlacementFailures < 0 {
+		errs = append(errs, fmt.Errorf("max_placement_failures must be >= 0, got %d", c.MaxPlacementFailures))
+	}
+	if c.MaxPlacementFailures > 0 && (c.PlacementFailureWindow <= 0 || c.PlacementBreakerCooldown <= 0) {
+		errs = append(errs, fmt.Errorf("placement_failure_window and placement_breaker_cooldown must be > 0 when max_placement_failures > 0, got %s and %s", c.PlacementFailureWindow, c.PlacementBreakerCooldown))
+	}
+	if c.TakerAccount.Configured() && (c.TakerAccount.PrivateKey == "" || c.TakerAccount.APIKey == "") {
+		errs = append(errs, fmt.Errorf("taker_account requires both private_key and api_key"))
+	}
+	if c.WSReconnectBackoff < 0 || c.WSReconnectMaxBackoff < 0 {
+		errs = append(errs, fmt.Errorf("ws_reconnect_backoff and ws_reconnect_max_backoff must be >= 0, got %s and %s", c.WSReconnectBackoff, c.WSReconnectMaxBackoff))
+	}
+	if c.WSMaxReconnectAttempts < 0 {
+		errs = append(errs, fmt.Errorf("ws_max_reconnect_attempts must be >= 0, got %d", c.WSMaxReconnectAttempts))
+	}
+	if c.FlattenTime != "" {
+		if _, err := time.Parse("15:04", c.FlattenTime); err != nil {
+			errs = append(errs, fmt.Errorf("flatten_time must be HH:MM (UTC), got %q", c.FlattenTime))
+		}
+	}
+	if c.FeeRefreshInterval < 0 {
+		errs = append(errs, fmt.Errorf("fee_refresh_interval must be >= 0, got %s", c.FeeRefreshInterval))
+	}
+	if c.DefaultFeeBps < 0 {
+		errs = append(errs, fmt.Errorf("default_fee_bps must be >= 0, got %f", c.DefaultFeeBps))
+	}
+	for assetID, bps := range c.FeeOverridesBps {
+		if bps < 0 {
+			errs = append(errs, fmt.Errorf("fee_overrides_bps[%s] must be >= 0, got %f", assetID, bps))
+		}
+	}
+	if c.WatchdogTimeout < 0 {
+		errs = append(errs, fmt.Errorf("watchdog_timeout must be >= 0, got %s", c.WatchdogTimeout))
+	}
+	if c.BookStaleAfter < 0 {
+		errs = append(errs, fmt.Errorf("book_stale_after must be >= 0, got %s", c.BookStaleAfter))
 	}
 	if c.BuilderSyncInterval <= 0 {
-		return fmt.Errorf("builder_sync_interval must be > 0, got %s", c.BuilderSyncInterval)
+		errs = append(errs, fmt.Errorf("builder_sync_interval must be > 0, got %s", c.BuilderSyncInterval))
 	}
 	if c.API.Enabled {
 		addr := strings.TrimSpace(c.API.Addr)
 		if addr == "" {
-			return fmt.Errorf("api.addr must be set when api.enabled=true")
+			errs = append(errs, fmt.Errorf("api.addr must be set when api.enabled=true"))
+		} else if strings.TrimSpace(c.API.Token) == "" && !isLoopbackAddr(addr) {
+			errs = append(errs, fmt.Errorf("api.token is required when api.enabled=true and api.addr is not loopback"))
 		}
-		if strings.TrimSpace(c.API.Token) == "" && !isLoopbackAddr(addr) {
-			return fmt.Errorf("api.token is required when api.enabled=true and api.addr is not loopback")
+	}
+
+	errs = append(errs, validateMakerQuoting("maker", c.Maker)...)
+	if c.Maker.RefreshInterval <= 0 {
+		errs = append(errs, fmt.Errorf("maker.refresh_interval must be > 0, got %s", c.Maker.RefreshInterval))
+	}
+	if c.Maker.AutoSelectTop < 0 {
+		errs = append(errs, fmt.Errorf("maker.auto_select_top must be >= 0, got %d", c.Maker.AutoSelectTop))
+	}
+	if c.Selector.ProfitabilityWeight < 0 || c.Selector.ProfitabilityWeight > 1 {
+		errs = append(errs, fmt.Errorf("selector.profitability_weight must be between 0 and 1, got %f", c.Selector.ProfitabilityWeight))
+	}
+	if c.Selector.MinVolatility < 0 || c.Selector.MaxVolatility < 0 {
+		errs = append(errs, fmt.Errorf("selector.min_volatility and max_volatility must be >= 0, got %f and %f", c.Selector.MinVolatility, c.Selector.MaxVolatility))
+	} else if c.Selector.MaxVolatility > 0 && c.Selector.MaxVolatility < c.Selector.MinVolatility {
+		errs = append(errs, fmt.Errorf("selector.max_volatility (%f) must be >= min_volatility (%f)", c.Selector.MaxVolatility, c.Selector.MinVolatility))
+	}
+	if c.Maker.MaxOrderAge < 0 {
+		errs = append(errs, fmt.Errorf("maker.max_order_age must be >= 0, got %s", c.Maker.MaxOrderAge))
+	}
+	if c.Maker.MaxCancelsBeforeCooldown < 0 {
+		errs = append(errs, fmt.Errorf("maker.max_cancels_before_cooldown must be >= 0, got %d", c.Maker.MaxCancelsBeforeCooldown))
+	}
+	if c.Maker.MaxCancelsBeforeCooldown > 0 && c.Maker.CancelWindow <= 0 {
+		errs = append(errs, fmt.Errorf("maker.cancel_window must be > 0 when max_cancels_before_cooldown is set, got %s", c.Maker.CancelWindow))
+	}
+	if c.Maker.CancelCooldown < 0 {
+		errs = append(errs, fmt.Errorf("maker.cancel_cooldown must be >= 0, got %s", c.Maker.CancelCooldown))
+	}
+	errs = append(errs, validateTakerSignal("taker", c.Taker)...)
+	if c.Taker.FlowWindow < 0 {
+		errs = append(errs, fmt.Errorf("taker.flow_window must be >= 0, got %s", c.Taker.FlowWindow))
+	}
+
+	assetIDs := make([]string, 0, len(c.MarketOverrides))
+	for assetID := range c.MarketOverrides {
+		assetIDs = append(assetIDs, assetID)
+	}
+	sort.Strings(assetIDs)
+	for _, assetID := range assetIDs {
+		override := c.MarketOverrides[assetID]
+		if strings.TrimSpace(assetID) == "" {
+			errs = append(errs, fmt.Errorf("market_overrides keys must be non-empty asset IDs"))
+			continue
 		}
+		errs = append(errs, validateMakerQuoting("market_overrides."+assetID+".maker", override.Maker)...)
+		errs = append(errs, validateTakerSignal("market_overrides."+assetID+".taker", override.Taker)...)
+	}
+
+	if c.Notify.MinFillNotifyUSDC < 0 {
+		errs = append(errs, fmt.Errorf("notify.min_fill_notify_usdc must be >= 0, got %f", c.Notify.MinFillNotifyUSDC))
+	}
+	if c.Notify.LargeFillNotifyUSDC < 0 {
+		errs = append(errs, fmt.Errorf("notify.large_fill_notify_usdc must be >= 0, got %f", c.Notify.LargeFillNotifyUSDC))
+	}
+	if c.Notify.NotifyCooldown < 0 {
+		errs = append(errs, fmt.Errorf("notify.notify_cooldown must be >= 0, got %s", c.Notify.NotifyCooldown))
+	}
+	if c.Notify.IdleAlertAfter < 0 {
+		errs = append(errs, fmt.Errorf("notify.idle_alert_after must be >= 0, got %s", c.Notify.IdleAlertAfter))
 	}
 
 	if c.Risk.MaxOpenOrders <= 0 {
-		return fmt.Errorf("risk.max_open_orders must be > 0, got %d", c.Risk.MaxOpenOrders)
+		errs = append(errs, fmt.Errorf("risk.max_open_orders must be > 0, got %d", c.Risk.MaxOpenOrders))
 	}
 	if c.Risk.MaxDailyLossUSDC < 0 {
-		return fmt.Errorf("risk.max_daily_loss_usdc must be >= 0, got %f", c.Risk.MaxDailyLossUSDC)
+		errs = append(errs, fmt.Errorf("risk.max_daily_loss_usdc must be >= 0, got %f", c.Risk.MaxDailyLossUSDC))
 	}
 	if c.Risk.AccountCapitalUSDC < 0 {
-		return fmt.Errorf("risk.account_capital_usdc must be >= 0, got %f", c.Risk.AccountCapitalUSDC)
+		errs = append(errs, fmt.Errorf("risk.account_capital_usdc must be >= 0, got %f", c.Risk.AccountCapitalUSDC))
 	}
 	if c.Risk.MaxPositionPerMarket <= 0 {
-		return fmt.Errorf("risk.max_position_per_market must be > 0, got %f", c.Risk.MaxPositionPerMarket)
+		errs = append(errs, fmt.Errorf("risk.max_position_per_market must be > 0, got %f", c.Risk.MaxPositionPerMarket))
+	}
+	if c.Risk.MaxLongPerMarketUSDC < 0 {
+		errs = append(errs, fmt.Errorf("risk.max_long_per_market_usdc must be >= 0, got %f", c.Risk.MaxLongPerMarketUSDC))
+	}
+	if c.Risk.MaxShortPerMarketUSDC < 0 {
+		errs = append(errs, fmt.Errorf("risk.max_short_per_market_usdc must be >= 0, got %f", c.Risk.MaxShortPerMarketUSDC))
 	}
 	if c.Risk.MaxDailyLossPct < 0 || c.Risk.MaxDailyLossPct > 1 {
-		return fmt.Errorf("risk.max_daily_loss_pct must be within [0,1], got %f", c.Risk.MaxDailyLossPct)
+		errs = append(errs, fmt.Errorf("risk.max_daily_loss_pct must be within [0,1], got %f", c.Risk.MaxDailyLossPct))
 	}
 	if c.Risk.MaxDrawdownPct < 0 || c.Risk.MaxDrawdownPct > 1 {
-		return fmt.Errorf("risk.max_drawdown_pct must be within [0,1], got %f", c.Risk.MaxDrawdownPct)
+		errs = append(errs, fmt.Errorf("risk.max_drawdown_pct must be within [0,1], got %f", c.Risk.MaxDrawdownPct))
+	}
+	if c.Risk.MaxDrawdownVelocityUSDCPerMin < 0 {
+		errs = append(errs, fmt.Errorf("risk.max_drawdown_velocity_usdc_per_min must be >= 0, got %f", c.Risk.MaxDrawdownVelocityUSDCPerMin))
+	}
+	if c.Risk.DrawdownVelocityWindow < 0 {
+		errs = append(errs, fmt.Errorf("risk.drawdown_velocity_window must be >= 0, got %s", c.Risk.DrawdownVelocityWindow))
+	}
+	if c.Taker.RealizationWindow < 0 {
+		errs = append(errs, fmt.Errorf("taker.realization_window must be >= 0, got %s", c.Taker.RealizationWindow))
 	}
 	if c.Risk.RiskSyncInterval <= 0 {
-		return fmt.Errorf("risk.risk_sync_interval must be > 0, got %s", c.Risk.RiskSyncInterval)
+		errs = append(errs, fmt.Errorf("risk.risk_sync_interval must be > 0, got %s", c.Risk.RiskSyncInterval))
 	}
 	if c.Risk.MaxConsecutiveLosses < 0 {
-		return fmt.Errorf("risk.max_consecutive_losses must be >= 0, got %d", c.Risk.MaxConsecutiveLosses)
+		errs = append(errs, fmt.Errorf("risk.max_consecutive_losses must be >= 0, got %d", c.Risk.MaxConsecutiveLosses))
 	}
 	if c.Risk.ConsecutiveLossCooldown < 0 {
-		return fmt.Errorf("risk.consecutive_loss_cooldown must be >= 0, got %s", c.Risk.ConsecutiveLossCooldown)
+		errs = append(errs, fmt.Errorf("risk.consecutive_loss_cooldown must be >= 0, got %s", c.Risk.ConsecutiveLossCooldown))
+	}
+	if c.Risk.MaxGrossExposureUSDC < 0 {
+		errs = append(errs, fmt.Errorf("risk.max_gross_exposure_usdc must be >= 0, got %f", c.Risk.MaxGrossExposureUSDC))
+	}
+	if c.Risk.MaxFillsPerMinute < 0 {
+		errs = append(errs, fmt.Errorf("risk.max_fills_per_minute must be >= 0, got %d", c.Risk.MaxFillsPerMinute))
+	}
+	if c.Risk.MaxOrderNotionalUSDC < 0 {
+		errs = append(errs, fmt.Errorf("risk.max_order_notional_usdc must be >= 0, got %f", c.Risk.MaxOrderNotionalUSDC))
 	}
+	if c.Risk.MaxGrossExposurePct < 0 {
+		errs = append(errs, fmt.Errorf("risk.max_gross_exposure_pct must be >= 0, got %f", c.Risk.MaxGrossExposurePct))
+	}
+	if c.Risk.MaxGroupExposureUSDC < 0 {
+		errs = append(errs, fmt.Errorf("risk.max_group_exposure_usdc must be >= 0, got %f", c.Risk.MaxGroupExposureUSDC))
+	}
+	for name, members := range c.Risk.CorrelationGroups {
+		if len(members) == 0 {
+			errs = append(errs, fmt.Errorf("risk.correlation_groups.%s must list at least one asset ID", name))
+		}
+	}
+	if c.Risk.CooldownEscalationFactor < 0 {
+		errs = append(errs, fmt.Errorf("risk.cooldown_escalation_factor must be >= 0, got %f", c.Risk.CooldownEscalationFactor))
+	}
+	if c.Risk.MaxHoldingTime < 0 {
+		errs = append(errs, fmt.Errorf("risk.max_holding_time must be >= 0, got %s", c.Risk.MaxHoldingTime))
+	}
+	if c.Risk.MaxCooldown < 0 {
+		errs = append(errs, fmt.Errorf("risk.max_cooldown must be >= 0, got %s", c.Risk.MaxCooldown))
+	}
+	if c.Risk.ConcentrationWarnHHI < 0 || c.Risk.ConcentrationWarnHHI > 1 {
+		errs = append(errs, fmt.Errorf("risk.concentration_warn_hhi must be within [0,1], got %f", c.Risk.ConcentrationWarnHHI))
+	}
+
+	return errors.Join(errs...)
+}
 
-	return nil
+// validateMakerQuoting checks the quoting parameters shared by the global
+// maker section and per-market overrides.
+func validateMakerQuoting(prefix string, m MakerConfig) []error {
+	var errs []error
+	if m.MinSpreadBps < 0 {
+		errs = append(errs, fmt.Errorf("%s.min_spread_bps must be >= 0, got %f", prefix, m.MinSpreadBps))
+	}
+	if m.SpreadMultiplier <= 0 {
+		errs = append(errs, fmt.Errorf("%s.spread_multiplier must be > 0, got %f", prefix, m.SpreadMultiplier))
+	}
+	if m.OrderSizeUSDC <= 0 {
+		errs = append(errs, fmt.Errorf("%s.order_size_usdc must be > 0, got %f", prefix, m.OrderSizeUSDC))
+	}
+	if m.MinOrderSizeUSDC < 0 {
+		errs = append(errs, fmt.Errorf("%s.min_order_size_usdc must be >= 0, got %f", prefix, m.MinOrderSizeUSDC))
+	}
+	if m.MaxOrdersPerMarket < 0 {
+		errs = append(errs, fmt.Errorf("%s.max_orders_per_market must be >= 0, got %d", prefix, m.MaxOrdersPerMarket))
+	}
+	if m.InventorySkewBps < 0 {
+		errs = append(errs, fmt.Errorf("%s.inventory_skew_bps must be >= 0, got %f", prefix, m.InventorySkewBps))
+	}
+	if m.InventoryWidenFactor < 0 {
+		errs = append(errs, fmt.Errorf("%s.inventory_widen_factor must be >= 0, got %f", prefix, m.InventoryWidenFactor))
+	}
+	if m.VolSpreadMultiplier < 0 {
+		errs = append(errs, fmt.Errorf("%s.vol_spread_multiplier must be >= 0, got %f", prefix, m.VolSpreadMultiplier))
+	}
+	if m.VolSpreadMaxBps < 0 {
+		errs = append(errs, fmt.Errorf("%s.vol_spread_max_bps must be >= 0, got %f", prefix, m.VolSpreadMaxBps))
+	}
+	if m.PostFillPauseMs < 0 {
+		errs = append(errs, fmt.Errorf("%s.post_fill_pause_ms must be >= 0, got %d", prefix, m.PostFillPauseMs))
+	}
+	if mode := strings.ToLower(strings.TrimSpace(m.QuoteMode)); mode != "" && mode != "mid" && mode != "join" && mode != "improve" {
+		errs = append(errs, fmt.Errorf("%s.quote_mode must be 'mid', 'join' or 'improve', got %q", prefix, m.QuoteMode))
+	}
+	if m.TickSize < 0 || m.TickSize >= 1 {
+		errs = append(errs, fmt.Errorf("%s.tick_size must be within [0,1), got %f", prefix, m.TickSize))
+	}
+	if m.VolSpreadMultiplier > 0 && m.VolWindow < 2 {
+		errs = append(errs, fmt.Errorf("%s.vol_window must be >= 2 when vol_spread_multiplier > 0, got %d", prefix, m.VolWindow))
+	}
+	return errs
+}
+
+// validateTakerSignal checks the signal parameters shared by the global taker
+// section and per-market overrides.
+func validateTakerSignal(prefix string, t TakerConfig) []error {
+	var errs []error
+	if t.AmountUSDC <= 0 {
+		errs = append(errs, fmt.Errorf("%s.amount_usdc must be > 0, got %f", prefix, t.AmountUSDC))
+	}
+	if t.MaxSlippageBps < 0 || t.MaxSlippageBps > 10000 {
+		errs = append(errs, fmt.Errorf("%s.max_slippage_bps must be within [0,10000], got %f", prefix, t.MaxSlippageBps))
+	}
+	if t.MinImbalance < 0 || t.MinImbalance > 1 {
+		errs = append(errs, fmt.Errorf("%s.min_imbalance must be within [0,1], got %f", prefix, t.MinImbalance))
+	}
+	if t.DepthLevels <= 0 {
+		errs = append(errs, fmt.Errorf("%s.depth_levels must be > 0, got %d", prefix, t.DepthLevels))
+	}
+	if t.Cooldown < 0 {
+		errs = append(errs, fmt.Errorf("%s.cooldown must be >= 0, got %s", prefix, t.Cooldown))
+	}
+	if t.GlobalCooldown < 0 {
+		errs = append(errs, fmt.Errorf("%s.global_cooldown must be >= 0, got %s", prefix, t.GlobalCooldown))
+	}
+	if t.MinArbSizeUSDC < 0 {
+		errs = append(errs, fmt.Errorf("%s.min_arb_size_usdc must be >= 0, got %f", prefix, t.MinArbSizeUSDC))
+	}
+	if t.MomentumWeight < 0 {
+		errs = append(errs, fmt.Errorf("%s.momentum_weight must be >= 0, got %f", prefix, t.MomentumWeight))
+	}
+	if t.MomentumWindow < 0 {
+		errs = append(errs, fmt.Errorf("%s.momentum_window must be >= 0, got %s", prefix, t.MomentumWindow))
+	}
+	if typ := strings.ToUpper(strings.TrimSpace(t.MarketableLimitType)); typ != "" && typ != "FOK" && typ != "GTC" {
+		errs = append(errs, fmt.Errorf("%s.marketable_limit_type must be 'FOK' or 'GTC', got %q", prefix, t.MarketableLimitType))
+	}
+	return errs
 }
 
 func isLoopbackAddr(addr string) bool {
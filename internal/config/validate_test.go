@@ -1,6 +1,7 @@
 package config
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -12,6 +13,73 @@ func TestValidateDefaultConfig(t *testing.T) {
 	}
 }
 
+func TestValidateInvalidStrategyConfig(t *testing.T) {
+	cfg := Default()
+	cfg.Maker.MinSpreadBps = -5
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected negative maker.min_spread_bps to fail validation")
+	}
+
+	cfg = Default()
+	cfg.Maker.OrderSizeUSDC = 0
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected zero maker.order_size_usdc to fail validation")
+	}
+
+	cfg = Default()
+	cfg.Taker.MaxSlippageBps = 20000
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected taker.max_slippage_bps > 10000 to fail validation")
+	}
+
+	cfg = Default()
+	cfg.Taker.AmountUSDC = -1
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected negative taker.amount_usdc to fail validation")
+	}
+}
+
+func TestValidateRecordRequiresDir(t *testing.T) {
+	cfg := Default()
+	cfg.Record.Enabled = true
+	cfg.Record.Dir = " "
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected enabled recorder without record.dir to fail validation")
+	}
+}
+
+func TestValidateInvalidMarketOverride(t *testing.T) {
+	cfg := Default()
+	bad := cfg.Maker
+	bad.OrderSizeUSDC = 0
+	cfg.MarketOverrides = map[string]MarketOverride{"token-a": {Maker: bad, Taker: cfg.Taker}}
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "market_overrides.token-a.maker.order_size_usdc") {
+		t.Fatalf("expected override field to be named in error, got: %v", err)
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	cfg := Default()
+	cfg.TradingMode = "foo"
+	cfg.Maker.MinSpreadBps = -1
+	cfg.Maker.OrderSizeUSDC = 0
+	cfg.Risk.MaxDailyLossUSDC = -10
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected invalid config to fail validation")
+	}
+	for _, field := range []string{"trading_mode", "maker.min_spread_bps", "maker.order_size_usdc", "risk.max_daily_loss_usdc"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Fatalf("expected error to mention %s, got: %v", field, err)
+		}
+	}
+	if got := len(strings.Split(err.Error(), "\n")); got != 4 {
+		t.Fatalf("expected 4 problems reported, got %d: %v", got, err)
+	}
+}
+
 func TestValidateInvalidTradingMode(t *testing.T) {
 	cfg := Default()
 	cfg.TradingMode = "invalid-mode"
@@ -20,6 +88,25 @@ func TestValidateInvalidTradingMode(t *testing.T) {
 	}
 }
 
+func TestValidateMakerQuoteMode(t *testing.T) {
+	cfg := Default()
+	cfg.Maker.QuoteMode = "improve"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected improve to be valid, got %v", err)
+	}
+
+	cfg.Maker.QuoteMode = "inside"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "maker.quote_mode") {
+		t.Fatalf("expected unknown maker.quote_mode to fail validation, got %v", err)
+	}
+
+	cfg = Default()
+	cfg.Maker.TickSize = 1
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "maker.tick_size") {
+		t.Fatalf("expected maker.tick_size of 1 to fail validation, got %v", err)
+	}
+}
+
 func TestValidateInvalidPaperConfig(t *testing.T) {
 	cfg := Default()
 	cfg.Paper.InitialBalanceUSDC = 0
@@ -32,6 +119,18 @@ func TestValidateInvalidPaperConfig(t *testing.T) {
 	if err := cfg.Validate(); err == nil {
 		t.Fatal("expected negative paper.fee_bps to fail validation")
 	}
+
+	cfg = Default()
+	cfg.Paper.SlippageModel = "impact"
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected unknown paper.slippage_model to fail validation")
+	}
+
+	cfg = Default()
+	cfg.Paper.MaxSlippageBps = -1
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected negative paper.max_slippage_bps to fail validation")
+	}
 }
 
 func TestValidateInvalidRiskPct(t *testing.T) {
@@ -90,6 +189,96 @@ func TestValidateInvalidRiskCoreValues(t *testing.T) {
 	if err := cfg.Validate(); err == nil {
 		t.Fatal("expected negative risk.consecutive_loss_cooldown to fail validation")
 	}
+
+	cfg = Default()
+	cfg.Risk.ConcentrationWarnHHI = 1.5
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected risk.concentration_warn_hhi > 1 to fail validation")
+	}
+
+	cfg = Default()
+	cfg.CostBasisMode = "lifo"
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected unknown cost_basis_mode to fail validation")
+	}
+
+	cfg = Default()
+	cfg.Taker.RealizationWindow = -time.Minute
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected negative taker.realization_window to fail validation")
+	}
+
+	cfg = Default()
+	cfg.Notify.IdleAlertAfter = -time.Hour
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected negative notify.idle_alert_after to fail validation")
+	}
+
+	cfg = Default()
+	cfg.SizeIncrement = -0.5
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected negative size_increment to fail validation")
+	}
+
+	cfg = Default()
+	cfg.MidMethod = "vwap"
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected unknown mid_method to fail validation")
+	}
+
+	cfg = Default()
+	cfg.DefaultFeeBps = -1
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected negative default_fee_bps to fail validation")
+	}
+
+	cfg = Default()
+	cfg.FeeOverridesBps = map[string]float64{"asset-1": -5}
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected negative fee_overrides_bps entry to fail validation")
+	}
+
+	cfg = Default()
+	cfg.Taker.GlobalCooldown = -time.Second
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected negative taker.global_cooldown to fail validation")
+	}
+
+	cfg = Default()
+	cfg.Risk.MaxDrawdownVelocityUSDCPerMin = -1
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected negative risk.max_drawdown_velocity_usdc_per_min to fail validation")
+	}
+
+	cfg = Default()
+	cfg.Risk.MaxCooldown = -time.Minute
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected negative risk.max_cooldown to fail validation")
+	}
+
+	cfg = Default()
+	cfg.Risk.MaxShortPerMarketUSDC = -1
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected negative risk.max_short_per_market_usdc to fail validation")
+	}
+
+	cfg = Default()
+	cfg.Risk.MaxGrossExposureUSDC = -1
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected negative risk.max_gross_exposure_usdc to fail validation")
+	}
+
+	cfg = Default()
+	cfg.Notify.MinFillNotifyUSDC = -1
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected negative notify.min_fill_notify_usdc to fail validation")
+	}
+
+	cfg = Default()
+	cfg.Notify.NotifyCooldown = -time.Second
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected negative notify.notify_cooldown to fail validation")
+	}
 }
 
 func TestValidateInvalidBuilderSyncInterval(t *testing.T) {
@@ -124,3 +313,81 @@ func TestValidateAPITokenOptionalOnLoopbackAddress(t *testing.T) {
 		t.Fatalf("expected localhost api.addr with empty token to be valid, got %v", err)
 	}
 }
+
+func TestValidateWatchdogTimeout(t *testing.T) {
+	cfg := Default()
+	cfg.WatchdogTimeout = -time.Second
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected negative watchdog_timeout to fail validation")
+	}
+}
+
+func TestValidateMakerCancelCooldown(t *testing.T) {
+	cfg := Default()
+	cfg.Maker.MaxCancelsBeforeCooldown = 5
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected default cancel window to be valid, got %v", err)
+	}
+	cfg.Maker.CancelWindow = 0
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected zero cancel_window with max_cancels_before_cooldown to fail validation")
+	}
+	cfg = Default()
+	cfg.Maker.MaxCancelsBeforeCooldown = -1
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected negative max_cancels_before_cooldown to fail validation")
+	}
+}
+
+func TestValidateSelectorVolatilityBand(t *testing.T) {
+	cfg := Default()
+	cfg.Selector.MinVolatility = 50
+	cfg.Selector.MaxVolatility = 20
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected max_volatility below min_volatility to fail validation")
+	}
+	cfg.Selector.MaxVolatility = 0
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected an open upper bound to be valid, got %v", err)
+	}
+}
+
+func TestValidateCryptoMapping(t *testing.T) {
+	cfg := Default()
+	cfg.Crypto.Mapping = map[string][]string{"BTCUSDT": {"btc-yes", "btc-no"}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected crypto mapping to be valid, got %v", err)
+	}
+
+	cfg.Crypto.Mapping = map[string][]string{"BTCUSDT": {"btc-yes", " "}}
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected an empty mapped asset ID to fail validation")
+	}
+	cfg.Crypto.Mapping = map[string][]string{"ETHUSDT": {}}
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected a symbol without asset IDs to fail validation")
+	}
+
+	cfg = Default()
+	cfg.Crypto.MinPriceChangePct = -0.01
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected negative crypto.min_price_change_pct to fail validation")
+	}
+	cfg = Default()
+	cfg.Crypto.Cooldown = -time.Second
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected negative crypto.cooldown to fail validation")
+	}
+}
+
+func TestValidateTakerAccountRequiresKeyAndAPIKey(t *testing.T) {
+	cfg := Default()
+	cfg.TakerAccount.APIKey = "taker-key"
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected taker_account without private_key to fail validation")
+	}
+	cfg.TakerAccount.PrivateKey = "0xabc"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected complete taker_account to be valid, got %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package execution
+
+import (
+	"sync"
+	"time"
+)
+
+// Rejection sources.
+const (
+	RejectedByRisk = "risk" // blocked by a risk check before submission
+	RejectedByCLOB = "clob" // refused by the exchange or failed to build
+)
+
+// Rejection is an order the bot meant to place but did not.
+type Rejection struct {
+	Time    time.Time
+	AssetID string
+	Side    string
+	// SizeUSDC is the intended order amount.
+	SizeUSDC float64
+	Source   string
+	Reason   string
+}
+
+// RejectionLog keeps the most recent rejections, dropping the oldest once
+// full. It is safe for concurrent use.
+type RejectionLog struct {
+	mu      sync.Mutex
+	max     int
+	entries []Rejection // oldest first
+}
+
+// NewRejectionLog creates a log holding up to max rejections. Sizes below 1
+// are raised to 1.
+func NewRejectionLog(max int) *RejectionLog {
+	if max < 1 {
+		max = 1
+	}
+	return &RejectionLog{max: max}
+}
+
+// Add records r.
+func (l *RejectionLog) Add(r Rejection) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, r)
+	if len(l.entries) > l.max {
+		l.entries = append(l.entries[:0], l.entries[len(l.entries)-l.max:]...)
+	}
+}
+
+// Recent returns the last limit rejections, most recent first. A
+// non-positive limit returns all of them.
+func (l *RejectionLog) Recent(limit int) []Rejection {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := len(l.entries)
+	if limit <= 0 || limit > n {
+		limit = n
+	}
+	out := make([]Rejection, limit)
+	for i := range out {
+		out[i] = l.entries[n-1-i]
+	}
+	return out
+}
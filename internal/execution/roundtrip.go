@@ -0,0 +1,98 @@
+package execution
+
+import "time"
+
+// RoundTrip is one position cycle in an asset: opened from flat, closed when
+// it returned to flat or flipped side. RealizedPnL is everything realized
+// while it was open, before fees.
+type RoundTrip struct {
+	AssetID     string
+	Side        string // BUY for a long trip, SELL for a short one
+	OpenedAt    time.Time
+	ClosedAt    time.Time
+	RealizedPnL float64
+}
+
+// RoundTripSummary aggregates closed round-trips. AvgLoss and LargestLoss are
+// negative; break-even trips count towards Trips but neither wins nor losses.
+type RoundTripSummary struct {
+	Trips       int
+	Wins        int
+	Losses      int
+	WinRate     float64 // wins / trips, 0..1
+	AvgWin      float64
+	AvgLoss     float64
+	LargestWin  float64
+	LargestLoss float64
+	Expectancy  float64 // mean realized PnL per trip
+}
+
+// recordTrip books the PnL a fill realized against the asset's open trip and
+// closes the trip when the fill took the position flat or through zero. It
+// must run before markOpened so the trip's opening time is still on pos.
+// Caller must hold t.mu.
+func (t *Tracker) recordTrip(pos *Position, before, realizedBefore float64, at time.Time) {
+	if before == 0 {
+		return
+	}
+	pnl := t.tripPnL[pos.AssetID] + pos.RealizedPnL - realizedBefore
+	if pos.NetSize != 0 && (before > 0) == (pos.NetSize > 0) {
+		t.tripPnL[pos.AssetID] = pnl
+		return
+	}
+	side := "BUY"
+	if before < 0 {
+		side = "SELL"
+	}
+	t.trips = append(t.trips, RoundTrip{
+		AssetID:     pos.AssetID,
+		Side:        side,
+		OpenedAt:    pos.OpenedAt,
+		ClosedAt:    at,
+		RealizedPnL: pnl,
+	})
+	delete(t.tripPnL, pos.AssetID)
+}
+
+// RoundTrips returns the closed round-trips in the order they closed.
+func (t *Tracker) RoundTrips() []RoundTrip {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	out := make([]RoundTrip, len(t.trips))
+	copy(out, t.trips)
+	return out
+}
+
+// SummarizeRoundTrips computes win rate, average and extreme outcomes and
+// expectancy over trips.
+func SummarizeRoundTrips(trips []RoundTrip) RoundTripSummary {
+	var s RoundTripSummary
+	var won, lost, total float64
+	for _, trip := range trips {
+		pnl := trip.RealizedPnL
+		total += pnl
+		switch {
+		case pnl > 0:
+			s.Wins++
+			won += pnl
+			s.LargestWin = max(s.LargestWin, pnl)
+		case pnl < 0:
+			s.Losses++
+			lost += pnl
+			s.LargestLoss = min(s.LargestLoss, pnl)
+		}
+	}
+	s.Trips = len(trips)
+	if s.Trips == 0 {
+		return s
+	}
+	s.WinRate = float64(s.Wins) / float64(s.Trips)
+	if s.Wins > 0 {
+		s.AvgWin = won / float64(s.Wins)
+	}
+	if s.Losses > 0 {
+		s.AvgLoss = lost / float64(s.Losses)
+	}
+	s.Expectancy = total / float64(s.Trips)
+	return s
+}
@@ -0,0 +1,138 @@
+package execution
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// State is the persistable part of a tracker: the orders still resting on
+// the exchange and the positions they trade against. Fill history is not
+// saved.
+type State struct {
+	SavedAt   time.Time           `json:"saved_at"`
+	Orders    []OrderState        `json:"orders"`
+	Positions map[string]Position `json:"positions"`
+	Lots      map[string][]Lot    `json:"lots,omitempty"`
+}
+
+// Export captures the LIVE orders, positions and open lots for persistence.
+func (t *Tracker) Export() State {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	st := State{
+		SavedAt:   t.now().UTC(),
+		Positions: make(map[string]Position, len(t.positions)),
+	}
+	for _, o := range t.orders {
+		if o.Status == "LIVE" {
+			st.Orders = append(st.Orders, *o)
+		}
+	}
+	for assetID, p := range t.positions {
+		st.Positions[assetID] = *p
+	}
+	for assetID, lots := range t.lots {
+		if len(lots) == 0 {
+			continue
+		}
+		if st.Lots == nil {
+			st.Lots = make(map[string][]Lot)
+		}
+		st.Lots[assetID] = append([]Lot(nil), lots...)
+	}
+	return st
+}
+
+// Import adopts a previously exported state, replacing the tracked orders,
+// positions and lots. The tracker is left untouched if the state is invalid.
+func (t *Tracker) Import(st State) error {
+	orders := make(map[string]*OrderState, len(st.Orders))
+	for _, o := range st.Orders {
+		if o.ID == "" || o.AssetID == "" {
+			return fmt.Errorf("invalid tracker state: order missing id or asset")
+		}
+		if !finite(o.Price, o.OrigSize, o.FilledSize) {
+			return fmt.Errorf("invalid tracker state: order %s has non-finite amounts", o.ID)
+		}
+		o := o
+		o.Status = "LIVE"
+		orders[o.ID] = &o
+	}
+	positions := make(map[string]*Position, len(st.Positions))
+	for assetID, p := range st.Positions {
+		if !finite(p.NetSize, p.AvgEntryPrice, p.RealizedPnL) {
+			return fmt.Errorf("invalid tracker state: position for %s", assetID)
+		}
+		p := p
+		p.AssetID = assetID
+		positions[assetID] = &p
+	}
+	lots := make(map[string][]Lot, len(st.Lots))
+	for assetID, ls := range st.Lots {
+		for _, l := range ls {
+			if !finite(l.Price, l.Size) || l.Size < 0 {
+				return fmt.Errorf("invalid tracker state: lot for %s", assetID)
+			}
+		}
+		if len(ls) > 0 {
+			lots[assetID] = append([]Lot(nil), ls...)
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.orders = orders
+	t.positions = positions
+	t.lots = lots
+	return nil
+}
+
+func finite(vs ...float64) bool {
+	for _, v := range vs {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// SaveState writes the exported state to path, replacing it atomically.
+func (t *Tracker) SaveState(path string) error {
+	data, err := json.MarshalIndent(t.Export(), "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadState imports state from path. A missing file returns an error
+// satisfying errors.Is(err, fs.ErrNotExist); a corrupt file leaves the
+// tracker at its current state.
+func (t *Tracker) LoadState(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var st State
+	if err := json.Unmarshal(data, &st); err != nil {
+		return fmt.Errorf("decode tracker state %s: %w", path, err)
+	}
+	return t.Import(st)
+}
@@ -1,6 +1,9 @@
 package execution
 
 import (
+	"fmt"
+	"log"
+	"math"
 	"strconv"
 	"sync"
 	"time"
@@ -8,7 +11,10 @@ import (
 	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/ws"
 )
 
-// OrderState tracks the lifecycle of a placed order.
+// OrderState tracks the lifecycle of a placed order. FilledSize is the
+// exchange's matched size in shares; OrigSize is taken from the exchange too
+// once it reports the order, and is the size given to RegisterOrder until
+// then.
 type OrderState struct {
 	ID         string
 	AssetID    string
@@ -18,6 +24,7 @@ type OrderState struct {
 	Price      float64
 	OrigSize   float64
 	FilledSize float64
+	Strategy   string // optional origin tag, e.g. "external"
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
 }
@@ -30,6 +37,7 @@ type Fill struct {
 	Side      string
 	Price     float64
 	Size      float64
+	Fee       float64 // USDC, from the asset's cached fee rate
 	Timestamp time.Time
 }
 
@@ -40,6 +48,28 @@ type Position struct {
 	AvgEntryPrice float64
 	RealizedPnL   float64
 	TotalFills    int
+	// OpenedAt is when the position last left flat or flipped side. It is
+	// zero while the position is flat.
+	OpenedAt time.Time
+}
+
+// CostBasisMode selects how realized PnL is computed on reducing fills.
+type CostBasisMode string
+
+const (
+	// CostBasisAverage realizes PnL against the running average entry price.
+	CostBasisAverage CostBasisMode = "average"
+	// CostBasisFIFO realizes PnL against the oldest open lots first.
+	CostBasisFIFO CostBasisMode = "fifo"
+)
+
+// Lot is an open tax lot tracked in FIFO mode.
+type Lot struct {
+	TradeID  string
+	Side     string // BUY for a long lot, SELL for a short lot
+	Price    float64
+	Size     float64 // remaining open size
+	OpenedAt time.Time
 }
 
 // Tracker monitors orders, fills, and positions.
@@ -48,7 +78,18 @@ type Tracker struct {
 	orders    map[string]*OrderState // orderID -> state
 	fills     []Fill
 	positions map[string]*Position // assetID -> position
-	OnFill    func(Fill)           // callback for risk integration
+	lots      map[string][]Lot     // assetID -> open lots, oldest first (FIFO mode)
+	trips     []RoundTrip
+	tripPnL   map[string]float64 // assetID -> PnL realized so far in the open trip
+	costBasis CostBasisMode
+	feeRates  map[string]float64 // assetID -> fee rate bps
+	totalFees float64
+	malformed int        // trade events skipped for unparseable numbers
+	OnFill    func(Fill) // callback for risk integration
+	// OnOrderFilled is called once per tracked order, when an update first
+	// shows part of it matched.
+	OnOrderFilled func(OrderState)
+	now           func() time.Time
 }
 
 // NewTracker creates a Tracker ready to use.
@@ -56,14 +97,51 @@ func NewTracker() *Tracker {
 	return &Tracker{
 		orders:    make(map[string]*OrderState),
 		positions: make(map[string]*Position),
+		lots:      make(map[string][]Lot),
+		tripPnL:   make(map[string]float64),
+		costBasis: CostBasisAverage,
+		feeRates:  make(map[string]float64),
+		now:       time.Now,
 	}
 }
 
+// SetClock replaces the time source used to stamp orders and fills.
+func (t *Tracker) SetClock(now func() time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.now = now
+}
+
+// SetCostBasisMode switches between average and FIFO accounting. It should be
+// called before any fills are recorded; unknown modes fall back to average.
+func (t *Tracker) SetCostBasisMode(mode CostBasisMode) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if mode != CostBasisFIFO {
+		mode = CostBasisAverage
+	}
+	t.costBasis = mode
+}
+
+// CostBasisMode returns the active cost-basis accounting mode.
+func (t *Tracker) CostBasisMode() CostBasisMode {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.costBasis
+}
+
+// SetFeeRate caches the fee rate (bps of notional) charged on fills for an asset.
+func (t *Tracker) SetFeeRate(assetID string, bps float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.feeRates[assetID] = bps
+}
+
 // RegisterOrder records a newly placed order.
 func (t *Tracker) RegisterOrder(id, assetID, market, side string, price, size float64) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
-	now := time.Now()
+	now := t.now()
 	t.orders[id] = &OrderState{
 		ID:        id,
 		AssetID:   assetID,
@@ -77,11 +155,18 @@ func (t *Tracker) RegisterOrder(id, assetID, market, side string, price, size fl
 	}
 }
 
-// ProcessOrderEvent updates order state from a WebSocket order event.
-func (t *Tracker) ProcessOrderEvent(ev ws.OrderEvent) {
+// TagOrder labels a registered order with the strategy that placed it.
+func (t *Tracker) TagOrder(id, strategy string) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
+	if o, ok := t.orders[id]; ok {
+		o.Strategy = strategy
+	}
+}
 
+// ProcessOrderEvent updates order state from a WebSocket order event.
+func (t *Tracker) ProcessOrderEvent(ev ws.OrderEvent) {
+	t.mu.Lock()
 	o, ok := t.orders[ev.ID]
 	if !ok {
 		// Order placed externally or before tracker started; create stub.
@@ -97,38 +182,74 @@ func (t *Tracker) ProcessOrderEvent(ev ws.OrderEvent) {
 			Price:      price,
 			OrigSize:   origSize,
 			FilledSize: matched,
-			CreatedAt:  time.Now(),
-			UpdatedAt:  time.Now(),
+			CreatedAt:  t.now(),
+			UpdatedAt:  t.now(),
 		}
 		t.orders[ev.ID] = o
+		t.mu.Unlock()
 		return
 	}
 
+	wasFilled := o.FilledSize > 0
 	o.Status = ev.Status
-	o.UpdatedAt = time.Now()
-	if matched, err := strconv.ParseFloat(ev.SizeMatched, 64); err == nil {
+	o.UpdatedAt = t.now()
+	if orig, err := strconv.ParseFloat(ev.OriginalSize, 64); err == nil && orig > 0 {
+		o.OrigSize = orig
+	}
+	// Matched size only grows; a stale update must not undo a partial fill.
+	if matched, err := strconv.ParseFloat(ev.SizeMatched, 64); err == nil && matched > o.FilledSize {
 		o.FilledSize = matched
 	}
+	snapshot, cb := *o, t.OnOrderFilled
+	t.mu.Unlock()
+
+	if cb != nil && !wasFilled && snapshot.FilledSize > 0 {
+		cb(snapshot)
+	}
+}
+
+// RemainingSize is the part of the order still resting: OrigSize less what
+// has been matched, never below zero.
+func (o OrderState) RemainingSize() float64 {
+	return math.Max(o.OrigSize-o.FilledSize, 0)
+}
+
+// PartiallyFilled reports whether the order has matched some, but not all, of
+// its size.
+func (o OrderState) PartiallyFilled() bool {
+	return o.FilledSize > 0 && o.RemainingSize() > 1e-9
 }
 
 // ProcessTradeEvent records a fill and updates the position.
+// Events with a malformed price or size are logged, counted, and skipped so
+// they never reach the cost basis as a zero-price fill.
 func (t *Tracker) ProcessTradeEvent(ev ws.TradeEvent) {
-	price, _ := strconv.ParseFloat(ev.Price, 64)
-	size, _ := strconv.ParseFloat(ev.Size, 64)
+	price, size, err := parseTradeNumbers(ev)
+	if err != nil {
+		t.mu.Lock()
+		t.malformed++
+		t.mu.Unlock()
+		log.Printf("tracker: skipping trade %s for %s: %v", ev.ID, ev.AssetID, err)
+		return
+	}
 	if size == 0 {
 		return
 	}
 
 	fill := Fill{
-		TradeID:   ev.ID,
-		AssetID:   ev.AssetID,
-		Side:      ev.Side,
-		Price:     price,
-		Size:      size,
-		Timestamp: time.Now(),
+		TradeID: ev.ID,
+		AssetID: ev.AssetID,
+		Side:    ev.Side,
+		Price:   price,
+		Size:    size,
 	}
 
 	t.mu.Lock()
+	fill.Timestamp = t.now()
+	if bps := t.feeRates[fill.AssetID]; bps > 0 {
+		fill.Fee = price * size * bps / 10000
+	}
+	t.totalFees += fill.Fee
 	t.fills = append(t.fills, fill)
 	t.updatePosition(fill)
 	cb := t.OnFill
@@ -139,6 +260,23 @@ func (t *Tracker) ProcessTradeEvent(ev ws.TradeEvent) {
 	}
 }
 
+// parseTradeNumbers strictly parses the price and size of a trade event.
+// A price must be a finite number > 0; a size must be a finite number >= 0.
+func parseTradeNumbers(ev ws.TradeEvent) (price, size float64, err error) {
+	size, err = strconv.ParseFloat(ev.Size, 64)
+	if err != nil || math.IsNaN(size) || math.IsInf(size, 0) || size < 0 {
+		return 0, 0, fmt.Errorf("malformed size %q", ev.Size)
+	}
+	if size == 0 {
+		return 0, 0, nil
+	}
+	price, err = strconv.ParseFloat(ev.Price, 64)
+	if err != nil || math.IsNaN(price) || math.IsInf(price, 0) || price <= 0 {
+		return 0, 0, fmt.Errorf("malformed price %q", ev.Price)
+	}
+	return price, size, nil
+}
+
 // updatePosition adjusts the position for a fill. Caller must hold t.mu.
 func (t *Tracker) updatePosition(f Fill) {
 	pos, ok := t.positions[f.AssetID]
@@ -147,6 +285,16 @@ func (t *Tracker) updatePosition(f Fill) {
 		t.positions[f.AssetID] = pos
 	}
 	pos.TotalFills++
+	before, realizedBefore := pos.NetSize, pos.RealizedPnL
+	defer func() {
+		t.recordTrip(pos, before, realizedBefore, f.Timestamp)
+		markOpened(pos, before, f.Timestamp)
+	}()
+
+	if t.costBasis == CostBasisFIFO {
+		t.updateLots(pos, f)
+		return
+	}
 
 	if f.Side == "BUY" {
 		// Increasing long position — adjust cost basis.
@@ -188,6 +336,151 @@ func (t *Tracker) updatePosition(f Fill) {
 	}
 }
 
+// markOpened stamps OpenedAt when pos leaves flat or flips side, and clears
+// it when pos returns to flat.
+func markOpened(pos *Position, before float64, at time.Time) {
+	switch {
+	case pos.NetSize == 0:
+		pos.OpenedAt = time.Time{}
+	case before == 0 || (before > 0) != (pos.NetSize > 0) || pos.OpenedAt.IsZero():
+		pos.OpenedAt = at
+	}
+}
+
+// updateLots matches a fill against the oldest opposite-side lots, realizing
+// PnL per lot, and opens a new lot for any remainder. Caller must hold t.mu.
+func (t *Tracker) updateLots(pos *Position, f Fill) {
+	lots := t.lots[f.AssetID]
+	remaining := f.Size
+	for len(lots) > 0 && remaining > 0 && lots[0].Side != f.Side {
+		lot := &lots[0]
+		qty := math.Min(remaining, lot.Size)
+		if lot.Side == "BUY" {
+			pos.RealizedPnL += (f.Price - lot.Price) * qty
+		} else {
+			pos.RealizedPnL += (lot.Price - f.Price) * qty
+		}
+		lot.Size -= qty
+		remaining -= qty
+		if lot.Size <= 0 {
+			lots = lots[1:]
+		}
+	}
+	if remaining > 0 {
+		lots = append(lots, Lot{
+			TradeID:  f.TradeID,
+			Side:     f.Side,
+			Price:    f.Price,
+			Size:     remaining,
+			OpenedAt: f.Timestamp,
+		})
+	}
+	t.lots[f.AssetID] = lots
+
+	var net, cost float64
+	for _, lot := range lots {
+		cost += lot.Price * lot.Size
+		if lot.Side == "BUY" {
+			net += lot.Size
+		} else {
+			net -= lot.Size
+		}
+	}
+	pos.NetSize = net
+	pos.AvgEntryPrice = 0
+	if net != 0 {
+		pos.AvgEntryPrice = cost / math.Abs(net)
+	}
+}
+
+// SettlePosition closes the whole position in assetID at the resolution payout
+// price, realizing PnL against the current cost basis, and records the closing
+// fill with fee, in USDC, charged on the redemption. It returns false when
+// there is no open position.
+func (t *Tracker) SettlePosition(assetID, tradeID string, payout, fee float64) (Fill, bool) {
+	t.mu.Lock()
+	pos, ok := t.positions[assetID]
+	if !ok || pos.NetSize == 0 {
+		t.mu.Unlock()
+		return Fill{}, false
+	}
+	fill := Fill{
+		TradeID:   tradeID,
+		AssetID:   assetID,
+		Side:      "SELL",
+		Price:     payout,
+		Size:      pos.NetSize,
+		Fee:       fee,
+		Timestamp: t.now(),
+	}
+	before, realizedBefore := pos.NetSize, pos.RealizedPnL
+	if pos.NetSize > 0 {
+		pos.RealizedPnL += (payout - pos.AvgEntryPrice) * pos.NetSize
+	} else {
+		fill.Side = "BUY"
+		fill.Size = -pos.NetSize
+		pos.RealizedPnL += (pos.AvgEntryPrice - payout) * fill.Size
+	}
+	pos.NetSize = 0
+	pos.AvgEntryPrice = 0
+	t.recordTrip(pos, before, realizedBefore, fill.Timestamp)
+	pos.OpenedAt = time.Time{}
+	pos.TotalFills++
+	delete(t.lots, assetID)
+	t.totalFees += fee
+	t.fills = append(t.fills, fill)
+	cb := t.OnFill
+	t.mu.Unlock()
+
+	if cb != nil {
+		cb(fill)
+	}
+	return fill, true
+}
+
+// SeedPosition sets a position discovered outside the tracker, e.g. held on
+// the exchange across a crash. Realized PnL already booked for the asset is
+// kept; in FIFO mode the holding becomes a single lot at avgEntry.
+func (t *Tracker) SeedPosition(assetID string, netSize, avgEntry float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	pos, ok := t.positions[assetID]
+	if !ok {
+		pos = &Position{AssetID: assetID}
+		t.positions[assetID] = pos
+	}
+	before := pos.NetSize
+	pos.NetSize = netSize
+	pos.AvgEntryPrice = avgEntry
+	if netSize == 0 {
+		pos.AvgEntryPrice = 0
+	}
+	markOpened(pos, before, t.now())
+	delete(t.lots, assetID)
+	delete(t.tripPnL, assetID)
+	if t.costBasis == CostBasisFIFO && netSize != 0 {
+		side := "BUY"
+		if netSize < 0 {
+			side = "SELL"
+		}
+		t.lots[assetID] = []Lot{{TradeID: "seed", Side: side, Price: avgEntry, Size: math.Abs(netSize), OpenedAt: t.now()}}
+	}
+}
+
+// Lots returns the open FIFO lots for an asset, oldest first. It is empty
+// unless the tracker runs in FIFO mode.
+func (t *Tracker) Lots(assetID string) []Lot {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	lots := t.lots[assetID]
+	if len(lots) == 0 {
+		return nil
+	}
+	out := make([]Lot, len(lots))
+	copy(out, lots)
+	return out
+}
+
 // Position returns the current position for an asset (nil if none).
 func (t *Tracker) Position(assetID string) *Position {
 	t.mu.RLock()
@@ -224,6 +517,19 @@ func (t *Tracker) OpenOrderCount() int {
 	return n
 }
 
+// OpenOrderCounts returns the number of LIVE orders per asset.
+func (t *Tracker) OpenOrderCounts() map[string]int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	out := make(map[string]int)
+	for _, o := range t.orders {
+		if o.Status == "LIVE" {
+			out[o.AssetID]++
+		}
+	}
+	return out
+}
+
 // TotalFills returns the total number of recorded fills.
 func (t *Tracker) TotalFills() int {
 	t.mu.RLock()
@@ -231,6 +537,21 @@ func (t *Tracker) TotalFills() int {
 	return len(t.fills)
 }
 
+// TotalFees returns the sum of fees across all recorded fills.
+func (t *Tracker) TotalFees() float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.totalFees
+}
+
+// MalformedTradeCount returns how many trade events were skipped because
+// their price or size could not be parsed.
+func (t *Tracker) MalformedTradeCount() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.malformed
+}
+
 // TotalRealizedPnL sums realized PnL across all positions.
 func (t *Tracker) TotalRealizedPnL() float64 {
 	t.mu.RLock()
@@ -270,6 +591,35 @@ func (t *Tracker) RecentFills(limit int) []Fill {
 	return out
 }
 
+// Order returns a copy of the tracked order with the given ID.
+func (t *Tracker) Order(id string) (OrderState, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	o, ok := t.orders[id]
+	if !ok {
+		return OrderState{}, false
+	}
+	return *o, true
+}
+
+// FillsRange returns up to limit fills in chronological order starting at
+// offset, so callers can page through the full history without copying it.
+func (t *Tracker) FillsRange(offset, limit int) []Fill {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	n := len(t.fills)
+	if offset < 0 || offset >= n || limit <= 0 {
+		return nil
+	}
+	end := offset + limit
+	if end > n {
+		end = n
+	}
+	out := make([]Fill, end-offset)
+	copy(out, t.fills[offset:end])
+	return out
+}
+
 // ActiveOrders returns a snapshot of all LIVE orders.
 func (t *Tracker) ActiveOrders() []OrderState {
 	t.mu.RLock()
@@ -1,9 +1,13 @@
 package execution
 
 import (
+	"errors"
+	"io/fs"
 	"math"
+	"path/filepath"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/ws"
 )
@@ -154,6 +158,26 @@ func TestCallbackOnFill(t *testing.T) {
 	}
 }
 
+func TestOnOrderFilledOncePerOrder(t *testing.T) {
+	tr := NewTracker()
+	var filled []string
+	tr.OnOrderFilled = func(o OrderState) {
+		filled = append(filled, o.ID)
+	}
+	tr.RegisterOrder("ord-1", "asset-1", "market-1", "BUY", 0.55, 100)
+	for _, matched := range []string{"0", "40", "100"} {
+		tr.ProcessOrderEvent(ws.OrderEvent{
+			ID: "ord-1", AssetID: "asset-1", OriginalSize: "100", SizeMatched: matched, Status: "LIVE",
+		})
+	}
+	// An order first seen already matched was not placed through the tracker.
+	tr.ProcessOrderEvent(ws.OrderEvent{ID: "ord-2", AssetID: "asset-1", OriginalSize: "10", SizeMatched: "10", Status: "MATCHED"})
+
+	if len(filled) != 1 || filled[0] != "ord-1" {
+		t.Fatalf("expected one callback for ord-1, got %v", filled)
+	}
+}
+
 func TestTotalFillsCount(t *testing.T) {
 	tr := NewTracker()
 	tr.ProcessTradeEvent(ws.TradeEvent{ID: "t-1", AssetID: "a", Side: "BUY", Price: "0.50", Size: "10"})
@@ -164,6 +188,43 @@ func TestTotalFillsCount(t *testing.T) {
 	}
 }
 
+func TestPositionOpenedAtFollowsFlatAndFlips(t *testing.T) {
+	for _, mode := range []CostBasisMode{CostBasisAverage, CostBasisFIFO} {
+		tr := NewTracker()
+		tr.SetCostBasisMode(mode)
+		now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		tr.SetClock(func() time.Time { return now })
+		trade := func(id, side, size string) {
+			tr.ProcessTradeEvent(ws.TradeEvent{ID: id, AssetID: "asset-1", Side: side, Price: "0.50", Size: size})
+		}
+
+		opened := now
+		trade("t-1", "BUY", "10")
+		now = now.Add(time.Hour)
+		trade("t-2", "BUY", "5")
+		if got := tr.Position("asset-1").OpenedAt; !got.Equal(opened) {
+			t.Fatalf("%s: expected adding to keep the open time %s, got %s", mode, opened, got)
+		}
+
+		now = now.Add(time.Hour)
+		trade("t-3", "SELL", "20")
+		if got := tr.Position("asset-1").OpenedAt; !got.Equal(now) {
+			t.Fatalf("%s: expected a flip to restart the open time, got %s", mode, got)
+		}
+
+		now = now.Add(time.Hour)
+		trade("t-4", "BUY", "5")
+		if got := tr.Position("asset-1").OpenedAt; !got.IsZero() {
+			t.Fatalf("%s: expected flat to clear the open time, got %s", mode, got)
+		}
+
+		tr.SeedPosition("asset-1", 3, 0.4)
+		if got := tr.Position("asset-1").OpenedAt; !got.Equal(now) {
+			t.Fatalf("%s: expected a seeded position to open now, got %s", mode, got)
+		}
+	}
+}
+
 func TestPositionsSnapshot(t *testing.T) {
 	tr := NewTracker()
 	tr.ProcessTradeEvent(ws.TradeEvent{ID: "t-1", AssetID: "a", Side: "BUY", Price: "0.50", Size: "10"})
@@ -200,3 +261,270 @@ func TestZeroSizeTradeIgnored(t *testing.T) {
 		t.Fatal("expected nil position for zero-size trade")
 	}
 }
+
+func TestMalformedTradeSkippedAndCounted(t *testing.T) {
+	tr := NewTracker()
+	var callbacks int
+	tr.OnFill = func(Fill) { callbacks++ }
+
+	tr.ProcessTradeEvent(ws.TradeEvent{ID: "t-1", AssetID: "a", Side: "BUY", Price: "0.5O", Size: "10"})
+	tr.ProcessTradeEvent(ws.TradeEvent{ID: "t-2", AssetID: "a", Side: "BUY", Price: "", Size: "10"})
+	tr.ProcessTradeEvent(ws.TradeEvent{ID: "t-3", AssetID: "a", Side: "BUY", Price: "0.50", Size: "ten"})
+
+	if tr.TotalFills() != 0 {
+		t.Fatalf("expected malformed trades to be skipped, got %d fills", tr.TotalFills())
+	}
+	if tr.Position("a") != nil {
+		t.Fatal("expected no position from malformed trades")
+	}
+	if callbacks != 0 {
+		t.Fatalf("expected no OnFill callbacks, got %d", callbacks)
+	}
+	if got := tr.MalformedTradeCount(); got != 3 {
+		t.Fatalf("expected 3 malformed trades counted, got %d", got)
+	}
+
+	tr.ProcessTradeEvent(ws.TradeEvent{ID: "t-4", AssetID: "a", Side: "BUY", Price: "0.50", Size: "10"})
+	pos := tr.Position("a")
+	if pos == nil || pos.AvgEntryPrice != 0.50 {
+		t.Fatalf("expected valid trade to set avg entry 0.50, got %+v", pos)
+	}
+	if got := tr.MalformedTradeCount(); got != 3 {
+		t.Fatalf("expected malformed count unchanged at 3, got %d", got)
+	}
+}
+
+func TestFillFeesAccumulateFromFeeRate(t *testing.T) {
+	tr := NewTracker()
+	tr.SetFeeRate("a", 20)
+
+	tr.ProcessTradeEvent(ws.TradeEvent{ID: "t-1", AssetID: "a", Side: "BUY", Price: "0.50", Size: "100"})
+	tr.ProcessTradeEvent(ws.TradeEvent{ID: "t-2", AssetID: "a", Side: "SELL", Price: "0.60", Size: "50"})
+	tr.ProcessTradeEvent(ws.TradeEvent{ID: "t-3", AssetID: "b", Side: "BUY", Price: "0.40", Size: "10"})
+
+	fills := tr.RecentFills(3)
+	if len(fills) != 3 {
+		t.Fatalf("expected 3 fills, got %d", len(fills))
+	}
+	// RecentFills is newest first.
+	if fills[0].Fee != 0 {
+		t.Fatalf("expected no fee without a cached rate, got %f", fills[0].Fee)
+	}
+	if math.Abs(fills[2].Fee-0.10) > 1e-9 {
+		t.Fatalf("expected first fill fee 0.10, got %f", fills[2].Fee)
+	}
+	// 0.5*100*0.002 + 0.6*50*0.002 = 0.10 + 0.06
+	if got := tr.TotalFees(); math.Abs(got-0.16) > 1e-9 {
+		t.Fatalf("expected total fees 0.16, got %f", got)
+	}
+}
+
+func TestCostBasisAverageVsFIFO(t *testing.T) {
+	run := func(mode CostBasisMode) *Tracker {
+		tr := NewTracker()
+		tr.SetCostBasisMode(mode)
+		tr.ProcessTradeEvent(ws.TradeEvent{ID: "b-1", AssetID: "a", Side: "BUY", Price: "0.40", Size: "10"})
+		tr.ProcessTradeEvent(ws.TradeEvent{ID: "b-2", AssetID: "a", Side: "BUY", Price: "0.60", Size: "10"})
+		tr.ProcessTradeEvent(ws.TradeEvent{ID: "s-1", AssetID: "a", Side: "SELL", Price: "0.70", Size: "15"})
+		return tr
+	}
+
+	avg := run(CostBasisAverage)
+	// Average entry 0.50: (0.70-0.50)*15 = 3.0
+	if got := avg.Position("a").RealizedPnL; math.Abs(got-3.0) > 1e-9 {
+		t.Fatalf("average: expected realized 3.0, got %f", got)
+	}
+	if lots := avg.Lots("a"); lots != nil {
+		t.Fatalf("average: expected no lots, got %+v", lots)
+	}
+
+	fifo := run(CostBasisFIFO)
+	// Oldest lot first: (0.70-0.40)*10 + (0.70-0.60)*5 = 3.5
+	pos := fifo.Position("a")
+	if math.Abs(pos.RealizedPnL-3.5) > 1e-9 {
+		t.Fatalf("fifo: expected realized 3.5, got %f", pos.RealizedPnL)
+	}
+	if math.Abs(pos.NetSize-5) > 1e-9 || math.Abs(pos.AvgEntryPrice-0.60) > 1e-9 {
+		t.Fatalf("fifo: expected 5 @ 0.60 remaining, got %f @ %f", pos.NetSize, pos.AvgEntryPrice)
+	}
+	lots := fifo.Lots("a")
+	if len(lots) != 1 || lots[0].TradeID != "b-2" || math.Abs(lots[0].Size-5) > 1e-9 {
+		t.Fatalf("fifo: expected remaining half of b-2, got %+v", lots)
+	}
+}
+
+func TestCostBasisFIFOFlipsToShortLot(t *testing.T) {
+	tr := NewTracker()
+	tr.SetCostBasisMode(CostBasisFIFO)
+	tr.ProcessTradeEvent(ws.TradeEvent{ID: "b-1", AssetID: "a", Side: "BUY", Price: "0.50", Size: "10"})
+	tr.ProcessTradeEvent(ws.TradeEvent{ID: "s-1", AssetID: "a", Side: "SELL", Price: "0.55", Size: "14"})
+	tr.ProcessTradeEvent(ws.TradeEvent{ID: "b-2", AssetID: "a", Side: "BUY", Price: "0.45", Size: "4"})
+
+	pos := tr.Position("a")
+	// Long closed: 0.05*10 = 0.5; short covered: 0.10*4 = 0.4
+	if math.Abs(pos.RealizedPnL-0.9) > 1e-9 {
+		t.Fatalf("expected realized 0.9, got %f", pos.RealizedPnL)
+	}
+	if pos.NetSize != 0 || len(tr.Lots("a")) != 0 {
+		t.Fatalf("expected flat position, got net=%f lots=%+v", pos.NetSize, tr.Lots("a"))
+	}
+}
+
+func TestStateRoundTripKeepsLiveOrdersAndPositions(t *testing.T) {
+	tr := NewTracker()
+	tr.RegisterOrder("o1", "a1", "m1", "BUY", 0.40, 10)
+	tr.RegisterOrder("o2", "a1", "m1", "SELL", 0.60, 10)
+	tr.ProcessOrderEvent(ws.OrderEvent{ID: "o2", Status: "CANCELED"})
+	tr.ProcessTradeEvent(ws.TradeEvent{ID: "t1", AssetID: "a1", Side: "BUY", Price: "0.40", Size: "5"})
+
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := tr.SaveState(path); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	restored := NewTracker()
+	if err := restored.LoadState(path); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if got := restored.ActiveOrders(); len(got) != 1 || got[0].ID != "o1" {
+		t.Fatalf("expected only o1 restored, got %+v", got)
+	}
+	if pos := restored.Position("a1"); pos == nil || pos.NetSize != 5 || pos.AvgEntryPrice != 0.40 {
+		t.Fatalf("expected restored position, got %+v", pos)
+	}
+
+	if err := restored.Import(State{Orders: []OrderState{{ID: "", AssetID: "a1"}}}); err == nil {
+		t.Fatal("expected invalid state to be rejected")
+	}
+	if restored.OpenOrderCount() != 1 {
+		t.Fatal("expected rejected import to leave tracker untouched")
+	}
+	if err := restored.LoadState(filepath.Join(t.TempDir(), "missing.json")); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected not-exist error, got %v", err)
+	}
+}
+
+func TestSeedPositionReplacesHoldingAndLots(t *testing.T) {
+	tr := NewTracker()
+	tr.SetCostBasisMode(CostBasisFIFO)
+	tr.SeedPosition("a1", 8, 0.45)
+	if pos := tr.Position("a1"); pos == nil || pos.NetSize != 8 || pos.AvgEntryPrice != 0.45 {
+		t.Fatalf("expected seeded position, got %+v", pos)
+	}
+	if lots := tr.Lots("a1"); len(lots) != 1 || lots[0].Size != 8 || lots[0].Side != "BUY" {
+		t.Fatalf("expected one seed lot, got %+v", lots)
+	}
+
+	tr.ProcessTradeEvent(ws.TradeEvent{ID: "t1", AssetID: "a1", Side: "SELL", Price: "0.55", Size: "8"})
+	if pos := tr.Position("a1"); math.Abs(pos.RealizedPnL-0.8) > 1e-9 {
+		t.Fatalf("expected PnL realized against seed entry, got %+v", pos)
+	}
+}
+
+func TestRoundTripsPairOpeningAndClosingFills(t *testing.T) {
+	tr := NewTracker()
+	trade := func(id, asset, side, price, size string) {
+		tr.ProcessTradeEvent(ws.TradeEvent{ID: id, AssetID: asset, Side: side, Price: price, Size: size})
+	}
+	// Win: long 10 at 0.40, scaled out at 0.50 and 0.60.
+	trade("t-1", "asset-1", "BUY", "0.40", "10")
+	trade("t-2", "asset-1", "SELL", "0.50", "5")
+	trade("t-3", "asset-1", "SELL", "0.60", "5")
+	// Loss: long 20 at 0.50, closed at 0.45.
+	trade("t-4", "asset-2", "BUY", "0.50", "20")
+	trade("t-5", "asset-2", "SELL", "0.45", "20")
+	// Win: long 10 at 0.60, flipped short by selling 15 at 0.70.
+	trade("t-6", "asset-3", "BUY", "0.60", "10")
+	trade("t-7", "asset-3", "SELL", "0.70", "15")
+
+	trips := tr.RoundTrips()
+	if len(trips) != 3 {
+		t.Fatalf("expected 3 closed round-trips, got %+v", trips)
+	}
+	want := []struct {
+		asset, side string
+		pnl         float64
+	}{
+		{"asset-1", "BUY", 1.5},
+		{"asset-2", "BUY", -1.0},
+		{"asset-3", "BUY", 1.0},
+	}
+	for i, w := range want {
+		if trips[i].AssetID != w.asset || trips[i].Side != w.side || math.Abs(trips[i].RealizedPnL-w.pnl) > 1e-9 {
+			t.Errorf("trip %d: expected %s %s %.2f, got %+v", i, w.asset, w.side, w.pnl, trips[i])
+		}
+	}
+
+	// The flipped short only becomes a trip once it is closed.
+	if _, ok := tr.SettlePosition("asset-3", "settle-1", 0, 0); !ok {
+		t.Fatal("expected the remaining short to settle")
+	}
+	trips = tr.RoundTrips()
+	if len(trips) != 4 || math.Abs(trips[3].RealizedPnL-3.5) > 1e-9 || trips[3].Side != "SELL" {
+		t.Fatalf("expected a 4th trip winning 3.5 on settlement, got %+v", trips)
+	}
+
+	sum := SummarizeRoundTrips(trips)
+	if sum.Trips != 4 || sum.Wins != 3 || sum.Losses != 1 {
+		t.Fatalf("unexpected counts: %+v", sum)
+	}
+	if math.Abs(sum.WinRate-0.75) > 1e-9 || math.Abs(sum.AvgWin-2.0) > 1e-9 || math.Abs(sum.AvgLoss-(-1.0)) > 1e-9 {
+		t.Fatalf("unexpected averages: %+v", sum)
+	}
+	if math.Abs(sum.LargestWin-3.5) > 1e-9 || math.Abs(sum.LargestLoss-(-1.0)) > 1e-9 {
+		t.Fatalf("unexpected extremes: %+v", sum)
+	}
+	if math.Abs(sum.Expectancy-1.25) > 1e-9 {
+		t.Fatalf("expected expectancy 1.25, got %f", sum.Expectancy)
+	}
+}
+
+func TestRoundTripsInFIFOMode(t *testing.T) {
+	tr := NewTracker()
+	tr.SetCostBasisMode(CostBasisFIFO)
+	tr.ProcessTradeEvent(ws.TradeEvent{ID: "t-1", AssetID: "asset-1", Side: "BUY", Price: "0.40", Size: "5"})
+	tr.ProcessTradeEvent(ws.TradeEvent{ID: "t-2", AssetID: "asset-1", Side: "BUY", Price: "0.50", Size: "5"})
+	tr.ProcessTradeEvent(ws.TradeEvent{ID: "t-3", AssetID: "asset-1", Side: "SELL", Price: "0.45", Size: "10"})
+	// Short 5 at 0.50, covered at 0.40.
+	tr.ProcessTradeEvent(ws.TradeEvent{ID: "t-4", AssetID: "asset-2", Side: "SELL", Price: "0.50", Size: "5"})
+	tr.ProcessTradeEvent(ws.TradeEvent{ID: "t-5", AssetID: "asset-2", Side: "BUY", Price: "0.40", Size: "5"})
+
+	trips := tr.RoundTrips()
+	if len(trips) != 2 || math.Abs(trips[0].RealizedPnL) > 1e-9 {
+		t.Fatalf("expected a break-even trip first, got %+v", trips)
+	}
+	if trips[1].Side != "SELL" || math.Abs(trips[1].RealizedPnL-0.5) > 1e-9 {
+		t.Fatalf("expected a short trip winning 0.5, got %+v", trips[1])
+	}
+	if sum := SummarizeRoundTrips(trips); sum.Wins != 1 || sum.Losses != 0 || sum.WinRate != 0.5 {
+		t.Fatalf("expected the break-even trip to be neither win nor loss, got %+v", sum)
+	}
+}
+
+func TestPartialFillLeavesRemainingSize(t *testing.T) {
+	tr := NewTracker()
+	tr.RegisterOrder("ord-1", "asset-1", "market-1", "BUY", 0.50, 100)
+
+	tr.ProcessOrderEvent(ws.OrderEvent{
+		ID: "ord-1", AssetID: "asset-1", Side: "BUY", Price: "0.50",
+		OriginalSize: "100", SizeMatched: "50", Status: "LIVE",
+	})
+	o, _ := tr.Order("ord-1")
+	if o.FilledSize != 50 || o.RemainingSize() != 50 || !o.PartiallyFilled() {
+		t.Fatalf("expected 50 of 100 filled and 50 remaining, got %+v", o)
+	}
+	if tr.OpenOrderCount() != 1 {
+		t.Fatalf("expected the partly filled order to stay open, got %d", tr.OpenOrderCount())
+	}
+
+	// A late update with less matched must not undo the fill.
+	tr.ProcessOrderEvent(ws.OrderEvent{ID: "ord-1", OriginalSize: "100", SizeMatched: "20", Status: "LIVE"})
+	if o, _ := tr.Order("ord-1"); o.FilledSize != 50 {
+		t.Fatalf("expected filled size to stay at 50, got %f", o.FilledSize)
+	}
+
+	tr.ProcessOrderEvent(ws.OrderEvent{ID: "ord-1", OriginalSize: "100", SizeMatched: "100", Status: "MATCHED"})
+	o, _ = tr.Order("ord-1")
+	if o.RemainingSize() != 0 || o.PartiallyFilled() {
+		t.Fatalf("expected a fully filled order, got %+v", o)
+	}
+}
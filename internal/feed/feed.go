@@ -2,26 +2,111 @@ package feed
 
 import (
 	"fmt"
-	"strconv"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/ws"
 )
 
 // BookSnapshot maintains an in-memory orderbook snapshot per asset.
 type BookSnapshot struct {
-	mu    sync.RWMutex
-	books map[string]ws.OrderbookEvent
+	mu         sync.RWMutex
+	books      map[string]ws.OrderbookEvent
+	updated    map[string]time.Time
+	staleAfter time.Duration
+	midMethod  string
+	now        func() time.Time
 }
 
 func NewBookSnapshot() *BookSnapshot {
-	return &BookSnapshot{books: make(map[string]ws.OrderbookEvent)}
+	return &BookSnapshot{
+		books:   make(map[string]ws.OrderbookEvent),
+		updated: make(map[string]time.Time),
+		now:     time.Now,
+	}
+}
+
+// SetStaleAfter sets how old a book may get before it is considered stale.
+// Zero disables staleness checks.
+func (s *BookSnapshot) SetStaleAfter(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.staleAfter = d
+}
+
+// SetMidMethod selects how Mid prices a book; see BookMid.
+func (s *BookSnapshot) SetMidMethod(method string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.midMethod = method
+}
+
+// SetClock replaces the time source used to age books.
+func (s *BookSnapshot) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
 }
 
+// Update stores the latest book for the event's asset. The book's age is
+// measured from the event's exchange timestamp when present, so a delayed
+// delivery is already stale on arrival; otherwise from receipt.
 func (s *BookSnapshot) Update(event ws.OrderbookEvent) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.books[event.AssetID] = event
+	ts, ok := EventTime(event)
+	if !ok {
+		ts = s.now()
+	}
+	s.updated[event.AssetID] = ts
+}
+
+// LastUpdate returns when the asset's book was last updated.
+func (s *BookSnapshot) LastUpdate(assetID string) (time.Time, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ts, ok := s.updated[assetID]
+	return ts, ok
+}
+
+// IsStale reports whether the asset's book is older than the stale threshold.
+// Assets without a book are not stale; they simply have no data.
+func (s *BookSnapshot) IsStale(assetID string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.isStaleLocked(assetID)
+}
+
+func (s *BookSnapshot) isStaleLocked(assetID string) bool {
+	if s.staleAfter <= 0 {
+		return false
+	}
+	ts, ok := s.updated[assetID]
+	return ok && s.now().Sub(ts) > s.staleAfter
+}
+
+// StaleAssets returns the sorted IDs of assets whose books are stale.
+func (s *BookSnapshot) StaleAssets() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var ids []string
+	for id := range s.books {
+		if s.isStaleLocked(id) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// MidFresh is Mid but fails when the book is stale.
+func (s *BookSnapshot) MidFresh(assetID string) (float64, error) {
+	if s.IsStale(assetID) {
+		return 0, fmt.Errorf("book for %s is stale", assetID)
+	}
+	return s.Mid(assetID)
 }
 
 func (s *BookSnapshot) Get(assetID string) (ws.OrderbookEvent, bool) {
@@ -31,6 +116,8 @@ func (s *BookSnapshot) Get(assetID string) (ws.OrderbookEvent, bool) {
 	return b, ok
 }
 
+// Mid prices the asset's book by the method set with SetMidMethod, the
+// simple best bid/ask mid by default.
 func (s *BookSnapshot) Mid(assetID string) (float64, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -38,34 +125,14 @@ func (s *BookSnapshot) Mid(assetID string) (float64, error) {
 	if !ok || len(b.Bids) == 0 || len(b.Asks) == 0 {
 		return 0, fmt.Errorf("no book for %s", assetID)
 	}
-	bid, err := strconv.ParseFloat(b.Bids[0].Price, 64)
-	if err != nil {
-		return 0, err
-	}
-	ask, err := strconv.ParseFloat(b.Asks[0].Price, 64)
-	if err != nil {
-		return 0, err
-	}
-	return (bid + ask) / 2, nil
+	return BookMid(b, s.midMethod)
 }
 
 // Depth returns total bid and ask depth for the top n levels.
 func (s *BookSnapshot) Depth(assetID string, levels int) (bidDepth, askDepth float64) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	b, ok := s.books[assetID]
-	if !ok {
-		return 0, 0
-	}
-	for i := 0; i < levels && i < len(b.Bids); i++ {
-		size, _ := strconv.ParseFloat(b.Bids[i].Size, 64)
-		bidDepth += size
-	}
-	for i := 0; i < levels && i < len(b.Asks); i++ {
-		size, _ := strconv.ParseFloat(b.Asks[i].Size, 64)
-		askDepth += size
-	}
-	return bidDepth, askDepth
+	return BookDepth(s.books[assetID], levels)
 }
 
 // AssetIDs returns all tracked assets.
@@ -1,7 +1,9 @@
 package feed
 
 import (
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/ws"
 )
@@ -80,3 +82,52 @@ func TestBookSnapshotAssetIDs(t *testing.T) {
 		t.Fatalf("expected 2 assets, got %d", len(ids))
 	}
 }
+
+func TestBookSnapshotStaleness(t *testing.T) {
+	snap := NewBookSnapshot()
+	snap.SetStaleAfter(30 * time.Second)
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	snap.SetClock(func() time.Time { return now })
+
+	book := func(assetID string, ts time.Time) ws.OrderbookEvent {
+		return ws.OrderbookEvent{
+			AssetID:   assetID,
+			Bids:      []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+			Asks:      []ws.OrderbookLevel{{Price: "0.52", Size: "100"}},
+			Timestamp: strconv.FormatInt(ts.UnixMilli(), 10),
+		}
+	}
+	snap.Update(book("old", now.Add(-2*time.Minute)))
+	snap.Update(book("fresh", now.Add(-5*time.Second)))
+
+	if !snap.IsStale("old") {
+		t.Fatal("expected 2-minute-old book to be stale")
+	}
+	if snap.IsStale("fresh") {
+		t.Fatal("expected 5-second-old book to be fresh")
+	}
+	if _, err := snap.MidFresh("old"); err == nil {
+		t.Fatal("expected MidFresh to reject stale book")
+	}
+	if mid, err := snap.MidFresh("fresh"); err != nil || mid != 0.51 {
+		t.Fatalf("expected fresh mid 0.51, got %f (%v)", mid, err)
+	}
+	if stale := snap.StaleAssets(); len(stale) != 1 || stale[0] != "old" {
+		t.Fatalf("expected only old to be stale, got %v", stale)
+	}
+
+	// A book without an exchange timestamp ages from receipt.
+	snap.Update(ws.OrderbookEvent{AssetID: "untimed"})
+	if snap.IsStale("untimed") {
+		t.Fatal("expected just-received book to be fresh")
+	}
+	now = now.Add(time.Minute)
+	if !snap.IsStale("untimed") {
+		t.Fatal("expected book to go stale once it stops updating")
+	}
+
+	snap.SetStaleAfter(0)
+	if snap.IsStale("old") {
+		t.Fatal("expected zero stale_after to disable staleness")
+	}
+}
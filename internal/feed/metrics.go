@@ -0,0 +1,310 @@
+package feed
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/ws"
+)
+
+// The helpers below assume levels are sorted best-first, as the CLOB sends
+// them, and run in O(levels).
+
+// BookDepth sums size over the top levels of each side.
+func BookDepth(book ws.OrderbookEvent, levels int) (bidDepth, askDepth float64) {
+	for i := 0; i < levels && i < len(book.Bids); i++ {
+		size, _ := strconv.ParseFloat(book.Bids[i].Size, 64)
+		bidDepth += size
+	}
+	for i := 0; i < levels && i < len(book.Asks); i++ {
+		size, _ := strconv.ParseFloat(book.Asks[i].Size, 64)
+		askDepth += size
+	}
+	return bidDepth, askDepth
+}
+
+// BookImbalance returns (bid - ask) / (bid + ask) depth over the top levels,
+// in [-1, 1]. ok is false when both sides are empty.
+func BookImbalance(book ws.OrderbookEvent, levels int) (imbalance float64, ok bool) {
+	bidDepth, askDepth := BookDepth(book, levels)
+	total := bidDepth + askDepth
+	if total == 0 {
+		return 0, false
+	}
+	return (bidDepth - askDepth) / total, true
+}
+
+// BookTop returns the best bid and ask prices.
+func BookTop(book ws.OrderbookEvent) (bestBid, bestAsk float64, err error) {
+	if len(book.Bids) == 0 || len(book.Asks) == 0 {
+		return 0, 0, fmt.Errorf("no two-sided book for %s", book.AssetID)
+	}
+	if bestBid, err = strconv.ParseFloat(book.Bids[0].Price, 64); err != nil {
+		return 0, 0, err
+	}
+	if bestAsk, err = strconv.ParseFloat(book.Asks[0].Price, 64); err != nil {
+		return 0, 0, err
+	}
+	return bestBid, bestAsk, nil
+}
+
+// Mid price methods for BookMid.
+const (
+	MidSimple     = "simple"     // halfway between best bid and ask
+	MidMicroprice = "microprice" // best bid and ask weighted by the opposite side's size
+	MidWeighted   = "weighted"   // microprice over the top weightedMidLevels levels
+)
+
+// weightedMidLevels is how many levels a side MidWeighted averages.
+const weightedMidLevels = 5
+
+// BookMid returns the book's mid by method; an empty or unknown method is
+// MidSimple. The microprice weights each side's price by the size resting on
+// the other, so a heavier bid pulls it toward the ask, where the price is
+// more likely to go next. MidWeighted does the same with each side's VWAP and
+// depth over its top levels, clamped to the touch.
+func BookMid(book ws.OrderbookEvent, method string) (float64, error) {
+	bid, ask, err := BookTop(book)
+	if err != nil {
+		return 0, err
+	}
+	switch method {
+	case MidMicroprice:
+		bidSize, _ := strconv.ParseFloat(book.Bids[0].Size, 64)
+		askSize, _ := strconv.ParseFloat(book.Asks[0].Size, 64)
+		if bidSize+askSize > 0 {
+			return (bid*askSize + ask*bidSize) / (bidSize + askSize), nil
+		}
+	case MidWeighted:
+		bidVWAP, bidDepth := sideVWAP(BookLevels(book, "BUY"), weightedMidLevels)
+		askVWAP, askDepth := sideVWAP(BookLevels(book, "SELL"), weightedMidLevels)
+		if bidDepth+askDepth > 0 {
+			mid := (bidVWAP*askDepth + askVWAP*bidDepth) / (bidDepth + askDepth)
+			return math.Min(math.Max(mid, bid), ask), nil
+		}
+	}
+	return (bid + ask) / 2, nil
+}
+
+// sideVWAP is the size-weighted price and total size of the top levels.
+func sideVWAP(levels []Level, n int) (vwap, depth float64) {
+	var notional float64
+	for i := 0; i < n && i < len(levels); i++ {
+		notional += levels[i].Price * levels[i].Size
+		depth += levels[i].Size
+	}
+	if depth == 0 {
+		return 0, 0
+	}
+	return notional / depth, depth
+}
+
+// BookSpread returns best ask minus best bid.
+func BookSpread(book ws.OrderbookEvent) (float64, error) {
+	bid, ask, err := BookTop(book)
+	if err != nil {
+		return 0, err
+	}
+	return ask - bid, nil
+}
+
+// BookDepthWithinBps sums the size resting on one side ("BUY" for bids,
+// "SELL" for asks) priced within bps of the mid.
+func BookDepthWithinBps(book ws.OrderbookEvent, side string, bps float64) float64 {
+	bid, ask, err := BookTop(book)
+	if err != nil || bps < 0 {
+		return 0
+	}
+	mid := (bid + ask) / 2
+	band := mid * bps / 10000
+	var depth float64
+	if strings.EqualFold(side, "BUY") {
+		for _, lvl := range book.Bids {
+			price, _ := strconv.ParseFloat(lvl.Price, 64)
+			if price < mid-band {
+				break
+			}
+			size, _ := strconv.ParseFloat(lvl.Size, 64)
+			depth += size
+		}
+		return depth
+	}
+	for _, lvl := range book.Asks {
+		price, _ := strconv.ParseFloat(lvl.Price, 64)
+		if price > mid+band {
+			break
+		}
+		size, _ := strconv.ParseFloat(lvl.Size, 64)
+		depth += size
+	}
+	return depth
+}
+
+// Level is a parsed book level.
+type Level struct {
+	Price float64 `json:"price"`
+	Size  float64 `json:"size"`
+}
+
+// BookLevels parses one side of the book ("BUY" for bids, "SELL" for asks),
+// best price first.
+func BookLevels(book ws.OrderbookEvent, side string) []Level {
+	raw := book.Asks
+	if strings.EqualFold(side, "BUY") {
+		raw = book.Bids
+	}
+	levels := make([]Level, 0, len(raw))
+	for _, lvl := range raw {
+		price, err := strconv.ParseFloat(lvl.Price, 64)
+		if err != nil {
+			continue
+		}
+		size, _ := strconv.ParseFloat(lvl.Size, 64)
+		levels = append(levels, Level{Price: price, Size: size})
+	}
+	return levels
+}
+
+// BookDepthAtOrBetter sums the size and notional on one side priced at or
+// better than limit: bids at or above it for "BUY", asks at or below it for
+// "SELL".
+func BookDepthAtOrBetter(book ws.OrderbookEvent, side string, limit float64) (size, notional float64) {
+	buy := strings.EqualFold(side, "BUY")
+	for _, lvl := range BookLevels(book, side) {
+		if (buy && lvl.Price < limit) || (!buy && lvl.Price > limit) {
+			break
+		}
+		size += lvl.Size
+		notional += lvl.Size * lvl.Price
+	}
+	return size, notional
+}
+
+// BookMetrics is a point-in-time summary of one asset's book.
+type BookMetrics struct {
+	AssetID       string    `json:"asset_id"`
+	BestBid       float64   `json:"best_bid"`
+	BestAsk       float64   `json:"best_ask"`
+	Mid           float64   `json:"mid"`
+	Spread        float64   `json:"spread"`
+	SpreadBps     float64   `json:"spread_bps"`
+	BidDepth      float64   `json:"bid_depth"`
+	AskDepth      float64   `json:"ask_depth"`
+	Imbalance     float64   `json:"imbalance"`
+	Levels        int       `json:"levels"`
+	BidDepthInBps float64   `json:"bid_depth_within_bps"`
+	AskDepthInBps float64   `json:"ask_depth_within_bps"`
+	DepthBps      float64   `json:"depth_bps"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	Stale         bool      `json:"stale"`
+}
+
+// BookView is a parsed copy of one asset's full stored book.
+type BookView struct {
+	AssetID   string    `json:"asset_id"`
+	Bids      []Level   `json:"bids"`
+	Asks      []Level   `json:"asks"`
+	Mid       float64   `json:"mid"`
+	Spread    float64   `json:"spread"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Stale     bool      `json:"stale"`
+}
+
+// Book returns every stored level of the asset's book with its mid and
+// spread, which are 0 unless both sides have a level. The levels are fresh
+// slices, so callers may keep them after later updates.
+func (s *BookSnapshot) Book(assetID string) (BookView, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	b, ok := s.books[assetID]
+	if !ok {
+		return BookView{}, false
+	}
+	v := BookView{
+		AssetID:   assetID,
+		Bids:      BookLevels(b, "BUY"),
+		Asks:      BookLevels(b, "SELL"),
+		UpdatedAt: s.updated[assetID],
+		Stale:     s.isStaleLocked(assetID),
+	}
+	if bid, ask, err := BookTop(b); err == nil {
+		v.Mid = (bid + ask) / 2
+		v.Spread = ask - bid
+	}
+	return v, true
+}
+
+// Spread returns the asset's best ask minus best bid.
+func (s *BookSnapshot) Spread(assetID string) (float64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	b, ok := s.books[assetID]
+	if !ok {
+		return 0, fmt.Errorf("no book for %s", assetID)
+	}
+	return BookSpread(b)
+}
+
+// DepthWithinBps returns the size on side ("BUY" or "SELL") within bps of mid.
+func (s *BookSnapshot) DepthWithinBps(assetID, side string, bps float64) float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return BookDepthWithinBps(s.books[assetID], side, bps)
+}
+
+// Levels returns the parsed levels on one side of the asset's book.
+func (s *BookSnapshot) Levels(assetID, side string) []Level {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return BookLevels(s.books[assetID], side)
+}
+
+// DepthAtOrBetter returns the size and notional on one side of the asset's
+// book priced at or better than limit.
+func (s *BookSnapshot) DepthAtOrBetter(assetID, side string, limit float64) (size, notional float64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return BookDepthAtOrBetter(s.books[assetID], side, limit)
+}
+
+// Imbalance returns the top-levels depth imbalance, or 0 without a book.
+func (s *BookSnapshot) Imbalance(assetID string, levels int) float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	imbalance, _ := BookImbalance(s.books[assetID], levels)
+	return imbalance
+}
+
+// Metrics summarizes the asset's book from a single consistent read. Depth
+// and imbalance use the top levels; the within-bps depth uses depthBps.
+func (s *BookSnapshot) Metrics(assetID string, levels int, depthBps float64) (BookMetrics, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	b, ok := s.books[assetID]
+	if !ok {
+		return BookMetrics{}, false
+	}
+	m := BookMetrics{
+		AssetID:   assetID,
+		Levels:    levels,
+		DepthBps:  depthBps,
+		UpdatedAt: s.updated[assetID],
+		Stale:     s.isStaleLocked(assetID),
+	}
+	m.BidDepth, m.AskDepth = BookDepth(b, levels)
+	m.Imbalance, _ = BookImbalance(b, levels)
+	if bid, ask, err := BookTop(b); err == nil {
+		m.BestBid, m.BestAsk = bid, ask
+		m.Mid = (bid + ask) / 2
+		m.Spread = ask - bid
+		if m.Mid > 0 {
+			m.SpreadBps = m.Spread / m.Mid * 10000
+		}
+		m.BidDepthInBps = BookDepthWithinBps(b, "BUY", depthBps)
+		m.AskDepthInBps = BookDepthWithinBps(b, "SELL", depthBps)
+	}
+	return m, true
+}
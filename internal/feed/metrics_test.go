@@ -0,0 +1,140 @@
+package feed
+
+import (
+	"math"
+	"testing"
+
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/ws"
+)
+
+func knownBook() ws.OrderbookEvent {
+	return ws.OrderbookEvent{
+		AssetID: "token-1",
+		Bids: []ws.OrderbookLevel{
+			{Price: "0.49", Size: "100"},
+			{Price: "0.48", Size: "50"},
+			{Price: "0.40", Size: "500"},
+		},
+		Asks: []ws.OrderbookLevel{
+			{Price: "0.51", Size: "30"},
+			{Price: "0.52", Size: "20"},
+			{Price: "0.60", Size: "400"},
+		},
+	}
+}
+
+func TestBookSnapshotBookCopiesAllLevels(t *testing.T) {
+	snap := NewBookSnapshot()
+	snap.Update(knownBook())
+
+	v, ok := snap.Book("token-1")
+	if !ok {
+		t.Fatal("expected a book for token-1")
+	}
+	if len(v.Bids) != 3 || len(v.Asks) != 3 || v.Bids[2] != (Level{Price: 0.40, Size: 500}) {
+		t.Fatalf("expected every level, got bids=%v asks=%v", v.Bids, v.Asks)
+	}
+	if v.Mid != 0.5 || math.Abs(v.Spread-0.02) > 1e-9 || v.UpdatedAt.IsZero() {
+		t.Fatalf("unexpected book summary: %+v", v)
+	}
+	if _, ok := snap.Book("missing"); ok {
+		t.Fatal("expected no book for an unknown asset")
+	}
+}
+
+func TestBookSnapshotMetrics(t *testing.T) {
+	snap := NewBookSnapshot()
+	snap.Update(knownBook())
+
+	spread, err := snap.Spread("token-1")
+	if err != nil || math.Abs(spread-0.02) > 1e-9 {
+		t.Fatalf("expected spread 0.02, got %f (%v)", spread, err)
+	}
+	if _, err := snap.Spread("missing"); err == nil {
+		t.Fatal("expected error for unknown asset")
+	}
+
+	// Mid 0.50; 500 bps band is [0.475, 0.525].
+	if got := snap.DepthWithinBps("token-1", "BUY", 500); got != 150 {
+		t.Fatalf("expected bid depth 150 within 500 bps, got %f", got)
+	}
+	if got := snap.DepthWithinBps("token-1", "SELL", 500); got != 50 {
+		t.Fatalf("expected ask depth 50 within 500 bps, got %f", got)
+	}
+	if got := snap.DepthWithinBps("token-1", "SELL", 100); got != 0 {
+		t.Fatalf("expected no ask depth within 100 bps, got %f", got)
+	}
+
+	// Top 2 levels: bids 150, asks 50 → (150-50)/200.
+	if got := snap.Imbalance("token-1", 2); math.Abs(got-0.5) > 1e-9 {
+		t.Fatalf("expected imbalance 0.5, got %f", got)
+	}
+
+	m, ok := snap.Metrics("token-1", 2, 500)
+	if !ok {
+		t.Fatal("expected metrics for token-1")
+	}
+	if m.Mid != 0.5 || math.Abs(m.SpreadBps-400) > 1e-6 || m.BidDepth != 150 || m.AskDepth != 50 || m.BidDepthInBps != 150 {
+		t.Fatalf("unexpected metrics: %+v", m)
+	}
+}
+
+func TestBookMidMethods(t *testing.T) {
+	book := ws.OrderbookEvent{
+		AssetID: "token-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.49", Size: "300"}, {Price: "0.48", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.51", Size: "100"}, {Price: "0.52", Size: "100"}},
+	}
+
+	simple, err := BookMid(book, MidSimple)
+	if err != nil || simple != 0.5 {
+		t.Fatalf("expected simple mid 0.5, got %f (%v)", simple, err)
+	}
+	if m, _ := BookMid(book, ""); m != simple {
+		t.Fatalf("expected empty method to be simple, got %f", m)
+	}
+
+	// 300 bid vs 100 ask at the touch: (0.49*100 + 0.51*300) / 400.
+	micro, err := BookMid(book, MidMicroprice)
+	if err != nil || math.Abs(micro-0.505) > 1e-9 {
+		t.Fatalf("expected microprice 0.505, got %f (%v)", micro, err)
+	}
+	if micro <= simple {
+		t.Fatalf("expected the heavier bid to pull the microprice toward the ask, got %f", micro)
+	}
+
+	// Swapping the sizes mirrors it toward the bid.
+	book.Bids[0].Size, book.Asks[0].Size = "100", "300"
+	if m, _ := BookMid(book, MidMicroprice); math.Abs(m-0.495) > 1e-9 {
+		t.Fatalf("expected microprice 0.495 with the heavier ask, got %f", m)
+	}
+
+	// Bids 0.49x100 + 0.48x100 (VWAP 0.485, depth 200); asks 0.51x300 +
+	// 0.52x100 (VWAP 0.5125, depth 400): (0.485*400 + 0.5125*200) / 600.
+	weighted, err := BookMid(book, MidWeighted)
+	if err != nil || math.Abs(weighted-(0.485*400+0.5125*200)/600) > 1e-9 {
+		t.Fatalf("unexpected weighted mid %f (%v)", weighted, err)
+	}
+
+	// The depth-weighted mid stays inside the touch.
+	if m, _ := BookMid(knownBook(), MidWeighted); m < 0.49 || m > 0.51 {
+		t.Fatalf("expected weighted mid within [0.49, 0.51], got %f", m)
+	}
+
+	if _, err := BookMid(ws.OrderbookEvent{AssetID: "one-sided", Bids: book.Bids}, MidMicroprice); err == nil {
+		t.Fatal("expected an error for a one-sided book")
+	}
+}
+
+func TestBookSnapshotMidUsesMethod(t *testing.T) {
+	snap := NewBookSnapshot()
+	snap.Update(knownBook())
+	if m, _ := snap.Mid("token-1"); m != 0.5 {
+		t.Fatalf("expected simple mid 0.5 by default, got %f", m)
+	}
+	snap.SetMidMethod(MidMicroprice)
+	want, _ := BookMid(knownBook(), MidMicroprice)
+	if m, _ := snap.Mid("token-1"); m != want || m <= 0.5 {
+		t.Fatalf("expected microprice %f, got %f", want, m)
+	}
+}
@@ -0,0 +1,210 @@
+package feed
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/ws"
+)
+
+// Record kinds written by Recorder.
+const (
+	RecordBook  = "book"
+	RecordOrder = "order"
+	RecordTrade = "trade"
+)
+
+// Record is one line of a recording. Exactly one of Book, Order or Trade is
+// set, matching Kind.
+type Record struct {
+	Kind       string             `json:"kind"`
+	RecordedAt time.Time          `json:"recorded_at"`
+	Book       *ws.OrderbookEvent `json:"book,omitempty"`
+	Order      *ws.OrderEvent     `json:"order,omitempty"`
+	Trade      *ws.TradeEvent     `json:"trade,omitempty"`
+}
+
+// RecorderConfig configures a Recorder.
+type RecorderConfig struct {
+	Dir           string
+	MaxFileBytes  int64         // rotate once the current file reaches this size; 0 disables size rotation
+	FlushInterval time.Duration // default 1s
+	BufferSize    int           // queued records before new ones are dropped; default 4096
+}
+
+// Recorder appends market and user events to rotating JSONL files. Record*
+// calls never block: when the write queue is full the record is dropped and
+// counted.
+type Recorder struct {
+	cfg     RecorderConfig
+	ch      chan Record
+	done    chan struct{}
+	dropped atomic.Int64
+
+	mu     sync.RWMutex // guards closed against concurrent sends
+	closed bool
+
+	// Owned by the writer goroutine.
+	file    *os.File
+	w       *bufio.Writer
+	written int64
+	day     time.Time
+}
+
+// NewRecorder creates the output directory and starts the background writer.
+func NewRecorder(cfg RecorderConfig) (*Recorder, error) {
+	if cfg.Dir == "" {
+		return nil, fmt.Errorf("recorder: dir is required")
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = time.Second
+	}
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = 4096
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("recorder: %w", err)
+	}
+	r := &Recorder{
+		cfg:  cfg,
+		ch:   make(chan Record, cfg.BufferSize),
+		done: make(chan struct{}),
+	}
+	go r.loop()
+	return r, nil
+}
+
+// RecordBook queues an order book event.
+func (r *Recorder) RecordBook(ev ws.OrderbookEvent) {
+	r.enqueue(Record{Kind: RecordBook, Book: &ev})
+}
+
+// RecordOrder queues a user order event.
+func (r *Recorder) RecordOrder(ev ws.OrderEvent) {
+	r.enqueue(Record{Kind: RecordOrder, Order: &ev})
+}
+
+// RecordTrade queues a user trade event.
+func (r *Recorder) RecordTrade(ev ws.TradeEvent) {
+	r.enqueue(Record{Kind: RecordTrade, Trade: &ev})
+}
+
+// Dropped returns how many records were discarded because the queue was full.
+func (r *Recorder) Dropped() int64 { return r.dropped.Load() }
+
+// Close drains queued records, flushes and closes the current file. It is
+// safe to call more than once; records queued after Close are dropped.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.ch)
+	}
+	r.mu.Unlock()
+	<-r.done
+	return nil
+}
+
+func (r *Recorder) enqueue(rec Record) {
+	rec.RecordedAt = time.Now().UTC()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		r.dropped.Add(1)
+		return
+	}
+	select {
+	case r.ch <- rec:
+	default:
+		r.dropped.Add(1)
+	}
+}
+
+func (r *Recorder) loop() {
+	defer close(r.done)
+	ticker := time.NewTicker(r.cfg.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case rec, ok := <-r.ch:
+			if !ok {
+				r.closeFile()
+				return
+			}
+			if err := r.write(rec); err != nil {
+				log.Printf("recorder: %v", err)
+			}
+		case <-ticker.C:
+			if r.w != nil {
+				if err := r.w.Flush(); err != nil {
+					log.Printf("recorder: flush: %v", err)
+				}
+			}
+		}
+	}
+}
+
+func (r *Recorder) write(rec Record) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("encode %s record: %w", rec.Kind, err)
+	}
+	line = append(line, '\n')
+	if r.needsRotation(rec.RecordedAt, int64(len(line))) {
+		if err := r.rotate(rec.RecordedAt); err != nil {
+			return err
+		}
+	}
+	n, err := r.w.Write(line)
+	r.written += int64(n)
+	return err
+}
+
+// needsRotation reports whether a new file is due: none is open yet, the UTC
+// day changed, or the line would push the file past MaxFileBytes.
+func (r *Recorder) needsRotation(now time.Time, size int64) bool {
+	if r.file == nil || !startOfDay(now).Equal(r.day) {
+		return true
+	}
+	return r.cfg.MaxFileBytes > 0 && r.written > 0 && r.written+size > r.cfg.MaxFileBytes
+}
+
+func (r *Recorder) rotate(now time.Time) error {
+	r.closeFile()
+	name := filepath.Join(r.cfg.Dir, "events-"+now.Format("20060102T150405.000000000Z")+".jsonl")
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", name, err)
+	}
+	r.file = f
+	r.w = bufio.NewWriter(f)
+	r.written = 0
+	r.day = startOfDay(now)
+	return nil
+}
+
+func (r *Recorder) closeFile() {
+	if r.file == nil {
+		return
+	}
+	if err := r.w.Flush(); err != nil {
+		log.Printf("recorder: flush: %v", err)
+	}
+	if err := r.file.Close(); err != nil {
+		log.Printf("recorder: close: %v", err)
+	}
+	r.file = nil
+	r.w = nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	utc := t.UTC()
+	return time.Date(utc.Year(), utc.Month(), utc.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,106 @@
+package feed
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/ws"
+)
+
+func readRecordings(t *testing.T, dir string) ([]Record, []string) {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var records []Record
+	for _, name := range files {
+		f, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		recs, err := ReadRecords(f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		records = append(records, recs...)
+	}
+	return records, files
+}
+
+func TestRecorderRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	rec, err := NewRecorder(RecorderConfig{Dir: dir})
+	if err != nil {
+		t.Fatalf("new recorder: %v", err)
+	}
+	book := ws.OrderbookEvent{
+		AssetID:   "token-1",
+		Market:    "market-1",
+		Bids:      []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:      []ws.OrderbookLevel{{Price: "0.52", Size: "80"}},
+		Timestamp: "1700000000000",
+	}
+	rec.RecordBook(book)
+	rec.RecordOrder(ws.OrderEvent{ID: "order-1", AssetID: "token-1", Side: "BUY", Price: "0.50"})
+	rec.RecordTrade(ws.TradeEvent{ID: "trade-1", AssetID: "token-1", Side: "BUY", Price: "0.50", Size: "2"})
+	if err := rec.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	rec.RecordBook(book) // after Close: dropped, not a panic
+
+	records, _ := readRecordings(t, dir)
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(records))
+	}
+	if records[0].Kind != RecordBook || records[0].Book == nil || records[0].Book.Asks[0].Size != "80" {
+		t.Fatalf("unexpected book record: %+v", records[0])
+	}
+	if records[1].Kind != RecordOrder || records[1].Order.ID != "order-1" {
+		t.Fatalf("unexpected order record: %+v", records[1])
+	}
+	if records[2].Kind != RecordTrade || records[2].Trade.Size != "2" {
+		t.Fatalf("unexpected trade record: %+v", records[2])
+	}
+	if rec.Dropped() != 1 {
+		t.Fatalf("expected 1 dropped record after close, got %d", rec.Dropped())
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	f, err := os.Open(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	events, err := ReadEvents(f)
+	if err != nil {
+		t.Fatalf("read events: %v", err)
+	}
+	if len(events) != 1 || events[0].AssetID != "token-1" || events[0].Timestamp != "1700000000000" {
+		t.Fatalf("expected the book event back for replay, got %+v", events)
+	}
+}
+
+func TestRecorderRotatesBySize(t *testing.T) {
+	dir := t.TempDir()
+	rec, err := NewRecorder(RecorderConfig{Dir: dir, MaxFileBytes: 200})
+	if err != nil {
+		t.Fatalf("new recorder: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		rec.RecordTrade(ws.TradeEvent{ID: "trade", AssetID: "token-1", Side: "SELL", Price: "0.40", Size: "1"})
+	}
+	if err := rec.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	records, files := readRecordings(t, dir)
+	if len(records) != 5 {
+		t.Fatalf("expected all 5 records across files, got %d", len(records))
+	}
+	if len(files) < 2 {
+		t.Fatalf("expected size rotation to produce several files, got %v", files)
+	}
+}
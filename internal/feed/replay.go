@@ -0,0 +1,133 @@
+package feed
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/ws"
+)
+
+// maxReplayLine bounds a single recorded event; full books can be large.
+const maxReplayLine = 4 << 20
+
+// ReadRecords decodes a Recorder file. Blank lines are skipped.
+func ReadRecords(r io.Reader) ([]Record, error) {
+	var records []Record
+	err := scanLines(r, func(line []byte) error {
+		var rec Record
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return err
+		}
+		records = append(records, rec)
+		return nil
+	})
+	return records, err
+}
+
+// ReadEvents decodes recorded order book events, one JSON object per line.
+// Lines may be bare OrderbookEvent objects or Recorder records; order and
+// trade records are skipped, and a book record without its own timestamp
+// takes the time it was recorded.
+func ReadEvents(r io.Reader) ([]ws.OrderbookEvent, error) {
+	var events []ws.OrderbookEvent
+	err := scanLines(r, func(line []byte) error {
+		var rec Record
+		if err := json.Unmarshal(line, &rec); err == nil && rec.Kind != "" {
+			if rec.Kind == RecordBook && rec.Book != nil {
+				ev := *rec.Book
+				if strings.TrimSpace(ev.Timestamp) == "" && !rec.RecordedAt.IsZero() {
+					ev.Timestamp = strconv.FormatInt(rec.RecordedAt.UnixMilli(), 10)
+				}
+				events = append(events, ev)
+			}
+			return nil
+		}
+		var ev ws.OrderbookEvent
+		if err := json.Unmarshal(line, &ev); err != nil {
+			return err
+		}
+		events = append(events, ev)
+		return nil
+	})
+	return events, err
+}
+
+func scanLines(r io.Reader, fn func(line []byte) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxReplayLine)
+	n := 0
+	for scanner.Scan() {
+		n++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := fn(line); err != nil {
+			return fmt.Errorf("line %d: %w", n, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// EventTime parses the event's millisecond epoch timestamp.
+func EventTime(ev ws.OrderbookEvent) (time.Time, bool) {
+	ms, err := strconv.ParseInt(strings.TrimSpace(ev.Timestamp), 10, 64)
+	if err != nil || ms <= 0 {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(ms).UTC(), true
+}
+
+// Replayer emits recorded order book events in order, pacing them by the gaps
+// between their timestamps divided by speed.
+type Replayer struct {
+	events []ws.OrderbookEvent
+	speed  float64
+}
+
+// NewReplayer creates a Replayer. A speed <= 0 emits events as fast as the
+// consumer reads them.
+func NewReplayer(events []ws.OrderbookEvent, speed float64) *Replayer {
+	return &Replayer{events: events, speed: speed}
+}
+
+// Len returns the number of events to replay.
+func (r *Replayer) Len() int { return len(r.events) }
+
+// Events starts the replay and returns an unbuffered channel that is closed
+// after the last event or when ctx is cancelled.
+func (r *Replayer) Events(ctx context.Context) <-chan ws.OrderbookEvent {
+	ch := make(chan ws.OrderbookEvent)
+	go func() {
+		defer close(ch)
+		var prev time.Time
+		for _, ev := range r.events {
+			if ts, ok := EventTime(ev); ok {
+				if r.speed > 0 && !prev.IsZero() && ts.After(prev) {
+					wait := time.Duration(float64(ts.Sub(prev)) / r.speed)
+					timer := time.NewTimer(wait)
+					select {
+					case <-ctx.Done():
+						timer.Stop()
+						return
+					case <-timer.C:
+					}
+				}
+				prev = ts
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- ev:
+			}
+		}
+	}()
+	return ch
+}
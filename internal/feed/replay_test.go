@@ -0,0 +1,43 @@
+package feed
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadEventsAndReplayInOrder(t *testing.T) {
+	input := `{"asset_id":"token-1","timestamp":"1700000000000"}
+
+{"asset_id":"token-2","timestamp":"1700000000500"}
+{"asset_id":"token-1","timestamp":"1700000001000"}
+`
+	events, err := ReadEvents(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("read events: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(events))
+	}
+
+	ts, ok := EventTime(events[2])
+	if !ok || !ts.Equal(time.UnixMilli(1700000001000)) {
+		t.Fatalf("unexpected event time %v (ok=%v)", ts, ok)
+	}
+
+	var got []string
+	for ev := range NewReplayer(events, 0).Events(context.Background()) {
+		got = append(got, ev.AssetID)
+	}
+	if strings.Join(got, ",") != "token-1,token-2,token-1" {
+		t.Fatalf("expected events in recorded order, got %v", got)
+	}
+}
+
+func TestReadEventsRejectsMalformedLine(t *testing.T) {
+	_, err := ReadEvents(strings.NewReader("{\"asset_id\":\"token-1\"}\nnot json\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("expected error naming line 2, got %v", err)
+	}
+}
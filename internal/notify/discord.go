@@ -0,0 +1,227 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Discord embed colors.
+const (
+	discordColorInfo    = 0x3498DB
+	discordColorGood    = 0x2ECC71
+	discordColorWarning = 0xF1C40F
+	discordColorDanger  = 0xE74C3C
+)
+
+// discordContentLimit is Discord's maximum message content length.
+const discordContentLimit = 2000
+
+// DiscordNotifier sends alerts to a Discord channel via an incoming webhook.
+type DiscordNotifier struct {
+	webhookURL string
+	httpClient *http.Client
+	enabled    bool
+}
+
+// NewDiscordNotifier creates a DiscordNotifier. Notifications are enabled
+// only when webhookURL is non-empty.
+func NewDiscordNotifier(webhookURL string) *DiscordNotifier {
+	webhookURL = strings.TrimSpace(webhookURL)
+	return &DiscordNotifier{
+		webhookURL: webhookURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		enabled:    webhookURL != "",
+	}
+}
+
+// Enabled reports whether the notifier is active.
+func (n *DiscordNotifier) Enabled() bool { return n.enabled }
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+type discordEmbed struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	Color       int            `json:"color"`
+	Fields      []discordField `json:"fields,omitempty"`
+	Timestamp   string         `json:"timestamp,omitempty"`
+}
+
+type discordPayload struct {
+	Content string         `json:"content,omitempty"`
+	Embeds  []discordEmbed `json:"embeds,omitempty"`
+}
+
+// Send posts a plain markdown message to the webhook.
+func (n *DiscordNotifier) Send(ctx context.Context, msg string) error {
+	return n.post(ctx, discordPayload{Content: truncate(msg, discordContentLimit)})
+}
+
+func (n *DiscordNotifier) sendEmbed(ctx context.Context, embed discordEmbed) error {
+	embed.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	return n.post(ctx, discordPayload{Embeds: []discordEmbed{embed}})
+}
+
+func (n *DiscordNotifier) post(ctx context.Context, payload discordPayload) error {
+	if !n.enabled {
+		return nil
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("notify: encode discord payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("notify: build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("notify: send: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("notify: discord %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// NotifyFill sends a trade fill alert.
+func (n *DiscordNotifier) NotifyFill(ctx context.Context, assetID, side string, price, size float64) error {
+	color := discordColorGood
+	if strings.EqualFold(side, "SELL") {
+		color = discordColorInfo
+	}
+	return n.sendEmbed(ctx, discordEmbed{
+		Title: "Fill",
+		Color: color,
+		Fields: []discordField{
+			{Name: "Asset", Value: "`" + assetID + "`"},
+			{Name: "Side", Value: side, Inline: true},
+			{Name: "Price", Value: fmt.Sprintf("%.4f", price), Inline: true},
+			{Name: "Size", Value: fmt.Sprintf("%.2f", size), Inline: true},
+		},
+	})
+}
+
+// NotifyStopLoss sends a stop-loss trigger alert.
+func (n *DiscordNotifier) NotifyStopLoss(ctx context.Context, assetID string, pnl float64) error {
+	return n.sendEmbed(ctx, discordEmbed{
+		Title: "Stop-Loss Triggered",
+		Color: discordColorDanger,
+		Fields: []discordField{
+			{Name: "Asset", Value: "`" + assetID + "`"},
+			{Name: "PnL", Value: fmt.Sprintf("%.2f USDC", pnl), Inline: true},
+		},
+	})
+}
+
+// NotifyEmergencyStop sends an emergency stop alert.
+func (n *DiscordNotifier) NotifyEmergencyStop(ctx context.Context) error {
+	return n.sendEmbed(ctx, discordEmbed{
+		Title:       "EMERGENCY STOP",
+		Description: "Max drawdown exceeded. All trading halted.",
+		Color:       discordColorDanger,
+	})
+}
+
+// NotifyDailySummary sends a daily performance summary.
+func (n *DiscordNotifier) NotifyDailySummary(ctx context.Context, pnl float64, fills int, volume float64) error {
+	color := discordColorGood
+	if pnl < 0 {
+		color = discordColorDanger
+	}
+	return n.sendEmbed(ctx, discordEmbed{
+		Title: "Daily Summary",
+		Color: color,
+		Fields: []discordField{
+			{Name: "PnL", Value: fmt.Sprintf("%.2f USDC", pnl), Inline: true},
+			{Name: "Fills", Value: fmt.Sprintf("%d", fills), Inline: true},
+			{Name: "Volume", Value: fmt.Sprintf("%.2f USDC", volume), Inline: true},
+		},
+	})
+}
+
+// NotifyRiskCooldown sends a risk cooldown alert after a loss streak.
+func (n *DiscordNotifier) NotifyRiskCooldown(ctx context.Context, consecutiveLosses, maxConsecutiveLosses int, cooldownRemaining time.Duration) error {
+	return n.sendEmbed(ctx, discordEmbed{
+		Title: "Risk Cooldown",
+		Color: discordColorWarning,
+		Fields: []discordField{
+			{Name: "Consecutive Losses", Value: fmt.Sprintf("%d/%d", consecutiveLosses, maxConsecutiveLosses), Inline: true},
+			{Name: "Cooldown Remaining", Value: fmt.Sprintf("%.0fs", cooldownRemaining.Seconds()), Inline: true},
+		},
+	})
+}
+
+// NotifyRiskStateChange sends a risk guardrail transition alert.
+func (n *DiscordNotifier) NotifyRiskStateChange(ctx context.Context, change RiskStateChange) error {
+	color := discordColorWarning
+	if change.NewCanTrade {
+		color = discordColorGood
+	}
+	return n.sendEmbed(ctx, discordEmbed{
+		Title: "Risk State Change",
+		Color: color,
+		Fields: []discordField{
+			{Name: "Transitions", Value: change.summary()},
+			{Name: "Can Trade", Value: fmt.Sprintf("%t → %t", change.OldCanTrade, change.NewCanTrade), Inline: true},
+			{Name: "Blocked", Value: reasons(change.BlockedReasons), Inline: true},
+		},
+	})
+}
+
+// NotifyAlert sends a free-form operational alert.
+func (n *DiscordNotifier) NotifyAlert(ctx context.Context, title, text string) error {
+	return n.sendEmbed(ctx, discordEmbed{
+		Title:       title,
+		Description: text,
+		Color:       discordColorWarning,
+	})
+}
+
+// NotifyDailyCoachTemplate sends a pre-rendered daily coaching template.
+func (n *DiscordNotifier) NotifyDailyCoachTemplate(ctx context.Context, textHTML string) error {
+	return n.Send(ctx, htmlToMarkdown(textHTML))
+}
+
+// NotifyWeeklyReviewTemplate sends a pre-rendered weekly review template.
+func (n *DiscordNotifier) NotifyWeeklyReviewTemplate(ctx context.Context, textHTML string) error {
+	return n.Send(ctx, htmlToMarkdown(textHTML))
+}
+
+var htmlToMarkdownReplacer = strings.NewReplacer(
+	"<b>", "**", "</b>", "**",
+	"<strong>", "**", "</strong>", "**",
+	"<i>", "*", "</i>", "*",
+	"<em>", "*", "</em>", "*",
+	"<code>", "`", "</code>", "`",
+	"<pre>", "```\n", "</pre>", "\n```",
+)
+
+// htmlToMarkdown converts the Telegram HTML subset used by the templates to
+// Discord markdown.
+func htmlToMarkdown(s string) string {
+	return html.UnescapeString(htmlToMarkdownReplacer.Replace(s))
+}
+
+func truncate(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	return strings.ToValidUTF8(s[:limit-3], "") + "..."
+}
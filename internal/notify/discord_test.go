@@ -0,0 +1,105 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func discordTestServer(t *testing.T, status int, got *discordPayload) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("expected application/json, got %q", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestDiscordNotifierDisabled(t *testing.T) {
+	n := NewDiscordNotifier("  ")
+	if n.Enabled() {
+		t.Fatal("expected disabled notifier without webhook URL")
+	}
+	if err := n.NotifyEmergencyStop(context.Background()); err != nil {
+		t.Fatalf("disabled notify should succeed: %v", err)
+	}
+}
+
+func TestDiscordNotifyFillEmbed(t *testing.T) {
+	var got discordPayload
+	srv := discordTestServer(t, http.StatusNoContent, &got)
+	n := NewDiscordNotifier(srv.URL)
+
+	if err := n.NotifyFill(context.Background(), "asset-1", "BUY", 0.5, 10); err != nil {
+		t.Fatalf("notify fill: %v", err)
+	}
+	if len(got.Embeds) != 1 {
+		t.Fatalf("expected one embed, got %+v", got)
+	}
+	embed := got.Embeds[0]
+	if embed.Title != "Fill" || embed.Color != discordColorGood || embed.Timestamp == "" {
+		t.Fatalf("unexpected embed header: %+v", embed)
+	}
+	if len(embed.Fields) != 4 || embed.Fields[0].Value != "`asset-1`" || embed.Fields[2].Value != "0.5000" {
+		t.Fatalf("unexpected embed fields: %+v", embed.Fields)
+	}
+}
+
+func TestDiscordNotifyRiskCooldownEmbed(t *testing.T) {
+	var got discordPayload
+	srv := discordTestServer(t, http.StatusNoContent, &got)
+	n := NewDiscordNotifier(srv.URL)
+
+	if err := n.NotifyRiskCooldown(context.Background(), 3, 3, 2*time.Minute); err != nil {
+		t.Fatalf("notify cooldown: %v", err)
+	}
+	embed := got.Embeds[0]
+	if embed.Title != "Risk Cooldown" || embed.Color != discordColorWarning {
+		t.Fatalf("unexpected embed: %+v", embed)
+	}
+	if embed.Fields[0].Value != "3/3" || embed.Fields[1].Value != "120s" {
+		t.Fatalf("unexpected cooldown fields: %+v", embed.Fields)
+	}
+}
+
+func TestDiscordTemplateConvertsHTML(t *testing.T) {
+	var got discordPayload
+	srv := discordTestServer(t, http.StatusOK, &got)
+	n := NewDiscordNotifier(srv.URL)
+
+	msg := "<b>Daily Trading Coach</b>\nAsset: <code>a&amp;b</code>"
+	if err := n.NotifyDailyCoachTemplate(context.Background(), msg); err != nil {
+		t.Fatalf("notify daily coach: %v", err)
+	}
+	if got.Content != "**Daily Trading Coach**\nAsset: `a&b`" {
+		t.Fatalf("unexpected content: %q", got.Content)
+	}
+	if len(got.Embeds) != 0 {
+		t.Fatalf("expected plain content message, got embeds %+v", got.Embeds)
+	}
+}
+
+func TestDiscordSendErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "invalid webhook", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	err := NewDiscordNotifier(srv.URL).Send(context.Background(), "hi")
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("expected 404 error, got %v", err)
+	}
+}
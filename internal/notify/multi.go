@@ -0,0 +1,97 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Channel is the alert surface shared by every notifier in this package.
+type Channel interface {
+	NotifyFill(ctx context.Context, assetID, side string, price, size float64) error
+	NotifyStopLoss(ctx context.Context, assetID string, pnl float64) error
+	NotifyEmergencyStop(ctx context.Context) error
+	NotifyDailySummary(ctx context.Context, pnl float64, fills int, volume float64) error
+	NotifyRiskCooldown(ctx context.Context, consecutiveLosses, maxConsecutiveLosses int, cooldownRemaining time.Duration) error
+	NotifyRiskStateChange(ctx context.Context, change RiskStateChange) error
+	NotifyAlert(ctx context.Context, title, text string) error
+	NotifyDailyCoachTemplate(ctx context.Context, textHTML string) error
+	NotifyWeeklyReviewTemplate(ctx context.Context, textHTML string) error
+}
+
+// MultiNotifier forwards each alert to every wrapped channel. A failing
+// channel does not stop delivery to the rest; all errors are joined.
+type MultiNotifier struct {
+	channels []Channel
+}
+
+// NewMultiNotifier creates a MultiNotifier over the given channels, skipping nils.
+func NewMultiNotifier(channels ...Channel) *MultiNotifier {
+	m := &MultiNotifier{}
+	for _, c := range channels {
+		if c != nil {
+			m.channels = append(m.channels, c)
+		}
+	}
+	return m
+}
+
+// Len returns the number of wrapped channels.
+func (m *MultiNotifier) Len() int { return len(m.channels) }
+
+func (m *MultiNotifier) each(fn func(Channel) error) error {
+	var errs []error
+	for _, c := range m.channels {
+		if err := fn(c); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// NotifyFill sends a trade fill alert to every channel.
+func (m *MultiNotifier) NotifyFill(ctx context.Context, assetID, side string, price, size float64) error {
+	return m.each(func(c Channel) error { return c.NotifyFill(ctx, assetID, side, price, size) })
+}
+
+// NotifyStopLoss sends a stop-loss trigger alert to every channel.
+func (m *MultiNotifier) NotifyStopLoss(ctx context.Context, assetID string, pnl float64) error {
+	return m.each(func(c Channel) error { return c.NotifyStopLoss(ctx, assetID, pnl) })
+}
+
+// NotifyEmergencyStop sends an emergency stop alert to every channel.
+func (m *MultiNotifier) NotifyEmergencyStop(ctx context.Context) error {
+	return m.each(func(c Channel) error { return c.NotifyEmergencyStop(ctx) })
+}
+
+// NotifyDailySummary sends a daily performance summary to every channel.
+func (m *MultiNotifier) NotifyDailySummary(ctx context.Context, pnl float64, fills int, volume float64) error {
+	return m.each(func(c Channel) error { return c.NotifyDailySummary(ctx, pnl, fills, volume) })
+}
+
+// NotifyRiskCooldown sends a risk cooldown alert to every channel.
+func (m *MultiNotifier) NotifyRiskCooldown(ctx context.Context, consecutiveLosses, maxConsecutiveLosses int, cooldownRemaining time.Duration) error {
+	return m.each(func(c Channel) error {
+		return c.NotifyRiskCooldown(ctx, consecutiveLosses, maxConsecutiveLosses, cooldownRemaining)
+	})
+}
+
+// NotifyRiskStateChange sends a risk guardrail transition to every channel.
+func (m *MultiNotifier) NotifyRiskStateChange(ctx context.Context, change RiskStateChange) error {
+	return m.each(func(c Channel) error { return c.NotifyRiskStateChange(ctx, change) })
+}
+
+// NotifyAlert sends a free-form operational alert to every channel.
+func (m *MultiNotifier) NotifyAlert(ctx context.Context, title, text string) error {
+	return m.each(func(c Channel) error { return c.NotifyAlert(ctx, title, text) })
+}
+
+// NotifyDailyCoachTemplate sends the daily coaching template to every channel.
+func (m *MultiNotifier) NotifyDailyCoachTemplate(ctx context.Context, textHTML string) error {
+	return m.each(func(c Channel) error { return c.NotifyDailyCoachTemplate(ctx, textHTML) })
+}
+
+// NotifyWeeklyReviewTemplate sends the weekly review template to every channel.
+func (m *MultiNotifier) NotifyWeeklyReviewTemplate(ctx context.Context, textHTML string) error {
+	return m.each(func(c Channel) error { return c.NotifyWeeklyReviewTemplate(ctx, textHTML) })
+}
@@ -0,0 +1,105 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type recordingChannel struct {
+	events []string
+	err    error
+}
+
+func (r *recordingChannel) record(event string) error {
+	r.events = append(r.events, event)
+	return r.err
+}
+
+func (r *recordingChannel) NotifyFill(context.Context, string, string, float64, float64) error {
+	return r.record("fill")
+}
+
+func (r *recordingChannel) NotifyStopLoss(context.Context, string, float64) error {
+	return r.record("stop_loss")
+}
+
+func (r *recordingChannel) NotifyEmergencyStop(context.Context) error {
+	return r.record("emergency_stop")
+}
+
+func (r *recordingChannel) NotifyDailySummary(context.Context, float64, int, float64) error {
+	return r.record("daily_summary")
+}
+
+func (r *recordingChannel) NotifyRiskCooldown(context.Context, int, int, time.Duration) error {
+	return r.record("risk_cooldown")
+}
+
+func (r *recordingChannel) NotifyRiskStateChange(context.Context, RiskStateChange) error {
+	return r.record("risk_state_change")
+}
+
+func (r *recordingChannel) NotifyAlert(context.Context, string, string) error {
+	return r.record("alert")
+}
+
+func (r *recordingChannel) NotifyDailyCoachTemplate(context.Context, string) error {
+	return r.record("daily_coach")
+}
+
+func (r *recordingChannel) NotifyWeeklyReviewTemplate(context.Context, string) error {
+	return r.record("weekly_review")
+}
+
+func TestMultiNotifierDeliversToAllChannels(t *testing.T) {
+	a, b := &recordingChannel{}, &recordingChannel{}
+	m := NewMultiNotifier(a, nil, b)
+	if m.Len() != 2 {
+		t.Fatalf("expected nil channel to be skipped, got %d channels", m.Len())
+	}
+
+	ctx := context.Background()
+	_ = m.NotifyFill(ctx, "asset", "BUY", 0.5, 10)
+	_ = m.NotifyStopLoss(ctx, "asset", -1)
+	_ = m.NotifyEmergencyStop(ctx)
+	_ = m.NotifyDailySummary(ctx, 1, 2, 3)
+	_ = m.NotifyRiskCooldown(ctx, 3, 3, time.Minute)
+	_ = m.NotifyRiskStateChange(ctx, RiskStateChange{})
+	_ = m.NotifyAlert(ctx, "title", "text")
+	_ = m.NotifyDailyCoachTemplate(ctx, "daily")
+	_ = m.NotifyWeeklyReviewTemplate(ctx, "weekly")
+
+	want := []string{"fill", "stop_loss", "emergency_stop", "daily_summary", "risk_cooldown", "risk_state_change", "alert", "daily_coach", "weekly_review"}
+	for name, ch := range map[string]*recordingChannel{"a": a, "b": b} {
+		if len(ch.events) != len(want) {
+			t.Fatalf("channel %s: expected %v, got %v", name, want, ch.events)
+		}
+		for i := range want {
+			if ch.events[i] != want[i] {
+				t.Fatalf("channel %s: expected %v, got %v", name, want, ch.events)
+			}
+		}
+	}
+}
+
+func TestMultiNotifierFailureDoesNotBlockOthers(t *testing.T) {
+	errA := errors.New("telegram down")
+	errB := errors.New("webhook gone")
+	a := &recordingChannel{err: errA}
+	b := &recordingChannel{}
+	c := &recordingChannel{err: errB}
+	m := NewMultiNotifier(a, b, c)
+
+	err := m.NotifyFill(context.Background(), "asset", "SELL", 0.4, 5)
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Fatalf("expected joined errors, got %v", err)
+	}
+	if len(b.events) != 1 || len(c.events) != 1 {
+		t.Fatalf("expected all channels to receive the fill, got b=%v c=%v", b.events, c.events)
+	}
+	if err := NewMultiNotifier(b).NotifyEmergencyStop(context.Background()); err != nil {
+		t.Fatalf("expected nil error from healthy channel, got %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package notify
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// Risk state transitions reported in RiskStateChange.Transitions.
+const (
+	TransitionBecameBlocked    = "became_blocked"
+	TransitionBecameTradable   = "became_tradable"
+	TransitionCooldownEntered  = "cooldown_entered"
+	TransitionCooldownExited   = "cooldown_exited"
+	TransitionEmergencyStopOn  = "emergency_stop_on"
+	TransitionEmergencyStopOff = "emergency_stop_off"
+)
+
+// RiskState is the part of the risk guardrails that transitions are
+// detected on.
+type RiskState struct {
+	CanTrade       bool
+	BlockedReasons []string
+	InCooldown     bool
+	EmergencyStop  bool
+}
+
+// RiskStateChange is emitted when the risk guardrails move between states.
+type RiskStateChange struct {
+	Transitions       []string
+	OldCanTrade       bool
+	NewCanTrade       bool
+	OldBlockedReasons []string
+	BlockedReasons    []string
+	At                time.Time
+}
+
+// DiffRiskState returns the change from old to cur and whether anything
+// transitioned. A change in the set of blocked reasons alone, without a
+// can-trade, cooldown or emergency flip, is not a transition.
+func DiffRiskState(old, cur RiskState, at time.Time) (RiskStateChange, bool) {
+	var transitions []string
+	if old.CanTrade && !cur.CanTrade {
+		transitions = append(transitions, TransitionBecameBlocked)
+	} else if !old.CanTrade && cur.CanTrade {
+		transitions = append(transitions, TransitionBecameTradable)
+	}
+	if !old.InCooldown && cur.InCooldown {
+		transitions = append(transitions, TransitionCooldownEntered)
+	} else if old.InCooldown && !cur.InCooldown {
+		transitions = append(transitions, TransitionCooldownExited)
+	}
+	if !old.EmergencyStop && cur.EmergencyStop {
+		transitions = append(transitions, TransitionEmergencyStopOn)
+	} else if old.EmergencyStop && !cur.EmergencyStop {
+		transitions = append(transitions, TransitionEmergencyStopOff)
+	}
+	if len(transitions) == 0 {
+		return RiskStateChange{}, false
+	}
+	return RiskStateChange{
+		Transitions:       transitions,
+		OldCanTrade:       old.CanTrade,
+		NewCanTrade:       cur.CanTrade,
+		OldBlockedReasons: slices.Clone(old.BlockedReasons),
+		BlockedReasons:    slices.Clone(cur.BlockedReasons),
+		At:                at,
+	}, true
+}
+
+// summary renders the change as "became_blocked, cooldown_entered".
+func (c RiskStateChange) summary() string {
+	return strings.Join(c.Transitions, ", ")
+}
+
+// reasons renders blocked reasons for display, "none" when empty.
+func reasons(rs []string) string {
+	if len(rs) == 0 {
+		return "none"
+	}
+	return strings.Join(rs, ", ")
+}
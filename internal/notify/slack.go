@@ -0,0 +1,214 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	slackMaxAttempts    = 3
+	slackInitialBackoff = 500 * time.Millisecond
+)
+
+// SlackNotifier sends alerts to a Slack incoming webhook.
+type SlackNotifier struct {
+	webhookURL string
+	channel    string
+	httpClient *http.Client
+	enabled    bool
+	backoff    time.Duration
+}
+
+// NewSlackNotifier creates a SlackNotifier. Notifications are enabled only when
+// webhookURL is non-empty. channel optionally overrides the webhook's default
+// channel.
+func NewSlackNotifier(webhookURL, channel string) *SlackNotifier {
+	webhookURL = strings.TrimSpace(webhookURL)
+	return &SlackNotifier{
+		webhookURL: webhookURL,
+		channel:    strings.TrimSpace(channel),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		enabled:    webhookURL != "",
+		backoff:    slackInitialBackoff,
+	}
+}
+
+// Enabled reports whether the notifier is active.
+func (n *SlackNotifier) Enabled() bool { return n.enabled }
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackBlock struct {
+	Type   string      `json:"type"`
+	Text   *slackText  `json:"text,omitempty"`
+	Fields []slackText `json:"fields,omitempty"`
+}
+
+type slackPayload struct {
+	Channel string       `json:"channel,omitempty"`
+	Text    string       `json:"text"`
+	Blocks  []slackBlock `json:"blocks,omitempty"`
+}
+
+// Send posts a plain mrkdwn message to the webhook.
+func (n *SlackNotifier) Send(ctx context.Context, msg string) error {
+	return n.post(ctx, slackPayload{Text: msg})
+}
+
+// sendBlocks posts a header section followed by a field grid. title doubles as
+// the notification fallback text.
+func (n *SlackNotifier) sendBlocks(ctx context.Context, title string, fields ...string) error {
+	blocks := []slackBlock{{Type: "section", Text: &slackText{Type: "mrkdwn", Text: "*" + title + "*"}}}
+	if len(fields) > 0 {
+		grid := slackBlock{Type: "section"}
+		for _, f := range fields {
+			grid.Fields = append(grid.Fields, slackText{Type: "mrkdwn", Text: f})
+		}
+		blocks = append(blocks, grid)
+	}
+	return n.post(ctx, slackPayload{Text: title, Blocks: blocks})
+}
+
+func (n *SlackNotifier) post(ctx context.Context, payload slackPayload) error {
+	if !n.enabled {
+		return nil
+	}
+	payload.Channel = n.channel
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("notify: encode slack payload: %w", err)
+	}
+
+	backoff := n.backoff
+	var lastErr error
+	for attempt := 1; attempt <= slackMaxAttempts; attempt++ {
+		retry, err := n.postOnce(ctx, body)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retry || attempt == slackMaxAttempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	return lastErr
+}
+
+// postOnce performs a single webhook call and reports whether a failure is
+// worth retrying (rate limiting or a server-side error).
+func (n *SlackNotifier) postOnce(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("notify: build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("notify: send: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return retry, fmt.Errorf("notify: slack %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return false, nil
+}
+
+// NotifyFill sends a trade fill alert.
+func (n *SlackNotifier) NotifyFill(ctx context.Context, assetID, side string, price, size float64) error {
+	return n.sendBlocks(ctx, "Fill: "+side,
+		"*Asset*\n`"+assetID+"`",
+		fmt.Sprintf("*Price*\n%.4f", price),
+		fmt.Sprintf("*Size*\n%.2f", size),
+	)
+}
+
+// NotifyStopLoss sends a stop-loss trigger alert.
+func (n *SlackNotifier) NotifyStopLoss(ctx context.Context, assetID string, pnl float64) error {
+	return n.sendBlocks(ctx, ":warning: Stop-Loss Triggered",
+		"*Asset*\n`"+assetID+"`",
+		fmt.Sprintf("*PnL*\n%.2f USDC", pnl),
+	)
+}
+
+// NotifyEmergencyStop sends an emergency stop alert.
+func (n *SlackNotifier) NotifyEmergencyStop(ctx context.Context) error {
+	return n.sendBlocks(ctx, ":rotating_light: EMERGENCY STOP",
+		"Max drawdown exceeded. All trading halted.",
+	)
+}
+
+// NotifyDailySummary sends a daily performance summary.
+func (n *SlackNotifier) NotifyDailySummary(ctx context.Context, pnl float64, fills int, volume float64) error {
+	return n.sendBlocks(ctx, "Daily Summary",
+		fmt.Sprintf("*PnL*\n%.2f USDC", pnl),
+		fmt.Sprintf("*Fills*\n%d", fills),
+		fmt.Sprintf("*Volume*\n%.2f USDC", volume),
+	)
+}
+
+// NotifyRiskCooldown sends a risk cooldown alert after a loss streak.
+func (n *SlackNotifier) NotifyRiskCooldown(ctx context.Context, consecutiveLosses, maxConsecutiveLosses int, cooldownRemaining time.Duration) error {
+	return n.sendBlocks(ctx, ":hourglass: Risk Cooldown",
+		fmt.Sprintf("*Consecutive Losses*\n%d/%d", consecutiveLosses, maxConsecutiveLosses),
+		fmt.Sprintf("*Cooldown Remaining*\n%.0fs", cooldownRemaining.Seconds()),
+	)
+}
+
+// NotifyRiskStateChange sends a risk guardrail transition alert.
+func (n *SlackNotifier) NotifyRiskStateChange(ctx context.Context, change RiskStateChange) error {
+	return n.sendBlocks(ctx, ":traffic_light: Risk State Change",
+		"*Transitions*\n"+change.summary(),
+		fmt.Sprintf("*Can Trade*\n%t → %t", change.OldCanTrade, change.NewCanTrade),
+		"*Blocked*\n"+reasons(change.BlockedReasons),
+	)
+}
+
+// NotifyAlert sends a free-form operational alert.
+func (n *SlackNotifier) NotifyAlert(ctx context.Context, title, text string) error {
+	return n.sendBlocks(ctx, ":warning: "+title, text)
+}
+
+// NotifyDailyCoachTemplate sends a pre-rendered daily coaching template.
+func (n *SlackNotifier) NotifyDailyCoachTemplate(ctx context.Context, textHTML string) error {
+	return n.Send(ctx, htmlToSlack(textHTML))
+}
+
+// NotifyWeeklyReviewTemplate sends a pre-rendered weekly review template.
+func (n *SlackNotifier) NotifyWeeklyReviewTemplate(ctx context.Context, textHTML string) error {
+	return n.Send(ctx, htmlToSlack(textHTML))
+}
+
+var htmlToSlackReplacer = strings.NewReplacer(
+	"<b>", "*", "</b>", "*",
+	"<strong>", "*", "</strong>", "*",
+	"<i>", "_", "</i>", "_",
+	"<em>", "_", "</em>", "_",
+	"<code>", "`", "</code>", "`",
+	"<pre>", "```\n", "</pre>", "\n```",
+	"&#34;", `"`, "&quot;", `"`, "&#39;", "'",
+)
+
+// htmlToSlack converts the Telegram HTML subset used by the templates to Slack
+// mrkdwn. &amp;, &lt; and &gt; are left alone since Slack expects them escaped.
+func htmlToSlack(s string) string {
+	return htmlToSlackReplacer.Replace(s)
+}
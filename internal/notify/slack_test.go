@@ -0,0 +1,127 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSlackNotifierDisabled(t *testing.T) {
+	n := NewSlackNotifier("", "#alerts")
+	if n.Enabled() {
+		t.Fatal("expected disabled notifier without webhook URL")
+	}
+	if err := n.NotifyFill(context.Background(), "asset", "BUY", 0.5, 1); err != nil {
+		t.Fatalf("disabled notify should succeed: %v", err)
+	}
+}
+
+func TestSlackNotifyFillPayload(t *testing.T) {
+	var got slackPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("expected application/json, got %q", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	n := NewSlackNotifier(srv.URL, "#trading")
+	if err := n.NotifyFill(context.Background(), "asset-1", "SELL", 0.42, 12); err != nil {
+		t.Fatalf("notify fill: %v", err)
+	}
+	if got.Channel != "#trading" || got.Text != "Fill: SELL" {
+		t.Fatalf("unexpected payload header: %+v", got)
+	}
+	if len(got.Blocks) != 2 || got.Blocks[0].Text == nil || got.Blocks[0].Text.Text != "*Fill: SELL*" {
+		t.Fatalf("unexpected blocks: %+v", got.Blocks)
+	}
+	fields := got.Blocks[1].Fields
+	if len(fields) != 3 || fields[0].Text != "*Asset*\n`asset-1`" || fields[1].Text != "*Price*\n0.4200" || fields[0].Type != "mrkdwn" {
+		t.Fatalf("unexpected fields: %+v", fields)
+	}
+}
+
+func TestSlackTemplateConvertsHTML(t *testing.T) {
+	var got slackPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer srv.Close()
+
+	n := NewSlackNotifier(srv.URL, "")
+	if err := n.NotifyWeeklyReviewTemplate(context.Background(), "<b>Weekly</b> <i>a &amp; b</i>"); err != nil {
+		t.Fatalf("notify weekly: %v", err)
+	}
+	if got.Text != "*Weekly* _a &amp; b_" || got.Channel != "" || len(got.Blocks) != 0 {
+		t.Fatalf("unexpected payload: %+v", got)
+	}
+}
+
+func TestSlackRetriesTransientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			http.Error(w, "rate_limited", http.StatusTooManyRequests)
+		case 2:
+			http.Error(w, "oops", http.StatusBadGateway)
+		default:
+			_, _ = w.Write([]byte("ok"))
+		}
+	}))
+	defer srv.Close()
+
+	n := NewSlackNotifier(srv.URL, "")
+	n.backoff = 0
+	if err := n.NotifyEmergencyStop(context.Background()); err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestSlackDoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		http.Error(w, "invalid_payload", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	n := NewSlackNotifier(srv.URL, "")
+	n.backoff = 0
+	err := n.Send(context.Background(), "hi")
+	if err == nil || !strings.Contains(err.Error(), "400") {
+		t.Fatalf("expected 400 error, got %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("expected a single attempt, got %d", calls.Load())
+	}
+}
+
+func TestSlackGivesUpAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		http.Error(w, "down", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	n := NewSlackNotifier(srv.URL, "")
+	n.backoff = 0
+	if err := n.Send(context.Background(), "hi"); err == nil || !strings.Contains(err.Error(), "503") {
+		t.Fatalf("expected 503 error, got %v", err)
+	}
+	if calls.Load() != slackMaxAttempts {
+		t.Fatalf("expected %d attempts, got %d", slackMaxAttempts, calls.Load())
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"html"
 	"net/http"
 	"net/url"
 	"time"
@@ -104,6 +105,23 @@ func (n *Notifier) NotifyRiskCooldown(ctx context.Context, consecutiveLosses, ma
 	return n.Send(ctx, msg)
 }
 
+// NotifyRiskStateChange sends a risk guardrail transition alert.
+func (n *Notifier) NotifyRiskStateChange(ctx context.Context, change RiskStateChange) error {
+	msg := fmt.Sprintf(
+		"<b>Risk State Change</b>\nTransitions: %s\nCan Trade: %t → %t\nBlocked: %s",
+		change.summary(),
+		change.OldCanTrade,
+		change.NewCanTrade,
+		reasons(change.BlockedReasons),
+	)
+	return n.Send(ctx, msg)
+}
+
+// NotifyAlert sends a free-form operational alert.
+func (n *Notifier) NotifyAlert(ctx context.Context, title, text string) error {
+	return n.Send(ctx, "<b>"+html.EscapeString(title)+"</b>\n"+html.EscapeString(text))
+}
+
 // NotifyDailyCoachTemplate sends a pre-rendered daily coaching template.
 func (n *Notifier) NotifyDailyCoachTemplate(ctx context.Context, textHTML string) error {
 	return n.Send(ctx, textHTML)
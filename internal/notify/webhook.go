@@ -0,0 +1,130 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WebhookNotifier posts every alert as a JSON object to a generic HTTP
+// endpoint. Each body carries an "event" name plus the alert's fields, so
+// receivers can route on it without parsing chat formatting.
+type WebhookNotifier struct {
+	url        string
+	httpClient *http.Client
+	enabled    bool
+}
+
+// NewWebhookNotifier creates a WebhookNotifier. Notifications are enabled
+// only when url is non-empty.
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	url = strings.TrimSpace(url)
+	return &WebhookNotifier{
+		url:        url,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		enabled:    url != "",
+	}
+}
+
+// Enabled reports whether the notifier is active.
+func (n *WebhookNotifier) Enabled() bool { return n.enabled }
+
+func (n *WebhookNotifier) post(ctx context.Context, event string, fields map[string]interface{}) error {
+	if !n.enabled {
+		return nil
+	}
+	payload := map[string]interface{}{"event": event}
+	for k, v := range fields {
+		payload[k] = v
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("notify: encode webhook payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("notify: build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("notify: send: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("notify: webhook %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// NotifyFill sends a trade fill alert.
+func (n *WebhookNotifier) NotifyFill(ctx context.Context, assetID, side string, price, size float64) error {
+	return n.post(ctx, "fill", map[string]interface{}{"asset_id": assetID, "side": side, "price": price, "size": size})
+}
+
+// NotifyStopLoss sends a stop-loss trigger alert.
+func (n *WebhookNotifier) NotifyStopLoss(ctx context.Context, assetID string, pnl float64) error {
+	return n.post(ctx, "stop_loss", map[string]interface{}{"asset_id": assetID, "pnl": pnl})
+}
+
+// NotifyEmergencyStop sends an emergency stop alert.
+func (n *WebhookNotifier) NotifyEmergencyStop(ctx context.Context) error {
+	return n.post(ctx, "emergency_stop", nil)
+}
+
+// NotifyDailySummary sends a daily performance summary.
+func (n *WebhookNotifier) NotifyDailySummary(ctx context.Context, pnl float64, fills int, volume float64) error {
+	return n.post(ctx, "daily_summary", map[string]interface{}{"pnl": pnl, "fills": fills, "volume": volume})
+}
+
+// NotifyRiskCooldown sends a risk cooldown alert after a loss streak.
+func (n *WebhookNotifier) NotifyRiskCooldown(ctx context.Context, consecutiveLosses, maxConsecutiveLosses int, cooldownRemaining time.Duration) error {
+	return n.post(ctx, "risk_cooldown", map[string]interface{}{
+		"consecutive_losses":     consecutiveLosses,
+		"max_consecutive_losses": maxConsecutiveLosses,
+		"cooldown_remaining_sec": cooldownRemaining.Seconds(),
+	})
+}
+
+// NotifyRiskStateChange sends a risk guardrail transition.
+func (n *WebhookNotifier) NotifyRiskStateChange(ctx context.Context, change RiskStateChange) error {
+	return n.post(ctx, "risk_state_change", map[string]interface{}{
+		"transitions":         change.Transitions,
+		"old_can_trade":       change.OldCanTrade,
+		"new_can_trade":       change.NewCanTrade,
+		"old_blocked_reasons": nonNil(change.OldBlockedReasons),
+		"blocked_reasons":     nonNil(change.BlockedReasons),
+		"at":                  change.At.UTC().Format(time.RFC3339),
+	})
+}
+
+// NotifyAlert sends a free-form operational alert.
+func (n *WebhookNotifier) NotifyAlert(ctx context.Context, title, text string) error {
+	return n.post(ctx, "alert", map[string]interface{}{"title": title, "text": text})
+}
+
+// NotifyDailyCoachTemplate sends a pre-rendered daily coaching template.
+func (n *WebhookNotifier) NotifyDailyCoachTemplate(ctx context.Context, textHTML string) error {
+	return n.post(ctx, "daily_coach", map[string]interface{}{"text_html": textHTML})
+}
+
+// NotifyWeeklyReviewTemplate sends a pre-rendered weekly review template.
+func (n *WebhookNotifier) NotifyWeeklyReviewTemplate(ctx context.Context, textHTML string) error {
+	return n.post(ctx, "weekly_review", map[string]interface{}{"text_html": textHTML})
+}
+
+// nonNil keeps empty lists as [] rather than null in JSON.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,65 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWebhookNotifierDisabled(t *testing.T) {
+	n := NewWebhookNotifier("  ")
+	if n.Enabled() {
+		t.Fatal("expected disabled notifier without URL")
+	}
+	if err := n.NotifyEmergencyStop(context.Background()); err != nil {
+		t.Fatalf("disabled notify should succeed: %v", err)
+	}
+}
+
+func TestWebhookRiskStateChangePayload(t *testing.T) {
+	var got map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	at := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	change, ok := DiffRiskState(
+		RiskState{CanTrade: true},
+		RiskState{BlockedReasons: []string{"loss_cooldown_active"}, InCooldown: true},
+		at,
+	)
+	if !ok {
+		t.Fatal("expected a transition")
+	}
+	if err := NewWebhookNotifier(srv.URL).NotifyRiskStateChange(context.Background(), change); err != nil {
+		t.Fatalf("notify: %v", err)
+	}
+
+	if got["event"] != "risk_state_change" || got["old_can_trade"] != true || got["new_can_trade"] != false {
+		t.Fatalf("unexpected payload: %v", got)
+	}
+	transitions, _ := got["transitions"].([]interface{})
+	if len(transitions) != 2 || transitions[0] != TransitionBecameBlocked || transitions[1] != TransitionCooldownEntered {
+		t.Fatalf("unexpected transitions: %v", got["transitions"])
+	}
+	if old, _ := got["old_blocked_reasons"].([]interface{}); old == nil || len(old) != 0 {
+		t.Fatalf("expected empty old_blocked_reasons list, got %v", got["old_blocked_reasons"])
+	}
+	if got["at"] != "2026-01-01T12:00:00Z" {
+		t.Fatalf("unexpected timestamp: %v", got["at"])
+	}
+}
+
+func TestDiffRiskStateIgnoresReasonChurn(t *testing.T) {
+	old := RiskState{BlockedReasons: []string{"emergency_stop"}, EmergencyStop: true}
+	cur := RiskState{BlockedReasons: []string{"emergency_stop", "daily_loss_limit_reached"}, EmergencyStop: true}
+	if change, ok := DiffRiskState(old, cur, time.Now()); ok {
+		t.Fatalf("expected no transition while still blocked, got %+v", change)
+	}
+}
@@ -0,0 +1,57 @@
+package paper
+
+import "time"
+
+// midSample is a mid price observed at a point in time.
+type midSample struct {
+	at  time.Time
+	mid float64
+}
+
+// observeLocked records the book's mid for assetID and drops samples older
+// than the latency window. It is a no-op with latency disabled.
+func (s *Simulator) observeLocked(assetID string, bestBid, bestAsk float64) {
+	if s.latency <= 0 {
+		return
+	}
+	now := s.now()
+	samples := append(s.mids[assetID], midSample{at: now, mid: (bestBid + bestAsk) / 2})
+	cutoff := now.Add(-s.latency)
+	drop := 0
+	for drop < len(samples)-1 && samples[drop].at.Before(cutoff) {
+		drop++
+	}
+	s.mids[assetID] = samples[drop:]
+}
+
+// adverseMoveLocked is how far the price is assumed to move against an order
+// while it is in flight: the range the mid has covered over the last latency
+// window. Without a history it is 0.
+func (s *Simulator) adverseMoveLocked(assetID string) float64 {
+	samples := s.mids[assetID]
+	if len(samples) == 0 {
+		return 0
+	}
+	lo, hi := samples[0].mid, samples[0].mid
+	for _, smp := range samples[1:] {
+		lo = min(lo, smp.mid)
+		hi = max(hi, smp.mid)
+	}
+	return hi - lo
+}
+
+// delayedPrice observes the book and shifts price against side by the
+// adverse move, keeping it inside (0, 1).
+func (s *Simulator) delayedPrice(assetID, side string, price, bestBid, bestAsk float64) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.observeLocked(assetID, bestBid, bestAsk)
+	move := s.adverseMoveLocked(assetID)
+	if move <= 0 {
+		return price
+	}
+	if side == "BUY" {
+		return min(price+move, 0.999)
+	}
+	return max(price-move, 0.001)
+}
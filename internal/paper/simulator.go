@@ -2,6 +2,7 @@ package paper
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"sync"
@@ -15,8 +16,28 @@ type Config struct {
 	FeeBps             float64 `yaml:"fee_bps"`
 	SlippageBps        float64 `yaml:"slippage_bps"`
 	AllowShort         *bool   `yaml:"allow_short"`
+
+	// SlippageModel picks how market orders are priced: "flat" (the default)
+	// moves the touch by SlippageBps, "depth" walks the book's levels and
+	// fills at their volume-weighted average, so larger orders pay more.
+	// MaxSlippageBps caps the depth model's slippage from the touch; 0
+	// leaves it uncapped.
+	SlippageModel  string  `yaml:"slippage_model"`
+	MaxSlippageBps float64 `yaml:"max_slippage_bps"`
+
+	// LatencyMs simulates the network delay between deciding and reaching
+	// the exchange: marketable orders fill at a price moved against them by
+	// the mid's range over that window, and new resting orders only start
+	// matching once it has passed. 0 fills instantly.
+	LatencyMs int `yaml:"latency_ms"`
 }
 
+// Slippage models for Config.SlippageModel.
+const (
+	SlippageFlat  = "flat"
+	SlippageDepth = "depth"
+)
+
 type FillResult struct {
 	OrderID    string
 	TradeID    string
@@ -39,12 +60,25 @@ type Snapshot struct {
 	TotalTrades        int                `json:"total_trades"`
 	AllowShort         bool               `json:"allow_short"`
 	InventoryByAsset   map[string]float64 `json:"inventory_by_asset"`
+	RestingOrders      int                `json:"resting_orders"`
+}
+
+// restingOrder is an unfilled limit order waiting for the market to reach it.
+type restingOrder struct {
+	orderID    string
+	assetID    string
+	side       string
+	price      float64
+	amountUSDC float64
+	queueAhead float64   // visible size at our price that must trade before us
+	activeAt   time.Time // reaches the book after the simulated latency
 }
 
 type Simulator struct {
 	mu sync.Mutex
 
 	cfg Config
+	now func() time.Time
 
 	sequence        int64
 	balanceUSDC     float64
@@ -53,6 +87,11 @@ type Simulator struct {
 	totalTrades     int
 	allowShort      bool
 	inventory       map[string]float64 // assetID -> token units (can go negative if shorting)
+	entryPrice      map[string]float64 // assetID -> average price paid for the inventory
+	resting         []restingOrder     // in placement order
+
+	latency time.Duration
+	mids    map[string][]midSample // assetID -> mids within the latency window
 }
 
 func NewSimulator(cfg Config) *Simulator {
@@ -70,13 +109,28 @@ func NewSimulator(cfg Config) *Simulator {
 			FeeBps:             cfg.FeeBps,
 			SlippageBps:        cfg.SlippageBps,
 			AllowShort:         cfg.AllowShort,
+			LatencyMs:          cfg.LatencyMs,
+			SlippageModel:      strings.ToLower(strings.TrimSpace(cfg.SlippageModel)),
+			MaxSlippageBps:     cfg.MaxSlippageBps,
 		},
+		now:         time.Now,
 		balanceUSDC: initial,
 		allowShort:  allowShort,
 		inventory:   make(map[string]float64),
+		entryPrice:  make(map[string]float64),
+		latency:     time.Duration(cfg.LatencyMs) * time.Millisecond,
+		mids:        make(map[string][]midSample),
 	}
 }
 
+// SetClock replaces the time source used to stamp fills, so replayed books
+// produce fills dated at their recorded time.
+func (s *Simulator) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
 func (s *Simulator) Snapshot() Snapshot {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -92,6 +146,7 @@ func (s *Simulator) Snapshot() Snapshot {
 		TotalTrades:        s.totalTrades,
 		AllowShort:         s.allowShort,
 		InventoryByAsset:   inventory,
+		RestingOrders:      len(s.resting),
 	}
 }
 
@@ -110,8 +165,54 @@ func (s *Simulator) ExecuteMarket(assetID, side string, amountUSDC float64, book
 	default:
 		return FillResult{}, fmt.Errorf("unsupported side: %s", side)
 	}
-	price = applySlippage(price, side, s.cfg.SlippageBps)
-	return s.fill(assetID, side, amountUSDC, price, true)
+	touch := price
+	price = s.delayedPrice(assetID, side, price, bestBid, bestAsk)
+	slippage := s.cfg.SlippageBps
+	if s.cfg.SlippageModel == SlippageDepth {
+		slippage = s.depthSlippageBps(side, touch, amountUSDC, book)
+	}
+	price = applySlippage(price, side, slippage)
+	return s.fill("", assetID, side, amountUSDC, price, true)
+}
+
+// depthSlippageBps returns how far, in bps, the average price of walking the
+// book for amountUSDC lies from the touch. Levels are taken best first; size
+// beyond the visible depth is priced at the last level. The result is capped
+// at MaxSlippageBps when that is set.
+func (s *Simulator) depthSlippageBps(side string, touch, amountUSDC float64, book ws.OrderbookEvent) float64 {
+	levels := book.Asks
+	if side == "SELL" {
+		levels = book.Bids
+	}
+	remaining, tokens, last := amountUSDC, 0.0, touch
+	for _, lvl := range levels {
+		if remaining <= 0 {
+			break
+		}
+		price, err := strconv.ParseFloat(lvl.Price, 64)
+		if err != nil || price <= 0 {
+			continue
+		}
+		size, err := strconv.ParseFloat(lvl.Size, 64)
+		if err != nil || size <= 0 {
+			continue
+		}
+		notional := min(remaining, price*size)
+		tokens += notional / price
+		remaining -= notional
+		last = price
+	}
+	if remaining > 0 {
+		tokens += remaining / last
+	}
+	if tokens <= 0 || touch <= 0 {
+		return 0
+	}
+	bps := math.Abs(amountUSDC/tokens-touch) / touch * 10000
+	if s.cfg.MaxSlippageBps > 0 {
+		bps = min(bps, s.cfg.MaxSlippageBps)
+	}
+	return bps
 }
 
 func (s *Simulator) ExecuteLimit(assetID, side string, limitPrice, amountUSDC float64, book ws.OrderbookEvent) (FillResult, error) {
@@ -138,18 +239,172 @@ func (s *Simulator) ExecuteLimit(assetID, side string, limitPrice, amountUSDC fl
 		return FillResult{}, fmt.Errorf("unsupported side: %s", side)
 	}
 
+	ownLevels := book.Bids
+	if side == "SELL" {
+		ownLevels = book.Asks
+	}
 	if !fillable {
-		return s.openOrder(assetID, side, limitPrice, amountUSDC), nil
+		return s.openOrder(assetID, side, limitPrice, amountUSDC, levelSize(ownLevels, limitPrice)), nil
 	}
+	// The touch can move past the limit while the order is in flight; it
+	// then arrives uncrossed and rests at its limit like any other.
+	execPrice = s.delayedPrice(assetID, side, execPrice, bestBid, bestAsk)
+	if (side == "BUY" && execPrice > limitPrice+1e-9) || (side == "SELL" && execPrice < limitPrice-1e-9) {
+		return s.openOrder(assetID, side, limitPrice, amountUSDC, levelSize(ownLevels, limitPrice)), nil
+	}
+	// Slippage cannot fill it worse than its limit either.
 	execPrice = applySlippage(execPrice, side, s.cfg.SlippageBps)
-	return s.fill(assetID, side, amountUSDC, execPrice, false)
+	if side == "BUY" {
+		execPrice = min(execPrice, limitPrice)
+	} else {
+		execPrice = max(execPrice, limitPrice)
+	}
+	return s.fill("", assetID, side, amountUSDC, execPrice, false)
 }
 
-func (s *Simulator) openOrder(assetID, side string, price, amountUSDC float64) FillResult {
+// ProcessBook matches resting limit orders for the book's asset against a new
+// snapshot. An order fills at its limit price once the opposite side trades
+// through it, or once opposite-side size at its price has consumed the queue
+// that was visible ahead of it. Orders still within the simulated latency are
+// not matched yet. Orders that can no longer be funded are dropped.
+func (s *Simulator) ProcessBook(book ws.OrderbookEvent) []FillResult {
+	bestBid, bestAsk, err := topOfBook(book)
+	if err != nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	s.observeLocked(book.AssetID, bestBid, bestAsk)
+	now := s.now()
+	var due []restingOrder
+	kept := s.resting[:0]
+	for _, o := range s.resting {
+		if o.assetID != book.AssetID || now.Before(o.activeAt) {
+			kept = append(kept, o)
+			continue
+		}
+		var ownLevels, oppLevels []ws.OrderbookLevel
+		var through, touching bool
+		if o.side == "BUY" {
+			ownLevels, oppLevels = book.Bids, book.Asks
+			through = bestAsk < o.price-1e-9
+			touching = math.Abs(bestAsk-o.price) <= 1e-9
+		} else {
+			ownLevels, oppLevels = book.Asks, book.Bids
+			through = bestBid > o.price+1e-9
+			touching = math.Abs(bestBid-o.price) <= 1e-9
+		}
+		// Orders ahead of us can only leave the queue, never join it. A
+		// trade at our price usually also shrinks our level, so the queue
+		// moves by the larger of the two rather than their sum.
+		shrink := max(o.queueAhead-levelSize(ownLevels, o.price), 0)
+		var traded float64
+		if touching {
+			traded = levelSize(oppLevels, o.price)
+		}
+		o.queueAhead -= max(shrink, traded)
+		if through || (touching && o.queueAhead <= 0) {
+			due = append(due, o)
+			continue
+		}
+		kept = append(kept, o)
+	}
+	s.resting = kept
+	s.mu.Unlock()
+
+	fills := make([]FillResult, 0, len(due))
+	for _, o := range due {
+		fill, err := s.fill(o.orderID, o.assetID, o.side, o.amountUSDC, o.price, false)
+		if err != nil {
+			continue
+		}
+		fills = append(fills, fill)
+	}
+	return fills
+}
+
+// CancelOrder removes a resting limit order. It reports whether the order was resting.
+func (s *Simulator) CancelOrder(orderID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, o := range s.resting {
+		if o.orderID == orderID {
+			s.resting = append(s.resting[:i], s.resting[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Settle closes the inventory in assetID at its resolution payout: 1.0 for the
+// winning token and 0.0 for a loser. Long inventory is redeemed into cash and
+// short inventory is bought back at the payout, with the configured fee charged
+// on the payout notional. Resting orders for the asset are dropped. It returns
+// false when there was no inventory to settle.
+func (s *Simulator) Settle(assetID string, winning bool) (FillResult, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := s.resting[:0]
+	for _, o := range s.resting {
+		if o.assetID != assetID {
+			kept = append(kept, o)
+		}
+	}
+	s.resting = kept
+
+	inv := s.inventory[assetID]
+	if math.Abs(inv) < 1e-9 {
+		return FillResult{}, false
+	}
+	payout := 0.0
+	if winning {
+		payout = 1.0
+	}
+	size := math.Abs(inv)
+	notional := size * payout
+	fee := notional * s.cfg.FeeBps / 10000
+
+	side := "SELL"
+	if inv > 0 {
+		s.balanceUSDC += notional - fee
+	} else {
+		side = "BUY"
+		s.balanceUSDC -= notional + fee
+	}
+	s.feesPaidUSDC += fee
+	delete(s.inventory, assetID)
+	delete(s.entryPrice, assetID)
+
+	s.sequence++
+	return FillResult{
+		TradeID:    fmt.Sprintf("paper-settle-%06d", s.sequence),
+		AssetID:    assetID,
+		Side:       side,
+		Status:     "SETTLED",
+		Filled:     true,
+		Price:      payout,
+		Size:       size,
+		AmountUSDC: notional,
+		FeeUSDC:    fee,
+		Timestamp:  s.now().UTC(),
+	}, true
+}
+
+func (s *Simulator) openOrder(assetID, side string, price, amountUSDC, queueAhead float64) FillResult {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.sequence++
 	orderID := fmt.Sprintf("paper-order-%06d", s.sequence)
+	s.resting = append(s.resting, restingOrder{
+		orderID:    orderID,
+		assetID:    assetID,
+		side:       side,
+		price:      price,
+		amountUSDC: amountUSDC,
+		queueAhead: queueAhead,
+		activeAt:   s.now().Add(s.latency),
+	})
 	size := 0.0
 	if price > 0 {
 		size = amountUSDC / price
@@ -163,11 +418,13 @@ func (s *Simulator) openOrder(assetID, side string, price, amountUSDC float64) F
 		Price:      price,
 		Size:       size,
 		AmountUSDC: amountUSDC,
-		Timestamp:  time.Now().UTC(),
+		Timestamp:  s.now().UTC(),
 	}
 }
 
-func (s *Simulator) fill(assetID, side string, amountUSDC, price float64, marketOrder bool) (FillResult, error) {
+// fill executes an order at price. An empty orderID allocates a new one;
+// resting orders pass their existing ID so the fill links back to them.
+func (s *Simulator) fill(orderID, assetID, side string, amountUSDC, price float64, marketOrder bool) (FillResult, error) {
 	if amountUSDC <= 0 {
 		return FillResult{}, fmt.Errorf("amount_usdc must be positive")
 	}
@@ -197,16 +454,20 @@ func (s *Simulator) fill(assetID, side string, amountUSDC, price float64, market
 		return FillResult{}, fmt.Errorf("unsupported side: %s", side)
 	}
 
-	s.sequence++
-	orderID := fmt.Sprintf("paper-order-%06d", s.sequence)
+	if orderID == "" {
+		s.sequence++
+		orderID = fmt.Sprintf("paper-order-%06d", s.sequence)
+	}
 	s.sequence++
 	tradeID := fmt.Sprintf("paper-trade-%06d", s.sequence)
 
 	if side == "BUY" {
 		s.balanceUSDC -= amountUSDC + fee
+		s.updateEntryLocked(assetID, size, price)
 		s.inventory[assetID] += size
 	} else { // SELL
 		s.balanceUSDC += amountUSDC - fee
+		s.updateEntryLocked(assetID, -size, price)
 		s.inventory[assetID] -= size
 		if s.inventory[assetID] > -1e-9 && s.inventory[assetID] < 1e-9 {
 			delete(s.inventory, assetID)
@@ -232,10 +493,27 @@ func (s *Simulator) fill(assetID, side string, amountUSDC, price float64, market
 		Size:       size,
 		AmountUSDC: amountUSDC,
 		FeeUSDC:    fee,
-		Timestamp:  time.Now().UTC(),
+		Timestamp:  s.now().UTC(),
 	}, nil
 }
 
+// updateEntryLocked moves the average entry price of assetID's inventory for a
+// fill of delta tokens at price, before the inventory itself changes. Adding
+// to a holding averages the price in, reducing it keeps the entry, and
+// opening or flipping one starts over at price. Caller must hold s.mu.
+func (s *Simulator) updateEntryLocked(assetID string, delta, price float64) {
+	prev := s.inventory[assetID]
+	next := prev + delta
+	switch {
+	case math.Abs(next) < 1e-9:
+		delete(s.entryPrice, assetID)
+	case math.Abs(prev) < 1e-9 || prev*next < 0:
+		s.entryPrice[assetID] = price
+	case math.Abs(next) > math.Abs(prev):
+		s.entryPrice[assetID] = (s.entryPrice[assetID]*math.Abs(prev) + price*math.Abs(delta)) / math.Abs(next)
+	}
+}
+
 func topOfBook(book ws.OrderbookEvent) (bestBid, bestAsk float64, err error) {
 	if len(book.Bids) == 0 || len(book.Asks) == 0 {
 		return 0, 0, fmt.Errorf("missing top-of-book levels")
@@ -251,6 +529,21 @@ func topOfBook(book ws.OrderbookEvent) (bestBid, bestAsk float64, err error) {
 	return bestBid, bestAsk, nil
 }
 
+// levelSize returns the total visible size quoted at price.
+func levelSize(levels []ws.OrderbookLevel, price float64) float64 {
+	total := 0.0
+	for _, lvl := range levels {
+		p, err := strconv.ParseFloat(lvl.Price, 64)
+		if err != nil || math.Abs(p-price) > 1e-9 {
+			continue
+		}
+		if size, err := strconv.ParseFloat(lvl.Size, 64); err == nil {
+			total += size
+		}
+	}
+	return total
+}
+
 func applySlippage(price float64, side string, slippageBps float64) float64 {
 	if slippageBps <= 0 {
 		return price
@@ -3,6 +3,7 @@ package paper
 import (
 	"math"
 	"testing"
+	"time"
 
 	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/ws"
 )
@@ -157,3 +158,335 @@ func TestSnapshotIncludesInventoryByAsset(t *testing.T) {
 		t.Fatalf("expected inventory size 100, got %f", size)
 	}
 }
+
+func TestRestingBuyFillsWhenAskReachesPrice(t *testing.T) {
+	sim := NewSimulator(Config{InitialBalanceUSDC: 1000, FeeBps: 10})
+
+	order, err := sim.ExecuteLimit("asset-1", "BUY", 0.51, 51, sampleBook())
+	if err != nil {
+		t.Fatalf("ExecuteLimit: %v", err)
+	}
+	if order.Filled || sim.Snapshot().RestingOrders != 1 {
+		t.Fatalf("expected one resting order, got %+v", order)
+	}
+
+	if fills := sim.ProcessBook(sampleBook()); len(fills) != 0 {
+		t.Fatalf("expected no fill while ask stays at 0.52, got %d", len(fills))
+	}
+
+	moved := ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "500"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.51", Size: "200"}},
+	}
+	fills := sim.ProcessBook(moved)
+	if len(fills) != 1 {
+		t.Fatalf("expected resting buy to fill once ask reaches 0.51, got %d fills", len(fills))
+	}
+	fill := fills[0]
+	if fill.OrderID != order.OrderID || fill.Price != 0.51 || !fill.Filled {
+		t.Fatalf("unexpected fill: %+v", fill)
+	}
+	snap := sim.Snapshot()
+	if snap.RestingOrders != 0 {
+		t.Fatalf("expected no resting orders after fill, got %d", snap.RestingOrders)
+	}
+	if math.Abs(snap.InventoryByAsset["asset-1"]-100) > 1e-9 {
+		t.Fatalf("expected 100 tokens of inventory, got %f", snap.InventoryByAsset["asset-1"])
+	}
+}
+
+func TestEntryPriceFollowsInventory(t *testing.T) {
+	sim := NewSimulator(Config{InitialBalanceUSDC: 1000})
+	cheaper := sampleBook()
+	cheaper.Asks = []ws.OrderbookLevel{{Price: "0.48", Size: "500"}}
+
+	// 100 @ 0.52 then 100 @ 0.48 average to 0.50.
+	_, _ = sim.ExecuteMarket("asset-1", "BUY", 52, sampleBook())
+	_, _ = sim.ExecuteMarket("asset-1", "BUY", 48, cheaper)
+	if got := sim.Export().EntryPrices["asset-1"]; math.Abs(got-0.50) > 1e-9 {
+		t.Fatalf("expected averaged entry 0.50, got %f", got)
+	}
+
+	// Selling part of the holding keeps its entry.
+	_, _ = sim.ExecuteMarket("asset-1", "SELL", 50, sampleBook())
+	if got := sim.Export().EntryPrices["asset-1"]; math.Abs(got-0.50) > 1e-9 {
+		t.Fatalf("expected entry kept at 0.50 after a partial sell, got %f", got)
+	}
+
+	// Selling through flat opens a short at the sell price.
+	_, _ = sim.ExecuteMarket("asset-1", "SELL", 100, sampleBook())
+	if got := sim.Export().EntryPrices["asset-1"]; math.Abs(got-0.50) > 1e-9 || sim.Snapshot().InventoryByAsset["asset-1"] >= 0 {
+		t.Fatalf("expected a short entered at 0.50, got entry %f inventory %v", got, sim.Snapshot().InventoryByAsset)
+	}
+	if _, ok := sim.Settle("asset-1", false); !ok {
+		t.Fatal("expected the short to settle")
+	}
+	if _, ok := sim.Export().EntryPrices["asset-1"]; ok {
+		t.Fatal("expected no entry price once settled")
+	}
+}
+
+func TestRestingOrderWaitsForQueueAhead(t *testing.T) {
+	sim := NewSimulator(Config{InitialBalanceUSDC: 1000})
+
+	// Joins the 0.50 bid behind 500 visible.
+	if _, err := sim.ExecuteLimit("asset-1", "BUY", 0.50, 10, sampleBook()); err != nil {
+		t.Fatalf("ExecuteLimit: %v", err)
+	}
+
+	touch := ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "500"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.50", Size: "200"}},
+	}
+	if fills := sim.ProcessBook(touch); len(fills) != 0 {
+		t.Fatalf("expected no fill with 300 still queued ahead, got %d", len(fills))
+	}
+
+	// The bid shrinking by 200 as 200 trades at our price is one trade, not
+	// two: 100 stays ahead.
+	touch.Bids = []ws.OrderbookLevel{{Price: "0.50", Size: "100"}}
+	if fills := sim.ProcessBook(touch); len(fills) != 0 {
+		t.Fatalf("expected no fill with 100 still queued ahead, got %d", len(fills))
+	}
+
+	touch.Asks = []ws.OrderbookLevel{{Price: "0.50", Size: "100"}}
+	if fills := sim.ProcessBook(touch); len(fills) != 1 {
+		t.Fatalf("expected fill once queue ahead is consumed, got %d", len(fills))
+	}
+}
+
+func TestCancelOrderRemovesRestingOrder(t *testing.T) {
+	sim := NewSimulator(Config{InitialBalanceUSDC: 1000})
+	order, _ := sim.ExecuteLimit("asset-1", "SELL", 0.60, 10, sampleBook())
+
+	if !sim.CancelOrder(order.OrderID) {
+		t.Fatal("expected resting order to be cancelled")
+	}
+	if sim.CancelOrder(order.OrderID) {
+		t.Fatal("expected second cancel to report not resting")
+	}
+	crossed := ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.65", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.66", Size: "100"}},
+	}
+	if fills := sim.ProcessBook(crossed); len(fills) != 0 {
+		t.Fatalf("expected cancelled order not to fill, got %d", len(fills))
+	}
+}
+
+func TestSettleWinningLongRedeemsAtOne(t *testing.T) {
+	sim := NewSimulator(Config{InitialBalanceUSDC: 1000})
+	if _, err := sim.ExecuteMarket("asset-1", "BUY", 52, sampleBook()); err != nil {
+		t.Fatalf("ExecuteMarket: %v", err)
+	}
+
+	res, ok := sim.Settle("asset-1", true)
+	if !ok {
+		t.Fatal("expected inventory to settle")
+	}
+	if res.Price != 1 || res.Side != "SELL" || math.Abs(res.Size-100) > 1e-9 {
+		t.Fatalf("unexpected settlement: %+v", res)
+	}
+	snap := sim.Snapshot()
+	if math.Abs(snap.BalanceUSDC-1048) > 1e-9 {
+		t.Fatalf("expected balance 1048 after $1 payout, got %f", snap.BalanceUSDC)
+	}
+	if _, held := snap.InventoryByAsset["asset-1"]; held {
+		t.Fatal("expected inventory cleared after settlement")
+	}
+	if _, ok := sim.Settle("asset-1", true); ok {
+		t.Fatal("expected second settlement to be a no-op")
+	}
+}
+
+func TestSettleLosingLongPaysNothing(t *testing.T) {
+	sim := NewSimulator(Config{InitialBalanceUSDC: 1000, FeeBps: 10})
+	if _, err := sim.ExecuteMarket("asset-1", "BUY", 52, sampleBook()); err != nil {
+		t.Fatalf("ExecuteMarket: %v", err)
+	}
+	before := sim.Snapshot()
+
+	res, ok := sim.Settle("asset-1", false)
+	if !ok || res.Price != 0 || res.FeeUSDC != 0 {
+		t.Fatalf("expected zero payout settlement, got %+v ok=%v", res, ok)
+	}
+	after := sim.Snapshot()
+	if after.BalanceUSDC != before.BalanceUSDC {
+		t.Fatalf("expected balance unchanged for losing token, got %f -> %f", before.BalanceUSDC, after.BalanceUSDC)
+	}
+	if len(after.InventoryByAsset) != 0 {
+		t.Fatalf("expected inventory cleared, got %v", after.InventoryByAsset)
+	}
+}
+
+func TestLatencyWorsensMarketFillAfterMidMoves(t *testing.T) {
+	clock := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	run := func(latencyMs int) float64 {
+		sim := NewSimulator(Config{InitialBalanceUSDC: 1000, LatencyMs: latencyMs})
+		at := clock
+		sim.SetClock(func() time.Time { return at })
+		sim.ProcessBook(sampleBook()) // mid 0.51
+		at = at.Add(100 * time.Millisecond)
+		rising := ws.OrderbookEvent{
+			AssetID: "asset-1",
+			Bids:    []ws.OrderbookLevel{{Price: "0.52", Size: "500"}},
+			Asks:    []ws.OrderbookLevel{{Price: "0.54", Size: "500"}},
+		}
+		fill, err := sim.ExecuteMarket("asset-1", "BUY", 10, rising)
+		if err != nil {
+			t.Fatalf("ExecuteMarket: %v", err)
+		}
+		return fill.Price
+	}
+
+	instant, delayed := run(0), run(500)
+	if instant != 0.54 {
+		t.Fatalf("expected an instant fill at the ask, got %f", instant)
+	}
+	// The mid moved 0.02 within the latency window, so the buy pays that on top.
+	if math.Abs(delayed-0.56) > 1e-9 {
+		t.Fatalf("expected a latency-adjusted fill at 0.56, got %f", delayed)
+	}
+}
+
+func TestLatencyRestsMarketableLimitThatNoLongerCrosses(t *testing.T) {
+	rising := ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.52", Size: "500"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.54", Size: "500"}},
+	}
+	run := func(limit float64) FillResult {
+		sim := NewSimulator(Config{InitialBalanceUSDC: 1000, LatencyMs: 500})
+		at := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		sim.SetClock(func() time.Time { return at })
+		sim.ProcessBook(sampleBook()) // mid 0.51
+		at = at.Add(100 * time.Millisecond)
+		res, err := sim.ExecuteLimit("asset-1", "BUY", limit, 10, rising)
+		if err != nil {
+			t.Fatalf("ExecuteLimit: %v", err)
+		}
+		return res
+	}
+
+	// The ask drifts 0.02 in flight to 0.56, past a 0.55 limit: it rests.
+	if res := run(0.55); res.Filled || res.Status != "LIVE" || res.Price != 0.55 {
+		t.Fatalf("expected the uncrossed order to rest at 0.55, got %+v", res)
+	}
+	// A limit still through the drifted ask fills there.
+	if res := run(0.57); !res.Filled || math.Abs(res.Price-0.56) > 1e-9 {
+		t.Fatalf("expected a fill at the drifted 0.56 ask, got %+v", res)
+	}
+}
+
+func TestLatencyDefersRestingOrderMatching(t *testing.T) {
+	at := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	moved := ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.49", Size: "500"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.50", Size: "200"}},
+	}
+	for _, tc := range []struct {
+		latencyMs int
+		wantFills int
+	}{{0, 1}, {500, 0}} {
+		sim := NewSimulator(Config{InitialBalanceUSDC: 1000, LatencyMs: tc.latencyMs})
+		now := at
+		sim.SetClock(func() time.Time { return now })
+		if _, err := sim.ExecuteLimit("asset-1", "BUY", 0.51, 51, sampleBook()); err != nil {
+			t.Fatalf("ExecuteLimit: %v", err)
+		}
+		now = now.Add(100 * time.Millisecond)
+		if fills := sim.ProcessBook(moved); len(fills) != tc.wantFills {
+			t.Fatalf("latency %dms: expected %d fills before the order arrives, got %d", tc.latencyMs, tc.wantFills, len(fills))
+		}
+	}
+
+	sim := NewSimulator(Config{InitialBalanceUSDC: 1000, LatencyMs: 500})
+	now := at
+	sim.SetClock(func() time.Time { return now })
+	if _, err := sim.ExecuteLimit("asset-1", "BUY", 0.51, 51, sampleBook()); err != nil {
+		t.Fatalf("ExecuteLimit: %v", err)
+	}
+	now = now.Add(time.Second)
+	if fills := sim.ProcessBook(moved); len(fills) != 1 || fills[0].Price != 0.51 {
+		t.Fatalf("expected the order to fill at its limit once the latency passed, got %+v", fills)
+	}
+}
+
+func depthBook() ws.OrderbookEvent {
+	return ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids: []ws.OrderbookLevel{
+			{Price: "0.50", Size: "100"},
+			{Price: "0.48", Size: "100"},
+		},
+		Asks: []ws.OrderbookLevel{
+			{Price: "0.52", Size: "100"}, // 52 USDC
+			{Price: "0.55", Size: "100"}, // 55 USDC
+			{Price: "0.60", Size: "500"},
+		},
+	}
+}
+
+func TestDepthSlippageGrowsWithOrderSize(t *testing.T) {
+	sim := NewSimulator(Config{InitialBalanceUSDC: 1000, SlippageBps: 20, SlippageModel: SlippageDepth})
+
+	small, err := sim.ExecuteMarket("asset-1", "BUY", 26, depthBook())
+	if err != nil {
+		t.Fatalf("small order: %v", err)
+	}
+	if math.Abs(small.Price-0.52) > 1e-9 {
+		t.Fatalf("expected an order inside the top level to fill at the touch 0.52, got %f", small.Price)
+	}
+
+	large, err := sim.ExecuteMarket("asset-1", "BUY", 107, depthBook())
+	if err != nil {
+		t.Fatalf("large order: %v", err)
+	}
+	// 100 tokens at 0.52 and 100 at 0.55 for 107 USDC.
+	if math.Abs(large.Price-0.535) > 1e-9 {
+		t.Fatalf("expected the two-level average 0.535, got %f", large.Price)
+	}
+	if math.Abs(large.Size-200) > 1e-6 {
+		t.Fatalf("expected 200 tokens, got %f", large.Size)
+	}
+
+	sell, err := sim.ExecuteMarket("asset-1", "SELL", 98, depthBook())
+	if err != nil {
+		t.Fatalf("sell: %v", err)
+	}
+	if math.Abs(sell.Price-0.49) > 1e-9 {
+		t.Fatalf("expected a sell through both bid levels to average 0.49, got %f", sell.Price)
+	}
+}
+
+func TestDepthSlippageCappedByMaxSlippage(t *testing.T) {
+	sim := NewSimulator(Config{InitialBalanceUSDC: 1000, SlippageModel: SlippageDepth, MaxSlippageBps: 100})
+
+	fill, err := sim.ExecuteMarket("asset-1", "BUY", 107, depthBook())
+	if err != nil {
+		t.Fatalf("ExecuteMarket: %v", err)
+	}
+	if want := 0.52 * 1.01; math.Abs(fill.Price-want) > 1e-9 {
+		t.Fatalf("expected slippage capped at 100bps (%f), got %f", want, fill.Price)
+	}
+}
+
+func TestFlatSlippageIgnoresOrderSize(t *testing.T) {
+	sim := NewSimulator(Config{InitialBalanceUSDC: 1000, SlippageBps: 20})
+
+	small, err := sim.ExecuteMarket("asset-1", "BUY", 26, depthBook())
+	if err != nil {
+		t.Fatalf("small order: %v", err)
+	}
+	large, err := sim.ExecuteMarket("asset-1", "BUY", 107, depthBook())
+	if err != nil {
+		t.Fatalf("large order: %v", err)
+	}
+	if math.Abs(small.Price-large.Price) > 1e-12 || math.Abs(small.Price-0.52*1.002) > 1e-9 {
+		t.Fatalf("expected both orders at the flat price %f, got %f and %f", 0.52*1.002, small.Price, large.Price)
+	}
+}
@@ -0,0 +1,128 @@
+package paper
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// State is the persistable part of a paper account. Resting orders are not
+// saved; they are re-quoted after a restart.
+type State struct {
+	SavedAt         time.Time          `json:"saved_at"`
+	Sequence        int64              `json:"sequence"`
+	BalanceUSDC     float64            `json:"balance_usdc"`
+	FeesPaidUSDC    float64            `json:"fees_paid_usdc"`
+	TotalVolumeUSDC float64            `json:"total_volume_usdc"`
+	TotalTrades     int                `json:"total_trades"`
+	Inventory       map[string]float64 `json:"inventory"`
+	EntryPrices     map[string]float64 `json:"entry_prices,omitempty"` // average price paid per inventory asset
+}
+
+// Export captures the account state for persistence.
+func (s *Simulator) Export() State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	inventory := make(map[string]float64, len(s.inventory))
+	for assetID, size := range s.inventory {
+		inventory[assetID] = size
+	}
+	entryPrices := make(map[string]float64, len(s.entryPrice))
+	for assetID, price := range s.entryPrice {
+		entryPrices[assetID] = price
+	}
+	return State{
+		SavedAt:         time.Now().UTC(),
+		Sequence:        s.sequence,
+		BalanceUSDC:     s.balanceUSDC,
+		FeesPaidUSDC:    s.feesPaidUSDC,
+		TotalVolumeUSDC: s.totalVolumeUSDC,
+		TotalTrades:     s.totalTrades,
+		Inventory:       inventory,
+		EntryPrices:     entryPrices,
+	}
+}
+
+// Import replaces the account state with a previously exported one. The
+// simulator is left untouched if the state is invalid.
+func (s *Simulator) Import(st State) error {
+	for _, v := range []float64{st.BalanceUSDC, st.FeesPaidUSDC, st.TotalVolumeUSDC} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("invalid paper state: non-finite amount")
+		}
+	}
+	if st.FeesPaidUSDC < 0 || st.TotalVolumeUSDC < 0 || st.TotalTrades < 0 || st.Sequence < 0 {
+		return fmt.Errorf("invalid paper state: negative counters")
+	}
+	inventory := make(map[string]float64, len(st.Inventory))
+	for assetID, size := range st.Inventory {
+		if math.IsNaN(size) || math.IsInf(size, 0) {
+			return fmt.Errorf("invalid paper state: inventory for %s", assetID)
+		}
+		if size != 0 {
+			inventory[assetID] = size
+		}
+	}
+	// Files saved before entry prices were kept restore without them.
+	entryPrices := make(map[string]float64, len(st.EntryPrices))
+	for assetID, price := range st.EntryPrices {
+		if math.IsNaN(price) || math.IsInf(price, 0) || price < 0 {
+			return fmt.Errorf("invalid paper state: entry price for %s", assetID)
+		}
+		if _, held := inventory[assetID]; held {
+			entryPrices[assetID] = price
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sequence = st.Sequence
+	s.balanceUSDC = st.BalanceUSDC
+	s.feesPaidUSDC = st.FeesPaidUSDC
+	s.totalVolumeUSDC = st.TotalVolumeUSDC
+	s.totalTrades = st.TotalTrades
+	s.inventory = inventory
+	s.entryPrice = entryPrices
+	s.resting = nil
+	return nil
+}
+
+// SaveState writes the exported state to path, replacing it atomically.
+func (s *Simulator) SaveState(path string) error {
+	data, err := json.MarshalIndent(s.Export(), "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadState imports state from path. A missing file returns an error
+// satisfying errors.Is(err, fs.ErrNotExist); a corrupt file leaves the
+// simulator at its current state.
+func (s *Simulator) LoadState(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var st State
+	if err := json.Unmarshal(data, &st); err != nil {
+		return fmt.Errorf("decode paper state %s: %w", path, err)
+	}
+	return s.Import(st)
+}
@@ -0,0 +1,76 @@
+package paper
+
+import (
+	"errors"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStateRoundTrip(t *testing.T) {
+	sim := NewSimulator(Config{InitialBalanceUSDC: 1000, FeeBps: 10})
+	if _, err := sim.ExecuteMarket("asset-1", "BUY", 52, sampleBook()); err != nil {
+		t.Fatalf("ExecuteMarket: %v", err)
+	}
+	if _, err := sim.ExecuteMarket("asset-2", "SELL", 25, sampleBook()); err != nil {
+		t.Fatalf("ExecuteMarket: %v", err)
+	}
+	want := sim.Snapshot()
+
+	path := filepath.Join(t.TempDir(), "paper.json")
+	if err := sim.SaveState(path); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+
+	restored := NewSimulator(Config{InitialBalanceUSDC: 1000, FeeBps: 10})
+	if err := restored.LoadState(path); err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	got := restored.Snapshot()
+	if math.Abs(got.BalanceUSDC-want.BalanceUSDC) > 1e-9 ||
+		math.Abs(got.FeesPaidUSDC-want.FeesPaidUSDC) > 1e-9 ||
+		math.Abs(got.TotalVolumeUSDC-want.TotalVolumeUSDC) > 1e-9 ||
+		got.TotalTrades != want.TotalTrades {
+		t.Fatalf("restored snapshot mismatch: want %+v got %+v", want, got)
+	}
+	for assetID, size := range want.InventoryByAsset {
+		if math.Abs(got.InventoryByAsset[assetID]-size) > 1e-9 {
+			t.Fatalf("inventory %s: want %f got %f", assetID, size, got.InventoryByAsset[assetID])
+		}
+	}
+	if entries := restored.Export().EntryPrices; entries["asset-1"] != 0.52 || entries["asset-2"] != 0.50 {
+		t.Fatalf("expected entry prices 0.52 long and 0.50 short restored, got %v", entries)
+	}
+
+	// Order IDs keep advancing after a restore instead of repeating.
+	next, err := restored.ExecuteMarket("asset-1", "BUY", 1, sampleBook())
+	if err != nil {
+		t.Fatalf("ExecuteMarket after restore: %v", err)
+	}
+	if next.OrderID != "paper-order-000005" {
+		t.Fatalf("expected sequence to continue, got %s", next.OrderID)
+	}
+}
+
+func TestLoadStateMissingAndCorruptFiles(t *testing.T) {
+	dir := t.TempDir()
+	sim := NewSimulator(Config{InitialBalanceUSDC: 500})
+
+	err := sim.LoadState(filepath.Join(dir, "missing.json"))
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected not-exist error, got %v", err)
+	}
+
+	corrupt := filepath.Join(dir, "corrupt.json")
+	if err := os.WriteFile(corrupt, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.LoadState(corrupt); err == nil {
+		t.Fatal("expected corrupt file to fail")
+	}
+	if got := sim.Snapshot().BalanceUSDC; got != 500 {
+		t.Fatalf("expected fresh balance 500 after failed load, got %f", got)
+	}
+}
@@ -18,6 +18,7 @@ type PortfolioTracker struct {
 	positions    []data.Position
 	totalValue   float64
 	lastSync     time.Time
+	lastErr      error
 	syncInterval time.Duration
 }
 
@@ -30,16 +31,18 @@ func NewTracker(dataClient data.Client, userAddr common.Address, syncInterval ti
 	}
 }
 
-// Sync fetches current positions and portfolio value from the Data API.
+// Sync fetches current positions and portfolio value from the Data API. A
+// failure keeps the cached values and is reported by LastError until the next
+// successful sync.
 func (t *PortfolioTracker) Sync(ctx context.Context) error {
 	positions, err := t.dataClient.Positions(ctx, &data.PositionsRequest{User: t.userAddr})
 	if err != nil {
-		return err
+		return t.failed(err)
 	}
 
 	values, err := t.dataClient.Value(ctx, &data.ValueRequest{User: t.userAddr})
 	if err != nil {
-		return err
+		return t.failed(err)
 	}
 
 	var totalValue float64
@@ -52,10 +55,18 @@ func (t *PortfolioTracker) Sync(ctx context.Context) error {
 	t.positions = positions
 	t.totalValue = totalValue
 	t.lastSync = time.Now()
+	t.lastErr = nil
 	t.mu.Unlock()
 	return nil
 }
 
+func (t *PortfolioTracker) failed(err error) error {
+	t.mu.Lock()
+	t.lastErr = err
+	t.mu.Unlock()
+	return err
+}
+
 // Positions returns cached positions.
 func (t *PortfolioTracker) Positions() []data.Position {
 	t.mu.RLock()
@@ -77,6 +88,14 @@ func (t *PortfolioTracker) LastSync() time.Time {
 	return t.lastSync
 }
 
+// LastError returns the error from the most recent sync, or nil if it
+// succeeded or none has run yet.
+func (t *PortfolioTracker) LastError() error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.lastErr
+}
+
 // RecentTrades fetches recent trades from the Data API.
 func (t *PortfolioTracker) RecentTrades(ctx context.Context, limit int) ([]data.Trade, error) {
 	return t.dataClient.Trades(ctx, &data.TradesRequest{User: &t.userAddr, Limit: &limit})
@@ -1,9 +1,12 @@
 package portfolio
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/GoPolymarket/polymarket-go-sdk/pkg/data"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -34,3 +37,39 @@ func TestTrackerInitialState(t *testing.T) {
 		t.Errorf("expected 0 positions, got %d", len(tracker.Positions()))
 	}
 }
+
+type flakyData struct {
+	data.Client
+	err error
+}
+
+func (d *flakyData) Positions(context.Context, *data.PositionsRequest) (data.PositionsResponse, error) {
+	return nil, d.err
+}
+
+func (d *flakyData) Value(context.Context, *data.ValueRequest) (data.ValueResponse, error) {
+	return nil, d.err
+}
+
+func TestTrackerLastErrorTracksSync(t *testing.T) {
+	client := &flakyData{err: errors.New("data api down")}
+	tracker := NewTracker(client, common.Address{19: 0xaa}, 5*time.Minute)
+
+	if err := tracker.Sync(context.Background()); err == nil {
+		t.Fatal("expected sync error")
+	}
+	if tracker.LastError() == nil {
+		t.Fatal("expected LastError after a failed sync")
+	}
+	if !tracker.LastSync().IsZero() {
+		t.Error("failed sync should not set last sync")
+	}
+
+	client.err = nil
+	if err := tracker.Sync(context.Background()); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if err := tracker.LastError(); err != nil {
+		t.Errorf("expected LastError cleared after a successful sync, got %v", err)
+	}
+}
@@ -2,6 +2,9 @@ package risk
 
 import (
 	"fmt"
+	"math"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -9,16 +12,39 @@ import (
 )
 
 type Config struct {
-	MaxOpenOrders           int
-	MaxDailyLossUSDC        float64
-	MaxDailyLossPct         float64 // percentage loss cap derived from account capital (0.02 = 2%)
-	AccountCapitalUSDC      float64 // baseline capital for percentage-based limits
-	MaxPositionPerMarket    float64
-	StopLossPerMarket       float64 // max loss per market before unwind
-	MaxDrawdownPct          float64 // max total drawdown as fraction of daily start
-	RiskSyncInterval        time.Duration
-	MaxConsecutiveLosses    int
-	ConsecutiveLossCooldown time.Duration
+	MaxOpenOrders                 int
+	MaxDailyLossUSDC              float64
+	MaxDailyLossPct               float64 // percentage loss cap derived from account capital (0.02 = 2%)
+	AccountCapitalUSDC            float64 // baseline capital for percentage-based limits
+	MaxPositionPerMarket          float64
+	MaxLongPerMarketUSDC          float64       // long exposure cap per market (0 = MaxPositionPerMarket)
+	MaxShortPerMarketUSDC         float64       // short exposure cap per market (0 = MaxPositionPerMarket)
+	StopLossPerMarket             float64       // max loss per market before unwind
+	MaxDrawdownPct                float64       // max total drawdown as fraction of daily start
+	MaxDrawdownVelocityUSDCPerMin float64       // loss rate over the velocity window that trips emergency stop (0 = disabled)
+	DrawdownVelocityWindow        time.Duration // lookback for the loss rate (default 5m)
+	RiskSyncInterval              time.Duration
+	MaxConsecutiveLosses          int
+	ConsecutiveLossCooldown       time.Duration
+	CooldownEscalationFactor      float64       // multiplier applied per repeated cooldown in a day (<= 1 = fixed)
+	MaxCooldown                   time.Duration // cap on escalated cooldowns (0 = uncapped)
+	ConcentrationWarnHHI          float64       // warn when position HHI exceeds this (0 = disabled)
+	MaxGrossExposureUSDC          float64       // cap on summed exposure across all markets (0 = disabled)
+	MaxGrossExposurePct           float64       // gross exposure cap as a fraction of account capital (0 = disabled)
+
+	// CorrelationGroups maps a group name to asset IDs that would lose
+	// together; MaxGroupExposureUSDC caps their summed absolute exposure
+	// (0 = disabled). A stand-in for a full correlation matrix.
+	CorrelationGroups    map[string][]string
+	MaxGroupExposureUSDC float64
+
+	// MaxOrderNotionalUSDC rejects any single order larger than this,
+	// however much position room is left (0 = disabled).
+	MaxOrderNotionalUSDC float64
+
+	// MaxFillsPerMinute is a runaway-trading breaker: more fills than this
+	// within a rolling minute trips emergency stop (0 = disabled).
+	MaxFillsPerMinute int
 }
 
 type Snapshot struct {
@@ -29,6 +55,27 @@ type Snapshot struct {
 	InCooldown           bool
 	CooldownRemaining    time.Duration
 	MaxConsecutiveLosses int
+	CooldownMultiplier   float64
+	CooldownTriggers     int
+	ConcentrationHHI     float64
+	ConcentrationWarnHHI float64
+	ConcentrationWarning bool
+	GrossExposureUSDC    float64
+	GrossExposureLimit   float64
+	DrawdownVelocity     float64 // recent loss rate in USDC/min (0 when flat or gaining)
+	MaxDrawdownVelocity  float64
+	PositionsUSDC        map[string]float64 // tokenID → signed USDC exposure (copy)
+	GroupExposureUSDC    map[string]float64 // correlation group → summed absolute exposure
+	GroupExposureLimit   float64
+	MaxOrderNotional     float64 // per-order size cap (0 = disabled)
+}
+
+// defaultDrawdownVelocityWindow is used when DrawdownVelocityWindow is unset.
+const defaultDrawdownVelocityWindow = 5 * time.Minute
+
+type pnlSample struct {
+	at  time.Time
+	pnl float64
 }
 
 type Manager struct {
@@ -36,11 +83,15 @@ type Manager struct {
 	cfg               Config
 	openOrders        int
 	dailyPnL          float64
-	positions         map[string]float64 // tokenID → USDC exposure
+	positions         map[string]float64 // tokenID → signed USDC exposure (negative = short)
+	sharedExposure    float64            // absolute exposure held through other accounts, counted toward the gross limit
 	emergencyStop     bool
 	dailyStartPnL     float64 // PnL at start of day for drawdown calc
 	consecutiveLosses int
 	cooldownUntil     time.Time
+	cooldownTriggers  int // cooldowns triggered since the last daily reset
+	pnlSamples        []pnlSample
+	fillTimes         []time.Time // fills within the last minute, oldest first
 }
 
 func New(cfg Config) *Manager {
@@ -50,7 +101,9 @@ func New(cfg Config) *Manager {
 	}
 }
 
-func (m *Manager) Allow(tokenID string, amountUSDC float64) error {
+// Allow checks whether an order of amountUSDC on side ("BUY" or "SELL") may be
+// placed for tokenID.
+func (m *Manager) Allow(tokenID, side string, amountUSDC float64) error {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
@@ -67,13 +120,102 @@ func (m *Manager) Allow(tokenID string, amountUSDC float64) error {
 	if dailyLossLimit > 0 && m.dailyPnL <= -dailyLossLimit {
 		return fmt.Errorf("daily loss limit reached: %.2f/%.2f", m.dailyPnL, -dailyLossLimit)
 	}
+	if limit := m.cfg.MaxOrderNotionalUSDC; limit > 0 && amountUSDC > limit {
+		return fmt.Errorf("max order notional for %s: %.2f > %.2f", tokenID, amountUSDC, limit)
+	}
 	pos := m.positions[tokenID]
-	if pos+amountUSDC > m.cfg.MaxPositionPerMarket {
-		return fmt.Errorf("position limit for %s: %.2f+%.2f > %.2f", tokenID, pos, amountUSDC, m.cfg.MaxPositionPerMarket)
+	next := pos + amountUSDC
+	if strings.EqualFold(side, "SELL") {
+		next = pos - amountUSDC
+	}
+	if next > 0 && next > pos {
+		if limit := m.longLimit(); next > limit {
+			return fmt.Errorf("long position limit for %s: %.2f -> %.2f > %.2f", tokenID, pos, next, limit)
+		}
+	}
+	if next < 0 && next < pos {
+		if limit := m.shortLimit(); -next > limit {
+			return fmt.Errorf("short position limit for %s: %.2f -> %.2f > %.2f", tokenID, -pos, -next, limit)
+		}
+	}
+	if limit := m.grossExposureLimitLocked(); limit > 0 {
+		gross := m.grossExposureLocked() + m.sharedExposure
+		nextGross := gross - abs(pos) + abs(next)
+		if nextGross > gross && nextGross > limit {
+			return fmt.Errorf("gross exposure limit: %.2f -> %.2f > %.2f", gross, nextGross, limit)
+		}
+	}
+	if limit := m.cfg.MaxGroupExposureUSDC; limit > 0 {
+		for name, members := range m.cfg.CorrelationGroups {
+			if !slices.Contains(members, tokenID) {
+				continue
+			}
+			group := m.groupExposureLocked(members)
+			nextGroup := group - abs(pos) + abs(next)
+			if nextGroup > group && nextGroup > limit {
+				return fmt.Errorf("group exposure limit for %s: %.2f -> %.2f > %.2f", name, group, nextGroup, limit)
+			}
+		}
 	}
 	return nil
 }
 
+// RemainingCapacity returns how much USDC can still be bought of tokenID
+// before Allow would refuse it on the long, gross-exposure or correlation
+// group limits. It never goes below 0 and ignores the non-exposure checks
+// such as emergency stop, cooldown and open-order count.
+func (m *Manager) RemainingCapacity(tokenID string) float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	pos := m.positions[tokenID]
+	capacity := m.longLimit() - pos
+	// Buying first unwinds any short, which frees gross and group room.
+	unwind := abs(pos) - pos
+	if limit := m.grossExposureLimitLocked(); limit > 0 {
+		capacity = math.Min(capacity, limit-m.grossExposureLocked()-m.sharedExposure+unwind)
+	}
+	if limit := m.cfg.MaxGroupExposureUSDC; limit > 0 {
+		for _, members := range m.cfg.CorrelationGroups {
+			if slices.Contains(members, tokenID) {
+				capacity = math.Min(capacity, limit-m.groupExposureLocked(members)+unwind)
+			}
+		}
+	}
+	return math.Max(capacity, 0)
+}
+
+// groupExposureLocked sums the absolute exposure of the given assets.
+func (m *Manager) groupExposureLocked(members []string) float64 {
+	var total float64
+	for _, tokenID := range members {
+		total += abs(m.positions[tokenID])
+	}
+	return total
+}
+
+func (m *Manager) longLimit() float64 {
+	if m.cfg.MaxLongPerMarketUSDC > 0 {
+		return m.cfg.MaxLongPerMarketUSDC
+	}
+	return m.cfg.MaxPositionPerMarket
+}
+
+func (m *Manager) shortLimit() float64 {
+	if m.cfg.MaxShortPerMarketUSDC > 0 {
+		return m.cfg.MaxShortPerMarketUSDC
+	}
+	return m.cfg.MaxPositionPerMarket
+}
+
+// SetConfig replaces the risk limits while keeping positions, PnL and
+// cooldown state.
+func (m *Manager) SetConfig(cfg Config) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cfg = cfg
+}
+
 func (m *Manager) SetOpenOrders(n int) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -126,6 +268,7 @@ func (m *Manager) ResetDaily() {
 	m.dailyPnL = 0
 	m.consecutiveLosses = 0
 	m.cooldownUntil = time.Time{}
+	m.cooldownTriggers = 0
 }
 
 // SyncFromTracker updates risk state from the execution tracker.
@@ -135,11 +278,11 @@ func (m *Manager) SyncFromTracker(openOrders int, positions map[string]execution
 	m.openOrders = openOrders
 	m.dailyPnL = realizedPnL
 
-	// Rebuild position exposure from tracker positions.
+	// Rebuild signed position exposure from tracker positions.
 	m.positions = make(map[string]float64, len(positions))
 	for assetID, pos := range positions {
-		exposure := pos.AvgEntryPrice * abs(pos.NetSize)
-		if exposure > 0 {
+		exposure := pos.AvgEntryPrice * pos.NetSize
+		if exposure != 0 {
 			m.positions[assetID] = exposure
 		}
 	}
@@ -166,6 +309,149 @@ func (m *Manager) EvaluateDrawdown(realizedPnL, unrealizedPnL, capital float64)
 	return drawdownPct >= m.cfg.MaxDrawdownPct
 }
 
+// RecordPnLSample adds a total (realized + unrealized) PnL observation to the
+// rolling velocity window and reports whether the recent loss rate exceeds
+// MaxDrawdownVelocityUSDCPerMin.
+func (m *Manager) RecordPnLSample(now time.Time, totalPnL float64) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	window := m.velocityWindowLocked()
+	m.pnlSamples = append(m.pnlSamples, pnlSample{at: now, pnl: totalPnL})
+	cutoff := now.Add(-window)
+	drop := 0
+	for drop < len(m.pnlSamples)-1 && m.pnlSamples[drop].at.Before(cutoff) {
+		drop++
+	}
+	m.pnlSamples = m.pnlSamples[drop:]
+
+	limit := m.cfg.MaxDrawdownVelocityUSDCPerMin
+	return limit > 0 && m.drawdownVelocityLocked() > limit
+}
+
+// RecordFill counts a fill at now in the rolling one-minute window and
+// reports whether the count exceeds MaxFillsPerMinute.
+func (m *Manager) RecordFill(now time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.fillTimes = append(m.fillTimes, now)
+	cutoff := now.Add(-time.Minute)
+	drop := 0
+	for drop < len(m.fillTimes) && !m.fillTimes[drop].After(cutoff) {
+		drop++
+	}
+	m.fillTimes = m.fillTimes[drop:]
+
+	limit := m.cfg.MaxFillsPerMinute
+	return limit > 0 && len(m.fillTimes) > limit
+}
+
+// FillsLastMinute returns how many fills the rolling window holds.
+func (m *Manager) FillsLastMinute() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.fillTimes)
+}
+
+// DrawdownVelocity returns the current loss rate in USDC per minute.
+func (m *Manager) DrawdownVelocity() float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.drawdownVelocityLocked()
+}
+
+// drawdownVelocityLocked measures the drop from the window's PnL peak to the
+// latest sample, per minute since the peak. Elapsed time is floored at one
+// minute so a sudden loss reads as its full size rather than an inflated rate.
+func (m *Manager) drawdownVelocityLocked() float64 {
+	if len(m.pnlSamples) < 2 {
+		return 0
+	}
+	latest := m.pnlSamples[len(m.pnlSamples)-1]
+	peak := m.pnlSamples[0]
+	for _, s := range m.pnlSamples[1:] {
+		if s.pnl >= peak.pnl {
+			peak = s
+		}
+	}
+	loss := peak.pnl - latest.pnl
+	if loss <= 0 {
+		return 0
+	}
+	minutes := latest.at.Sub(peak.at).Minutes()
+	if minutes < 1 {
+		minutes = 1
+	}
+	return loss / minutes
+}
+
+func (m *Manager) velocityWindowLocked() time.Duration {
+	if m.cfg.DrawdownVelocityWindow > 0 {
+		return m.cfg.DrawdownVelocityWindow
+	}
+	return defaultDrawdownVelocityWindow
+}
+
+// ConcentrationHHI returns the Herfindahl-Hirschman index over current position
+// exposures: the sum of squared exposure shares, ranging from 1/n (evenly spread
+// across n markets) to 1 (a single market). Returns 0 when flat.
+func (m *Manager) ConcentrationHHI() float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.concentrationHHILocked()
+}
+
+func (m *Manager) concentrationHHILocked() float64 {
+	total := m.grossExposureLocked()
+	if total <= 0 {
+		return 0
+	}
+	var hhi float64
+	for _, exposure := range m.positions {
+		share := abs(exposure) / total
+		hhi += share * share
+	}
+	return hhi
+}
+
+// GrossExposureUSDC returns the summed absolute exposure across all markets,
+// including that shared from other accounts.
+func (m *Manager) GrossExposureUSDC() float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.grossExposureLocked() + m.sharedExposure
+}
+
+// SetSharedExposure sets the absolute exposure other accounts hold, which
+// counts toward the gross exposure limit alongside this manager's positions.
+func (m *Manager) SetSharedExposure(usdc float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sharedExposure = usdc
+}
+
+func (m *Manager) grossExposureLocked() float64 {
+	var total float64
+	for _, exposure := range m.positions {
+		total += abs(exposure)
+	}
+	return total
+}
+
+// grossExposureLimitLocked returns the tighter of the fixed and
+// capital-derived gross exposure caps, or 0 when neither is set.
+func (m *Manager) grossExposureLimitLocked() float64 {
+	limit := m.cfg.MaxGrossExposureUSDC
+	if m.cfg.AccountCapitalUSDC > 0 && m.cfg.MaxGrossExposurePct > 0 {
+		derived := m.cfg.AccountCapitalUSDC * m.cfg.MaxGrossExposurePct
+		if limit <= 0 || derived < limit {
+			limit = derived
+		}
+	}
+	return limit
+}
+
 func abs(x float64) float64 {
 	if x < 0 {
 		return -x
@@ -181,13 +467,17 @@ func (m *Manager) DailyLossLimitUSDC() float64 {
 }
 
 // RecordTradeResult updates consecutive-loss state using realized PnL deltas.
-// Returns true when loss streak triggers a cooldown.
+// Returns true when loss streak triggers a cooldown. Results that arrive while
+// a cooldown is running neither extend it nor count as another trigger.
 func (m *Manager) RecordTradeResult(realizedDelta float64) bool {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if m.inCooldownLocked() {
+		return false
+	}
 	// Start a fresh streak when previous cooldown has already elapsed.
-	if !m.cooldownUntil.IsZero() && !time.Now().Before(m.cooldownUntil) {
+	if !m.cooldownUntil.IsZero() {
 		m.cooldownUntil = time.Time{}
 		m.consecutiveLosses = 0
 	}
@@ -202,14 +492,33 @@ func (m *Manager) RecordTradeResult(realizedDelta float64) bool {
 		return false
 	}
 
-	cooldown := m.cfg.ConsecutiveLossCooldown
-	if cooldown <= 0 {
-		cooldown = 15 * time.Minute
-	}
-	m.cooldownUntil = time.Now().Add(cooldown)
+	m.cooldownTriggers++
+	m.cooldownUntil = time.Now().Add(m.cooldownDurationLocked())
 	return true
 }
 
+// cooldownMultiplierLocked returns the escalation applied to the most recent
+// cooldown: factor^(triggers-1), or 1 before any trigger or when escalation
+// is disabled.
+func (m *Manager) cooldownMultiplierLocked() float64 {
+	if m.cfg.CooldownEscalationFactor <= 1 || m.cooldownTriggers <= 1 {
+		return 1
+	}
+	return math.Pow(m.cfg.CooldownEscalationFactor, float64(m.cooldownTriggers-1))
+}
+
+func (m *Manager) cooldownDurationLocked() time.Duration {
+	base := m.cfg.ConsecutiveLossCooldown
+	if base <= 0 {
+		base = 15 * time.Minute
+	}
+	cooldown := time.Duration(float64(base) * m.cooldownMultiplierLocked())
+	if m.cfg.MaxCooldown > 0 && cooldown > m.cfg.MaxCooldown {
+		cooldown = m.cfg.MaxCooldown
+	}
+	return cooldown
+}
+
 func (m *Manager) ConsecutiveLosses() int {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -239,6 +548,15 @@ func (m *Manager) Snapshot() Snapshot {
 	if inCooldown {
 		remaining = time.Until(m.cooldownUntil)
 	}
+	hhi := m.concentrationHHILocked()
+	positions := make(map[string]float64, len(m.positions))
+	for tokenID, exposure := range m.positions {
+		positions[tokenID] = exposure
+	}
+	groups := make(map[string]float64, len(m.cfg.CorrelationGroups))
+	for name, members := range m.cfg.CorrelationGroups {
+		groups[name] = m.groupExposureLocked(members)
+	}
 	return Snapshot{
 		EmergencyStop:        m.emergencyStop,
 		DailyPnL:             m.dailyPnL,
@@ -247,6 +565,19 @@ func (m *Manager) Snapshot() Snapshot {
 		InCooldown:           inCooldown,
 		CooldownRemaining:    remaining,
 		MaxConsecutiveLosses: m.cfg.MaxConsecutiveLosses,
+		CooldownMultiplier:   m.cooldownMultiplierLocked(),
+		CooldownTriggers:     m.cooldownTriggers,
+		ConcentrationHHI:     hhi,
+		ConcentrationWarnHHI: m.cfg.ConcentrationWarnHHI,
+		ConcentrationWarning: m.cfg.ConcentrationWarnHHI > 0 && hhi > m.cfg.ConcentrationWarnHHI,
+		GrossExposureUSDC:    m.grossExposureLocked() + m.sharedExposure,
+		GrossExposureLimit:   m.grossExposureLimitLocked(),
+		DrawdownVelocity:     m.drawdownVelocityLocked(),
+		MaxDrawdownVelocity:  m.cfg.MaxDrawdownVelocityUSDCPerMin,
+		PositionsUSDC:        positions,
+		GroupExposureUSDC:    groups,
+		GroupExposureLimit:   m.cfg.MaxGroupExposureUSDC,
+		MaxOrderNotional:     m.cfg.MaxOrderNotionalUSDC,
 	}
 }
 
@@ -1,6 +1,8 @@
 package risk
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -9,7 +11,7 @@ import (
 
 func TestAllowOrderBasic(t *testing.T) {
 	m := New(Config{MaxOpenOrders: 5, MaxDailyLossUSDC: 100, MaxPositionPerMarket: 50})
-	if err := m.Allow("token-1", 25); err != nil {
+	if err := m.Allow("token-1", "BUY", 25); err != nil {
 		t.Fatalf("expected allow, got %v", err)
 	}
 }
@@ -17,7 +19,7 @@ func TestAllowOrderBasic(t *testing.T) {
 func TestBlockOnMaxOrders(t *testing.T) {
 	m := New(Config{MaxOpenOrders: 2, MaxDailyLossUSDC: 100, MaxPositionPerMarket: 50})
 	m.SetOpenOrders(2)
-	if err := m.Allow("token-1", 25); err == nil {
+	if err := m.Allow("token-1", "BUY", 25); err == nil {
 		t.Fatal("expected block on max orders")
 	}
 }
@@ -25,7 +27,7 @@ func TestBlockOnMaxOrders(t *testing.T) {
 func TestBlockOnDailyLoss(t *testing.T) {
 	m := New(Config{MaxOpenOrders: 20, MaxDailyLossUSDC: 100, MaxPositionPerMarket: 50})
 	m.RecordPnL(-101)
-	if err := m.Allow("token-1", 25); err == nil {
+	if err := m.Allow("token-1", "BUY", 25); err == nil {
 		t.Fatal("expected block on daily loss")
 	}
 }
@@ -33,7 +35,7 @@ func TestBlockOnDailyLoss(t *testing.T) {
 func TestBlockOnPositionLimit(t *testing.T) {
 	m := New(Config{MaxOpenOrders: 20, MaxDailyLossUSDC: 100, MaxPositionPerMarket: 50})
 	m.AddPosition("token-1", 30)
-	if err := m.Allow("token-1", 25); err == nil {
+	if err := m.Allow("token-1", "BUY", 25); err == nil {
 		t.Fatal("expected block on position limit")
 	}
 }
@@ -41,7 +43,7 @@ func TestBlockOnPositionLimit(t *testing.T) {
 func TestEmergencyStop(t *testing.T) {
 	m := New(Config{MaxOpenOrders: 20, MaxDailyLossUSDC: 100, MaxPositionPerMarket: 50})
 	m.SetEmergencyStop(true)
-	if err := m.Allow("token-1", 10); err == nil {
+	if err := m.Allow("token-1", "BUY", 10); err == nil {
 		t.Fatal("expected block on emergency stop")
 	}
 }
@@ -64,10 +66,10 @@ func TestRemovePosition(t *testing.T) {
 	m.AddPosition("token-1", 30)
 	m.RemovePosition("token-1", 10)
 
-	if err := m.Allow("token-1", 25); err != nil {
+	if err := m.Allow("token-1", "BUY", 25); err != nil {
 		t.Fatalf("expected allow: 20+25 <= 50, got %v", err)
 	}
-	if err := m.Allow("token-1", 31); err == nil {
+	if err := m.Allow("token-1", "BUY", 31); err == nil {
 		t.Fatal("expected block: 20+31 > 50")
 	}
 }
@@ -77,7 +79,7 @@ func TestRemovePositionDeletesAtZero(t *testing.T) {
 	m.AddPosition("token-1", 30)
 	m.RemovePosition("token-1", 30)
 
-	if err := m.Allow("token-1", 50); err != nil {
+	if err := m.Allow("token-1", "BUY", 50); err != nil {
 		t.Fatalf("expected allow after full removal, got %v", err)
 	}
 }
@@ -87,7 +89,7 @@ func TestRemovePositionBelowZero(t *testing.T) {
 	m.AddPosition("token-1", 10)
 	m.RemovePosition("token-1", 20)
 
-	if err := m.Allow("token-1", 50); err != nil {
+	if err := m.Allow("token-1", "BUY", 50); err != nil {
 		t.Fatalf("expected allow after over-removal, got %v", err)
 	}
 }
@@ -95,11 +97,11 @@ func TestRemovePositionBelowZero(t *testing.T) {
 func TestSetOpenOrders(t *testing.T) {
 	m := New(Config{MaxOpenOrders: 5, MaxDailyLossUSDC: 100, MaxPositionPerMarket: 50})
 	m.SetOpenOrders(3)
-	if err := m.Allow("token-1", 10); err != nil {
+	if err := m.Allow("token-1", "BUY", 10); err != nil {
 		t.Fatalf("expected allow at 3/5 orders, got %v", err)
 	}
 	m.SetOpenOrders(5)
-	if err := m.Allow("token-1", 10); err == nil {
+	if err := m.Allow("token-1", "BUY", 10); err == nil {
 		t.Fatal("expected block at 5/5 orders")
 	}
 }
@@ -107,11 +109,11 @@ func TestSetOpenOrders(t *testing.T) {
 func TestEmergencyStopToggle(t *testing.T) {
 	m := New(Config{MaxOpenOrders: 20, MaxDailyLossUSDC: 100, MaxPositionPerMarket: 50})
 	m.SetEmergencyStop(true)
-	if err := m.Allow("token-1", 10); err == nil {
+	if err := m.Allow("token-1", "BUY", 10); err == nil {
 		t.Fatal("expected block on emergency stop")
 	}
 	m.SetEmergencyStop(false)
-	if err := m.Allow("token-1", 10); err != nil {
+	if err := m.Allow("token-1", "BUY", 10); err != nil {
 		t.Fatalf("expected allow after emergency stop cleared, got %v", err)
 	}
 }
@@ -130,15 +132,38 @@ func TestSyncFromTracker(t *testing.T) {
 		t.Fatalf("expected daily PnL -15.5, got %f", m.DailyPnL())
 	}
 	// Open orders synced.
-	if err := m.Allow("x", 10); err != nil {
+	if err := m.Allow("x", "BUY", 10); err != nil {
 		t.Fatalf("expected allow at 3/5 orders, got %v", err)
 	}
 	m.SetOpenOrders(5)
-	if err := m.Allow("x", 10); err == nil {
+	if err := m.Allow("x", "BUY", 10); err == nil {
 		t.Fatal("expected block at 5/5 orders")
 	}
 }
 
+func TestSnapshotPositionsUSDCFromTracker(t *testing.T) {
+	m := New(Config{MaxOpenOrders: 5, MaxPositionPerMarket: 50})
+	m.SyncFromTracker(0, map[string]execution.Position{
+		"asset-1": {AssetID: "asset-1", NetSize: 10, AvgEntryPrice: 0.50},
+		"asset-2": {AssetID: "asset-2", NetSize: -5, AvgEntryPrice: 0.60},
+		"asset-3": {AssetID: "asset-3", NetSize: 0, AvgEntryPrice: 0.40},
+	}, 0)
+
+	snap := m.Snapshot()
+	if len(snap.PositionsUSDC) != 2 {
+		t.Fatalf("expected 2 non-flat positions, got %v", snap.PositionsUSDC)
+	}
+	if snap.PositionsUSDC["asset-1"] != 5 || snap.PositionsUSDC["asset-2"] != -3 {
+		t.Fatalf("unexpected positions_usdc: %v", snap.PositionsUSDC)
+	}
+
+	// Mutating the snapshot must not leak into the manager.
+	snap.PositionsUSDC["asset-1"] = 999
+	if got := m.Snapshot().PositionsUSDC["asset-1"]; got != 5 {
+		t.Fatalf("expected snapshot map to be a copy, manager now reports %f", got)
+	}
+}
+
 func TestStopLossTriggered(t *testing.T) {
 	m := New(Config{StopLossPerMarket: 20})
 	pos := execution.Position{
@@ -179,6 +204,50 @@ func TestEmergencyOnDrawdown(t *testing.T) {
 	}
 }
 
+func TestDrawdownVelocityRapidLossTrips(t *testing.T) {
+	m := New(Config{MaxOpenOrders: 10, MaxPositionPerMarket: 50, MaxDrawdownVelocityUSDCPerMin: 5, DrawdownVelocityWindow: 10 * time.Minute})
+	start := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	if m.RecordPnLSample(start, 10) {
+		t.Fatal("single sample should not trip")
+	}
+	if m.RecordPnLSample(start.Add(time.Minute), 8) {
+		t.Fatal("2 USDC/min loss should stay under the limit")
+	}
+	if !m.RecordPnLSample(start.Add(3*time.Minute), -10) {
+		t.Fatal("expected 20 USDC drop over 3 minutes to trip the velocity limit")
+	}
+	if v := m.Snapshot().DrawdownVelocity; v < 6.66 || v > 6.67 {
+		t.Fatalf("expected velocity ~6.67 USDC/min, got %f", v)
+	}
+}
+
+func TestDrawdownVelocitySlowLossDoesNotTrip(t *testing.T) {
+	m := New(Config{MaxOpenOrders: 10, MaxPositionPerMarket: 50, MaxDrawdownVelocityUSDCPerMin: 5, DrawdownVelocityWindow: time.Hour})
+	start := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	// The same 20 USDC loss spread over 20 minutes is 1 USDC/min.
+	for i := 0; i <= 20; i++ {
+		if m.RecordPnLSample(start.Add(time.Duration(i)*time.Minute), 10-float64(i)) {
+			t.Fatalf("slow loss tripped velocity limit at minute %d", i)
+		}
+	}
+	if v := m.DrawdownVelocity(); v < 0.99 || v > 1.01 {
+		t.Fatalf("expected velocity ~1 USDC/min, got %f", v)
+	}
+}
+
+func TestDrawdownVelocityWindowForgetsOldPeaks(t *testing.T) {
+	m := New(Config{MaxOpenOrders: 10, MaxPositionPerMarket: 50, MaxDrawdownVelocityUSDCPerMin: 5, DrawdownVelocityWindow: 5 * time.Minute})
+	start := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	m.RecordPnLSample(start, 50)
+	m.RecordPnLSample(start.Add(30*time.Minute), 10)
+	if m.RecordPnLSample(start.Add(31*time.Minute), 9) {
+		t.Fatal("loss outside the window should not count toward velocity")
+	}
+}
+
 func TestDailyReset(t *testing.T) {
 	m := New(Config{MaxOpenOrders: 20, MaxDailyLossUSDC: 100, MaxPositionPerMarket: 50})
 	m.RecordPnL(-50)
@@ -205,7 +274,7 @@ func TestDailyLossLimitFromCapitalPct(t *testing.T) {
 	}
 
 	m.RecordPnL(-20)
-	if err := m.Allow("token-1", 1); err == nil {
+	if err := m.Allow("token-1", "BUY", 1); err == nil {
 		t.Fatal("expected block once derived daily loss limit is reached")
 	}
 }
@@ -229,7 +298,7 @@ func TestConsecutiveLossCooldown(t *testing.T) {
 	if !m.InCooldown() {
 		t.Fatal("expected cooldown to be active after 3 consecutive losses")
 	}
-	if err := m.Allow("token-1", 1); err == nil {
+	if err := m.Allow("token-1", "BUY", 1); err == nil {
 		t.Fatal("expected allow to block while cooldown is active")
 	}
 }
@@ -284,3 +353,376 @@ func TestConsecutiveLossCooldownResetsAfterExpiry(t *testing.T) {
 		t.Fatalf("expected fresh streak count 1 after cooldown expiry, got %d", got)
 	}
 }
+
+func TestCooldownEscalatesOnRepeatedStreaks(t *testing.T) {
+	m := New(Config{
+		MaxOpenOrders:            20,
+		MaxPositionPerMarket:     50,
+		MaxConsecutiveLosses:     2,
+		ConsecutiveLossCooldown:  10 * time.Minute,
+		CooldownEscalationFactor: 2,
+		MaxCooldown:              30 * time.Minute,
+	})
+
+	triggerStreak := func() time.Duration {
+		t.Helper()
+		m.RecordTradeResult(-1)
+		if !m.RecordTradeResult(-1) {
+			t.Fatal("expected loss streak to trigger cooldown")
+		}
+		remaining := m.CooldownRemaining()
+		// Simulate cooldown elapsed without waiting in test.
+		m.cooldownUntil = time.Now().Add(-time.Second)
+		return remaining
+	}
+
+	first := triggerStreak()
+	if first > 10*time.Minute || first < 9*time.Minute {
+		t.Fatalf("expected first cooldown ~10m, got %s", first)
+	}
+	if got := m.Snapshot().CooldownMultiplier; got != 1 {
+		t.Fatalf("expected multiplier 1 after first trigger, got %f", got)
+	}
+
+	second := triggerStreak()
+	if second <= first {
+		t.Fatalf("expected second cooldown %s to exceed first %s", second, first)
+	}
+	if second < 19*time.Minute {
+		t.Fatalf("expected second cooldown ~20m, got %s", second)
+	}
+	if got := m.Snapshot().CooldownMultiplier; got != 2 {
+		t.Fatalf("expected multiplier 2 after second trigger, got %f", got)
+	}
+
+	third := triggerStreak()
+	if third > 30*time.Minute || third < 29*time.Minute {
+		t.Fatalf("expected third cooldown capped at 30m, got %s", third)
+	}
+
+	m.ResetDaily()
+	if snap := m.Snapshot(); snap.CooldownTriggers != 0 || snap.CooldownMultiplier != 1 {
+		t.Fatalf("expected escalation reset at daily reset, got triggers=%d multiplier=%f", snap.CooldownTriggers, snap.CooldownMultiplier)
+	}
+	if again := triggerStreak(); again > 10*time.Minute {
+		t.Fatalf("expected base cooldown after daily reset, got %s", again)
+	}
+}
+
+func TestLossesDuringCooldownDoNotRetrigger(t *testing.T) {
+	m := New(Config{
+		MaxOpenOrders:            20,
+		MaxPositionPerMarket:     50,
+		MaxConsecutiveLosses:     2,
+		ConsecutiveLossCooldown:  10 * time.Minute,
+		CooldownEscalationFactor: 2,
+		MaxCooldown:              time.Hour,
+	})
+	m.RecordTradeResult(-1)
+	if !m.RecordTradeResult(-1) {
+		t.Fatal("expected loss streak to trigger cooldown")
+	}
+	until := m.cooldownUntil
+
+	for i := 0; i < 3; i++ {
+		if m.RecordTradeResult(-1) {
+			t.Fatalf("loss %d during the cooldown re-triggered it", i+1)
+		}
+	}
+	snap := m.Snapshot()
+	if snap.CooldownTriggers != 1 || snap.CooldownMultiplier != 1 {
+		t.Fatalf("expected one trigger at 1x, got triggers=%d multiplier=%f", snap.CooldownTriggers, snap.CooldownMultiplier)
+	}
+	if !m.cooldownUntil.Equal(until) {
+		t.Fatalf("expected the running cooldown left at %s, got %s", until, m.cooldownUntil)
+	}
+
+	// Once it elapses a fresh streak is needed, and that escalates.
+	m.cooldownUntil = time.Now().Add(-time.Second)
+	if m.RecordTradeResult(-1) {
+		t.Fatal("expected a single loss after the cooldown to start a new streak")
+	}
+	if !m.RecordTradeResult(-1) {
+		t.Fatal("expected the new streak to trigger a cooldown")
+	}
+	if got := m.Snapshot().CooldownTriggers; got != 2 {
+		t.Fatalf("expected 2 triggers, got %d", got)
+	}
+	if remaining := m.CooldownRemaining(); remaining < 19*time.Minute {
+		t.Fatalf("expected the second cooldown escalated to ~20m, got %s", remaining)
+	}
+
+	m.ResetDaily()
+	if snap := m.Snapshot(); snap.CooldownTriggers != 0 || snap.InCooldown || snap.ConsecutiveLosses != 0 {
+		t.Fatalf("expected daily reset to clear the trigger count and cooldown, got %+v", snap)
+	}
+}
+
+func TestConcentrationHHISingleDominantPosition(t *testing.T) {
+	m := New(Config{MaxOpenOrders: 10, MaxPositionPerMarket: 100, ConcentrationWarnHHI: 0.5})
+	m.SyncFromTracker(0, map[string]execution.Position{
+		"token-1": {AssetID: "token-1", NetSize: 90, AvgEntryPrice: 0.5},
+		"token-2": {AssetID: "token-2", NetSize: 10, AvgEntryPrice: 0.5},
+	}, 0)
+
+	hhi := m.ConcentrationHHI()
+	// shares 0.9 and 0.1 → 0.81 + 0.01
+	if hhi < 0.82-1e-9 || hhi > 0.82+1e-9 {
+		t.Fatalf("expected HHI 0.82, got %f", hhi)
+	}
+	snap := m.Snapshot()
+	if !snap.ConcentrationWarning {
+		t.Fatal("expected concentration warning for dominant position")
+	}
+}
+
+func TestConcentrationHHIEvenlySpreadPositions(t *testing.T) {
+	m := New(Config{MaxOpenOrders: 10, MaxPositionPerMarket: 100, ConcentrationWarnHHI: 0.5})
+	m.SyncFromTracker(0, map[string]execution.Position{
+		"token-1": {AssetID: "token-1", NetSize: 10, AvgEntryPrice: 0.5},
+		"token-2": {AssetID: "token-2", NetSize: -10, AvgEntryPrice: 0.5},
+		"token-3": {AssetID: "token-3", NetSize: 10, AvgEntryPrice: 0.5},
+		"token-4": {AssetID: "token-4", NetSize: 10, AvgEntryPrice: 0.5},
+	}, 0)
+
+	hhi := m.ConcentrationHHI()
+	if hhi < 0.25-1e-9 || hhi > 0.25+1e-9 {
+		t.Fatalf("expected HHI 0.25 for four equal positions, got %f", hhi)
+	}
+	if m.Snapshot().ConcentrationWarning {
+		t.Fatal("did not expect concentration warning for evenly spread positions")
+	}
+}
+
+func TestConcentrationHHIFlat(t *testing.T) {
+	m := New(Config{MaxOpenOrders: 10, MaxPositionPerMarket: 100, ConcentrationWarnHHI: 0.5})
+	if hhi := m.ConcentrationHHI(); hhi != 0 {
+		t.Fatalf("expected HHI 0 with no positions, got %f", hhi)
+	}
+	if m.Snapshot().ConcentrationWarning {
+		t.Fatal("did not expect concentration warning when flat")
+	}
+}
+
+func TestGrossExposureLimitBlocksAcrossMarkets(t *testing.T) {
+	m := New(Config{MaxOpenOrders: 10, MaxPositionPerMarket: 5, MaxGrossExposureUSDC: 10})
+	for _, token := range []string{"token-1", "token-2", "token-3"} {
+		if err := m.Allow(token, "BUY", 3); err != nil {
+			t.Fatalf("expected %s to be allowed under the gross cap: %v", token, err)
+		}
+		m.AddPosition(token, 3)
+	}
+	if err := m.Allow("token-4", "BUY", 1); err != nil {
+		t.Fatalf("expected order reaching the cap exactly to be allowed: %v", err)
+	}
+	m.AddPosition("token-4", 1)
+	if err := m.Allow("token-5", "BUY", 0.5); err == nil {
+		t.Fatal("expected order beyond gross exposure cap to be blocked")
+	}
+
+	snap := m.Snapshot()
+	if snap.GrossExposureUSDC != 10 || snap.GrossExposureLimit != 10 {
+		t.Fatalf("expected gross exposure 10/10, got %f/%f", snap.GrossExposureUSDC, snap.GrossExposureLimit)
+	}
+}
+
+func TestGrossExposureLimitFromCapitalPct(t *testing.T) {
+	m := New(Config{
+		MaxOpenOrders:        10,
+		MaxPositionPerMarket: 100,
+		AccountCapitalUSDC:   100,
+		MaxGrossExposurePct:  0.2,
+		MaxGrossExposureUSDC: 50,
+	})
+	m.SyncFromTracker(0, map[string]execution.Position{
+		"token-1": {AssetID: "token-1", NetSize: 20, AvgEntryPrice: 0.5},
+		"token-2": {AssetID: "token-2", NetSize: -10, AvgEntryPrice: 0.5},
+	}, 0)
+
+	if got := m.GrossExposureUSDC(); got != 15 {
+		t.Fatalf("expected gross exposure 15, got %f", got)
+	}
+	if err := m.Allow("token-3", "BUY", 5); err != nil {
+		t.Fatalf("expected order within derived cap to be allowed: %v", err)
+	}
+	if err := m.Allow("token-3", "BUY", 6); err == nil {
+		t.Fatal("expected tighter pct-derived cap (20) to block")
+	}
+	if got := m.Snapshot().GrossExposureLimit; got != 20 {
+		t.Fatalf("expected effective limit 20, got %f", got)
+	}
+}
+
+func TestGrossExposureLimitCountsSharedExposure(t *testing.T) {
+	m := New(Config{MaxOpenOrders: 10, MaxPositionPerMarket: 10, MaxGrossExposureUSDC: 10})
+	m.AddPosition("token-1", 3)
+	m.SetSharedExposure(6)
+
+	if got := m.Snapshot().GrossExposureUSDC; got != 9 {
+		t.Fatalf("expected gross exposure 9 with the shared 6, got %f", got)
+	}
+	if got := m.RemainingCapacity("token-2"); got != 1 {
+		t.Fatalf("expected 1 USDC of room left, got %f", got)
+	}
+	if err := m.Allow("token-2", "BUY", 2); err == nil {
+		t.Fatal("expected the other account's exposure to count toward the cap")
+	}
+	m.SetSharedExposure(0)
+	if err := m.Allow("token-2", "BUY", 2); err != nil {
+		t.Fatalf("expected the order allowed once the other account is flat: %v", err)
+	}
+}
+
+func TestGrossExposureUnlimitedByDefault(t *testing.T) {
+	m := New(Config{MaxOpenOrders: 10, MaxPositionPerMarket: 5})
+	for i := 0; i < 30; i++ {
+		token := fmt.Sprintf("token-%d", i)
+		if err := m.Allow(token, "BUY", 5); err != nil {
+			t.Fatalf("expected no gross cap by default: %v", err)
+		}
+		m.AddPosition(token, 5)
+	}
+}
+
+func TestCorrelationGroupSharesExposureCap(t *testing.T) {
+	m := New(Config{
+		MaxOpenOrders:        10,
+		MaxPositionPerMarket: 10,
+		CorrelationGroups:    map[string][]string{"election": {"token-1", "token-2", "token-3"}},
+		MaxGroupExposureUSDC: 12,
+	})
+	m.AddPosition("token-1", 5)
+	m.AddPosition("token-2", 5)
+
+	if err := m.Allow("token-3", "BUY", 2); err != nil {
+		t.Fatalf("expected order reaching the group cap exactly to be allowed: %v", err)
+	}
+	if err := m.Allow("token-3", "BUY", 3); err == nil || !strings.Contains(err.Error(), "group exposure limit for election") {
+		t.Fatalf("expected group cap to block a member, got %v", err)
+	}
+	if err := m.Allow("token-1", "SELL", 3); err != nil {
+		t.Fatalf("expected reducing a member's exposure to pass: %v", err)
+	}
+	if err := m.Allow("token-9", "BUY", 8); err != nil {
+		t.Fatalf("expected ungrouped market to ignore the group cap: %v", err)
+	}
+
+	snap := m.Snapshot()
+	if snap.GroupExposureUSDC["election"] != 10 || snap.GroupExposureLimit != 12 {
+		t.Fatalf("expected group exposure 10/12, got %v/%f", snap.GroupExposureUSDC, snap.GroupExposureLimit)
+	}
+}
+
+func TestLongCapReachedWhileShortsRemainAllowed(t *testing.T) {
+	m := New(Config{MaxOpenOrders: 10, MaxPositionPerMarket: 50, MaxLongPerMarketUSDC: 10, MaxShortPerMarketUSDC: 4})
+	for i := 0; i < 2; i++ {
+		if err := m.Allow("token-1", "BUY", 5); err != nil {
+			t.Fatalf("buy %d: expected allow under long cap, got %v", i+1, err)
+		}
+		m.AddPosition("token-1", 5)
+	}
+	if err := m.Allow("token-1", "BUY", 1); err == nil {
+		t.Fatal("expected long cap to block further buys")
+	}
+	// Selling down the long is always allowed, and can flip into a short up to the short cap.
+	if err := m.Allow("token-1", "SELL", 14); err != nil {
+		t.Fatalf("expected sell into a 4 USDC short to be allowed, got %v", err)
+	}
+	if err := m.Allow("token-1", "SELL", 15); err == nil {
+		t.Fatal("expected sell beyond short cap to be blocked")
+	}
+	if err := m.Allow("token-2", "SELL", 4); err != nil {
+		t.Fatalf("expected fresh short within cap on another market, got %v", err)
+	}
+}
+
+func TestShortCapFromTrackerPositions(t *testing.T) {
+	m := New(Config{MaxOpenOrders: 10, MaxPositionPerMarket: 20, MaxShortPerMarketUSDC: 5})
+	m.SyncFromTracker(0, map[string]execution.Position{
+		"token-1": {AssetID: "token-1", NetSize: -8, AvgEntryPrice: 0.5},
+	}, 0)
+
+	if err := m.Allow("token-1", "SELL", 2); err == nil {
+		t.Fatal("expected short cap to block adding to a 4 USDC short")
+	}
+	if err := m.Allow("token-1", "BUY", 3); err != nil {
+		t.Fatalf("expected buy covering the short to be allowed, got %v", err)
+	}
+	// Long side falls back to MaxPositionPerMarket.
+	if err := m.Allow("token-1", "BUY", 24); err != nil {
+		t.Fatalf("expected buy into a 20 USDC long to be allowed, got %v", err)
+	}
+	if err := m.Allow("token-1", "BUY", 25); err == nil {
+		t.Fatal("expected fallback long cap to block")
+	}
+}
+
+func TestRemainingCapacityTracksTightestLimit(t *testing.T) {
+	m := New(Config{MaxOpenOrders: 20, MaxPositionPerMarket: 50, MaxGrossExposureUSDC: 80})
+	if got := m.RemainingCapacity("token-1"); got != 50 {
+		t.Fatalf("expected the full position limit when flat, got %.2f", got)
+	}
+	m.AddPosition("token-1", 30)
+	if got := m.RemainingCapacity("token-1"); got != 20 {
+		t.Fatalf("expected 20 left under the position limit, got %.2f", got)
+	}
+	m.AddPosition("token-2", 45)
+	if got := m.RemainingCapacity("token-1"); got != 5 {
+		t.Fatalf("expected gross exposure to bind at 5, got %.2f", got)
+	}
+	m.AddPosition("token-3", 10)
+	if got := m.RemainingCapacity("token-1"); got != 0 {
+		t.Fatalf("expected no capacity over the gross limit, got %.2f", got)
+	}
+	if err := m.Allow("token-1", "BUY", 1); err == nil {
+		t.Fatal("expected Allow to agree there is no capacity")
+	}
+}
+
+func TestRemainingCapacityCountsShortUnwind(t *testing.T) {
+	m := New(Config{MaxOpenOrders: 20, MaxPositionPerMarket: 50, MaxGrossExposureUSDC: 40})
+	m.SyncFromTracker(0, map[string]execution.Position{
+		"token-1": {AssetID: "token-1", NetSize: -40, AvgEntryPrice: 0.5},
+	}, 0)
+	// Short 20 USDC: buying 20 flattens it, then another 40 fits under gross.
+	got := m.RemainingCapacity("token-1")
+	if got != 60 {
+		t.Fatalf("expected 60, got %.2f", got)
+	}
+	if err := m.Allow("token-1", "BUY", got); err != nil {
+		t.Fatalf("expected Allow at the reported capacity, got %v", err)
+	}
+}
+
+func TestMaxOrderNotionalRejectsOversizedOrder(t *testing.T) {
+	m := New(Config{MaxOpenOrders: 20, MaxPositionPerMarket: 10000, MaxOrderNotionalUSDC: 100})
+	if err := m.Allow("token-1", "BUY", 100); err != nil {
+		t.Fatalf("expected an order at the cap to pass, got %v", err)
+	}
+	for _, side := range []string{"BUY", "SELL"} {
+		err := m.Allow("token-1", side, 150)
+		if err == nil || !strings.Contains(err.Error(), "max order notional") {
+			t.Fatalf("expected %s over the per-order cap to be rejected despite headroom, got %v", side, err)
+		}
+	}
+	if got := m.Snapshot().MaxOrderNotional; got != 100 {
+		t.Fatalf("expected snapshot to report the cap, got %.2f", got)
+	}
+}
+
+func TestRecordFillTripsOverRollingMinute(t *testing.T) {
+	m := New(Config{MaxOpenOrders: 20, MaxFillsPerMinute: 3})
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		if m.RecordFill(start.Add(time.Duration(i) * 10 * time.Second)) {
+			t.Fatalf("fill %d should be within the limit", i+1)
+		}
+	}
+	if !m.RecordFill(start.Add(30 * time.Second)) {
+		t.Fatal("expected the fourth fill within a minute to trip")
+	}
+	// A minute after the first burst only the latest fills remain.
+	if m.RecordFill(start.Add(95 * time.Second)) {
+		t.Fatalf("expected old fills to age out, window holds %d", m.FillsLastMinute())
+	}
+}
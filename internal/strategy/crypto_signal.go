@@ -165,6 +165,29 @@ func (t *CryptoSignalTracker) ProcessPrice(update CryptoPriceUpdate) []CryptoSig
 	return signals
 }
 
+// ScaleForInventory returns the signal amount shrunk by the share of the
+// per-market limit already held in the signal's direction: a BUY against an
+// existing long or a SELL against an existing short. netUSDC is the signed
+// position exposure and limitUSDC the limit on that side. It is 0 once the
+// limit is reached; a non-positive limit leaves the amount as is.
+func (s CryptoSignal) ScaleForInventory(netUSDC, limitUSDC float64) float64 {
+	if limitUSDC <= 0 {
+		return s.AmountUSDC
+	}
+	held := netUSDC
+	if s.Side == "SELL" {
+		held = -netUSDC
+	}
+	if held <= 0 {
+		return s.AmountUSDC
+	}
+	room := limitUSDC - held
+	if room <= 0 {
+		return 0
+	}
+	return math.Min(s.AmountUSDC*room/limitUSDC, room)
+}
+
 // TrackedSymbols returns the crypto symbols being tracked.
 func (t *CryptoSignalTracker) TrackedSymbols() []string {
 	t.mu.RLock()
@@ -1,6 +1,7 @@
 package strategy
 
 import (
+	"math"
 	"testing"
 	"time"
 )
@@ -159,3 +160,30 @@ func TestCryptoSignalTrackerSetMapping(t *testing.T) {
 		t.Fatalf("expected 2 tracked symbols, got %d", len(symbols))
 	}
 }
+
+func TestCryptoSignalScaleForInventory(t *testing.T) {
+	buy := CryptoSignal{Side: "BUY", AmountUSDC: 10}
+	sell := CryptoSignal{Side: "SELL", AmountUSDC: 10}
+
+	if got := buy.ScaleForInventory(0, 100); got != 10 {
+		t.Errorf("flat position: expected full 10, got %f", got)
+	}
+	if got := buy.ScaleForInventory(-40, 100); got != 10 {
+		t.Errorf("buy against a short: expected full 10, got %f", got)
+	}
+	if got := buy.ScaleForInventory(75, 100); got != 2.5 {
+		t.Errorf("long at 75%% of limit: expected 2.5, got %f", got)
+	}
+	if got := buy.ScaleForInventory(98, 100); math.Abs(got-0.2) > 1e-9 {
+		t.Errorf("near the limit: expected 0.2, got %f", got)
+	}
+	if got := buy.ScaleForInventory(100, 100); got != 0 {
+		t.Errorf("at the limit: expected 0, got %f", got)
+	}
+	if got := sell.ScaleForInventory(-50, 100); got != 5 {
+		t.Errorf("sell against a short: expected 5, got %f", got)
+	}
+	if got := buy.ScaleForInventory(500, 0); got != 10 {
+		t.Errorf("no limit: expected full 10, got %f", got)
+	}
+}
@@ -0,0 +1,42 @@
+package strategy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExternalStrategyTag labels orders placed on behalf of an external signal feed.
+const ExternalStrategyTag = "external"
+
+// ExternalSignal is a discretionary trade signal injected from outside the bot.
+type ExternalSignal struct {
+	AssetID    string  `json:"asset_id"`
+	Side       string  `json:"side"`
+	AmountUSDC float64 `json:"amount_usdc"`
+	MaxPrice   float64 `json:"max_price"` // optional limit price; 0 places a market order
+	Reason     string  `json:"reason"`
+}
+
+// Normalize trims identifiers and upper-cases the side.
+func (s *ExternalSignal) Normalize() {
+	s.AssetID = strings.TrimSpace(s.AssetID)
+	s.Side = strings.ToUpper(strings.TrimSpace(s.Side))
+	s.Reason = strings.TrimSpace(s.Reason)
+}
+
+// Validate checks that the signal is well formed.
+func (s ExternalSignal) Validate() error {
+	if s.AssetID == "" {
+		return fmt.Errorf("asset_id is required")
+	}
+	if s.Side != "BUY" && s.Side != "SELL" {
+		return fmt.Errorf("side must be BUY or SELL, got %q", s.Side)
+	}
+	if s.AmountUSDC <= 0 {
+		return fmt.Errorf("amount_usdc must be > 0, got %f", s.AmountUSDC)
+	}
+	if s.MaxPrice < 0 || s.MaxPrice >= 1 {
+		return fmt.Errorf("max_price must be within [0,1), got %f", s.MaxPrice)
+	}
+	return nil
+}
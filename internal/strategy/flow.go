@@ -13,6 +13,13 @@ type FlowSample struct {
 	Timestamp time.Time
 }
 
+// FlowStat summarizes an asset's trades inside the tracker window.
+type FlowStat struct {
+	NetFlow float64 // -1 (all sells) to +1 (all buys)
+	VWAP    float64
+	Trades  int
+}
+
 // FlowTracker tracks order flow in a rolling window per asset.
 type FlowTracker struct {
 	mu      sync.RWMutex
@@ -85,6 +92,36 @@ func (ft *FlowTracker) VWAP(assetID string) float64 {
 	return totalNotional / totalSize
 }
 
+// Window returns the rolling window length.
+func (ft *FlowTracker) Window() time.Duration { return ft.window }
+
+// Stat returns the asset's net flow, VWAP and trade count over the window.
+func (ft *FlowTracker) Stat(assetID string) FlowStat {
+	ft.mu.RLock()
+	defer ft.mu.RUnlock()
+
+	cutoff := time.Now().Add(-ft.window)
+	var st FlowStat
+	var buyVol, sellVol, notional float64
+	for _, s := range ft.samples[assetID] {
+		if s.Timestamp.Before(cutoff) {
+			continue
+		}
+		st.Trades++
+		if s.Side == "BUY" {
+			buyVol += s.Size
+		} else {
+			sellVol += s.Size
+		}
+		notional += s.Price * s.Size
+	}
+	if total := buyVol + sellVol; total > 0 {
+		st.NetFlow = (buyVol - sellVol) / total
+		st.VWAP = notional / total
+	}
+	return st
+}
+
 // evict removes expired samples. Caller must hold ft.mu.
 func (ft *FlowTracker) evict(assetID string) {
 	cutoff := time.Now().Add(-ft.window)
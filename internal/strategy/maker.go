@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"time"
 
 	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/ws"
+
+	"github.com/GoPolymarket/polymarket-trader/internal/feed"
 )
 
 type MakerConfig struct {
@@ -17,8 +20,38 @@ type MakerConfig struct {
 	InventorySkewBps     float64 // default 30
 	InventoryWidenFactor float64 // default 0.5
 	MinOrderSizeUSDC     float64 // default 5
+
+	// VolSpreadMultiplier scales the quoted spread with recent mid volatility
+	// (multiplier × stddev of mid returns, in bps), floored at MinSpreadBps and
+	// capped at VolSpreadMaxBps when that is set. 0 disables.
+	VolSpreadMultiplier float64
+	VolSpreadMaxBps     float64
+
+	// PostFillPause keeps an asset unquoted for this long after a fill.
+	PostFillPause time.Duration
+
+	// UsePairFairValue quotes around PairFairValue when the complementary
+	// token's mid is known, instead of this book's mid alone.
+	UsePairFairValue bool
+
+	// QuoteMode anchors the quotes: QuoteModeMid (the default) spreads them
+	// about the mid, QuoteModeJoin rests on the best bid and ask, and
+	// QuoteModeImprove one TickSize inside them. TickSize defaults to
+	// DefaultTickSize.
+	QuoteMode string
+	TickSize  float64
 }
 
+// Maker quote modes.
+const (
+	QuoteModeMid     = "mid"
+	QuoteModeJoin    = "join"
+	QuoteModeImprove = "improve"
+)
+
+// DefaultTickSize is the price increment of a standard Polymarket book.
+const DefaultTickSize = 0.01
+
 type InventoryState struct {
 	NetPosition   float64
 	MaxPosition   float64
@@ -33,15 +66,77 @@ type Quote struct {
 }
 
 type Maker struct {
-	cfg MakerConfig
+	cfg       MakerConfig
+	vol       *VolatilityEstimator
+	midMethod string // see SetMidMethod
 }
 
 func NewMaker(cfg MakerConfig) *Maker {
 	return &Maker{cfg: cfg}
 }
 
+// SetConfig replaces the quoting parameters. It must not race with ComputeQuote.
+func (m *Maker) SetConfig(cfg MakerConfig) {
+	m.cfg = cfg
+}
+
+// MaxOrdersPerMarket returns the cap on open orders per asset; 0 is uncapped.
+func (m *Maker) MaxOrdersPerMarket() int {
+	return m.cfg.MaxOrdersPerMarket
+}
+
+// PostFillPause returns how long to stop quoting an asset after it fills.
+func (m *Maker) PostFillPause() time.Duration {
+	return m.cfg.PostFillPause
+}
+
+// SetVolatility attaches the estimator that volatility-scaled spreads read
+// from. The caller feeds it; a nil estimator disables volatility scaling.
+func (m *Maker) SetVolatility(v *VolatilityEstimator) {
+	m.vol = v
+}
+
+// SetMidMethod selects the reference mid quotes are centred on (a
+// feed.BookMid method). The market spread is still measured against the
+// simple mid.
+func (m *Maker) SetMidMethod(method string) {
+	m.midMethod = method
+}
+
+// baseSpreadBps is the full spread floor before market-spread and inventory
+// adjustments: MinSpreadBps, widened by recent volatility when enabled.
+func (m *Maker) baseSpreadBps(assetID string) float64 {
+	spread := m.cfg.MinSpreadBps
+	if m.vol == nil || m.cfg.VolSpreadMultiplier <= 0 {
+		return spread
+	}
+	sigma, ok := m.vol.StdDevBps(assetID)
+	if !ok {
+		return spread
+	}
+	spread = math.Max(spread, m.cfg.VolSpreadMultiplier*sigma)
+	if m.cfg.VolSpreadMaxBps > 0 {
+		spread = math.Min(spread, math.Max(m.cfg.VolSpreadMaxBps, m.cfg.MinSpreadBps))
+	}
+	return spread
+}
+
+// PairFairValue blends a binary token's mid with the price implied by its
+// complement: (mid + (1 - counterpartMid)) / 2.
+func PairFairValue(mid, counterpartMid float64) float64 {
+	return (mid + (1 - counterpartMid)) / 2
+}
+
 // ComputeQuote calculates bid/ask prices with optional inventory adjustment.
 func (m *Maker) ComputeQuote(book ws.OrderbookEvent, inv ...InventoryState) (Quote, error) {
+	return m.ComputeQuoteWithCounterpart(book, 0, inv...)
+}
+
+// ComputeQuoteWithCounterpart is ComputeQuote with the mid of the token's
+// YES/NO complement. With UsePairFairValue set and counterpartMid > 0 the
+// reference price becomes the pair fair value, kept inside the touch so the
+// quotes never cross the book.
+func (m *Maker) ComputeQuoteWithCounterpart(book ws.OrderbookEvent, counterpartMid float64, inv ...InventoryState) (Quote, error) {
 	if len(book.Bids) == 0 || len(book.Asks) == 0 {
 		return Quote{}, fmt.Errorf("empty book for %s", book.AssetID)
 	}
@@ -60,8 +155,16 @@ func (m *Maker) ComputeQuote(book ws.OrderbookEvent, inv ...InventoryState) (Quo
 
 	mid := (bestBid + bestAsk) / 2
 	marketSpreadBps := (bestAsk - bestBid) / mid * 10000
+	if ref, err := feed.BookMid(book, m.midMethod); err == nil {
+		mid = ref
+	}
+	if m.cfg.UsePairFairValue && counterpartMid > 0 && counterpartMid < 1 {
+		mid = math.Min(math.Max(PairFairValue(mid, counterpartMid), bestBid), bestAsk)
+	}
 
-	halfSpreadBps := math.Max(m.cfg.MinSpreadBps/2, marketSpreadBps*m.cfg.SpreadMultiplier/2)
+	minHalfSpreadBps := m.baseSpreadBps(book.AssetID) / 2
+	halfSpreadBps := math.Max(minHalfSpreadBps, marketSpreadBps*m.cfg.SpreadMultiplier/2)
+	skew := 0.0
 
 	size := m.cfg.OrderSizeUSDC
 
@@ -78,11 +181,13 @@ func (m *Maker) ComputeQuote(book ws.OrderbookEvent, inv ...InventoryState) (Quo
 
 		// Skew midpoint: if long, shift mid down (sell cheaper to reduce inventory).
 		skewBps := invRatio * m.cfg.InventorySkewBps
-		mid -= mid * skewBps / 10000
+		skew = mid * skewBps / 10000
+		mid -= skew
 
 		// Widen spread at high inventory.
 		widening := 1 + math.Abs(invRatio)*m.cfg.InventoryWidenFactor
 		halfSpreadBps *= widening
+		minHalfSpreadBps *= widening
 
 		// Reduce size at high inventory.
 		size *= (1 - math.Abs(invRatio)*0.5)
@@ -91,10 +196,15 @@ func (m *Maker) ComputeQuote(book ws.OrderbookEvent, inv ...InventoryState) (Quo
 		}
 	}
 
-	halfSpread := mid * halfSpreadBps / 10000
-
-	buyPrice := mid - halfSpread
-	sellPrice := mid + halfSpread
+	var buyPrice, sellPrice float64
+	switch m.cfg.QuoteMode {
+	case QuoteModeJoin, QuoteModeImprove:
+		buyPrice, sellPrice = m.touchQuote(bestBid, bestAsk, skew, minHalfSpreadBps)
+	default:
+		halfSpread := mid * halfSpreadBps / 10000
+		buyPrice = mid - halfSpread
+		sellPrice = mid + halfSpread
+	}
 
 	if buyPrice <= 0 {
 		buyPrice = 0.01
@@ -110,3 +220,83 @@ func (m *Maker) ComputeQuote(book ws.OrderbookEvent, inv ...InventoryState) (Quo
 		Size:      size,
 	}, nil
 }
+
+// SnapToTick moves q's prices onto the tick grid the CLOB accepts, the bid
+// down and the ask up so snapping never tightens the spread. Prices stay
+// within one tick of the [0,1] bounds. A non-positive tick uses
+// DefaultTickSize.
+func SnapToTick(q Quote, tick float64) Quote {
+	if tick <= 0 {
+		tick = DefaultTickSize
+	}
+	q.BuyPrice = math.Max(math.Floor(q.BuyPrice/tick+1e-9), 1) * tick
+	q.SellPrice = math.Min(math.Ceil(q.SellPrice/tick-1e-9), math.Round(1/tick)-1) * tick
+	q.BuyPrice = math.Round(q.BuyPrice*1e8) / 1e8
+	q.SellPrice = math.Round(q.SellPrice*1e8) / 1e8
+	return q
+}
+
+// touchQuote prices a join or improve quote off the best bid and ask. Improve
+// falls back to join when the spread is too narrow to step both sides in and
+// keep a tick between them. The inventory skew shifts both prices, the pair is then widened about
+// its centre to at least minHalfSpreadBps a side, and finally each price is
+// kept from crossing the book so the quotes stay post-only.
+func (m *Maker) touchQuote(bestBid, bestAsk, skew, minHalfSpreadBps float64) (buy, sell float64) {
+	tick := m.cfg.TickSize
+	if tick <= 0 {
+		tick = DefaultTickSize
+	}
+	buy, sell = bestBid, bestAsk
+	if m.cfg.QuoteMode == QuoteModeImprove && bestAsk-bestBid > 3*tick-1e-9 {
+		buy, sell = bestBid+tick, bestAsk-tick
+	}
+	buy -= skew
+	sell -= skew
+	center := (buy + sell) / 2
+	if minHalf := center * minHalfSpreadBps / 10000; sell-buy < 2*minHalf {
+		buy, sell = center-minHalf, center+minHalf
+	}
+	buy = math.Min(buy, bestAsk-tick)
+	sell = math.Max(sell, bestBid+tick)
+	return buy, sell
+}
+
+// ApplyFeeFloor widens q evenly about its mid until the spread covers twice
+// feeRateBps, the fee on each leg of a round trip. It reports whether q was
+// widened.
+func ApplyFeeFloor(q Quote, feeRateBps float64) (Quote, bool) {
+	if feeRateBps <= 0 || q.BuyPrice+q.SellPrice <= 0 {
+		return q, false
+	}
+	minSpread := feeRateBps * 2 / 10000
+	mid := (q.BuyPrice + q.SellPrice) / 2
+	if (q.SellPrice-q.BuyPrice)/mid >= minSpread {
+		return q, false
+	}
+	halfMin := mid * minSpread / 2
+	q.BuyPrice = mid - halfMin
+	q.SellPrice = mid + halfMin
+	return q, true
+}
+
+// RoundSizeDown rounds size down to a whole multiple of increment, so an
+// order never exceeds what was sized for it. A non-positive increment leaves
+// size unchanged.
+func RoundSizeDown(size, increment float64) float64 {
+	if increment <= 0 || size <= 0 {
+		return size
+	}
+	// The epsilon keeps sizes already on a multiple, like 0.3/0.1, from
+	// flooring one step short.
+	steps := math.Floor(size/increment + 1e-9)
+	return math.Round(steps*increment*1e8) / 1e8
+}
+
+// QuotePreview is a maker quote computed off the trading loop, with the fee
+// floor that was applied to it.
+type QuotePreview struct {
+	Quote       Quote
+	RawQuote    Quote // before the fee floor
+	FeeRateBps  float64
+	FeeAdjusted bool
+}
@@ -35,6 +35,34 @@ func TestMakerQuote(t *testing.T) {
 	}
 }
 
+func TestMakerCentresQuotesOnMicroprice(t *testing.T) {
+	cfg := MakerConfig{MinSpreadBps: 20, SpreadMultiplier: 1.5, OrderSizeUSDC: 25}
+	// A heavy bid puts the microprice at (0.50*100 + 0.52*300) / 400 = 0.515.
+	book := ws.OrderbookEvent{
+		AssetID: "token-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "300"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "100"}},
+	}
+
+	simple, err := NewMaker(cfg).ComputeQuote(book)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewMaker(cfg)
+	m.SetMidMethod("microprice")
+	micro, err := m.ComputeQuote(book)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if centre := (micro.BuyPrice + micro.SellPrice) / 2; math.Abs(centre-0.515) > 1e-9 {
+		t.Fatalf("expected quotes centred on the microprice 0.515, got %f", centre)
+	}
+	if micro.BuyPrice <= simple.BuyPrice || micro.SellPrice <= simple.SellPrice {
+		t.Fatalf("expected both quotes shifted toward the ask: simple=%+v micro=%+v", simple, micro)
+	}
+}
+
 func TestMakerSkipsEmptyBook(t *testing.T) {
 	m := NewMaker(MakerConfig{MinSpreadBps: 20, SpreadMultiplier: 1.5, OrderSizeUSDC: 25})
 	book := ws.OrderbookEvent{AssetID: "token-1"}
@@ -235,3 +263,218 @@ func TestMakerMinSizeFloor(t *testing.T) {
 		t.Fatalf("expected min size floor 5, got %f", quote.Size)
 	}
 }
+
+func TestMakerVolatilityWidensQuote(t *testing.T) {
+	cfg := MakerConfig{
+		MinSpreadBps:        20,
+		SpreadMultiplier:    0.5,
+		OrderSizeUSDC:       25,
+		VolSpreadMultiplier: 2,
+		VolSpreadMaxBps:     400,
+	}
+	book := ws.OrderbookEvent{
+		AssetID: "token-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.499", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.501", Size: "100"}},
+	}
+	quoteWidth := func(mids []float64) float64 {
+		vol := NewVolatilityEstimator(20)
+		for _, mid := range mids {
+			vol.Observe("token-1", mid)
+		}
+		m := NewMaker(cfg)
+		m.SetVolatility(vol)
+		q, err := m.ComputeQuote(book)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return q.SellPrice - q.BuyPrice
+	}
+
+	calm := quoteWidth([]float64{0.500, 0.5001, 0.500, 0.5001, 0.500})
+	choppy := quoteWidth([]float64{0.50, 0.52, 0.49, 0.53, 0.48})
+	if math.Abs(calm-0.001) > 1e-9 {
+		t.Fatalf("expected calm market to quote the 20bps floor, got width %f", calm)
+	}
+	if choppy <= calm {
+		t.Fatalf("expected choppy market to widen the quote: calm=%f choppy=%f", calm, choppy)
+	}
+	if choppy > 0.5*400/10000+1e-9 {
+		t.Fatalf("expected width capped at vol_spread_max_bps, got %f", choppy)
+	}
+
+	cfg.VolSpreadMultiplier = 0
+	if off := quoteWidth([]float64{0.50, 0.52, 0.49, 0.53, 0.48}); math.Abs(off-calm) > 1e-9 {
+		t.Fatalf("expected unchanged quote with volatility scaling disabled, got %f", off)
+	}
+}
+
+func TestMakerPairFairValueShiftsQuote(t *testing.T) {
+	book := ws.OrderbookEvent{
+		AssetID: "yes",
+		Bids:    []ws.OrderbookLevel{{Price: "0.48", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "100"}},
+	}
+	center := func(cfg MakerConfig, counterpartMid float64) float64 {
+		q, err := NewMaker(cfg).ComputeQuoteWithCounterpart(book, counterpartMid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return (q.BuyPrice + q.SellPrice) / 2
+	}
+	cfg := MakerConfig{MinSpreadBps: 20, SpreadMultiplier: 1, OrderSizeUSDC: 5, UsePairFairValue: true}
+
+	if got := PairFairValue(0.50, 0.48); math.Abs(got-0.51) > 1e-9 {
+		t.Fatalf("expected fair value 0.51, got %f", got)
+	}
+	if got := center(cfg, 0.48); math.Abs(got-0.51) > 1e-9 {
+		t.Fatalf("expected quotes centered on the 0.51 fair value, got %f", got)
+	}
+	if got := center(cfg, 0.30); math.Abs(got-0.52) > 1e-9 {
+		t.Fatalf("expected fair value clamped to the best ask, got %f", got)
+	}
+	if got := center(cfg, 0); math.Abs(got-0.50) > 1e-9 {
+		t.Fatalf("expected book mid without a counterpart, got %f", got)
+	}
+	cfg.UsePairFairValue = false
+	if got := center(cfg, 0.48); math.Abs(got-0.50) > 1e-9 {
+		t.Fatalf("expected book mid when disabled, got %f", got)
+	}
+}
+
+func TestSnapToTick(t *testing.T) {
+	got := SnapToTick(Quote{BuyPrice: 0.5049, SellPrice: 0.5151}, 0.01)
+	if got.BuyPrice != 0.5 || got.SellPrice != 0.52 {
+		t.Fatalf("expected 0.50/0.52, got %.4f/%.4f", got.BuyPrice, got.SellPrice)
+	}
+	got = SnapToTick(Quote{BuyPrice: 0.003, SellPrice: 0.9995}, 0)
+	if got.BuyPrice != 0.01 || got.SellPrice != 0.99 {
+		t.Fatalf("expected prices kept a tick inside the bounds, got %.4f/%.4f", got.BuyPrice, got.SellPrice)
+	}
+	if got = SnapToTick(Quote{BuyPrice: 0.3, SellPrice: 0.7}, 0.1); got.BuyPrice != 0.3 || got.SellPrice != 0.7 {
+		t.Fatalf("expected on-tick prices unchanged, got %.4f/%.4f", got.BuyPrice, got.SellPrice)
+	}
+}
+
+func TestRoundSizeDown(t *testing.T) {
+	cases := []struct {
+		size, inc, want float64
+	}{
+		{7.3, 0.5, 7.0},
+		{7.0, 0.5, 7.0},
+		{0.3, 0.1, 0.3},
+		{0.4, 0.5, 0},
+		{7.3, 0, 7.3},
+	}
+	for _, c := range cases {
+		if got := RoundSizeDown(c.size, c.inc); got != c.want {
+			t.Errorf("RoundSizeDown(%v, %v) = %v, want %v", c.size, c.inc, got, c.want)
+		}
+	}
+}
+
+func TestApplyFeeFloor(t *testing.T) {
+	q := Quote{AssetID: "a", BuyPrice: 0.50, SellPrice: 0.52, Size: 5}
+
+	got, adjusted := ApplyFeeFloor(q, 300)
+	if !adjusted || math.Abs(got.BuyPrice-0.4947) > 1e-9 || math.Abs(got.SellPrice-0.5253) > 1e-9 || got.Size != 5 {
+		t.Fatalf("expected 0.4947/0.5253 for a 300 bps fee, got %+v adjusted=%v", got, adjusted)
+	}
+	if got, adjusted := ApplyFeeFloor(q, 100); adjusted || got != q {
+		t.Fatalf("expected a spread covering the fee left alone, got %+v", got)
+	}
+	if got, adjusted := ApplyFeeFloor(q, 0); adjusted || got != q {
+		t.Fatalf("expected no fee to leave the quote alone, got %+v", got)
+	}
+}
+
+func TestMakerQuoteModes(t *testing.T) {
+	book := ws.OrderbookEvent{
+		AssetID: "token-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.55", Size: "100"}},
+	}
+	base := MakerConfig{MinSpreadBps: 20, SpreadMultiplier: 1.5, OrderSizeUSDC: 10}
+
+	cases := []struct {
+		mode      string
+		buy, sell float64
+	}{
+		{QuoteModeJoin, 0.50, 0.55},
+		{QuoteModeImprove, 0.51, 0.54},
+	}
+	for _, tc := range cases {
+		cfg := base
+		cfg.QuoteMode = tc.mode
+		q, err := NewMaker(cfg).ComputeQuote(book)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.mode, err)
+		}
+		if math.Abs(q.BuyPrice-tc.buy) > 1e-9 || math.Abs(q.SellPrice-tc.sell) > 1e-9 {
+			t.Errorf("%s: expected %.2f/%.2f, got %f/%f", tc.mode, tc.buy, tc.sell, q.BuyPrice, q.SellPrice)
+		}
+	}
+
+	// Mid is the default and spreads about the mid as before.
+	def, _ := NewMaker(base).ComputeQuote(book)
+	cfg := base
+	cfg.QuoteMode = QuoteModeMid
+	mid, _ := NewMaker(cfg).ComputeQuote(book)
+	if def != mid {
+		t.Fatalf("expected mid mode to match the default, got %+v vs %+v", mid, def)
+	}
+	halfSpread := 0.525 * (0.05 / 0.525 * 10000 * 1.5 / 2) / 10000
+	if math.Abs(mid.BuyPrice-(0.525-halfSpread)) > 1e-9 || math.Abs(mid.SellPrice-(0.525+halfSpread)) > 1e-9 {
+		t.Fatalf("unexpected mid quote %+v", mid)
+	}
+}
+
+func TestMakerImproveJoinsNarrowBook(t *testing.T) {
+	book := ws.OrderbookEvent{
+		AssetID: "token-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "100"}},
+	}
+	q, err := NewMaker(MakerConfig{SpreadMultiplier: 1, OrderSizeUSDC: 10, QuoteMode: QuoteModeImprove}).ComputeQuote(book)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(q.BuyPrice-0.50) > 1e-9 || math.Abs(q.SellPrice-0.52) > 1e-9 {
+		t.Fatalf("expected a two-tick book to be joined, got %f/%f", q.BuyPrice, q.SellPrice)
+	}
+}
+
+func TestMakerJoinKeepsMinSpreadAndPostOnly(t *testing.T) {
+	book := ws.OrderbookEvent{
+		AssetID: "token-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.51", Size: "100"}},
+	}
+	q, err := NewMaker(MakerConfig{MinSpreadBps: 500, SpreadMultiplier: 1, OrderSizeUSDC: 10, QuoteMode: QuoteModeJoin}).ComputeQuote(book)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 500 bps about the 0.505 centre is wider than the one-tick touch.
+	half := 0.505 * 250 / 10000
+	if math.Abs(q.BuyPrice-(0.505-half)) > 1e-9 || math.Abs(q.SellPrice-(0.505+half)) > 1e-9 {
+		t.Fatalf("expected the join quote widened to the spread floor, got %f/%f", q.BuyPrice, q.SellPrice)
+	}
+
+	// A short skews both quotes up; the bid must still rest below the ask.
+	wide := ws.OrderbookEvent{
+		AssetID: "token-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "100"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.54", Size: "100"}},
+	}
+	m := NewMaker(MakerConfig{SpreadMultiplier: 1, OrderSizeUSDC: 10, InventorySkewBps: 1000, QuoteMode: QuoteModeImprove})
+	q, err = m.ComputeQuote(wide, InventoryState{NetPosition: -100, MaxPosition: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.BuyPrice > 0.53+1e-9 {
+		t.Fatalf("expected the skewed bid held a tick under the 0.54 ask, got %f", q.BuyPrice)
+	}
+	if q.SellPrice <= q.BuyPrice {
+		t.Fatalf("expected sell above buy, got %f/%f", q.BuyPrice, q.SellPrice)
+	}
+}
@@ -0,0 +1,53 @@
+package strategy
+
+import "math"
+
+// neutralProfitScore is the profitability score of a market with no trading
+// history, halfway between deprioritize and focus.
+const neutralProfitScore = 50.0
+
+// MarketProfitScore rates a market's realized performance from 0 to 100:
+// realized PnL per fill and in total move the score from a neutral 50, a
+// long fill history adds confidence, and a market that takes most of the
+// fills while losing money is penalized.
+func MarketProfitScore(realizedPnL float64, fills int, fillSharePct float64) float64 {
+	score := neutralProfitScore
+	if fills > 0 {
+		score += clampf((realizedPnL/float64(fills))*40, -30, 30)
+	}
+	score += clampf(realizedPnL*6, -20, 20)
+	if fills >= 10 {
+		score += 10
+	} else if fills < 3 {
+		score -= 5
+	}
+	if fillSharePct >= 50 && realizedPnL < 0 {
+		score -= 15
+	}
+	return clampf(score, 0, 100)
+}
+
+// blendProfitability rescores candidates as a weighted mix of their
+// liquidity score, normalized to the best candidate, and their profitability
+// score (0–100, neutral when unknown). Both terms are in [0, 1].
+func blendProfitability(candidates []MarketCandidate, profit map[string]float64, weight float64) {
+	var best float64
+	for _, c := range candidates {
+		best = math.Max(best, c.Score)
+	}
+	for i := range candidates {
+		liquidity := 0.0
+		if best > 0 {
+			liquidity = candidates[i].Score / best
+		}
+		p, ok := profit[candidates[i].TokenID]
+		if !ok {
+			p = neutralProfitScore
+		}
+		candidates[i].Score = (1-weight)*liquidity + weight*p/100
+	}
+}
+
+func clampf(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
@@ -3,8 +3,10 @@ package strategy
 import (
 	"context"
 	"math"
+	"slices"
 	"sort"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/clobtypes"
@@ -64,6 +66,44 @@ type MarketCandidate struct {
 	Spread     float64
 	EndDate    time.Time
 	Score      float64
+
+	// VolatilityBps is the standard deviation of the market's recent price
+	// returns, or 0 when no price history was consulted.
+	VolatilityBps float64
+}
+
+// SelectorFilters reports, per filter, whether a market passed it.
+// Volatility is only measured for markets that pass the other filters and
+// counts as passed when it was not measured.
+type SelectorFilters struct {
+	Liquidity  bool
+	Volume24hr bool
+	Spread     bool
+	DaysToEnd  bool
+	Volatility bool
+}
+
+// Passed reports whether every filter passed.
+func (f SelectorFilters) Passed() bool {
+	return f.Liquidity && f.Volume24hr && f.Spread && f.DaysToEnd && f.Volatility
+}
+
+// CandidateEvaluation is one market as seen by the last Select run: its
+// inputs, the filters it passed, and whether any of its tokens made the top
+// N.
+type CandidateEvaluation struct {
+	MarketID   string
+	Question   string
+	TokenIDs   []string
+	Volume24hr float64
+	Liquidity  float64
+	Spread     float64
+	DaysToEnd  float64
+	Score      float64 // before profitability blending; 0 when filtered out
+
+	VolatilityBps float64
+	Filters       SelectorFilters
+	Selected      bool
 }
 
 // SelectorConfig controls Gamma-based market selection.
@@ -73,17 +113,72 @@ type SelectorConfig struct {
 	MinVolume24hr  float64
 	MaxSpread      float64
 	MinDaysToEnd   int
+
+	// ProfitabilityWeight (0–1) blends each token's profitability score, set
+	// with SetProfitScores, into the liquidity ranking. 0 ranks on liquidity
+	// alone.
+	ProfitabilityWeight float64
+
+	// MinVolatility and MaxVolatility bound the standard deviation, in bps,
+	// of a market's returns over its recent price history. Markets outside
+	// the band are excluded; 0 leaves that side open. The band needs a
+	// PriceHistorySource.
+	MinVolatility float64
+	MaxVolatility float64
+}
+
+// PriceHistorySource supplies a token's recent prices, oldest first.
+type PriceHistorySource interface {
+	PriceHistory(ctx context.Context, tokenID string) ([]float64, error)
 }
 
 // GammaSelector uses the Gamma API to find the best markets.
 type GammaSelector struct {
 	gammaClient gamma.Client
 	cfg         SelectorConfig
+	history     PriceHistorySource
+
+	mu     sync.Mutex
+	profit map[string]float64 // tokenID → profitability score (0–100)
+
+	lastRun  time.Time
+	lastEval []CandidateEvaluation
 }
 
-// NewGammaSelector creates a GammaSelector.
+// NewGammaSelector creates a GammaSelector. A Gamma client that also
+// implements PriceHistorySource is used for the volatility band.
 func NewGammaSelector(gammaClient gamma.Client, cfg SelectorConfig) *GammaSelector {
-	return &GammaSelector{gammaClient: gammaClient, cfg: cfg}
+	s := &GammaSelector{gammaClient: gammaClient, cfg: cfg}
+	if h, ok := gammaClient.(PriceHistorySource); ok {
+		s.history = h
+	}
+	return s
+}
+
+// SetPriceHistory sets the source of price history for the volatility band.
+func (s *GammaSelector) SetPriceHistory(src PriceHistorySource) {
+	s.history = src
+}
+
+// HasPriceHistory reports whether the volatility band can be applied.
+func (s *GammaSelector) HasPriceHistory() bool {
+	return s.history != nil
+}
+
+// SetProfitScores replaces the per-token profitability scores used by
+// ProfitabilityWeight. Tokens without a score are treated as neutral.
+func (s *GammaSelector) SetProfitScores(scores map[string]float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.profit = scores
+}
+
+// LastEvaluation returns when Select last ran successfully and every market
+// it evaluated, in the order Gamma returned them.
+func (s *GammaSelector) LastEvaluation() (time.Time, []CandidateEvaluation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastRun, slices.Clone(s.lastEval)
 }
 
 // Select queries Gamma for active markets, filters and scores them, and returns the top N.
@@ -102,6 +197,7 @@ func (s *GammaSelector) Select(ctx context.Context, topN int) ([]MarketCandidate
 
 	now := time.Now()
 	var candidates []MarketCandidate
+	evals := make([]CandidateEvaluation, 0, len(markets))
 
 	for _, m := range markets {
 		vol, _ := strconv.ParseFloat(m.Volume24hr, 64)
@@ -111,17 +207,29 @@ func (s *GammaSelector) Select(ctx context.Context, topN int) ([]MarketCandidate
 
 		daysToEnd := endDate.Sub(now).Hours() / 24
 
-		// Apply filters.
-		if liq < s.cfg.MinLiquidity {
-			continue
-		}
-		if vol < s.cfg.MinVolume24hr {
-			continue
+		tokens := m.ParsedTokens()
+		eval := CandidateEvaluation{
+			MarketID:   m.ConditionID,
+			Question:   m.Question,
+			Volume24hr: vol,
+			Liquidity:  liq,
+			Spread:     sprd,
+			DaysToEnd:  daysToEnd,
+			Filters: SelectorFilters{
+				Liquidity:  liq >= s.cfg.MinLiquidity,
+				Volume24hr: vol >= s.cfg.MinVolume24hr,
+				Spread:     s.cfg.MaxSpread <= 0 || sprd <= s.cfg.MaxSpread,
+				DaysToEnd:  daysToEnd >= float64(s.cfg.MinDaysToEnd),
+				Volatility: true,
+			},
 		}
-		if sprd > s.cfg.MaxSpread && s.cfg.MaxSpread > 0 {
-			continue
+		for _, tok := range tokens {
+			eval.TokenIDs = append(eval.TokenIDs, tok.TokenID)
 		}
-		if daysToEnd < float64(s.cfg.MinDaysToEnd) {
+
+		// Apply filters.
+		if !eval.Filters.Passed() {
+			evals = append(evals, eval)
 			continue
 		}
 
@@ -131,10 +239,26 @@ func (s *GammaSelector) Select(ctx context.Context, topN int) ([]MarketCandidate
 			timeDecay = 0
 		}
 
+		// The YES and NO prices mirror each other, so one token's history
+		// stands for the market. Markets without usable history are kept.
+		var volBps float64
+		if s.volatilityBand() && len(tokens) > 0 {
+			if v, ok := s.historyVolatility(ctx, tokens[0].TokenID); ok {
+				volBps = v
+				eval.VolatilityBps = v
+				if v < s.cfg.MinVolatility || (s.cfg.MaxVolatility > 0 && v > s.cfg.MaxVolatility) {
+					eval.Filters.Volatility = false
+					evals = append(evals, eval)
+					continue
+				}
+			}
+		}
+
 		// Score: higher volume, higher liquidity, lower spread → better.
 		score := vol * liq / (sprd + 0.001) * timeDecay
+		eval.Score = score
+		evals = append(evals, eval)
 
-		tokens := m.ParsedTokens()
 		for _, tok := range tokens {
 			candidates = append(candidates, MarketCandidate{
 				TokenID:    tok.TokenID,
@@ -145,10 +269,18 @@ func (s *GammaSelector) Select(ctx context.Context, topN int) ([]MarketCandidate
 				Spread:     sprd,
 				EndDate:    endDate,
 				Score:      score,
+
+				VolatilityBps: volBps,
 			})
 		}
 	}
 
+	if w := s.cfg.ProfitabilityWeight; w > 0 {
+		s.mu.Lock()
+		blendProfitability(candidates, s.profit, w)
+		s.mu.Unlock()
+	}
+
 	sort.Slice(candidates, func(i, j int) bool {
 		return candidates[i].Score > candidates[j].Score
 	})
@@ -156,7 +288,36 @@ func (s *GammaSelector) Select(ctx context.Context, topN int) ([]MarketCandidate
 	if topN > len(candidates) {
 		topN = len(candidates)
 	}
-	return candidates[:topN], nil
+	selected := candidates[:topN]
+	for i := range evals {
+		evals[i].Selected = slices.ContainsFunc(selected, func(c MarketCandidate) bool {
+			return slices.Contains(evals[i].TokenIDs, c.TokenID)
+		})
+	}
+	s.mu.Lock()
+	s.lastRun = now
+	s.lastEval = evals
+	s.mu.Unlock()
+	return selected, nil
+}
+
+func (s *GammaSelector) volatilityBand() bool {
+	return s.history != nil && (s.cfg.MinVolatility > 0 || s.cfg.MaxVolatility > 0)
+}
+
+// historyVolatility measures tokenID's price history the same way the maker
+// measures live mids. ok is false when the history is unavailable or too
+// short.
+func (s *GammaSelector) historyVolatility(ctx context.Context, tokenID string) (float64, bool) {
+	prices, err := s.history.PriceHistory(ctx, tokenID)
+	if err != nil {
+		return 0, false
+	}
+	est := NewVolatilityEstimator(len(prices))
+	for _, p := range prices {
+		est.Observe(tokenID, p)
+	}
+	return est.StdDevBps(tokenID)
 }
 
 func intPtr(v int) *int { return &v }
@@ -2,6 +2,8 @@ package strategy
 
 import (
 	"context"
+	"errors"
+	"math"
 	"testing"
 	"time"
 
@@ -142,6 +144,58 @@ func TestGammaSelectorFilters(t *testing.T) {
 	}
 }
 
+func TestGammaSelectorLastEvaluationReportsFailedFilters(t *testing.T) {
+	endDate := time.Now().Add(60 * 24 * time.Hour).Format(time.RFC3339)
+	mock := &mockGammaClient{
+		markets: []gamma.Market{
+			{
+				ConditionID: "wide-spread", Volume24hr: "1000", Liquidity: "5000", Spread: "0.20", EndDate: endDate,
+				Tokens: []gamma.Token{{TokenID: "t-wide"}}, Active: true,
+			},
+			{
+				ConditionID: "good", Volume24hr: "1000", Liquidity: "5000", Spread: "0.05", EndDate: endDate,
+				Tokens: []gamma.Token{{TokenID: "t-good"}}, Active: true,
+			},
+		},
+	}
+	s := NewGammaSelector(mock, SelectorConfig{
+		MinLiquidity:  1000,
+		MinVolume24hr: 500,
+		MaxSpread:     0.10,
+		MinDaysToEnd:  2,
+	})
+
+	if ran, evals := s.LastEvaluation(); !ran.IsZero() || len(evals) != 0 {
+		t.Fatalf("expected no evaluation before Select, got %v %+v", ran, evals)
+	}
+	if _, err := s.Select(context.Background(), 10); err != nil {
+		t.Fatal(err)
+	}
+	ran, evals := s.LastEvaluation()
+	if ran.IsZero() || len(evals) != 2 {
+		t.Fatalf("expected 2 evaluated markets, got %v %+v", ran, evals)
+	}
+
+	wide := evals[0]
+	if wide.MarketID != "wide-spread" || wide.Spread != 0.20 || wide.Selected || wide.Score != 0 {
+		t.Fatalf("unexpected wide-spread evaluation %+v", wide)
+	}
+	if wide.Filters.Spread || !wide.Filters.Liquidity || !wide.Filters.Volume24hr || !wide.Filters.DaysToEnd {
+		t.Fatalf("expected only the spread filter to fail, got %+v", wide.Filters)
+	}
+	if wide.Filters.Passed() {
+		t.Fatal("expected wide-spread to fail overall")
+	}
+
+	good := evals[1]
+	if good.MarketID != "good" || !good.Filters.Passed() || !good.Selected || good.Score <= 0 {
+		t.Fatalf("unexpected good evaluation %+v", good)
+	}
+	if len(good.TokenIDs) != 1 || good.TokenIDs[0] != "t-good" || good.DaysToEnd < 59 {
+		t.Fatalf("expected token and days to end on good evaluation, got %+v", good)
+	}
+}
+
 func TestGammaSelectorTopN(t *testing.T) {
 	endDate := time.Now().Add(60 * 24 * time.Hour).Format(time.RFC3339)
 	mock := &mockGammaClient{
@@ -170,3 +224,114 @@ func TestGammaSelectorEmpty(t *testing.T) {
 		t.Fatalf("expected 0 candidates, got %d", len(candidates))
 	}
 }
+
+func TestGammaSelectorProfitabilityWeight(t *testing.T) {
+	endDate := time.Now().Add(60 * 24 * time.Hour).Format(time.RFC3339)
+	mock := &mockGammaClient{
+		markets: []gamma.Market{
+			{ID: "m1", Volume24hr: "5000", Liquidity: "10000", Spread: "0.05", EndDate: endDate, Tokens: []gamma.Token{{TokenID: "t-liquid"}}, Active: true},
+			{ID: "m2", Volume24hr: "1000", Liquidity: "5000", Spread: "0.03", EndDate: endDate, Tokens: []gamma.Token{{TokenID: "t-profitable"}}, Active: true},
+		},
+	}
+	cfg := SelectorConfig{MinLiquidity: 500, MinVolume24hr: 500, MaxSpread: 0.10, MinDaysToEnd: 2}
+	// 12 fills and +5 USDC realized on half the fills.
+	profit := map[string]float64{"t-profitable": MarketProfitScore(5, 12, 50)}
+
+	liquidOnly := NewGammaSelector(mock, cfg)
+	liquidOnly.SetProfitScores(profit)
+	candidates, err := liquidOnly.Select(context.Background(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if candidates[0].TokenID != "t-liquid" {
+		t.Fatalf("expected liquidity ranking without a weight, got %s first", candidates[0].TokenID)
+	}
+
+	cfg.ProfitabilityWeight = 0.7
+	weighted := NewGammaSelector(mock, cfg)
+	weighted.SetProfitScores(profit)
+	candidates, err = weighted.Select(context.Background(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if candidates[0].TokenID != "t-profitable" {
+		t.Fatalf("expected the profitable asset to rank first, got %s (scores %.3f, %.3f)", candidates[0].TokenID, candidates[0].Score, candidates[1].Score)
+	}
+}
+
+// historyGammaClient is a Gamma client that also serves price history.
+type historyGammaClient struct {
+	mockGammaClient
+	history map[string][]float64
+}
+
+func (m *historyGammaClient) PriceHistory(_ context.Context, tokenID string) ([]float64, error) {
+	prices, ok := m.history[tokenID]
+	if !ok {
+		return nil, errors.New("no history")
+	}
+	return prices, nil
+}
+
+func TestGammaSelectorVolatilityBand(t *testing.T) {
+	endDate := time.Now().Add(60 * 24 * time.Hour).Format(time.RFC3339)
+	market := func(id string) gamma.Market {
+		return gamma.Market{ID: id, Volume24hr: "1000", Liquidity: "5000", Spread: "0.05", EndDate: endDate,
+			Tokens: []gamma.Token{{TokenID: "t-" + id}}, Active: true}
+	}
+	alternate := func(a, b float64) []float64 {
+		var out []float64
+		for i := 0; i < 10; i++ {
+			out = append(out, a, b)
+		}
+		return out
+	}
+	mock := &historyGammaClient{
+		mockGammaClient: mockGammaClient{markets: []gamma.Market{market("flat"), market("lively"), market("wild"), market("unknown")}},
+		history: map[string][]float64{
+			"t-flat":   alternate(0.50, 0.50),
+			"t-lively": alternate(0.50, 0.51), // ±198 bps returns
+			"t-wild":   alternate(0.30, 0.60), // ±6931 bps returns
+		},
+	}
+
+	s := NewGammaSelector(mock, SelectorConfig{MinLiquidity: 500, MinVolume24hr: 500, MinVolatility: 50, MaxVolatility: 1000})
+	if !s.HasPriceHistory() {
+		t.Fatal("expected the Gamma client to serve price history")
+	}
+	candidates, err := s.Select(context.Background(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]float64)
+	for _, c := range candidates {
+		got[c.TokenID] = c.VolatilityBps
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected lively and unknown to pass the band, got %v", got)
+	}
+	if v, ok := got["t-lively"]; !ok || math.Abs(v-203) > 1 {
+		t.Fatalf("expected t-lively at ~203 bps, got %v", got)
+	}
+	if v, ok := got["t-unknown"]; !ok || v != 0 {
+		t.Fatalf("expected t-unknown kept without a volatility, got %v", got)
+	}
+
+	// Without a band every market is kept.
+	s = NewGammaSelector(mock, SelectorConfig{MinLiquidity: 500, MinVolume24hr: 500})
+	if candidates, _ = s.Select(context.Background(), 10); len(candidates) != 4 {
+		t.Fatalf("expected 4 candidates without a band, got %d", len(candidates))
+	}
+}
+
+func TestMarketProfitScore(t *testing.T) {
+	if got := MarketProfitScore(0, 0, 0); got != 45 {
+		t.Fatalf("expected untraded score 45, got %f", got)
+	}
+	if got := MarketProfitScore(5, 12, 50); got <= 90 {
+		t.Fatalf("expected a strong score for a consistently profitable market, got %f", got)
+	}
+	if got := MarketProfitScore(-5, 12, 60); got >= 10 {
+		t.Fatalf("expected a poor score for a dominant losing market, got %f", got)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/GoPolymarket/polymarket-go-sdk/pkg/clob/ws"
+
+	"github.com/GoPolymarket/polymarket-trader/internal/feed"
 )
 
 type TakerConfig struct {
@@ -17,6 +19,9 @@ type TakerConfig struct {
 	MaxSlippageBps   float64
 	Cooldown         time.Duration
 	MinConfidenceBps float64
+	// GlobalCooldown suppresses every signal, on any asset and side, for this
+	// long after any taker trade. Zero disables it.
+	GlobalCooldown time.Duration
 
 	FlowWeight        float64       // default 0.3
 	ImbalanceWeight   float64       // default 0.5
@@ -24,6 +29,16 @@ type TakerConfig struct {
 	MinConvergenceBps float64       // default 50
 	FlowWindow        time.Duration // default 2m
 	MinCompositeScore float64       // default 0.3
+	MomentumWeight    float64       // default 0 (momentum ignored)
+	MomentumWindow    time.Duration // default 1m
+
+	// ResetCooldownOnDailyReset clears still-active per-side cooldowns at the
+	// UTC day boundary. When false, only elapsed cooldowns are dropped.
+	ResetCooldownOnDailyReset bool
+
+	// RequireFeeCoverage drops EvaluateEnhanced signals whose expected edge
+	// does not exceed the round-trip fee (2x the asset's fee rate).
+	RequireFeeCoverage bool
 }
 
 type Signal struct {
@@ -33,49 +48,107 @@ type Signal struct {
 	MaxPrice   float64
 	Mid        float64
 	Imbalance  float64
+	Momentum   float64 // normalized mid rate-of-change in [-1, 1]
+	Score      float64 // composite score (EvaluateEnhanced only)
+
+	ExpectedEdgeBps float64 // expected move implied by Score (EvaluateEnhanced only)
+}
+
+// momentumFullScale is the relative mid change over the momentum window that
+// maps to a full-strength (±1) momentum reading.
+const momentumFullScale = 0.05
+
+// Signal record outcomes, one per EvaluateEnhanced call that got as far as
+// scoring.
+const (
+	SignalFired          = "fired"
+	SignalBelowThreshold = "below_threshold"
+	SignalFeeNotCovered  = "fee_not_covered"
+	SignalCoolingDown    = "cooldown"
+)
+
+// SignalRecord is one scored EvaluateEnhanced evaluation, fired or not, with
+// its component scores. Side is the direction the signal pointed in even when
+// it was suppressed.
+type SignalRecord struct {
+	Time        time.Time `json:"time"`
+	AssetID     string    `json:"asset_id"`
+	Side        string    `json:"side"`
+	Mid         float64   `json:"mid"`
+	Imbalance   float64   `json:"imbalance"`
+	Flow        float64   `json:"flow"`
+	Convergence float64   `json:"convergence"`
+	Momentum    float64   `json:"momentum"`
+	Composite   float64   `json:"composite"`
+	Outcome     string    `json:"outcome"`
+}
+
+// scoreEdgeBps is the price move, in basis points, expected from a composite
+// score of 1. A signal's expected edge scales linearly with its score.
+const scoreEdgeBps = 100
+
+type midSample struct {
+	at  time.Time
+	mid float64
 }
 
 type Taker struct {
 	cfg        TakerConfig
 	mu         sync.Mutex
-	lastTrades map[string]time.Time
+	lastTrades map[tradeKey]time.Time
+	lastAny    *lastTradeClock        // last trade on any asset, see ShareGlobalCooldown
+	mids       map[string][]midSample // assetID → recent mids, oldest first
+
+	dailyTrades   map[string]int     // assetID → trades since last daily reset
+	dailyNotional map[string]float64 // assetID → USDC traded since last daily reset
+
+	observer func(SignalRecord) // see SetSignalObserver
 }
 
 func NewTaker(cfg TakerConfig) *Taker {
 	return &Taker{
-		cfg:        cfg,
-		lastTrades: make(map[string]time.Time),
+		cfg:           cfg,
+		lastTrades:    make(map[tradeKey]time.Time),
+		lastAny:       &lastTradeClock{},
+		mids:          make(map[string][]midSample),
+		dailyTrades:   make(map[string]int),
+		dailyNotional: make(map[string]float64),
 	}
 }
 
-func (tk *Taker) Evaluate(book ws.OrderbookEvent) (*Signal, error) {
-	if len(book.Bids) == 0 || len(book.Asks) == 0 {
-		return nil, fmt.Errorf("empty book for %s", book.AssetID)
-	}
+// ShareGlobalCooldown makes tk and other start each other's global cooldown,
+// so per-market takers count against the same window as the global taker.
+func (tk *Taker) ShareGlobalCooldown(other *Taker) {
+	tk.mu.Lock()
+	defer tk.mu.Unlock()
+	tk.lastAny = other.lastAny
+}
 
+// SetSignalObserver registers fn to receive a SignalRecord for every
+// EvaluateEnhanced evaluation that is scored. fn runs synchronously on the
+// evaluating goroutine; nil removes the observer.
+func (tk *Taker) SetSignalObserver(fn func(SignalRecord)) {
 	tk.mu.Lock()
-	if last, ok := tk.lastTrades[book.AssetID]; ok && time.Since(last) < tk.cfg.Cooldown {
-		tk.mu.Unlock()
-		return nil, nil
-	}
-	tk.mu.Unlock()
+	defer tk.mu.Unlock()
+	tk.observer = fn
+}
 
-	var bidDepth, askDepth float64
-	for i := 0; i < tk.cfg.DepthLevels && i < len(book.Bids); i++ {
-		size, _ := strconv.ParseFloat(book.Bids[i].Size, 64)
-		bidDepth += size
-	}
-	for i := 0; i < tk.cfg.DepthLevels && i < len(book.Asks); i++ {
-		size, _ := strconv.ParseFloat(book.Asks[i].Size, 64)
-		askDepth += size
+// SetConfig replaces the taker parameters; cooldown and daily state are kept.
+func (tk *Taker) SetConfig(cfg TakerConfig) {
+	tk.mu.Lock()
+	defer tk.mu.Unlock()
+	tk.cfg = cfg
+}
+
+func (tk *Taker) Evaluate(book ws.OrderbookEvent) (*Signal, error) {
+	if len(book.Bids) == 0 || len(book.Asks) == 0 {
+		return nil, fmt.Errorf("empty book for %s", book.AssetID)
 	}
 
-	totalDepth := bidDepth + askDepth
-	if totalDepth == 0 {
+	imbalance, ok := feed.BookImbalance(book, tk.cfg.DepthLevels)
+	if !ok {
 		return nil, nil
 	}
-
-	imbalance := (bidDepth - askDepth) / totalDepth
 	if math.Abs(imbalance) < tk.cfg.MinImbalance {
 		return nil, nil
 	}
@@ -88,6 +161,9 @@ func (tk *Taker) Evaluate(book ws.OrderbookEvent) (*Signal, error) {
 	if imbalance < 0 {
 		side = "SELL"
 	}
+	if tk.coolingDown(book.AssetID, side) {
+		return nil, nil
+	}
 
 	delta := mid * tk.cfg.MaxSlippageBps / 10000
 	maxPrice := mid + delta
@@ -139,37 +215,25 @@ func (tk *Taker) DetectConvergence(yesPrice, noPrice float64) (signal string, ed
 }
 
 // EvaluateEnhanced combines imbalance, flow, and convergence signals for a composite score.
-func (tk *Taker) EvaluateEnhanced(book ws.OrderbookEvent, flow *FlowTracker, counterpartPrice float64) (*Signal, error) {
+// feeRateBps is the asset's taker fee rate; with RequireFeeCoverage set, signals
+// whose expected edge does not cover twice that rate are dropped.
+func (tk *Taker) EvaluateEnhanced(book ws.OrderbookEvent, flow *FlowTracker, counterpartPrice, feeRateBps float64) (*Signal, error) {
 	if len(book.Bids) == 0 || len(book.Asks) == 0 {
 		return nil, fmt.Errorf("empty book for %s", book.AssetID)
 	}
 
-	tk.mu.Lock()
-	if last, ok := tk.lastTrades[book.AssetID]; ok && time.Since(last) < tk.cfg.Cooldown {
-		tk.mu.Unlock()
-		return nil, nil
-	}
-	tk.mu.Unlock()
+	bestBid, _ := strconv.ParseFloat(book.Bids[0].Price, 64)
+	bestAsk, _ := strconv.ParseFloat(book.Asks[0].Price, 64)
+	mid := (bestBid + bestAsk) / 2
+
+	// Track mids even while cooling down so momentum stays current.
+	momentum := tk.recordMid(book.AssetID, mid, time.Now())
 
 	// Compute imbalance.
-	var bidDepth, askDepth float64
-	for i := 0; i < tk.cfg.DepthLevels && i < len(book.Bids); i++ {
-		size, _ := strconv.ParseFloat(book.Bids[i].Size, 64)
-		bidDepth += size
-	}
-	for i := 0; i < tk.cfg.DepthLevels && i < len(book.Asks); i++ {
-		size, _ := strconv.ParseFloat(book.Asks[i].Size, 64)
-		askDepth += size
-	}
-	totalDepth := bidDepth + askDepth
-	if totalDepth == 0 {
+	imbalance, ok := feed.BookImbalance(book, tk.cfg.DepthLevels)
+	if !ok {
 		return nil, nil
 	}
-	imbalance := (bidDepth - askDepth) / totalDepth
-
-	bestBid, _ := strconv.ParseFloat(book.Bids[0].Price, 64)
-	bestAsk, _ := strconv.ParseFloat(book.Asks[0].Price, 64)
-	mid := (bestBid + bestAsk) / 2
 
 	// Get flow signal.
 	var netFlow float64
@@ -194,12 +258,11 @@ func (tk *Taker) EvaluateEnhanced(book ws.OrderbookEvent, flow *FlowTracker, cou
 
 	composite := imbalanceW*math.Abs(imbalance) + flowW*math.Abs(netFlow) + convergenceW*convergenceEdge
 
-	minScore := tk.cfg.MinCompositeScore
-	if minScore == 0 {
-		minScore = 0.3
-	}
-	if composite < minScore {
-		return nil, nil
+	// Momentum strengthens the score when it agrees with the book/flow
+	// direction and dampens it when it conflicts.
+	momentumW := tk.cfg.MomentumWeight
+	if direction := imbalanceW*imbalance + flowW*netFlow; momentumW > 0 && direction != 0 {
+		composite = math.Max(composite+momentumW*momentum*math.Copysign(1, direction), 0)
 	}
 
 	// Determine direction from strongest signal.
@@ -216,10 +279,45 @@ func (tk *Taker) EvaluateEnhanced(book ws.OrderbookEvent, flow *FlowTracker, cou
 	} else {
 		sellScore += flowW * (-netFlow)
 	}
+	if momentum > 0 {
+		buyScore += momentumW * momentum
+	} else {
+		sellScore += momentumW * (-momentum)
+	}
 	if sellScore > buyScore {
 		side = "SELL"
 	}
 
+	record := SignalRecord{
+		Time:        time.Now(),
+		AssetID:     book.AssetID,
+		Side:        side,
+		Mid:         mid,
+		Imbalance:   imbalance,
+		Flow:        netFlow,
+		Convergence: convergenceEdge,
+		Momentum:    momentum,
+		Composite:   composite,
+	}
+	minScore := tk.cfg.MinCompositeScore
+	if minScore == 0 {
+		minScore = 0.3
+	}
+	if composite < minScore {
+		tk.observe(record, SignalBelowThreshold)
+		return nil, nil
+	}
+	expectedEdgeBps := composite * scoreEdgeBps
+	if tk.cfg.RequireFeeCoverage && expectedEdgeBps <= 2*feeRateBps {
+		tk.observe(record, SignalFeeNotCovered)
+		return nil, nil
+	}
+	if tk.coolingDown(book.AssetID, side) {
+		tk.observe(record, SignalCoolingDown)
+		return nil, nil
+	}
+	tk.observe(record, SignalFired)
+
 	// Adaptive sizing: scale up to 1.5x at high confidence.
 	amount := tk.cfg.AmountUSDC * math.Min(composite/0.5, 1.5)
 	if amount < tk.cfg.AmountUSDC*0.5 {
@@ -242,11 +340,132 @@ func (tk *Taker) EvaluateEnhanced(book ws.OrderbookEvent, flow *FlowTracker, cou
 		MaxPrice:   maxPrice,
 		Mid:        mid,
 		Imbalance:  imbalance,
+		Momentum:   momentum,
+		Score:      composite,
+
+		ExpectedEdgeBps: expectedEdgeBps,
 	}, nil
 }
 
-func (tk *Taker) RecordTrade(assetID string) {
+// observe passes rec with outcome to the signal observer, if one is set.
+func (tk *Taker) observe(rec SignalRecord, outcome string) {
+	tk.mu.Lock()
+	fn := tk.observer
+	tk.mu.Unlock()
+	if fn == nil {
+		return
+	}
+	rec.Outcome = outcome
+	fn(rec)
+}
+
+// recordMid appends a mid sample, drops samples older than the momentum
+// window, and returns the normalized rate of change from the oldest sample
+// still in the window.
+func (tk *Taker) recordMid(assetID string, mid float64, now time.Time) float64 {
 	tk.mu.Lock()
 	defer tk.mu.Unlock()
-	tk.lastTrades[assetID] = time.Now()
+	window := tk.cfg.MomentumWindow
+	if window <= 0 {
+		window = time.Minute
+	}
+	samples := append(tk.mids[assetID], midSample{at: now, mid: mid})
+	cutoff := now.Add(-window)
+	drop := 0
+	for drop < len(samples)-1 && samples[drop].at.Before(cutoff) {
+		drop++
+	}
+	samples = samples[drop:]
+	tk.mids[assetID] = samples
+
+	oldest := samples[0].mid
+	if len(samples) < 2 || oldest <= 0 {
+		return 0
+	}
+	roc := (mid - oldest) / oldest
+	return math.Max(-1, math.Min(1, roc/momentumFullScale))
+}
+
+// tradeKey identifies one side of an asset for cooldowns.
+type tradeKey struct {
+	assetID string
+	side    string
+}
+
+// lastTradeClock holds the time of the most recent taker trade on any asset.
+type lastTradeClock struct {
+	mu sync.Mutex
+	at time.Time
+}
+
+func (c *lastTradeClock) set(at time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.at = at
+}
+
+func (c *lastTradeClock) get() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.at
+}
+
+// coolingDown reports whether side of assetID traded within the cooldown, or
+// any asset traded within the global cooldown. The opposite side is
+// unaffected by the per-asset cooldown, so a reversal is not suppressed.
+func (tk *Taker) coolingDown(assetID, side string) bool {
+	tk.mu.Lock()
+	defer tk.mu.Unlock()
+	if tk.cfg.GlobalCooldown > 0 {
+		if last := tk.lastAny.get(); !last.IsZero() && time.Since(last) < tk.cfg.GlobalCooldown {
+			return true
+		}
+	}
+	last, ok := tk.lastTrades[tradeKey{assetID, side}]
+	return ok && time.Since(last) < tk.cfg.Cooldown
+}
+
+// RecordTrade starts the cooldown for one side of an asset and the global
+// cooldown, and accumulates the asset's daily counters.
+func (tk *Taker) RecordTrade(assetID, side string, amountUSDC float64) {
+	tk.mu.Lock()
+	defer tk.mu.Unlock()
+	now := time.Now()
+	tk.lastTrades[tradeKey{assetID, side}] = now
+	tk.lastAny.set(now)
+	tk.dailyTrades[assetID]++
+	tk.dailyNotional[assetID] += amountUSDC
+}
+
+// DailyTradeCount returns the number of trades recorded for an asset since the last daily reset.
+func (tk *Taker) DailyTradeCount(assetID string) int {
+	tk.mu.Lock()
+	defer tk.mu.Unlock()
+	return tk.dailyTrades[assetID]
+}
+
+// DailyNotional returns the USDC traded for an asset since the last daily reset.
+func (tk *Taker) DailyNotional(assetID string) float64 {
+	tk.mu.Lock()
+	defer tk.mu.Unlock()
+	return tk.dailyNotional[assetID]
+}
+
+// ResetDaily clears per-asset daily counters at the UTC day boundary.
+// Active cooldowns survive the reset unless ResetCooldownOnDailyReset is set;
+// cooldowns that have already elapsed are always dropped.
+func (tk *Taker) ResetDaily() {
+	tk.mu.Lock()
+	defer tk.mu.Unlock()
+	tk.dailyTrades = make(map[string]int)
+	tk.dailyNotional = make(map[string]float64)
+	if tk.cfg.ResetCooldownOnDailyReset {
+		tk.lastTrades = make(map[tradeKey]time.Time)
+		return
+	}
+	for key, last := range tk.lastTrades {
+		if time.Since(last) >= tk.cfg.Cooldown {
+			delete(tk.lastTrades, key)
+		}
+	}
 }
@@ -80,7 +80,7 @@ func TestTakerCooldown(t *testing.T) {
 	if sig1 == nil {
 		t.Fatal("expected first signal")
 	}
-	tk.RecordTrade("token-1")
+	tk.RecordTrade("token-1", sig1.Side, 20)
 
 	sig2, _ := tk.Evaluate(book)
 	if sig2 != nil {
@@ -94,6 +94,144 @@ func TestTakerCooldown(t *testing.T) {
 	}
 }
 
+func TestTakerGlobalCooldownSuppressesOtherAssets(t *testing.T) {
+	tk := NewTaker(TakerConfig{
+		MinImbalance:   0.10,
+		DepthLevels:    1,
+		AmountUSDC:     20,
+		GlobalCooldown: 100 * time.Millisecond,
+	})
+	other := ws.OrderbookEvent{
+		AssetID: "token-2",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "300"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "50"}},
+	}
+
+	tk.RecordTrade("token-1", "SELL", 20)
+	if sig, _ := tk.Evaluate(other); sig != nil {
+		t.Fatalf("expected a token-1 trade to suppress token-2, got %+v", sig)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+	if sig, _ := tk.Evaluate(other); sig == nil {
+		t.Fatal("expected token-2 signal once the global cooldown elapsed")
+	}
+}
+
+func TestTakerShareGlobalCooldown(t *testing.T) {
+	cfg := TakerConfig{MinImbalance: 0.10, DepthLevels: 1, AmountUSDC: 20, GlobalCooldown: time.Hour}
+	global := NewTaker(cfg)
+	market := NewTaker(cfg)
+	market.ShareGlobalCooldown(global)
+	book := ws.OrderbookEvent{
+		AssetID: "token-2",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "300"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "50"}},
+	}
+
+	global.RecordTrade("token-1", "BUY", 20)
+	if sig, _ := market.Evaluate(book); sig != nil {
+		t.Fatalf("expected the global taker's trade to suppress the market taker, got %+v", sig)
+	}
+}
+
+func TestTakerCooldownIsPerSide(t *testing.T) {
+	tk := NewTaker(TakerConfig{
+		MinImbalance: 0.10,
+		DepthLevels:  1,
+		AmountUSDC:   20,
+		Cooldown:     time.Hour,
+	})
+	buyBook := ws.OrderbookEvent{
+		AssetID: "token-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "300"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "50"}},
+	}
+	sellBook := ws.OrderbookEvent{
+		AssetID: "token-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "50"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "300"}},
+	}
+
+	tk.RecordTrade("token-1", "BUY", 20)
+	if sig, _ := tk.Evaluate(buyBook); sig != nil {
+		t.Fatalf("expected the BUY cooldown to block another BUY, got %+v", sig)
+	}
+	if sig, _ := tk.EvaluateEnhanced(buyBook, nil, 0, 0); sig != nil {
+		t.Fatalf("expected the BUY cooldown to block an enhanced BUY, got %+v", sig)
+	}
+	if sig, _ := tk.Evaluate(sellBook); sig == nil || sig.Side != "SELL" {
+		t.Fatalf("expected a SELL despite the BUY cooldown, got %+v", sig)
+	}
+	if sig, _ := tk.EvaluateEnhanced(sellBook, nil, 0, 0); sig == nil || sig.Side != "SELL" {
+		t.Fatalf("expected an enhanced SELL despite the BUY cooldown, got %+v", sig)
+	}
+
+	other := buyBook
+	other.AssetID = "token-2"
+	if sig, _ := tk.Evaluate(other); sig == nil {
+		t.Fatal("expected other assets unaffected by the cooldown")
+	}
+}
+
+func TestTakerResetDailyClearsCountersKeepsActiveCooldown(t *testing.T) {
+	tk := NewTaker(TakerConfig{
+		MinImbalance: 0.10,
+		DepthLevels:  1,
+		AmountUSDC:   20,
+		Cooldown:     time.Hour,
+	})
+	book := ws.OrderbookEvent{
+		AssetID: "token-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "300"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "50"}},
+	}
+
+	tk.RecordTrade("token-1", "BUY", 20)
+	tk.RecordTrade("token-1", "BUY", 5)
+	if got := tk.DailyTradeCount("token-1"); got != 2 {
+		t.Fatalf("expected 2 daily trades, got %d", got)
+	}
+	if got := tk.DailyNotional("token-1"); math.Abs(got-25) > 1e-9 {
+		t.Fatalf("expected daily notional 25, got %f", got)
+	}
+
+	tk.ResetDaily()
+	if got := tk.DailyTradeCount("token-1"); got != 0 {
+		t.Fatalf("expected daily trades reset to 0, got %d", got)
+	}
+	if got := tk.DailyNotional("token-1"); got != 0 {
+		t.Fatalf("expected daily notional reset to 0, got %f", got)
+	}
+	if sig, _ := tk.Evaluate(book); sig != nil {
+		t.Fatal("expected active cooldown to survive daily reset")
+	}
+}
+
+func TestTakerResetDailyClearsCooldownWhenConfigured(t *testing.T) {
+	tk := NewTaker(TakerConfig{
+		MinImbalance:              0.10,
+		DepthLevels:               1,
+		AmountUSDC:                20,
+		Cooldown:                  time.Hour,
+		ResetCooldownOnDailyReset: true,
+	})
+	book := ws.OrderbookEvent{
+		AssetID: "token-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "300"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "50"}},
+	}
+
+	tk.RecordTrade("token-1", "BUY", 20)
+	if sig, _ := tk.Evaluate(book); sig != nil {
+		t.Fatal("expected cooldown block before reset")
+	}
+	tk.ResetDaily()
+	if sig, _ := tk.Evaluate(book); sig == nil {
+		t.Fatal("expected signal after daily reset cleared cooldown")
+	}
+}
+
 func TestTakerSellSignal(t *testing.T) {
 	tk := NewTaker(TakerConfig{
 		MinImbalance: 0.15,
@@ -136,6 +274,11 @@ func TestFlowTrackerNetFlow(t *testing.T) {
 	if math.Abs(vwap-0.50) > 1e-9 {
 		t.Fatalf("expected VWAP 0.50, got %f", vwap)
 	}
+
+	st := ft.Stat("asset-1")
+	if math.Abs(st.NetFlow-nf) > 1e-9 || math.Abs(st.VWAP-vwap) > 1e-9 || st.Trades != 3 {
+		t.Fatalf("expected Stat to match NetFlow/VWAP over 3 trades, got %+v", st)
+	}
 }
 
 func TestFlowTrackerWindowExpiry(t *testing.T) {
@@ -206,7 +349,7 @@ func TestCompositeSignalStrong(t *testing.T) {
 		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "50"}},
 	}
 
-	sig, err := tk.EvaluateEnhanced(book, ft, 0)
+	sig, err := tk.EvaluateEnhanced(book, ft, 0, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -238,7 +381,7 @@ func TestCompositeSignalWeak(t *testing.T) {
 		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "100"}},
 	}
 
-	sig, _ := tk.EvaluateEnhanced(book, nil, 0)
+	sig, _ := tk.EvaluateEnhanced(book, nil, 0, 0)
 	if sig != nil {
 		t.Fatal("expected no signal with weak composite")
 	}
@@ -267,7 +410,7 @@ func TestAdaptiveSizing(t *testing.T) {
 		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "10"}},
 	}
 
-	sig, _ := tk.EvaluateEnhanced(book, ft, 0)
+	sig, _ := tk.EvaluateEnhanced(book, ft, 0, 0)
 	if sig == nil {
 		t.Fatal("expected signal")
 		return
@@ -302,7 +445,7 @@ func TestEvaluateEnhancedBackwardCompat(t *testing.T) {
 		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "50"}},
 	}
 
-	sig, err := tk.EvaluateEnhanced(book, nil, 0)
+	sig, err := tk.EvaluateEnhanced(book, nil, 0, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -311,3 +454,143 @@ func TestEvaluateEnhancedBackwardCompat(t *testing.T) {
 		return
 	}
 }
+
+func TestEvaluateEnhancedMomentumScoresAgainstImbalance(t *testing.T) {
+	cfg := TakerConfig{
+		DepthLevels:       1,
+		AmountUSDC:        20,
+		MaxSlippageBps:    30,
+		ImbalanceWeight:   0.5,
+		FlowWeight:        0.3,
+		ConvergenceWeight: 0.2,
+		MinCompositeScore: 0.01,
+		MomentumWeight:    0.4,
+		MomentumWindow:    time.Minute,
+	}
+	book := func(bid, ask string) ws.OrderbookEvent {
+		return ws.OrderbookEvent{
+			AssetID: "asset-1",
+			Bids:    []ws.OrderbookLevel{{Price: bid, Size: "300"}},
+			Asks:    []ws.OrderbookLevel{{Price: ask, Size: "100"}},
+		}
+	}
+
+	flat, err := NewTaker(cfg).EvaluateEnhanced(book("0.50", "0.52"), nil, 0, 0)
+	if err != nil || flat == nil {
+		t.Fatalf("expected baseline signal, got %+v (%v)", flat, err)
+	}
+
+	rising := NewTaker(cfg)
+	if _, err := rising.EvaluateEnhanced(book("0.48", "0.50"), nil, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	up, err := rising.EvaluateEnhanced(book("0.50", "0.52"), nil, 0, 0)
+	if err != nil || up == nil {
+		t.Fatalf("expected signal with upward momentum, got %+v (%v)", up, err)
+	}
+	if up.Side != "BUY" || up.Momentum <= 0 {
+		t.Fatalf("expected BUY with positive momentum, got %+v", up)
+	}
+	if up.Score <= flat.Score {
+		t.Fatalf("expected upward momentum to raise BUY score: flat=%f up=%f", flat.Score, up.Score)
+	}
+
+	falling := NewTaker(cfg)
+	if _, err := falling.EvaluateEnhanced(book("0.52", "0.54"), nil, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	down, err := falling.EvaluateEnhanced(book("0.50", "0.52"), nil, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if down != nil && down.Score >= flat.Score {
+		t.Fatalf("expected conflicting momentum to dampen BUY score: flat=%f down=%f", flat.Score, down.Score)
+	}
+}
+
+func TestEvaluateEnhancedFeeCoverageSuppressesMarginalSignal(t *testing.T) {
+	cfg := TakerConfig{
+		MinImbalance:       0.05,
+		DepthLevels:        1,
+		AmountUSDC:         20,
+		MaxSlippageBps:     30,
+		ImbalanceWeight:    0.5,
+		FlowWeight:         0.3,
+		ConvergenceWeight:  0.2,
+		MinCompositeScore:  0.1,
+		RequireFeeCoverage: true,
+	}
+	// Imbalance ~0.71 gives a composite of ~0.36, an expected edge of ~36bps.
+	book := ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "300"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "50"}},
+	}
+
+	sig, err := NewTaker(cfg).EvaluateEnhanced(book, nil, 0, 10)
+	if err != nil || sig == nil {
+		t.Fatalf("expected signal when edge covers 2x10bps fees, got %v, %v", sig, err)
+	}
+	if math.Abs(sig.ExpectedEdgeBps-sig.Score*100) > 1e-9 {
+		t.Fatalf("expected edge to scale with score, got %.2f for score %.4f", sig.ExpectedEdgeBps, sig.Score)
+	}
+
+	if sig, _ := NewTaker(cfg).EvaluateEnhanced(book, nil, 0, 20); sig != nil {
+		t.Fatalf("expected signal suppressed when edge %.1fbps does not cover 2x20bps fees", sig.ExpectedEdgeBps)
+	}
+
+	cfg.RequireFeeCoverage = false
+	if sig, _ := NewTaker(cfg).EvaluateEnhanced(book, nil, 0, 20); sig == nil {
+		t.Fatal("expected signal when fee coverage is not required")
+	}
+}
+
+func TestEvaluateEnhancedRecordsSuppressedSignal(t *testing.T) {
+	tk := NewTaker(TakerConfig{
+		DepthLevels:       1,
+		AmountUSDC:        20,
+		MaxSlippageBps:    30,
+		ImbalanceWeight:   0.5,
+		FlowWeight:        0.3,
+		ConvergenceWeight: 0.2,
+		MinCompositeScore: 0.9,
+	})
+	var records []SignalRecord
+	tk.SetSignalObserver(func(rec SignalRecord) { records = append(records, rec) })
+	// Imbalance 0.5 scores ~0.25 from the book alone; with convergence it
+	// stays well under the 0.9 threshold.
+	book := ws.OrderbookEvent{
+		AssetID: "asset-1",
+		Bids:    []ws.OrderbookLevel{{Price: "0.50", Size: "300"}},
+		Asks:    []ws.OrderbookLevel{{Price: "0.52", Size: "100"}},
+	}
+
+	sig, err := tk.EvaluateEnhanced(book, nil, 0.40, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sig != nil {
+		t.Fatalf("expected no signal below threshold, got %+v", sig)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	rec := records[0]
+	if rec.Outcome != SignalBelowThreshold || rec.AssetID != "asset-1" || rec.Side != "BUY" {
+		t.Fatalf("unexpected record %+v", rec)
+	}
+	if math.Abs(rec.Imbalance-0.5) > 1e-9 || rec.Convergence <= 0 || rec.Composite <= 0 || rec.Composite >= 0.9 {
+		t.Fatalf("expected component scores on suppressed record, got %+v", rec)
+	}
+	if math.Abs(rec.Mid-0.51) > 1e-9 {
+		t.Fatalf("expected mid 0.51, got %f", rec.Mid)
+	}
+
+	tk.SetSignalObserver(nil)
+	if _, err := tk.EvaluateEnhanced(book, nil, 0.40, 0); err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected no records once observer removed, got %d", len(records))
+	}
+}
@@ -0,0 +1,68 @@
+package strategy
+
+import (
+	"math"
+	"sync"
+)
+
+// VolatilityEstimator keeps a rolling window of mid-price log returns per
+// asset and reports their standard deviation. Returns are taken between
+// consecutive observations, so the estimate is per book update rather than
+// per unit of time.
+type VolatilityEstimator struct {
+	mu      sync.Mutex
+	window  int
+	lastMid map[string]float64
+	returns map[string][]float64 // assetID → recent log returns, oldest first
+}
+
+// NewVolatilityEstimator creates an estimator over the last window returns.
+// Windows below 2 are raised to 2.
+func NewVolatilityEstimator(window int) *VolatilityEstimator {
+	if window < 2 {
+		window = 2
+	}
+	return &VolatilityEstimator{
+		window:  window,
+		lastMid: make(map[string]float64),
+		returns: make(map[string][]float64),
+	}
+}
+
+// Observe records a new mid for assetID. Non-positive mids are ignored.
+func (v *VolatilityEstimator) Observe(assetID string, mid float64) {
+	if mid <= 0 {
+		return
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if prev, ok := v.lastMid[assetID]; ok {
+		rets := append(v.returns[assetID], math.Log(mid/prev))
+		if len(rets) > v.window {
+			rets = rets[len(rets)-v.window:]
+		}
+		v.returns[assetID] = rets
+	}
+	v.lastMid[assetID] = mid
+}
+
+// StdDevBps returns the sample standard deviation of the asset's recent mid
+// returns in basis points. ok is false until two returns have been seen.
+func (v *VolatilityEstimator) StdDevBps(assetID string) (bps float64, ok bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	rets := v.returns[assetID]
+	if len(rets) < 2 {
+		return 0, false
+	}
+	var mean float64
+	for _, r := range rets {
+		mean += r
+	}
+	mean /= float64(len(rets))
+	var ss float64
+	for _, r := range rets {
+		ss += (r - mean) * (r - mean)
+	}
+	return math.Sqrt(ss/float64(len(rets)-1)) * 10000, true
+}
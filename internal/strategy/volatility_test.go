@@ -0,0 +1,35 @@
+package strategy
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVolatilityEstimatorRollingWindow(t *testing.T) {
+	v := NewVolatilityEstimator(3)
+	if _, ok := v.StdDevBps("a"); ok {
+		t.Fatal("expected no estimate before any returns")
+	}
+	for _, mid := range []float64{0.50, 0.51, 0.50} {
+		v.Observe("a", mid)
+	}
+	got, ok := v.StdDevBps("a")
+	r1, r2 := math.Log(0.51/0.50), math.Log(0.50/0.51)
+	mean := (r1 + r2) / 2
+	want := math.Sqrt((r1-mean)*(r1-mean)+(r2-mean)*(r2-mean)) * 10000
+	if !ok || math.Abs(got-want) > 1e-6 {
+		t.Fatalf("expected %f bps, got %f ok=%v", want, got, ok)
+	}
+
+	// Flat mids push the volatile returns out of the window.
+	for i := 0; i < 3; i++ {
+		v.Observe("a", 0.50)
+	}
+	if got, _ := v.StdDevBps("a"); got != 0 {
+		t.Fatalf("expected zero volatility once the window is flat, got %f", got)
+	}
+	v.Observe("a", 0)
+	if _, ok := v.StdDevBps("b"); ok {
+		t.Fatal("expected assets tracked independently")
+	}
+}